@@ -0,0 +1,113 @@
+// Command qe-export writes a snapshot of the classi dataset:
+//
+//	qe-export [-tipi classi,sottoclassi] [-dir directory]
+//
+// The snapshot is a .tar.gz named after its version, with a manifest of
+// the number of records and the checksum of every file; see
+// archivio.ScriviSnapshot. qe-import loads it back.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi/archivio"
+	classipersistence "github.com/emiliopalmerini/quintaedizione.api/internal/classi/persistence"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func main() {
+	godotenv.Load()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}))
+	slog.SetDefault(logger)
+
+	if err := run(os.Args[1:], os.Stdout, logger); err != nil {
+		fmt.Fprintln(os.Stderr, "qe-export:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer, logger *slog.Logger) error {
+	flags := flag.NewFlagSet("qe-export", flag.ContinueOnError)
+	tipiFlag := flags.String("tipi", "", "comma separated types to export (default all)")
+	dir := flags.String("dir", ".", "directory the snapshot is written to")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: qe-export [-tipi classi,sottoclassi] [-dir directory]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return errors.New("unexpected arguments")
+	}
+	tipi, err := shared.QueryEnumList(url.Values{"tipi": {*tipiFlag}}, "tipi", classi.TipiEsportazione...)
+	if err != nil {
+		return err
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		return errors.New("DATABASE_URL environment variable is required")
+	}
+	db, err := sqlx.Connect("postgres", databaseURL)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The snapshot is written to a temporary file, renamed once complete.
+	f, err := os.CreateTemp(*dir, ".qe-export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := f.Chmod(0o644); err != nil {
+		return err
+	}
+
+	service := classi.NewService(classipersistence.NewPostgresRepository(db), nil, nil, logger)
+	manifest, err := archivio.ScriviSnapshot(ctx, f, service, tipi, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	nome := filepath.Join(*dir, "quintaedizione-"+manifest.Versione+".tar.gz")
+	if err := os.Rename(f.Name(), nome); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, nome)
+	for _, file := range manifest.File {
+		fmt.Fprintf(out, "  %s: %d records, sha256 %s\n", file.Nome, file.NumeroElementi, file.SHA256)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Command qe-import loads a content pack of classi and sottoclassi into
+// the database:
+//
+//	qe-import [-dry-run] [-autore nome] <directory | snapshot.tar.gz>
+//
+// The directory is read with its subdirectories; see
+// archivio.LeggiPacchetto for the format of the files. A snapshot written
+// by qe-export is checked against its manifest first. The pack is
+// validated as a whole and written in a single transaction, and the
+// outcome of every class and subclass is printed. With -dry-run nothing is
+// written.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi/archivio"
+	classipersistence "github.com/emiliopalmerini/quintaedizione.api/internal/classi/persistence"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func main() {
+	godotenv.Load()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}))
+	slog.SetDefault(logger)
+
+	if err := run(os.Args[1:], os.Stdout, logger); err != nil {
+		fmt.Fprintln(os.Stderr, "qe-import:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer, logger *slog.Logger) error {
+	flags := flag.NewFlagSet("qe-import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "validate the pack and print the report without writing")
+	autore := flags.String("autore", "qe-import", "editor recorded in the history of the written resources")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: qe-import [-dry-run] [-autore nome] <directory | snapshot.tar.gz>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("exactly one directory or snapshot is required")
+	}
+
+	dir := flags.Arg(0)
+	if strings.HasSuffix(dir, ".tar.gz") || strings.HasSuffix(dir, ".tgz") {
+		tmp, err := os.MkdirTemp("", "qe-import-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+		if err := estraiSnapshot(dir, tmp, out); err != nil {
+			return err
+		}
+		dir = tmp
+	}
+
+	pacchetto, err := archivio.LeggiPacchetto(os.DirFS(dir))
+	if err != nil {
+		return err
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		return errors.New("DATABASE_URL environment variable is required")
+	}
+	db, err := sqlx.Connect("postgres", databaseURL)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx = shared.ConAutore(ctx, *autore)
+
+	service := classi.NewService(classipersistence.NewPostgresRepository(db), nil, nil, logger)
+	report, err := service.ImportaPacchetto(ctx, pacchetto, *dryRun)
+	if err != nil {
+		return err
+	}
+	return stampaReport(out, report)
+}
+
+func estraiSnapshot(nome, dir string, out io.Writer) error {
+	f, err := os.Open(nome)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	manifest, err := archivio.EstraiSnapshot(f, dir)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "snapshot %s\n\n", manifest.Versione)
+	return nil
+}
+
+func stampaReport(out io.Writer, report *classi.ReportImportazione) error {
+	conteggi := make(map[classi.EsitoImportazione]int)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ESITO\tTIPO\tCLASSE\tID")
+	for _, v := range report.Voci {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", v.Esito, v.Tipo, v.IDClasse, v.ID)
+		conteggi[v.Esito]++
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d creazione, %d modifica, %d invariata\n",
+		conteggi[classi.ImportazioneCreazione], conteggi[classi.ImportazioneModifica], conteggi[classi.ImportazioneInvariata])
+	if report.Simulazione {
+		fmt.Fprintln(out, "dry run: nothing was written")
+	}
+	return nil
+}
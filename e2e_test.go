@@ -105,8 +105,8 @@ func newTestAPI() (*testAPI, error) {
 	}
 
 	repo := persistence.NewPostgresRepository(db)
-	service := classi.NewService(repo, nil) // nil logger defaults to discard
-	handler := transports.NewHandler(service)
+	service := classi.NewService(repo, nil, nil) // nil logger defaults to discard
+	handler := transports.NewHandler(service, nil)
 
 	r := chi.NewRouter()
 	r.Use(middleware.Recoverer)
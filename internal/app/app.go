@@ -16,12 +16,59 @@ import (
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 
+	"github.com/emiliopalmerini/quintaedizione.api/internal/background"
+	backgroundpersistence "github.com/emiliopalmerini/quintaedizione.api/internal/background/persistence"
+	backgroundtransports "github.com/emiliopalmerini/quintaedizione.api/internal/background/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/bastioni"
+	bastionipersistence "github.com/emiliopalmerini/quintaedizione.api/internal/bastioni/persistence"
+	bastionitransports "github.com/emiliopalmerini/quintaedizione.api/internal/bastioni/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/calcoli"
+	calcolitransports "github.com/emiliopalmerini/quintaedizione.api/internal/calcoli/transports"
 	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
-	"github.com/emiliopalmerini/quintaedizione.api/internal/classi/persistence"
-	"github.com/emiliopalmerini/quintaedizione.api/internal/classi/transports"
+	classipersistence "github.com/emiliopalmerini/quintaedizione.api/internal/classi/persistence"
+	classitransports "github.com/emiliopalmerini/quintaedizione.api/internal/classi/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/condizioni"
+	condizionipersistence "github.com/emiliopalmerini/quintaedizione.api/internal/condizioni/persistence"
+	condizionitransports "github.com/emiliopalmerini/quintaedizione.api/internal/condizioni/transports"
 	"github.com/emiliopalmerini/quintaedizione.api/internal/config"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/dadi"
+	daditransports "github.com/emiliopalmerini/quintaedizione.api/internal/dadi/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/divinita"
+	divinitapersistence "github.com/emiliopalmerini/quintaedizione.api/internal/divinita/persistence"
+	divinitatransports "github.com/emiliopalmerini/quintaedizione.api/internal/divinita/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/glossario"
+	glossariopersistence "github.com/emiliopalmerini/quintaedizione.api/internal/glossario/persistence"
 	"github.com/emiliopalmerini/quintaedizione.api/internal/health"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/incantesimi"
+	incantesimipersistence "github.com/emiliopalmerini/quintaedizione.api/internal/incantesimi/persistence"
+	incantesimitransports "github.com/emiliopalmerini/quintaedizione.api/internal/incantesimi/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/linguaggi"
+	linguaggipersistence "github.com/emiliopalmerini/quintaedizione.api/internal/linguaggi/persistence"
+	linguaggitransports "github.com/emiliopalmerini/quintaedizione.api/internal/linguaggi/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/maestrie"
+	maestriepersistence "github.com/emiliopalmerini/quintaedizione.api/internal/maestrie/persistence"
+	maestrietransports "github.com/emiliopalmerini/quintaedizione.api/internal/maestrie/transports"
 	custommw "github.com/emiliopalmerini/quintaedizione.api/internal/middleware"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/mostri"
+	mostripersistence "github.com/emiliopalmerini/quintaedizione.api/internal/mostri/persistence"
+	mostritransports "github.com/emiliopalmerini/quintaedizione.api/internal/mostri/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+	oggettipersistence "github.com/emiliopalmerini/quintaedizione.api/internal/oggetti/persistence"
+	oggettitransports "github.com/emiliopalmerini/quintaedizione.api/internal/oggetti/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/personaggi"
+	personaggitransports "github.com/emiliopalmerini/quintaedizione.api/internal/personaggi/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/regole"
+	regolepersistence "github.com/emiliopalmerini/quintaedizione.api/internal/regole/persistence"
+	regoletransports "github.com/emiliopalmerini/quintaedizione.api/internal/regole/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/ricerca"
+	ricercatransports "github.com/emiliopalmerini/quintaedizione.api/internal/ricerca/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/specie"
+	speciepersistence "github.com/emiliopalmerini/quintaedizione.api/internal/specie/persistence"
+	specietransports "github.com/emiliopalmerini/quintaedizione.api/internal/specie/transports"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/talenti"
+	talentipersistence "github.com/emiliopalmerini/quintaedizione.api/internal/talenti/persistence"
+	talentitransports "github.com/emiliopalmerini/quintaedizione.api/internal/talenti/transports"
 )
 
 type App struct {
@@ -51,6 +98,9 @@ func New(cfg *config.Config, logger *slog.Logger) (*App, error) {
 	if cfg.APIKey == "" {
 		logger.Warn("API_KEY is not set — authentication is disabled (dev mode)")
 	}
+	if cfg.AdminAPIKey == "" {
+		logger.Info("ADMIN_API_KEY is not set — admin routes are disabled")
+	}
 
 	deps := &Dependencies{
 		DB:     db,
@@ -77,8 +127,8 @@ func (a *App) setupRoutes() {
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   a.deps.Config.CORS.AllowedOrigins,
 		AllowedMethods:   a.deps.Config.CORS.AllowedMethods,
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-API-Key"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", custommw.APIKeyHeader, custommw.AdminKeyHeader, shared.AutoreHeader, "If-Match", "If-None-Match"},
+		ExposedHeaders:   []string{"Link", "ETag"},
 		AllowCredentials: false,
 		MaxAge:           a.deps.Config.CORS.MaxAge,
 	}))
@@ -89,24 +139,135 @@ func (a *App) setupRoutes() {
 	}
 
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(30 * time.Second))
+
+	// Every route but the export has 30 seconds.
+	timeout := middleware.Timeout(30 * time.Second)
 
 	// Public endpoints
 	healthHandler := health.NewHandler(a.deps.DB.DB, a.deps.Config.Version)
-	r.Get("/health", healthHandler.ServeHTTP)
-	r.Get("/health/live", healthHandler.Liveness)
-	r.Get("/swagger", func(w http.ResponseWriter, r *http.Request) {
+	r.With(timeout).Get("/health", healthHandler.ServeHTTP)
+	r.With(timeout).Get("/health/live", healthHandler.Liveness)
+	r.With(timeout).Get("/swagger", func(w http.ResponseWriter, r *http.Request) {
 		http.ServeFile(w, r, "swagger/quintaedizioneswagger")
 	})
 
+	glossarioRepo := glossariopersistence.NewPostgresRepository(a.deps.DB)
+	glossarioService := glossario.NewService(glossarioRepo, a.deps.Logger)
+
+	oggettiRepo := oggettipersistence.NewPostgresRepository(a.deps.DB)
+	classiRepo := classipersistence.NewPostgresRepository(a.deps.DB)
+	classiService := classi.NewService(classiRepo, oggettiRepo, glossarioService, a.deps.Logger)
+
+	// The export streams the whole dataset and has a deadline of its own.
+	// Compress leaves its NDJSON as it is, since application/x-ndjson is not
+	// among its types, so each record reaches the client when it is flushed.
+	r.Route("/v1/export", func(r chi.Router) {
+		r.Use(custommw.APIKey(a.deps.Config.APIKey))
+		r.Use(middleware.Timeout(a.deps.Config.Server.ExportTimeout))
+		r.Mount("/", classitransports.NewEsportazioneHandler(classiService).Routes())
+	})
+
 	// Protected API routes
 	r.Route("/v1", func(r chi.Router) {
 		r.Use(custommw.APIKey(a.deps.Config.APIKey))
+		r.Use(timeout)
 
-		classiRepo := persistence.NewPostgresRepository(a.deps.DB)
-		classiService := classi.NewService(classiRepo, a.deps.Logger)
-		classiHandler := transports.NewHandler(classiService)
+		classiHandler := classitransports.NewHandler(classiService)
 		r.Mount("/classi", classiHandler.Routes())
+		r.Mount("/sotto-classi", classiHandler.SottoclassiRoutes())
+
+		// The write routes always require an admin key, dev included.
+		if a.deps.Config.AdminAPIKey != "" {
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(custommw.AdminKey(a.deps.Config.AdminAPIKey))
+				r.Mount("/classi", classitransports.NewAdminHandler(classiService).Routes())
+			})
+		}
+
+		incantesimiRepo := incantesimipersistence.NewPostgresRepository(a.deps.DB)
+		incantesimiService := incantesimi.NewService(incantesimiRepo, glossarioService, a.deps.Logger)
+		incantesimiHandler := incantesimitransports.NewHandler(incantesimiService)
+		r.Mount("/incantesimi", incantesimiHandler.Routes())
+
+		mostriRepo := mostripersistence.NewPostgresRepository(a.deps.DB)
+		mostriService := mostri.NewService(mostriRepo, glossarioService, a.deps.Logger)
+		mostriHandler := mostritransports.NewHandler(mostriService)
+		r.Mount("/mostri", mostriHandler.Routes())
+
+		oggettiService := oggetti.NewService(oggettiRepo, glossarioService, a.deps.Logger)
+		oggettiHandler := oggettitransports.NewHandler(oggettiService)
+		r.Mount("/oggetti", oggettiHandler.Routes())
+
+		maestrieRepo := maestriepersistence.NewPostgresRepository(a.deps.DB)
+		maestrieService := maestrie.NewService(maestrieRepo, glossarioService, a.deps.Logger)
+		maestrieHandler := maestrietransports.NewHandler(maestrieService)
+		r.Mount("/maestrie", maestrieHandler.Routes())
+
+		specieRepo := speciepersistence.NewPostgresRepository(a.deps.DB)
+		specieService := specie.NewService(specieRepo, glossarioService, a.deps.Logger)
+		specieHandler := specietransports.NewHandler(specieService)
+		r.Mount("/specie", specieHandler.Routes())
+
+		backgroundRepo := backgroundpersistence.NewPostgresRepository(a.deps.DB)
+		backgroundService := background.NewService(backgroundRepo, oggettiRepo, glossarioService, a.deps.Logger)
+		backgroundHandler := backgroundtransports.NewHandler(backgroundService)
+		r.Mount("/background", backgroundHandler.Routes())
+		r.Mount("/backeground", http.HandlerFunc(backgroundtransports.RedirectLegacyPath))
+
+		talentiRepo := talentipersistence.NewPostgresRepository(a.deps.DB)
+		talentiService := talenti.NewService(talentiRepo, glossarioService, a.deps.Logger)
+		talentiHandler := talentitransports.NewHandler(talentiService)
+		r.Mount("/talenti", talentiHandler.Routes())
+
+		regoleRepo := regolepersistence.NewPostgresRepository(a.deps.DB)
+		regoleService := regole.NewService(regoleRepo, glossarioService, a.deps.Logger)
+		regoleHandler := regoletransports.NewHandler(regoleService)
+		r.Mount("/regole", regoleHandler.Routes())
+
+		condizioniRepo := condizionipersistence.NewPostgresRepository(a.deps.DB)
+		condizioniService := condizioni.NewService(condizioniRepo, glossarioService, a.deps.Logger)
+		condizioniHandler := condizionitransports.NewHandler(condizioniService)
+		r.Mount("/condizioni", condizioniHandler.Routes())
+
+		linguaggiRepo := linguaggipersistence.NewPostgresRepository(a.deps.DB)
+		linguaggiService := linguaggi.NewService(linguaggiRepo, glossarioService, a.deps.Logger)
+		linguaggiHandler := linguaggitransports.NewHandler(linguaggiService)
+		r.Mount("/linguaggi", linguaggiHandler.Routes())
+
+		divinitaRepo := divinitapersistence.NewPostgresRepository(a.deps.DB)
+		divinitaService := divinita.NewService(divinitaRepo, glossarioService, a.deps.Logger)
+		divinitaHandler := divinitatransports.NewHandler(divinitaService)
+		r.Mount("/divinità", divinitaHandler.Routes())
+		r.Mount("/divinita", divinitaHandler.Routes())
+
+		bastioniRepo := bastionipersistence.NewPostgresRepository(a.deps.DB)
+		bastioniService := bastioni.NewService(bastioniRepo, glossarioService, a.deps.Logger)
+		bastioniHandler := bastionitransports.NewHandler(bastioniService)
+		r.Mount("/bastioni", bastioniHandler.Routes())
+
+		ricercaService := ricerca.NewService(map[ricerca.TipoRicerca]ricerca.Sorgente{
+			ricerca.Classi:      ricerca.NuovaSorgente(classi.AttributiRicercaClasse, classiRepo.SearchClassi),
+			ricerca.Sottoclassi: ricerca.NuovaSorgente(classi.AttributiRicercaSottoClasse, classiRepo.SearchSottoclassi),
+		}, a.deps.Logger)
+		ricercaHandler := ricercatransports.NewHandler(ricercaService)
+		r.Mount("/ricerca", ricercaHandler.Routes())
+
+		calcoliService := calcoli.NewService(classiRepo, a.deps.Logger)
+		calcoliHandler := calcolitransports.NewHandler(calcoliService)
+		r.Mount("/calcoli", calcoliHandler.Routes())
+
+		dadiService := dadi.NewService(nil, a.deps.Logger)
+		dadiHandler := daditransports.NewHandler(dadiService)
+		r.Mount("/dadi", dadiHandler.Routes())
+
+		personaggiService := personaggi.NewService(personaggi.Repositories{
+			Classi:     classiRepo,
+			Specie:     specieRepo,
+			Background: backgroundRepo,
+			Talenti:    talentiRepo,
+		}, a.deps.Logger)
+		personaggiHandler := personaggitransports.NewHandler(personaggiService)
+		r.Mount("/personaggi", personaggiHandler.Routes())
 	})
 
 	a.router = r
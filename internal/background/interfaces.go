@@ -0,0 +1,19 @@
+package background
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+)
+
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Background, int, error)
+	GetByID(ctx context.Context, id string) (*Background, error)
+	GetTalentiByIDs(ctx context.Context, ids []string) (map[string]Talento, error)
+}
+
+// UtensiliRepository is the item catalogue the tool proficiencies are
+// expanded against.
+type UtensiliRepository interface {
+	GetByIDs(ctx context.Context, ids []string) ([]oggetti.Oggetto, error)
+}
@@ -0,0 +1,45 @@
+package background
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+)
+
+type MockRepository struct {
+	ListFunc            func(ctx context.Context, filter ListFilter) ([]Background, int, error)
+	GetByIDFunc         func(ctx context.Context, id string) (*Background, error)
+	GetTalentiByIDsFunc func(ctx context.Context, ids []string) (map[string]Talento, error)
+}
+
+func (m *MockRepository) List(ctx context.Context, filter ListFilter) ([]Background, int, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockRepository) GetByID(ctx context.Context, id string) (*Background, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) GetTalentiByIDs(ctx context.Context, ids []string) (map[string]Talento, error) {
+	if m.GetTalentiByIDsFunc != nil {
+		return m.GetTalentiByIDsFunc(ctx, ids)
+	}
+	return map[string]Talento{}, nil
+}
+
+type MockUtensiliRepository struct {
+	GetByIDsFunc func(ctx context.Context, ids []string) ([]oggetti.Oggetto, error)
+}
+
+func (m *MockUtensiliRepository) GetByIDs(ctx context.Context, ids []string) ([]oggetti.Oggetto, error) {
+	if m.GetByIDsFunc != nil {
+		return m.GetByIDsFunc(ctx, ids)
+	}
+	return nil, nil
+}
@@ -0,0 +1,53 @@
+package background
+
+import (
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+// Espansione names a reference that can be expanded inline on request.
+type Espansione string
+
+const (
+	EspandiTalento  Espansione = "talento"
+	EspandiUtensili Espansione = "utensili"
+)
+
+var Espansioni = []Espansione{EspandiTalento, EspandiUtensili}
+
+// Talento is the summary of the origin feat granted by a background.
+type Talento struct {
+	ID          string `json:"id" db:"id"`
+	Nome        string `json:"nome" db:"nome"`
+	Descrizione string `json:"descrizione,omitempty" db:"descrizione"`
+	Categoria   string `json:"categoria" db:"categoria"`
+}
+
+// RiferimentoUtensile references a tool proficiency. Utensile is only set
+// when the utensili expansion is requested.
+type RiferimentoUtensile struct {
+	IDUtensile string           `json:"id-utensile"`
+	Utensile   *oggetti.Oggetto `json:"utensile,omitempty"`
+}
+
+type Background struct {
+	ID                          string                          `json:"id"`
+	Nome                        string                          `json:"nome"`
+	Descrizione                 string                          `json:"descrizione,omitempty"`
+	CaratteristicheAssociate    []shared.Caratteristica         `json:"caratteristiche-associate"`
+	IDTalento                   string                          `json:"id-talento,omitempty"`
+	Talento                     *Talento                        `json:"talento,omitempty"`
+	CompetenzaAbilita           []shared.Abilita                `json:"competenza-abilità,omitempty"`
+	CompetenzaUtensili          []RiferimentoUtensile           `json:"competenza-utensili,omitempty"`
+	Equipaggiamento             *shared.EquipaggiamentoPartenza `json:"equipaggiamento,omitempty"`
+	Riferimenti                 []shared.Riferimento            `json:"riferimenti,omitempty"`
+	DocumentazioneDiRiferimento string                          `json:"documentazione-di-riferimento"`
+}
+
+// ListFilter extends the shared list filter with the background-specific
+// filters. CaratteristicheAssociate uses AND semantics.
+type ListFilter struct {
+	shared.ListFilter
+	CaratteristicheAssociate []shared.Caratteristica
+	Espandi                  []Espansione
+}
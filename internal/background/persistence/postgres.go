@@ -0,0 +1,135 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/background"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type backgroundRow struct {
+	ID                          string                                            `db:"id"`
+	Nome                        string                                            `db:"nome"`
+	Descrizione                 sql.NullString                                    `db:"descrizione"`
+	CaratteristicheAssociate    pq.StringArray                                    `db:"caratteristiche_associate"`
+	IDTalento                   sql.NullString                                    `db:"id_talento"`
+	CompetenzaAbilita           shared.JSONSlice[shared.Abilita]                  `db:"competenza_abilita"`
+	CompetenzaUtensili          pq.StringArray                                    `db:"competenza_utensili"`
+	Equipaggiamento             shared.JSONObject[shared.EquipaggiamentoPartenza] `db:"equipaggiamento"`
+	DocumentazioneDiRiferimento string                                            `db:"documentazione_di_riferimento"`
+}
+
+func (r *backgroundRow) toBackground() background.Background {
+	b := background.Background{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Descrizione:                 r.Descrizione.String,
+		CaratteristicheAssociate:    []shared.Caratteristica{},
+		IDTalento:                   r.IDTalento.String,
+		CompetenzaAbilita:           r.CompetenzaAbilita,
+		Equipaggiamento:             r.Equipaggiamento.V,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+	for _, c := range r.CaratteristicheAssociate {
+		b.CaratteristicheAssociate = append(b.CaratteristicheAssociate, shared.Caratteristica(c))
+	}
+	for _, id := range r.CompetenzaUtensili {
+		b.CompetenzaUtensili = append(b.CompetenzaUtensili, background.RiferimentoUtensile{IDUtensile: id})
+	}
+	return b
+}
+
+const selectBackground = `
+	SELECT id, nome, descrizione, caratteristiche_associate, id_talento, competenza_abilita,
+	       competenza_utensili, equipaggiamento, documentazione_di_riferimento
+	FROM background`
+
+// filterConditions translates the background-specific filters into SQL
+// conditions and their named arguments. The shared filters are applied by
+// shared.NewPaginatedQuery.
+func filterConditions(filter background.ListFilter) (string, map[string]any) {
+	var where string
+	args := make(map[string]any)
+
+	if len(filter.CaratteristicheAssociate) > 0 {
+		caratteristiche := make([]string, len(filter.CaratteristicheAssociate))
+		for i, c := range filter.CaratteristicheAssociate {
+			caratteristiche[i] = string(c)
+		}
+		where += ` AND caratteristiche_associate @> :caratteristiche`
+		args["caratteristiche"] = pq.Array(caratteristiche)
+	}
+
+	return where, args
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filter background.ListFilter) ([]background.Background, int, error) {
+	where, args := filterConditions(filter)
+	q := shared.NewPaginatedQuery(
+		selectBackground+` WHERE 1=1`+where,
+		`SELECT COUNT(*) FROM background WHERE 1=1`+where,
+		args,
+		filter.ListFilter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []backgroundRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]background.Background, len(rows))
+	for i, row := range rows {
+		result[i] = row.toBackground()
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*background.Background, error) {
+	var row backgroundRow
+	if err := r.db.GetContext(ctx, &row, selectBackground+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get background by id: %w", err)
+	}
+
+	b := row.toBackground()
+	return &b, nil
+}
+
+func (r *PostgresRepository) GetTalentiByIDs(ctx context.Context, ids []string) (map[string]background.Talento, error) {
+	result := make(map[string]background.Talento)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	query := `SELECT id, nome, COALESCE(descrizione, '') AS descrizione, categoria FROM talenti WHERE id = ANY($1)`
+	var talenti []background.Talento
+	if err := r.db.SelectContext(ctx, &talenti, query, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("batch get talenti: %w", err)
+	}
+
+	for _, t := range talenti {
+		result[t.ID] = t
+	}
+	return result, nil
+}
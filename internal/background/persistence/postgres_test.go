@@ -0,0 +1,80 @@
+package persistence
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/background"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func TestFilterConditions(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		where, args := filterConditions(background.ListFilter{})
+
+		if where != "" || len(args) != 0 {
+			t.Errorf("expected no conditions, got %q %v", where, args)
+		}
+	})
+
+	t.Run("caratteristiche require every value", func(t *testing.T) {
+		filter := background.ListFilter{CaratteristicheAssociate: []shared.Caratteristica{shared.Intelligenza, shared.Saggezza}}
+
+		where, args := filterConditions(filter)
+
+		if !strings.Contains(where, "caratteristiche_associate @> :caratteristiche") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		caratteristiche := args["caratteristiche"].(*pq.StringArray)
+		if len(*caratteristiche) != 2 || (*caratteristiche)[0] != "Intelligenza" {
+			t.Errorf("unexpected caratteristiche arg %v", *caratteristiche)
+		}
+	})
+}
+
+func TestBackgroundRow_ToBackground(t *testing.T) {
+	t.Run("full row", func(t *testing.T) {
+		row := backgroundRow{
+			ID:                       "accolito",
+			Nome:                     "Accolito",
+			CaratteristicheAssociate: pq.StringArray{"Intelligenza", "Saggezza", "Carisma"},
+			IDTalento:                sql.NullString{String: "iniziato-alla-magia", Valid: true},
+			CompetenzaAbilita:        shared.JSONSlice[shared.Abilita]{{Abilita: "Intuizione", Competenza: true}},
+			CompetenzaUtensili:       pq.StringArray{"strumenti-da-calligrafo"},
+			Equipaggiamento: shared.JSONObject[shared.EquipaggiamentoPartenza]{V: &shared.EquipaggiamentoPartenza{
+				OpzioneB: &shared.Importo{Quantita: 50, Valuta: shared.MO},
+			}},
+		}
+
+		b := row.toBackground()
+
+		if len(b.CaratteristicheAssociate) != 3 || b.CaratteristicheAssociate[2] != shared.Carisma {
+			t.Errorf("unexpected caratteristiche %v", b.CaratteristicheAssociate)
+		}
+		if b.IDTalento != "iniziato-alla-magia" {
+			t.Errorf("unexpected id-talento %q", b.IDTalento)
+		}
+		if len(b.CompetenzaUtensili) != 1 || b.CompetenzaUtensili[0].IDUtensile != "strumenti-da-calligrafo" {
+			t.Errorf("unexpected competenza-utensili %v", b.CompetenzaUtensili)
+		}
+		if b.Equipaggiamento == nil || b.Equipaggiamento.OpzioneB.Quantita != 50 {
+			t.Errorf("unexpected equipaggiamento %+v", b.Equipaggiamento)
+		}
+	})
+
+	t.Run("empty arrays", func(t *testing.T) {
+		row := backgroundRow{ID: "eremita", Nome: "Eremita"}
+
+		b := row.toBackground()
+
+		if b.CaratteristicheAssociate == nil {
+			t.Error("expected empty caratteristiche, got nil")
+		}
+		if b.CompetenzaUtensili != nil || b.Equipaggiamento != nil {
+			t.Errorf("expected no utensili and equipaggiamento, got %+v", b)
+		}
+	})
+}
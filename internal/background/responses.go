@@ -0,0 +1,8 @@
+package background
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type ListBackgroundResponse struct {
+	shared.PaginationMeta
+	Background []Background `json:"background"`
+}
@@ -0,0 +1,120 @@
+package background
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	repo      Repository
+	utensili  UtensiliRepository
+	glossario shared.Glossario
+	logger    *slog.Logger
+}
+
+func NewService(repo Repository, utensili UtensiliRepository, glossario shared.Glossario, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:      repo,
+		utensili:  utensili,
+		glossario: glossario,
+		logger:    logger,
+	}
+}
+
+func (s *Service) ListBackground(ctx context.Context, filter ListFilter) (*ListBackgroundResponse, error) {
+	background, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list background", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	if err := s.espandi(ctx, background, filter.Espandi); err != nil {
+		return nil, err
+	}
+
+	return &ListBackgroundResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Background:     background,
+	}, nil
+}
+
+func (s *Service) GetBackground(ctx context.Context, id string, espandi []Espansione, campi shared.Campi) (*Background, error) {
+	background, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get background", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if background == nil {
+		return nil, ErrBackgroundNotFound(id)
+	}
+
+	result := []Background{*background}
+	if err := s.espandi(ctx, result, espandi); err != nil {
+		return nil, err
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		result[0].Riferimenti = s.glossario.Riferimenti(ctx, &result[0])
+	}
+	return &result[0], nil
+}
+
+// espandi fills the requested references of every background in place,
+// with one lookup per reference kind. Dangling references, and utensili
+// that reference an item of another tipo, are left unexpanded.
+func (s *Service) espandi(ctx context.Context, background []Background, espandi []Espansione) error {
+	if slices.Contains(espandi, EspandiTalento) {
+		var ids []string
+		for _, b := range background {
+			if b.IDTalento != "" {
+				ids = append(ids, b.IDTalento)
+			}
+		}
+		talenti, err := s.repo.GetTalentiByIDs(ctx, ids)
+		if err != nil {
+			s.logger.Error("failed to expand talenti", "error", err)
+			return shared.NewInternalError(err)
+		}
+		for i := range background {
+			if t, ok := talenti[background[i].IDTalento]; ok {
+				background[i].Talento = &t
+			}
+		}
+	}
+
+	if slices.Contains(espandi, EspandiUtensili) {
+		var ids []string
+		for _, b := range background {
+			for _, u := range b.CompetenzaUtensili {
+				ids = append(ids, u.IDUtensile)
+			}
+		}
+		catalogo, err := s.utensili.GetByIDs(ctx, ids)
+		if err != nil {
+			s.logger.Error("failed to expand utensili", "error", err)
+			return shared.NewInternalError(err)
+		}
+		utensili := make(map[string]oggetti.Oggetto, len(catalogo))
+		for _, o := range catalogo {
+			if o.Tipo == oggetti.TipoUtensile {
+				utensili[o.ID] = o
+			}
+		}
+		for i := range background {
+			for j, u := range background[i].CompetenzaUtensili {
+				if o, ok := utensili[u.IDUtensile]; ok {
+					background[i].CompetenzaUtensili[j].Utensile = &o
+				}
+			}
+		}
+	}
+
+	return nil
+}
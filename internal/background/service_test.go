@@ -0,0 +1,201 @@
+package background
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func newAccolito() *Background {
+	return &Background{
+		ID:                 "accolito",
+		Nome:               "Accolito",
+		IDTalento:          "iniziato-alla-magia",
+		CompetenzaUtensili: []RiferimentoUtensile{{IDUtensile: "strumenti-da-calligrafo"}},
+	}
+}
+
+func TestService_ListBackground(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success without expansion", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Background, int, error) {
+				return []Background{*newAccolito(), {ID: "artigiano", Nome: "Artigiano"}}, 2, nil
+			},
+			GetTalentiByIDsFunc: func(_ context.Context, _ []string) (map[string]Talento, error) {
+				t.Fatal("talenti must not be looked up without expansion")
+				return nil, nil
+			},
+		}
+
+		service := NewService(repo, &MockUtensiliRepository{}, nil, logger)
+
+		result, err := service.ListBackground(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 2 || len(result.Background) != 2 {
+			t.Errorf("unexpected result %+v", result)
+		}
+		if result.Background[0].Talento != nil {
+			t.Errorf("expected unexpanded talento, got %+v", result.Background[0].Talento)
+		}
+	})
+
+	t.Run("expands talenti with a single lookup", func(t *testing.T) {
+		var lookups [][]string
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Background, int, error) {
+				return []Background{*newAccolito(), {ID: "soldato", Nome: "Soldato", IDTalento: "colpitore-selvaggio"}, {ID: "senza-talento"}}, 3, nil
+			},
+			GetTalentiByIDsFunc: func(_ context.Context, ids []string) (map[string]Talento, error) {
+				lookups = append(lookups, ids)
+				return map[string]Talento{
+					"iniziato-alla-magia": {ID: "iniziato-alla-magia", Nome: "Iniziato alla Magia", Categoria: "Origine"},
+				}, nil
+			},
+		}
+
+		service := NewService(repo, &MockUtensiliRepository{}, nil, logger)
+
+		result, err := service.ListBackground(ctx, ListFilter{Espandi: []Espansione{EspandiTalento}})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(lookups) != 1 || len(lookups[0]) != 2 {
+			t.Fatalf("expected one lookup of 2 ids, got %v", lookups)
+		}
+		if result.Background[0].Talento == nil || result.Background[0].Talento.Nome != "Iniziato alla Magia" {
+			t.Errorf("unexpected talento %+v", result.Background[0].Talento)
+		}
+		if result.Background[1].Talento != nil {
+			t.Errorf("expected dangling talento to stay unexpanded, got %+v", result.Background[1].Talento)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Background, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, &MockUtensiliRepository{}, nil, logger)
+
+		_, err := service.ListBackground(ctx, ListFilter{})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestService_GetBackground(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("expands utensili", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Background, error) {
+				return newAccolito(), nil
+			},
+		}
+		utensili := &MockUtensiliRepository{
+			GetByIDsFunc: func(_ context.Context, ids []string) ([]oggetti.Oggetto, error) {
+				return []oggetti.Oggetto{
+					{ID: ids[0], Nome: "Strumenti da Calligrafo", Tipo: oggetti.TipoUtensile, Utensile: &oggetti.Utensile{CaratteristicaAssociata: shared.Destrezza}},
+				}, nil
+			},
+		}
+
+		service := NewService(repo, utensili, nil, logger)
+
+		result, err := service.GetBackground(ctx, "accolito", []Espansione{EspandiUtensili}, nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		utensile := result.CompetenzaUtensili[0].Utensile
+		if utensile == nil || utensile.Utensile == nil || utensile.Utensile.CaratteristicaAssociata != shared.Destrezza {
+			t.Errorf("unexpected utensile %+v", utensile)
+		}
+		if result.Talento != nil {
+			t.Errorf("expected unexpanded talento, got %+v", result.Talento)
+		}
+	})
+
+	t.Run("items of another tipo stay unexpanded", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Background, error) {
+				return newAccolito(), nil
+			},
+		}
+		utensili := &MockUtensiliRepository{
+			GetByIDsFunc: func(_ context.Context, ids []string) ([]oggetti.Oggetto, error) {
+				return []oggetti.Oggetto{{ID: ids[0], Nome: "Pugnale", Tipo: oggetti.TipoArma}}, nil
+			},
+		}
+
+		service := NewService(repo, utensili, nil, logger)
+
+		result, err := service.GetBackground(ctx, "accolito", []Espansione{EspandiUtensili}, nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if utensile := result.CompetenzaUtensili[0].Utensile; utensile != nil {
+			t.Errorf("expected unexpanded utensile, got %+v", utensile)
+		}
+	})
+
+	t.Run("expansion error", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Background, error) {
+				return newAccolito(), nil
+			},
+			GetTalentiByIDsFunc: func(_ context.Context, _ []string) (map[string]Talento, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, &MockUtensiliRepository{}, nil, logger)
+
+		_, err := service.GetBackground(ctx, "accolito", []Espansione{EspandiTalento}, nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != 500 {
+			t.Fatalf("expected internal AppError, got %v", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := NewService(&MockRepository{}, &MockUtensiliRepository{}, nil, logger)
+
+		_, err := service.GetBackground(ctx, "nonexistent", nil, nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+}
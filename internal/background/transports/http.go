@@ -0,0 +1,106 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/background"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type BackgroundService interface {
+	ListBackground(ctx context.Context, filter background.ListFilter) (*background.ListBackgroundResponse, error)
+	GetBackground(ctx context.Context, id string, espandi []background.Espansione, campi shared.Campi) (*background.Background, error)
+}
+
+type Handler struct {
+	service BackgroundService
+}
+
+func NewHandler(service BackgroundService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListBackground)
+	r.Get("/{id-background}", h.GetBackground)
+
+	return r
+}
+
+// RedirectLegacyPath permanently redirects the misspelled /backeground
+// path, published by earlier versions of the spec, to /background.
+func RedirectLegacyPath(w http.ResponseWriter, r *http.Request) {
+	target := strings.Replace(r.URL.Path, "/backeground", "/background", 1)
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+	http.Redirect(w, r, target, http.StatusPermanentRedirect)
+}
+
+func newListFilterFromRequest(r *http.Request) (background.ListFilter, error) {
+	base, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		return background.ListFilter{}, err
+	}
+	filter := background.ListFilter{ListFilter: base}
+	query := r.URL.Query()
+
+	if filter.CaratteristicheAssociate, err = shared.QueryEnumList(query, "caratteristica-associata", shared.Caratteristiche...); err != nil {
+		return filter, err
+	}
+	if filter.Espandi, err = shared.QueryEnumList(query, "espandi", background.Espansioni...); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) ListBackground(w http.ResponseWriter, r *http.Request) {
+	filter, err := newListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListBackground(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetBackground(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-background")
+	if err := shared.ValidateID("id-background", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	espandi, err := shared.QueryEnumList(r.URL.Query(), "espandi", background.Espansioni...)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	b, err := h.service.GetBackground(r.Context(), id, espandi, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, b, campi)
+}
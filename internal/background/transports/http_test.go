@@ -0,0 +1,154 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/background"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	listBackgroundFunc func(ctx context.Context, filter background.ListFilter) (*background.ListBackgroundResponse, error)
+	getBackgroundFunc  func(ctx context.Context, id string, espandi []background.Espansione, campi shared.Campi) (*background.Background, error)
+}
+
+func (m *mockService) ListBackground(ctx context.Context, filter background.ListFilter) (*background.ListBackgroundResponse, error) {
+	if m.listBackgroundFunc != nil {
+		return m.listBackgroundFunc(ctx, filter)
+	}
+	return &background.ListBackgroundResponse{}, nil
+}
+
+func (m *mockService) GetBackground(ctx context.Context, id string, espandi []background.Espansione, campi shared.Campi) (*background.Background, error) {
+	if m.getBackgroundFunc != nil {
+		return m.getBackgroundFunc(ctx, id, espandi, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc BackgroundService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/background", NewHandler(svc).Routes())
+	r.Mount("/backeground", http.HandlerFunc(RedirectLegacyPath))
+	return r
+}
+
+func TestHandler_ListBackground(t *testing.T) {
+	t.Run("with filters and expansion", func(t *testing.T) {
+		var captured background.ListFilter
+		svc := &mockService{
+			listBackgroundFunc: func(_ context.Context, filter background.ListFilter) (*background.ListBackgroundResponse, error) {
+				captured = filter
+				return &background.ListBackgroundResponse{PaginationMeta: shared.PaginationMeta{Pagina: 1}}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/background?caratteristica-associata=Saggezza&espandi=talento,utensili", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if len(captured.CaratteristicheAssociate) != 1 || captured.CaratteristicheAssociate[0] != shared.Saggezza {
+			t.Errorf("unexpected caratteristiche %v", captured.CaratteristicheAssociate)
+		}
+		if len(captured.Espandi) != 2 {
+			t.Errorf("unexpected espandi %v", captured.Espandi)
+		}
+	})
+
+	invalid := []struct {
+		name  string
+		query string
+	}{
+		{"unknown caratteristica", "caratteristica-associata=Fortuna"},
+		{"unknown expansion", "espandi=equipaggiamento"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/background?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_GetBackground(t *testing.T) {
+	t.Run("success with expansion", func(t *testing.T) {
+		var capturedEspandi []background.Espansione
+		svc := &mockService{
+			getBackgroundFunc: func(_ context.Context, id string, espandi []background.Espansione, _ shared.Campi) (*background.Background, error) {
+				capturedEspandi = espandi
+				return &background.Background{ID: id, Nome: "Accolito"}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/background/accolito?espandi=talento", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if len(capturedEspandi) != 1 || capturedEspandi[0] != background.EspandiTalento {
+			t.Errorf("unexpected espandi %v", capturedEspandi)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getBackgroundFunc: func(_ context.Context, id string, _ []background.Espansione, _ shared.Campi) (*background.Background, error) {
+				return nil, background.ErrBackgroundNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/background/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
+
+func TestRedirectLegacyPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		location string
+	}{
+		{"/backeground", "/background"},
+		{"/backeground/accolito", "/background/accolito"},
+		{"/backeground/accolito?espandi=talento", "/background/accolito?espandi=talento"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusPermanentRedirect {
+				t.Fatalf("expected status 308, got %d", rec.Code)
+			}
+			if got := rec.Header().Get("Location"); got != tt.location {
+				t.Errorf("expected location %q, got %q", tt.location, got)
+			}
+		})
+	}
+}
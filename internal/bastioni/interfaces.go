@@ -0,0 +1,8 @@
+package bastioni
+
+import "context"
+
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Bastione, int, error)
+	GetByID(ctx context.Context, id string) (*Bastione, error)
+}
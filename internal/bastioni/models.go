@@ -0,0 +1,67 @@
+package bastioni
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type TipoStruttura string
+
+const (
+	StrutturaBase     TipoStruttura = "Base"
+	StrutturaSpeciale TipoStruttura = "Speciale"
+)
+
+var TipiStruttura = []TipoStruttura{StrutturaBase, StrutturaSpeciale}
+
+type OrdineBastione string
+
+const (
+	Commerciare OrdineBastione = "Commerciare"
+	Creare      OrdineBastione = "Creare"
+	Mantenere   OrdineBastione = "Mantenere"
+	Potenziare  OrdineBastione = "Potenziare"
+	Raccogliere OrdineBastione = "Raccogliere"
+	Reclutare   OrdineBastione = "Reclutare"
+	Ricercare   OrdineBastione = "Ricercare"
+)
+
+var OrdiniBastione = []OrdineBastione{Commerciare, Creare, Mantenere, Potenziare, Raccogliere, Reclutare, Ricercare}
+
+type SpazioBastione string
+
+const (
+	Angusto  SpazioBastione = "Angusto"
+	Spazioso SpazioBastione = "Spazioso"
+	Vasto    SpazioBastione = "Vasto"
+)
+
+var SpaziBastione = []SpazioBastione{Angusto, Spazioso, Vasto}
+
+// MaxLivello is the highest character level.
+const MaxLivello = 20
+
+// Bastione is a bastion facility. LivelloRichiesto is the character level
+// needed to add it; Ordini lists the orders it can be given and Gregari the
+// number of hirelings that staff it.
+type Bastione struct {
+	ID                          string               `json:"id"`
+	Nome                        string               `json:"nome"`
+	Tipo                        TipoStruttura        `json:"tipo"`
+	LivelloRichiesto            int32                `json:"livello-richiesto"`
+	Prerequisito                string               `json:"prerequisito,omitempty"`
+	Spazio                      SpazioBastione       `json:"spazio"`
+	Ordini                      []OrdineBastione     `json:"ordini"`
+	Gregari                     int32                `json:"gregari"`
+	Descrizione                 string               `json:"descrizione,omitempty"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento"`
+}
+
+// ListFilter extends the shared list filter with the facility filters.
+// Livello keeps the facilities available at that character level; Ordine
+// and Spazio match any of the given values.
+type ListFilter struct {
+	shared.ListFilter
+	Tipo    *TipoStruttura
+	Livello *int32
+	Ordine  []OrdineBastione
+	Spazio  []SpazioBastione
+}
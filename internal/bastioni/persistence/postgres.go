@@ -0,0 +1,133 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/bastioni"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type bastioneRow struct {
+	ID                          string         `db:"id"`
+	Nome                        string         `db:"nome"`
+	Tipo                        string         `db:"tipo"`
+	LivelloRichiesto            int32          `db:"livello_richiesto"`
+	Prerequisito                sql.NullString `db:"prerequisito"`
+	Spazio                      string         `db:"spazio"`
+	Ordini                      pq.StringArray `db:"ordini"`
+	Gregari                     int32          `db:"gregari"`
+	Descrizione                 sql.NullString `db:"descrizione"`
+	DocumentazioneDiRiferimento string         `db:"documentazione_di_riferimento"`
+}
+
+func (r *bastioneRow) toBastione() bastioni.Bastione {
+	b := bastioni.Bastione{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Tipo:                        bastioni.TipoStruttura(r.Tipo),
+		LivelloRichiesto:            r.LivelloRichiesto,
+		Prerequisito:                r.Prerequisito.String,
+		Spazio:                      bastioni.SpazioBastione(r.Spazio),
+		Ordini:                      make([]bastioni.OrdineBastione, len(r.Ordini)),
+		Gregari:                     r.Gregari,
+		Descrizione:                 r.Descrizione.String,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+	for i, o := range r.Ordini {
+		b.Ordini[i] = bastioni.OrdineBastione(o)
+	}
+	return b
+}
+
+const selectBastione = `
+	SELECT id, nome, tipo, livello_richiesto, prerequisito, spazio, ordini, gregari,
+	       descrizione, documentazione_di_riferimento
+	FROM bastioni`
+
+// filterConditions translates the facility filters into SQL conditions and
+// their named arguments. Ordine uses array overlap so that a facility
+// matches when it accepts any of the requested orders.
+func filterConditions(filter bastioni.ListFilter) (string, map[string]any) {
+	var where string
+	args := make(map[string]any)
+
+	if filter.Tipo != nil {
+		where += ` AND tipo = :tipo`
+		args["tipo"] = string(*filter.Tipo)
+	}
+	if filter.Livello != nil {
+		where += ` AND livello_richiesto <= :livello`
+		args["livello"] = *filter.Livello
+	}
+	if len(filter.Ordine) > 0 {
+		ordini := make([]string, len(filter.Ordine))
+		for i, o := range filter.Ordine {
+			ordini[i] = string(o)
+		}
+		where += ` AND ordini && :ordine`
+		args["ordine"] = pq.Array(ordini)
+	}
+	if len(filter.Spazio) > 0 {
+		spazi := make([]string, len(filter.Spazio))
+		for i, s := range filter.Spazio {
+			spazi[i] = string(s)
+		}
+		where += ` AND spazio = ANY(:spazio)`
+		args["spazio"] = pq.Array(spazi)
+	}
+
+	return where, args
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filter bastioni.ListFilter) ([]bastioni.Bastione, int, error) {
+	where, args := filterConditions(filter)
+	q := shared.NewPaginatedQuery(
+		selectBastione+` WHERE 1=1`+where,
+		`SELECT COUNT(*) FROM bastioni WHERE 1=1`+where,
+		args,
+		filter.ListFilter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []bastioneRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]bastioni.Bastione, len(rows))
+	for i, row := range rows {
+		result[i] = row.toBastione()
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*bastioni.Bastione, error) {
+	var row bastioneRow
+	if err := r.db.GetContext(ctx, &row, selectBastione+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get bastione by id: %w", err)
+	}
+
+	bastione := row.toBastione()
+	return &bastione, nil
+}
@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/bastioni"
+)
+
+func TestFilterConditions(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		where, args := filterConditions(bastioni.ListFilter{})
+
+		if where != "" || len(args) != 0 {
+			t.Errorf("expected no conditions, got %q %v", where, args)
+		}
+	})
+
+	t.Run("facility filters", func(t *testing.T) {
+		tipo := bastioni.StrutturaSpeciale
+		livello := int32(9)
+		filter := bastioni.ListFilter{
+			Tipo:    &tipo,
+			Livello: &livello,
+			Ordine:  []bastioni.OrdineBastione{bastioni.Creare, bastioni.Commerciare},
+			Spazio:  []bastioni.SpazioBastione{bastioni.Vasto},
+		}
+
+		where, args := filterConditions(filter)
+
+		for _, cond := range []string{"tipo = :tipo", "livello_richiesto <= :livello", "ordini && :ordine", "spazio = ANY(:spazio)"} {
+			if !strings.Contains(where, cond) {
+				t.Errorf("expected %q in %q", cond, where)
+			}
+		}
+		if args["tipo"] != "Speciale" || args["livello"] != int32(9) {
+			t.Errorf("unexpected args %v", args)
+		}
+		ordini := args["ordine"].(*pq.StringArray)
+		if len(*ordini) != 2 || (*ordini)[1] != "Commerciare" {
+			t.Errorf("unexpected ordine arg %v", *ordini)
+		}
+	})
+}
+
+func TestBastioneRow_ToBastione(t *testing.T) {
+	row := bastioneRow{
+		ID:               "fucina",
+		Nome:             "Fucina",
+		Tipo:             "Speciale",
+		LivelloRichiesto: 5,
+		Spazio:           "Spazioso",
+		Ordini:           pq.StringArray{"Creare"},
+		Gregari:          2,
+	}
+
+	got := row.toBastione()
+
+	if got.Tipo != bastioni.StrutturaSpeciale || got.Spazio != bastioni.Spazioso || got.LivelloRichiesto != 5 {
+		t.Errorf("unexpected bastione %+v", got)
+	}
+	if len(got.Ordini) != 1 || got.Ordini[0] != bastioni.Creare {
+		t.Errorf("unexpected ordini %v", got.Ordini)
+	}
+}
@@ -0,0 +1,8 @@
+package bastioni
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type ListBastioniResponse struct {
+	shared.PaginationMeta
+	Bastioni []Bastione `json:"bastioni"`
+}
@@ -0,0 +1,54 @@
+package bastioni
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	repo      Repository
+	glossario shared.Glossario
+	logger    *slog.Logger
+}
+
+func NewService(repo Repository, glossario shared.Glossario, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:      repo,
+		glossario: glossario,
+		logger:    logger,
+	}
+}
+
+func (s *Service) ListBastioni(ctx context.Context, filter ListFilter) (*ListBastioniResponse, error) {
+	bastioni, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list bastioni", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListBastioniResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Bastioni:       bastioni,
+	}, nil
+}
+
+func (s *Service) GetBastione(ctx context.Context, id string, campi shared.Campi) (*Bastione, error) {
+	bastione, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get bastione", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if bastione == nil {
+		return nil, ErrBastioneNotFound(id)
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		bastione.Riferimenti = s.glossario.Riferimenti(ctx, bastione)
+	}
+	return bastione, nil
+}
@@ -0,0 +1,100 @@
+package bastioni
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestService_ListBastioni(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Bastione, int, error) {
+				return []Bastione{
+					{ID: "camera-da-letto", Nome: "Camera da Letto", Tipo: StrutturaBase},
+					{ID: "armeria", Nome: "Armeria", Tipo: StrutturaSpeciale},
+				}, 2, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.ListBastioni(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 2 || len(result.Bastioni) != 2 {
+			t.Errorf("expected 2 bastioni, got %d/%d", result.NumeroDiElementi, len(result.Bastioni))
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Bastione, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.ListBastioni(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestService_GetBastione(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Bastione, error) {
+				return &Bastione{ID: id, Nome: "Armeria"}, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.GetBastione(ctx, "armeria", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "armeria" {
+			t.Errorf("expected id 'armeria', got '%s'", result.ID)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := NewService(&MockRepository{}, nil, logger)
+
+		_, err := service.GetBastione(ctx, "nonexistent", nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+}
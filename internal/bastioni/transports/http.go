@@ -0,0 +1,102 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/bastioni"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type BastioniService interface {
+	ListBastioni(ctx context.Context, filter bastioni.ListFilter) (*bastioni.ListBastioniResponse, error)
+	GetBastione(ctx context.Context, id string, campi shared.Campi) (*bastioni.Bastione, error)
+}
+
+type Handler struct {
+	service BastioniService
+}
+
+func NewHandler(service BastioniService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListBastioni)
+	r.Get("/{id-bastione}", h.GetBastione)
+
+	return r
+}
+
+func newListFilterFromRequest(r *http.Request) (bastioni.ListFilter, error) {
+	base, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		return bastioni.ListFilter{}, err
+	}
+	filter := bastioni.ListFilter{ListFilter: base}
+	query := r.URL.Query()
+
+	if filter.Tipo, err = shared.QueryEnum(query, "tipo", bastioni.TipiStruttura...); err != nil {
+		return filter, err
+	}
+
+	livello, err := shared.QueryInt(query, "livello", 1, bastioni.MaxLivello)
+	if err != nil {
+		return filter, err
+	}
+	if livello != nil {
+		l := int32(*livello)
+		filter.Livello = &l
+	}
+
+	if filter.Ordine, err = shared.QueryEnumList(query, "ordine", bastioni.OrdiniBastione...); err != nil {
+		return filter, err
+	}
+	if filter.Spazio, err = shared.QueryEnumList(query, "spazio", bastioni.SpaziBastione...); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) ListBastioni(w http.ResponseWriter, r *http.Request) {
+	filter, err := newListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListBastioni(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetBastione(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-bastione")
+	if err := shared.ValidateID("id-bastione", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	bastione, err := h.service.GetBastione(r.Context(), id, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, bastione, campi)
+}
@@ -0,0 +1,138 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/bastioni"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	listBastioniFunc func(ctx context.Context, filter bastioni.ListFilter) (*bastioni.ListBastioniResponse, error)
+	getBastioneFunc  func(ctx context.Context, id string, campi shared.Campi) (*bastioni.Bastione, error)
+}
+
+func (m *mockService) ListBastioni(ctx context.Context, filter bastioni.ListFilter) (*bastioni.ListBastioniResponse, error) {
+	if m.listBastioniFunc != nil {
+		return m.listBastioniFunc(ctx, filter)
+	}
+	return &bastioni.ListBastioniResponse{}, nil
+}
+
+func (m *mockService) GetBastione(ctx context.Context, id string, campi shared.Campi) (*bastioni.Bastione, error) {
+	if m.getBastioneFunc != nil {
+		return m.getBastioneFunc(ctx, id, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc BastioniService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/bastioni", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_ListBastioni(t *testing.T) {
+	t.Run("with facility filters", func(t *testing.T) {
+		var captured bastioni.ListFilter
+		svc := &mockService{
+			listBastioniFunc: func(_ context.Context, filter bastioni.ListFilter) (*bastioni.ListBastioniResponse, error) {
+				captured = filter
+				return &bastioni.ListBastioniResponse{
+					PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+					Bastioni:       []bastioni.Bastione{{ID: "fucina", Nome: "Fucina"}},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/bastioni?tipo=Speciale&livello=9&ordine=Creare,Commerciare&spazio=Vasto", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if captured.Tipo == nil || *captured.Tipo != bastioni.StrutturaSpeciale {
+			t.Errorf("unexpected tipo %v", captured.Tipo)
+		}
+		if captured.Livello == nil || *captured.Livello != 9 {
+			t.Errorf("unexpected livello %v", captured.Livello)
+		}
+		if len(captured.Ordine) != 2 || len(captured.Spazio) != 1 {
+			t.Errorf("unexpected ordine/spazio %v %v", captured.Ordine, captured.Spazio)
+		}
+
+		var response bastioni.ListBastioniResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Bastioni) != 1 {
+			t.Errorf("expected 1 bastione, got %d", len(response.Bastioni))
+		}
+	})
+
+	invalid := []struct {
+		name  string
+		query string
+	}{
+		{"unknown tipo", "tipo=Avanzata"},
+		{"livello out of range", "livello=21"},
+		{"unknown ordine", "ordine=Saccheggiare"},
+		{"unknown spazio", "spazio=Enorme"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/bastioni?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_GetBastione(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := &mockService{
+			getBastioneFunc: func(_ context.Context, id string, _ shared.Campi) (*bastioni.Bastione, error) {
+				return &bastioni.Bastione{ID: id, Nome: "Fucina"}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/bastioni/fucina", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getBastioneFunc: func(_ context.Context, id string, _ shared.Campi) (*bastioni.Bastione, error) {
+				return nil, bastioni.ErrBastioneNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/bastioni/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,16 @@
+package calcoli
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+)
+
+// ClassiRepository is the class data the calculators are driven by.
+type ClassiRepository interface {
+	GetByID(ctx context.Context, id string) (*classi.Classe, error)
+	GetSottoclasseByID(ctx context.Context, classeID, sottoclasseID string) (*classi.SottoClasse, error)
+	// GetIncantatoriCompleti returns the full caster classes ordered by
+	// id; their spell slots are the multiclass spellcaster table.
+	GetIncantatoriCompleti(ctx context.Context) ([]classi.Classe, error)
+}
@@ -0,0 +1,34 @@
+package calcoli
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+)
+
+type MockClassiRepository struct {
+	GetByIDFunc                func(ctx context.Context, id string) (*classi.Classe, error)
+	GetSottoclasseByIDFunc     func(ctx context.Context, classeID, sottoclasseID string) (*classi.SottoClasse, error)
+	GetIncantatoriCompletiFunc func(ctx context.Context) ([]classi.Classe, error)
+}
+
+func (m *MockClassiRepository) GetByID(ctx context.Context, id string) (*classi.Classe, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockClassiRepository) GetSottoclasseByID(ctx context.Context, classeID, sottoclasseID string) (*classi.SottoClasse, error) {
+	if m.GetSottoclasseByIDFunc != nil {
+		return m.GetSottoclasseByIDFunc(ctx, classeID, sottoclasseID)
+	}
+	return nil, nil
+}
+
+func (m *MockClassiRepository) GetIncantatoriCompleti(ctx context.Context) ([]classi.Classe, error) {
+	if m.GetIncantatoriCompletiFunc != nil {
+		return m.GetIncantatoriCompletiFunc(ctx)
+	}
+	return nil, nil
+}
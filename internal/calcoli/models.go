@@ -0,0 +1,87 @@
+package calcoli
+
+import (
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+// LivelloDiClasse is one class of a (possibly multiclassed) character.
+type LivelloDiClasse struct {
+	IDClasse      string `json:"id-classe"`
+	Livello       int32  `json:"livello"`
+	IDSottoclasse string `json:"id-sotto-classe,omitempty"`
+}
+
+type RichiestaSlotIncantesimi struct {
+	Classi []LivelloDiClasse `json:"classi"`
+}
+
+// MagiaDelPatto is the pact magic of a warlock-like class, which is kept
+// apart from the other spell slots.
+type MagiaDelPatto struct {
+	IDClasse        string                   `json:"id-classe"`
+	Livello         int32                    `json:"livello"`
+	SlotIncantesimi []classi.SlotIncantesimo `json:"slot-incantesimi"`
+}
+
+type RisultatoSlotIncantesimi struct {
+	LivelloIncantatore int32                    `json:"livello-incantatore"`
+	SlotIncantesimi    []classi.SlotIncantesimo `json:"slot-incantesimi"`
+	MagiaDelPatto      *MagiaDelPatto           `json:"magia-del-patto,omitempty"`
+}
+
+// ModalitaPuntiFerita is how the hit points of the levels after the first
+// are determined.
+type ModalitaPuntiFerita string
+
+const (
+	MediaFissa ModalitaPuntiFerita = "media-fissa"
+	Massimo    ModalitaPuntiFerita = "massimo"
+	Tirati     ModalitaPuntiFerita = "tirati"
+)
+
+var ModalitaPuntiFeritaValide = []ModalitaPuntiFerita{MediaFissa, Massimo, Tirati}
+
+// RichiestaPuntiFerita lists the classes in the order they were taken:
+// the first level of the first class gets the maximum of its hit die. Seme
+// makes the Tirati mode reproducible.
+type RichiestaPuntiFerita struct {
+	Classi                   []LivelloDiClasse   `json:"classi"`
+	ModificatoreCostituzione int32               `json:"modificatore-costituzione"`
+	Modalita                 ModalitaPuntiFerita `json:"modalità,omitempty"`
+	Seme                     *uint64             `json:"seme,omitempty"`
+}
+
+type PuntiFeritaLivello struct {
+	Livello       int32             `json:"livello"`
+	IDClasse      string            `json:"id-classe"`
+	LivelloClasse int32             `json:"livello-classe"`
+	DadoVita      shared.TipoDiDado `json:"dado-vita"`
+	ValoreDado    int32             `json:"valore-dado"`
+	PuntiFerita   int32             `json:"punti-ferita"`
+}
+
+type RisultatoPuntiFerita struct {
+	Modalita ModalitaPuntiFerita  `json:"modalità"`
+	Seme     *uint64              `json:"seme,omitempty"`
+	Livelli  []PuntiFeritaLivello `json:"livelli"`
+	Totale   int32                `json:"totale"`
+}
+
+// RichiestaValuta converts the sum of Da into the currencies of A, or into
+// every currency when A is empty.
+type RichiestaValuta struct {
+	Da []shared.Importo
+	A  []shared.Valuta
+}
+
+// RisultatoValuta is the converted amount as the fewest coins of the
+// requested currencies. Resto is the part they cannot express, as the
+// fewest coins of any currency; Tassi the worth of each currency in MR.
+type RisultatoValuta struct {
+	Da           []shared.Importo        `json:"da"`
+	MoneteDiRame shared.Denaro           `json:"monete-di-rame"`
+	A            []shared.Importo        `json:"a"`
+	Resto        []shared.Importo        `json:"resto"`
+	Tassi        map[shared.Valuta]int64 `json:"tassi"`
+}
@@ -0,0 +1,304 @@
+package calcoli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+const (
+	minModificatore = -5
+	maxModificatore = 10
+)
+
+type Service struct {
+	classi ClassiRepository
+	logger *slog.Logger
+}
+
+func NewService(classi ClassiRepository, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		classi: classi,
+		logger: logger,
+	}
+}
+
+// livelloCaricato is a LivelloDiClasse with its class data loaded.
+type livelloCaricato struct {
+	LivelloDiClasse
+	classe      *classi.Classe
+	sottoclasse *classi.SottoClasse
+}
+
+// tipoIncantatore is the caster type of the subclass when it has one, of
+// the class otherwise.
+func (l livelloCaricato) tipoIncantatore() *classi.TipoIncantatore {
+	if l.sottoclasse != nil && l.sottoclasse.TipoIncantatore != nil {
+		return l.sottoclasse.TipoIncantatore
+	}
+	return l.classe.TipoIncantatore
+}
+
+func (l livelloCaricato) slot() []classi.SlotIncantesimo {
+	return classi.Progressione(l.classe, l.sottoclasse)[l.Livello-1].SlotIncantesimi
+}
+
+// SlotIncantesimi computes the spell slots of a multiclassed character. A
+// single spellcasting class uses its own table; with more than one, the
+// spellcaster level indexes the table of a full caster. Pact magic is
+// reported separately and never counts towards the spellcaster level.
+func (s *Service) SlotIncantesimi(ctx context.Context, richiesta RichiestaSlotIncantesimi) (*RisultatoSlotIncantesimi, error) {
+	if err := validaClassi(richiesta.Classi); err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	livelli, err := s.carica(ctx, richiesta.Classi)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RisultatoSlotIncantesimi{SlotIncantesimi: []classi.SlotIncantesimo{}}
+	var incantatori []livelloCaricato
+	for _, l := range livelli {
+		tipo := l.tipoIncantatore()
+		switch {
+		case tipo == nil:
+		case *tipo == classi.IncantatorePatto:
+			if result.MagiaDelPatto != nil {
+				err := errors.New("only one class with pact magic is supported")
+				return nil, shared.NewBadRequestError(err.Error(), err)
+			}
+			result.MagiaDelPatto = &MagiaDelPatto{IDClasse: l.IDClasse, Livello: l.Livello, SlotIncantesimi: l.slot()}
+		default:
+			incantatori = append(incantatori, l)
+			result.LivelloIncantatore += tipo.LivelloIncantatore(l.Livello)
+		}
+	}
+
+	switch {
+	case len(incantatori) == 1:
+		result.SlotIncantesimi = incantatori[0].slot()
+	case len(incantatori) > 1 && result.LivelloIncantatore > 0:
+		tabella, err := s.tabellaMulticlasse(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result.SlotIncantesimi = tabella[result.LivelloIncantatore-1].SlotIncantesimi
+	}
+	if result.SlotIncantesimi == nil {
+		result.SlotIncantesimi = []classi.SlotIncantesimo{}
+	}
+
+	return result, nil
+}
+
+// tabellaMulticlasse returns the multiclass spellcaster table, the levels
+// of the first full caster class by id. The full casters are expected to
+// share it; a class that does not is logged and ignored.
+func (s *Service) tabellaMulticlasse(ctx context.Context) ([]classi.Livello, error) {
+	completi, err := s.classi.GetIncantatoriCompleti(ctx)
+	if err != nil {
+		s.logger.Error("failed to get incantatori completi", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if len(completi) == 0 {
+		return nil, shared.NewInternalError(errors.New("no full caster class to read the multiclass spell slots from"))
+	}
+
+	tabella := classi.Progressione(&completi[0], nil)
+	for _, c := range completi[1:] {
+		altra := classi.Progressione(&c, nil)
+		uguali := slices.EqualFunc(tabella, altra, func(a, b classi.Livello) bool {
+			return slices.Equal(a.SlotIncantesimi, b.SlotIncantesimi)
+		})
+		if !uguali {
+			s.logger.Warn("full caster spell slots differ from the multiclass table",
+				"classe", c.ID, "riferimento", completi[0].ID)
+		}
+	}
+	return tabella, nil
+}
+
+// PuntiFerita computes the hit points gained at each character level. The
+// first level of the first class gets the maximum of its hit die, the
+// others the fixed average (half the die plus one), the maximum or a roll
+// depending on the mode. The Constitution modifier is added at every level
+// and a level always grants at least 1 hit point.
+func (s *Service) PuntiFerita(ctx context.Context, richiesta RichiestaPuntiFerita) (*RisultatoPuntiFerita, error) {
+	if err := validaPuntiFerita(&richiesta); err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	livelli, err := s.carica(ctx, richiesta.Classi)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RisultatoPuntiFerita{Modalita: richiesta.Modalita}
+	var rng *rand.Rand
+	if richiesta.Modalita == Tirati {
+		seme := rand.Uint64()
+		if richiesta.Seme != nil {
+			seme = *richiesta.Seme
+		}
+		result.Seme = &seme
+		rng = rand.New(rand.NewPCG(seme, seme))
+	}
+
+	var livello int32
+	for _, l := range livelli {
+		facce := int32(l.classe.DadoVita.Facce())
+		if facce == 0 {
+			err := fmt.Errorf("classe %s has invalid dado-vita %q", l.IDClasse, l.classe.DadoVita)
+			s.logger.Error("failed to compute punti ferita", "error", err)
+			return nil, shared.NewInternalError(err)
+		}
+
+		for lc := range l.Livello {
+			livello++
+			var dado int32
+			switch {
+			case livello == 1, richiesta.Modalita == Massimo:
+				dado = facce
+			case richiesta.Modalita == Tirati:
+				dado = rng.Int32N(facce) + 1
+			default:
+				dado = facce/2 + 1
+			}
+
+			pf := PuntiFeritaLivello{
+				Livello:       livello,
+				IDClasse:      l.IDClasse,
+				LivelloClasse: lc + 1,
+				DadoVita:      l.classe.DadoVita,
+				ValoreDado:    dado,
+				PuntiFerita:   max(dado+richiesta.ModificatoreCostituzione, 1),
+			}
+			result.Livelli = append(result.Livelli, pf)
+			result.Totale += pf.PuntiFerita
+		}
+	}
+
+	return result, nil
+}
+
+// ConvertiValuta converts an amount of money using the canonical exchange
+// rates of shared.Valuta.
+func (s *Service) ConvertiValuta(_ context.Context, richiesta RichiestaValuta) (*RisultatoValuta, error) {
+	if len(richiesta.Da) == 0 {
+		err := errors.New("da is required")
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	denaro := shared.DenaroDi(richiesta.Da...)
+	monete, resto, err := denaro.Normalizza(richiesta.A...)
+	if err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+	moneteResto, _, err := resto.Normalizza()
+	if err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	tassi := make(map[shared.Valuta]int64, len(shared.Valute))
+	for _, v := range shared.Valute {
+		tassi[v] = int64(v.Valore())
+	}
+
+	return &RisultatoValuta{
+		Da:           richiesta.Da,
+		MoneteDiRame: denaro,
+		A:            monete,
+		Resto:        moneteResto,
+		Tassi:        tassi,
+	}, nil
+}
+
+func validaClassi(livelli []LivelloDiClasse) error {
+	if len(livelli) == 0 {
+		return errors.New("classi is required")
+	}
+
+	var totale int32
+	visti := make(map[string]bool, len(livelli))
+	for _, l := range livelli {
+		if err := shared.ValidateID("id-classe", l.IDClasse); err != nil {
+			return err
+		}
+		if l.IDSottoclasse != "" {
+			if err := shared.ValidateID("id-sotto-classe", l.IDSottoclasse); err != nil {
+				return err
+			}
+		}
+		if l.Livello < 1 || l.Livello > classi.MaxLivello {
+			return fmt.Errorf("livello of %s must be between 1 and %d", l.IDClasse, classi.MaxLivello)
+		}
+		if visti[l.IDClasse] {
+			return fmt.Errorf("classe %s is listed more than once", l.IDClasse)
+		}
+		visti[l.IDClasse] = true
+		totale += l.Livello
+	}
+	if totale > classi.MaxLivello {
+		return fmt.Errorf("total character level cannot exceed %d", classi.MaxLivello)
+	}
+	return nil
+}
+
+func (s *Service) carica(ctx context.Context, livelli []LivelloDiClasse) ([]livelloCaricato, error) {
+	result := make([]livelloCaricato, len(livelli))
+	for i, l := range livelli {
+		classe, err := s.classi.GetByID(ctx, l.IDClasse)
+		if err != nil {
+			s.logger.Error("failed to get classe", "id", l.IDClasse, "error", err)
+			return nil, shared.NewInternalError(err)
+		}
+		if classe == nil {
+			return nil, classi.ErrClasseNotFound(l.IDClasse)
+		}
+		result[i] = livelloCaricato{LivelloDiClasse: l, classe: classe}
+
+		if l.IDSottoclasse == "" {
+			continue
+		}
+		sottoclasse, err := s.classi.GetSottoclasseByID(ctx, l.IDClasse, l.IDSottoclasse)
+		if err != nil {
+			s.logger.Error("failed to get sottoclasse", "classeID", l.IDClasse, "sottoclasseID", l.IDSottoclasse, "error", err)
+			return nil, shared.NewInternalError(err)
+		}
+		if sottoclasse == nil {
+			return nil, classi.ErrSottoclasseNotFound(l.IDSottoclasse)
+		}
+		result[i].sottoclasse = sottoclasse
+	}
+	return result, nil
+}
+
+func validaPuntiFerita(richiesta *RichiestaPuntiFerita) error {
+	if err := validaClassi(richiesta.Classi); err != nil {
+		return err
+	}
+	if richiesta.ModificatoreCostituzione < minModificatore || richiesta.ModificatoreCostituzione > maxModificatore {
+		return fmt.Errorf("modificatore-costituzione must be between %d and %d", minModificatore, maxModificatore)
+	}
+	if richiesta.Modalita == "" {
+		richiesta.Modalita = MediaFissa
+	}
+	if !slices.Contains(ModalitaPuntiFeritaValide, richiesta.Modalita) {
+		return fmt.Errorf("modalità must be one of: %s, %s, %s", MediaFissa, Massimo, Tirati)
+	}
+	if richiesta.Seme != nil && richiesta.Modalita != Tirati {
+		return fmt.Errorf("seme is only allowed with modalità %s", Tirati)
+	}
+	return nil
+}
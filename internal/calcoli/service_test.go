@@ -0,0 +1,465 @@
+package calcoli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func tipo(t classi.TipoIncantatore) *classi.TipoIncantatore { return &t }
+
+// slot builds the spellcasting of a level from the number of slots of
+// each spell level, starting at 1st.
+func slot(livello int32, numeri ...int32) classi.ProprietaLivello {
+	inc := &classi.IncantesimiClasse{}
+	for i, n := range numeri {
+		inc.SlotIncantesimi = append(inc.SlotIncantesimi, classi.SlotIncantesimo{NumeroSlot: n, LivelloSlotIncantesimo: int32(i + 1)})
+	}
+	return classi.ProprietaLivello{LivelloClasse: livello, IncantesimiClasse: inc}
+}
+
+var (
+	mago = &classi.Classe{ID: "mago", DadoVita: classi.D6, TipoIncantatore: tipo(classi.IncantatoreCompleto), ProprietaDiClasse: []classi.ProprietaLivello{
+		slot(1, 2), slot(2, 3), slot(3, 4, 2), slot(4, 4, 3), slot(5, 4, 3, 2), slot(6, 4, 3, 3),
+	}}
+	paladino = &classi.Classe{ID: "paladino", DadoVita: classi.D10, TipoIncantatore: tipo(classi.IncantatoreMezzo), ProprietaDiClasse: []classi.ProprietaLivello{
+		slot(1, 2), slot(3, 3), slot(5, 4, 2),
+	}}
+	guerriero = &classi.Classe{ID: "guerriero", DadoVita: classi.D10}
+	warlock   = &classi.Classe{ID: "warlock", DadoVita: classi.D8, TipoIncantatore: tipo(classi.IncantatorePatto), ProprietaDiClasse: []classi.ProprietaLivello{
+		slot(1, 1), slot(2, 2),
+	}}
+	cavaliereMistico = &classi.SottoClasse{ID: "cavaliere-mistico", IDClasseAssociata: "guerriero", TipoIncantatore: tipo(classi.IncantatoreTerzo), ProprietaDiSottoclasse: []classi.ProprietaLivello{
+		slot(3, 2),
+	}}
+)
+
+func newTestRepository() *MockClassiRepository {
+	classiByID := map[string]*classi.Classe{"mago": mago, "paladino": paladino, "guerriero": guerriero, "warlock": warlock}
+	return &MockClassiRepository{
+		GetByIDFunc: func(_ context.Context, id string) (*classi.Classe, error) {
+			return classiByID[id], nil
+		},
+		GetSottoclasseByIDFunc: func(_ context.Context, classeID, sottoclasseID string) (*classi.SottoClasse, error) {
+			if classeID == "guerriero" && sottoclasseID == "cavaliere-mistico" {
+				return cavaliereMistico, nil
+			}
+			return nil, nil
+		},
+		GetIncantatoriCompletiFunc: func(_ context.Context) ([]classi.Classe, error) {
+			return []classi.Classe{*mago}, nil
+		},
+	}
+}
+
+func TestService_SlotIncantesimi(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	tests := []struct {
+		name               string
+		classi             []LivelloDiClasse
+		livelloIncantatore int32
+		slot               []int32
+		patto              []int32
+	}{
+		{
+			name:               "single half caster uses its own table",
+			classi:             []LivelloDiClasse{{IDClasse: "paladino", Livello: 5}},
+			livelloIncantatore: 3,
+			slot:               []int32{4, 2},
+		},
+		{
+			name:               "half caster rounds up",
+			classi:             []LivelloDiClasse{{IDClasse: "paladino", Livello: 3}, {IDClasse: "mago", Livello: 2}},
+			livelloIncantatore: 4,
+			slot:               []int32{4, 3},
+		},
+		{
+			name: "third caster from the subclass rounds down",
+			classi: []LivelloDiClasse{
+				{IDClasse: "guerriero", Livello: 4, IDSottoclasse: "cavaliere-mistico"},
+				{IDClasse: "mago", Livello: 5},
+			},
+			livelloIncantatore: 6,
+			slot:               []int32{4, 3, 3},
+		},
+		{
+			name:               "class without spellcasting",
+			classi:             []LivelloDiClasse{{IDClasse: "guerriero", Livello: 5}, {IDClasse: "mago", Livello: 1}},
+			livelloIncantatore: 1,
+			slot:               []int32{2},
+		},
+		{
+			name:               "pact magic is kept separate",
+			classi:             []LivelloDiClasse{{IDClasse: "warlock", Livello: 2}, {IDClasse: "paladino", Livello: 1}},
+			livelloIncantatore: 1,
+			slot:               []int32{2},
+			patto:              []int32{2},
+		},
+		{
+			name:   "no spellcasting",
+			classi: []LivelloDiClasse{{IDClasse: "guerriero", Livello: 2, IDSottoclasse: "cavaliere-mistico"}},
+			slot:   []int32{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(newTestRepository(), logger)
+
+			result, err := service.SlotIncantesimi(ctx, RichiestaSlotIncantesimi{Classi: tt.classi})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.LivelloIncantatore != tt.livelloIncantatore {
+				t.Errorf("expected livello incantatore %d, got %d", tt.livelloIncantatore, result.LivelloIncantatore)
+			}
+			assertSlot(t, result.SlotIncantesimi, tt.slot)
+			if tt.patto == nil {
+				if result.MagiaDelPatto != nil {
+					t.Errorf("expected no magia del patto, got %+v", result.MagiaDelPatto)
+				}
+				return
+			}
+			if result.MagiaDelPatto == nil {
+				t.Fatal("expected magia del patto")
+			}
+			assertSlot(t, result.MagiaDelPatto.SlotIncantesimi, tt.patto)
+		})
+	}
+
+	invalid := []struct {
+		name   string
+		classi []LivelloDiClasse
+	}{
+		{"no classi", nil},
+		{"livello zero", []LivelloDiClasse{{IDClasse: "mago"}}},
+		{"total above 20", []LivelloDiClasse{{IDClasse: "mago", Livello: 15}, {IDClasse: "paladino", Livello: 6}}},
+		{"duplicate classe", []LivelloDiClasse{{IDClasse: "mago", Livello: 1}, {IDClasse: "mago", Livello: 2}}},
+		{"invalid id", []LivelloDiClasse{{IDClasse: "inv@lid", Livello: 1}}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			service := NewService(newTestRepository(), logger)
+
+			_, err := service.SlotIncantesimi(ctx, RichiestaSlotIncantesimi{Classi: tt.classi})
+
+			assertStatus(t, err, 400)
+		})
+	}
+
+	t.Run("classe not found", func(t *testing.T) {
+		service := NewService(newTestRepository(), logger)
+
+		_, err := service.SlotIncantesimi(ctx, RichiestaSlotIncantesimi{Classi: []LivelloDiClasse{{IDClasse: "monaco", Livello: 1}}})
+
+		assertStatus(t, err, 404)
+	})
+
+	t.Run("sottoclasse of another classe", func(t *testing.T) {
+		service := NewService(newTestRepository(), logger)
+
+		_, err := service.SlotIncantesimi(ctx, RichiestaSlotIncantesimi{Classi: []LivelloDiClasse{
+			{IDClasse: "mago", Livello: 3, IDSottoclasse: "cavaliere-mistico"},
+		}})
+
+		assertStatus(t, err, 404)
+	})
+
+	t.Run("full casters with different tables are logged", func(t *testing.T) {
+		chierico := classi.Classe{ID: "chierico", TipoIncantatore: tipo(classi.IncantatoreCompleto), ProprietaDiClasse: []classi.ProprietaLivello{slot(1, 3)}}
+		stregone := classi.Classe{ID: "stregone", TipoIncantatore: tipo(classi.IncantatoreCompleto), ProprietaDiClasse: mago.ProprietaDiClasse}
+		repo := newTestRepository()
+		repo.GetIncantatoriCompletiFunc = func(_ context.Context) ([]classi.Classe, error) {
+			return []classi.Classe{chierico, *mago, stregone}, nil
+		}
+		var log bytes.Buffer
+		service := NewService(repo, slog.New(slog.NewTextHandler(&log, nil)))
+
+		result, err := service.SlotIncantesimi(ctx, RichiestaSlotIncantesimi{Classi: []LivelloDiClasse{
+			{IDClasse: "paladino", Livello: 2}, {IDClasse: "mago", Livello: 1},
+		}})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertSlot(t, result.SlotIncantesimi, []int32{3})
+		if !strings.Contains(log.String(), "classe=mago") || !strings.Contains(log.String(), "classe=stregone") {
+			t.Errorf("expected a warning for mago and stregone, got %q", log.String())
+		}
+	})
+
+	t.Run("no full caster to read the table from", func(t *testing.T) {
+		repo := newTestRepository()
+		repo.GetIncantatoriCompletiFunc = nil
+		service := NewService(repo, logger)
+
+		_, err := service.SlotIncantesimi(ctx, RichiestaSlotIncantesimi{Classi: []LivelloDiClasse{
+			{IDClasse: "paladino", Livello: 2}, {IDClasse: "mago", Livello: 1},
+		}})
+
+		assertStatus(t, err, 500)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockClassiRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*classi.Classe, error) {
+				return nil, errors.New("database error")
+			},
+		}
+		service := NewService(repo, logger)
+
+		_, err := service.SlotIncantesimi(ctx, RichiestaSlotIncantesimi{Classi: []LivelloDiClasse{{IDClasse: "mago", Livello: 1}}})
+
+		assertStatus(t, err, 500)
+	})
+}
+
+func TestService_PuntiFerita(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+	seme := uint64(7)
+
+	tests := []struct {
+		name      string
+		richiesta RichiestaPuntiFerita
+		punti     []int32
+	}{
+		{
+			name:      "fixed average with the first level at maximum",
+			richiesta: RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "paladino", Livello: 2}, {IDClasse: "mago", Livello: 2}}, ModificatoreCostituzione: 2},
+			punti:     []int32{12, 8, 6, 6},
+		},
+		{
+			name:      "only the first class gets the maximum",
+			richiesta: RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "mago", Livello: 1}, {IDClasse: "paladino", Livello: 1}}},
+			punti:     []int32{6, 6},
+		},
+		{
+			name:      "maximum",
+			richiesta: RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "warlock", Livello: 3}}, ModificatoreCostituzione: 1, Modalita: Massimo},
+			punti:     []int32{9, 9, 9},
+		},
+		{
+			name:      "at least one hit point per level",
+			richiesta: RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "mago", Livello: 2}}, ModificatoreCostituzione: -5},
+			punti:     []int32{1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(newTestRepository(), logger)
+
+			result, err := service.PuntiFerita(ctx, tt.richiesta)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Livelli) != len(tt.punti) {
+				t.Fatalf("expected %d levels, got %+v", len(tt.punti), result.Livelli)
+			}
+			var totale int32
+			for i, want := range tt.punti {
+				if got := result.Livelli[i]; got.PuntiFerita != want || got.Livello != int32(i+1) {
+					t.Errorf("level %d: expected %d hit points, got %+v", i+1, want, got)
+				}
+				totale += want
+			}
+			if result.Totale != totale || result.Seme != nil {
+				t.Errorf("expected total %d without seed, got %d %v", totale, result.Totale, result.Seme)
+			}
+		})
+	}
+
+	t.Run("rolled with seed", func(t *testing.T) {
+		service := NewService(newTestRepository(), logger)
+		richiesta := RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "paladino", Livello: 10}}, Modalita: Tirati, Seme: &seme}
+
+		a, err := service.PuntiFerita(ctx, richiesta)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, _ := service.PuntiFerita(ctx, richiesta)
+
+		if a.Totale != b.Totale || a.Seme == nil || *a.Seme != seme {
+			t.Errorf("expected reproducible rolls, got %d and %d", a.Totale, b.Totale)
+		}
+		if a.Livelli[0].ValoreDado != 10 || a.Livelli[1].LivelloClasse != 2 || a.Livelli[1].DadoVita != classi.D10 {
+			t.Errorf("unexpected levels %+v", a.Livelli[:2])
+		}
+		for _, l := range a.Livelli {
+			if l.ValoreDado < 1 || l.ValoreDado > 10 {
+				t.Errorf("roll %d out of range", l.ValoreDado)
+			}
+		}
+	})
+
+	t.Run("rolled without seed reports the seed used", func(t *testing.T) {
+		service := NewService(newTestRepository(), logger)
+
+		result, err := service.PuntiFerita(ctx, RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "mago", Livello: 3}}, Modalita: Tirati})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Seme == nil {
+			t.Error("expected the seed used")
+		}
+	})
+
+	invalid := []struct {
+		name      string
+		richiesta RichiestaPuntiFerita
+	}{
+		{"no classi", RichiestaPuntiFerita{}},
+		{"modifier too low", RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "mago", Livello: 1}}, ModificatoreCostituzione: -6}},
+		{"unknown modalita", RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "mago", Livello: 1}}, Modalita: "media"}},
+		{"seed without rolls", RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "mago", Livello: 1}}, Seme: &seme}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			service := NewService(newTestRepository(), logger)
+
+			_, err := service.PuntiFerita(ctx, tt.richiesta)
+
+			assertStatus(t, err, 400)
+		})
+	}
+
+	t.Run("classe not found", func(t *testing.T) {
+		service := NewService(newTestRepository(), logger)
+
+		_, err := service.PuntiFerita(ctx, RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "monaco", Livello: 1}}})
+
+		assertStatus(t, err, 404)
+	})
+
+	t.Run("homebrew hit die", func(t *testing.T) {
+		repo := &MockClassiRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*classi.Classe, error) {
+				return &classi.Classe{ID: id, DadoVita: "d14"}, nil
+			},
+		}
+		service := NewService(repo, logger)
+
+		result, err := service.PuntiFerita(ctx, RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "titano", Livello: 2}}})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Totale != 14+8 {
+			t.Errorf("expected 22 hit points, got %d", result.Totale)
+		}
+	})
+
+	t.Run("invalid hit die", func(t *testing.T) {
+		repo := &MockClassiRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*classi.Classe, error) {
+				return &classi.Classe{ID: id, DadoVita: "tanti"}, nil
+			},
+		}
+		service := NewService(repo, logger)
+
+		_, err := service.PuntiFerita(ctx, RichiestaPuntiFerita{Classi: []LivelloDiClasse{{IDClasse: "titano", Livello: 1}}})
+
+		assertStatus(t, err, 500)
+	})
+}
+
+func assertSlot(t *testing.T, got []classi.SlotIncantesimo, want []int32) {
+	t.Helper()
+	if got == nil || len(got) != len(want) {
+		t.Fatalf("expected slots %v, got %+v", want, got)
+	}
+	for i, n := range want {
+		if got[i].LivelloSlotIncantesimo != int32(i+1) || got[i].NumeroSlot != n {
+			t.Errorf("expected slots %v, got %+v", want, got)
+		}
+	}
+}
+
+func assertStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var appErr *shared.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected AppError, got %v", err)
+	}
+	if appErr.HTTPStatus != status {
+		t.Errorf("expected status %d, got %d", status, appErr.HTTPStatus)
+	}
+}
+
+func TestService_ConvertiValuta(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(newTestRepository(), newTestLogger())
+
+	t.Run("single currency with remainder", func(t *testing.T) {
+		result, err := service.ConvertiValuta(ctx, RichiestaValuta{
+			Da: []shared.Importo{{Quantita: 15, Valuta: shared.MO}, {Quantita: 7, Valuta: shared.MA}},
+			A:  []shared.Valuta{shared.ME},
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.MoneteDiRame != 1570 {
+			t.Errorf("expected 1570 MR, got %d", result.MoneteDiRame)
+		}
+		if len(result.A) != 1 || result.A[0] != (shared.Importo{Quantita: 31, Valuta: shared.ME}) {
+			t.Errorf("unexpected conversion %v", result.A)
+		}
+		if len(result.Resto) != 1 || result.Resto[0] != (shared.Importo{Quantita: 2, Valuta: shared.MA}) {
+			t.Errorf("unexpected resto %v", result.Resto)
+		}
+		if result.Tassi[shared.MP] != 1000 || len(result.Tassi) != len(shared.Valute) {
+			t.Errorf("unexpected tassi %v", result.Tassi)
+		}
+	})
+
+	t.Run("normalisation into every currency", func(t *testing.T) {
+		result, err := service.ConvertiValuta(ctx, RichiestaValuta{
+			Da: []shared.Importo{{Quantita: 1234, Valuta: shared.MR}},
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.A) != 4 || result.A[0].Valuta != shared.MP || len(result.Resto) != 0 {
+			t.Errorf("unexpected result %+v", result)
+		}
+	})
+
+	t.Run("amount too large for the currency", func(t *testing.T) {
+		_, err := service.ConvertiValuta(ctx, RichiestaValuta{
+			Da: []shared.Importo{{Quantita: math.MaxInt32, Valuta: shared.MP}},
+			A:  []shared.Valuta{shared.MR},
+		})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != 400 {
+			t.Errorf("expected 400 AppError, got %v", err)
+		}
+	})
+
+	t.Run("missing da", func(t *testing.T) {
+		_, err := service.ConvertiValuta(ctx, RichiestaValuta{A: []shared.Valuta{shared.MO}})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != 400 {
+			t.Errorf("expected 400 AppError, got %v", err)
+		}
+	})
+}
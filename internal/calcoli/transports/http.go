@@ -0,0 +1,130 @@
+package transports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/calcoli"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type CalcoliService interface {
+	SlotIncantesimi(ctx context.Context, richiesta calcoli.RichiestaSlotIncantesimi) (*calcoli.RisultatoSlotIncantesimi, error)
+	PuntiFerita(ctx context.Context, richiesta calcoli.RichiestaPuntiFerita) (*calcoli.RisultatoPuntiFerita, error)
+	ConvertiValuta(ctx context.Context, richiesta calcoli.RichiestaValuta) (*calcoli.RisultatoValuta, error)
+}
+
+type Handler struct {
+	service CalcoliService
+}
+
+func NewHandler(service CalcoliService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/slot-incantesimi", h.SlotIncantesimi)
+	r.Post("/punti-ferita", h.PuntiFerita)
+	r.Get("/valuta", h.ConvertiValuta)
+
+	return r
+}
+
+func (h *Handler) SlotIncantesimi(w http.ResponseWriter, r *http.Request) {
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	var richiesta calcoli.RichiestaSlotIncantesimi
+	if err := shared.DecodeJSON(w, r, &richiesta); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	result, err := h.service.SlotIncantesimi(r.Context(), richiesta)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, result, campi)
+}
+
+func (h *Handler) PuntiFerita(w http.ResponseWriter, r *http.Request) {
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	var richiesta calcoli.RichiestaPuntiFerita
+	if err := shared.DecodeJSON(w, r, &richiesta); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	result, err := h.service.PuntiFerita(r.Context(), richiesta)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, result, campi)
+}
+
+// newRichiestaValuta reads da, one or more amounts such as "15 MO" that are
+// added up, and a, the currencies to convert to.
+func newRichiestaValuta(r *http.Request) (calcoli.RichiestaValuta, error) {
+	var richiesta calcoli.RichiestaValuta
+	query := r.URL.Query()
+
+	da, err := shared.QueryList(query, "da")
+	if err != nil {
+		return richiesta, err
+	}
+	if len(da) == 0 {
+		return richiesta, errors.New("da is required")
+	}
+	for _, value := range da {
+		importo, err := shared.ParseImporto(value)
+		if err != nil {
+			return richiesta, fmt.Errorf("da: %w", err)
+		}
+		richiesta.Da = append(richiesta.Da, importo)
+	}
+
+	if richiesta.A, err = shared.QueryEnumList(query, "a", shared.Valute...); err != nil {
+		return richiesta, err
+	}
+	return richiesta, nil
+}
+
+func (h *Handler) ConvertiValuta(w http.ResponseWriter, r *http.Request) {
+	richiesta, err := newRichiestaValuta(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	result, err := h.service.ConvertiValuta(r.Context(), richiesta)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, result, campi)
+}
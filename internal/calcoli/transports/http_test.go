@@ -0,0 +1,240 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/calcoli"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	slotIncantesimiFunc func(ctx context.Context, richiesta calcoli.RichiestaSlotIncantesimi) (*calcoli.RisultatoSlotIncantesimi, error)
+	puntiFeritaFunc     func(ctx context.Context, richiesta calcoli.RichiestaPuntiFerita) (*calcoli.RisultatoPuntiFerita, error)
+	convertiValutaFunc  func(ctx context.Context, richiesta calcoli.RichiestaValuta) (*calcoli.RisultatoValuta, error)
+}
+
+func (m *mockService) SlotIncantesimi(ctx context.Context, richiesta calcoli.RichiestaSlotIncantesimi) (*calcoli.RisultatoSlotIncantesimi, error) {
+	if m.slotIncantesimiFunc != nil {
+		return m.slotIncantesimiFunc(ctx, richiesta)
+	}
+	return &calcoli.RisultatoSlotIncantesimi{}, nil
+}
+
+func (m *mockService) PuntiFerita(ctx context.Context, richiesta calcoli.RichiestaPuntiFerita) (*calcoli.RisultatoPuntiFerita, error) {
+	if m.puntiFeritaFunc != nil {
+		return m.puntiFeritaFunc(ctx, richiesta)
+	}
+	return &calcoli.RisultatoPuntiFerita{}, nil
+}
+
+func (m *mockService) ConvertiValuta(ctx context.Context, richiesta calcoli.RichiestaValuta) (*calcoli.RisultatoValuta, error) {
+	if m.convertiValutaFunc != nil {
+		return m.convertiValutaFunc(ctx, richiesta)
+	}
+	return &calcoli.RisultatoValuta{}, nil
+}
+
+func newTestRouter(svc CalcoliService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/calcoli", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_SlotIncantesimi(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var captured calcoli.RichiestaSlotIncantesimi
+		svc := &mockService{
+			slotIncantesimiFunc: func(_ context.Context, richiesta calcoli.RichiestaSlotIncantesimi) (*calcoli.RisultatoSlotIncantesimi, error) {
+				captured = richiesta
+				return &calcoli.RisultatoSlotIncantesimi{
+					LivelloIncantatore: 3,
+					SlotIncantesimi:    []classi.SlotIncantesimo{{NumeroSlot: 4, LivelloSlotIncantesimo: 1}},
+				}, nil
+			},
+		}
+
+		body := `{"classi":[{"id-classe":"guerriero","livello":3,"id-sotto-classe":"cavaliere-mistico"},{"id-classe":"mago","livello":2}]}`
+		req := httptest.NewRequest(http.MethodPost, "/calcoli/slot-incantesimi", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if len(captured.Classi) != 2 || captured.Classi[0].IDSottoclasse != "cavaliere-mistico" || captured.Classi[1].Livello != 2 {
+			t.Errorf("unexpected request %+v", captured)
+		}
+
+		var response calcoli.RisultatoSlotIncantesimi
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.LivelloIncantatore != 3 || len(response.SlotIncantesimi) != 1 {
+			t.Errorf("unexpected response %+v", response)
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		svc := &mockService{
+			slotIncantesimiFunc: func(_ context.Context, _ calcoli.RichiestaSlotIncantesimi) (*calcoli.RisultatoSlotIncantesimi, error) {
+				return nil, classi.ErrClasseNotFound("monaco")
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/calcoli/slot-incantesimi", strings.NewReader(`{"classi":[{"id-classe":"monaco","livello":1}]}`))
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+
+	for _, body := range []string{``, `{"classi":`, `{"classi":[{"id_classe":"mago"}]}`} {
+		t.Run("invalid body "+body, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/calcoli/slot-incantesimi", strings.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_PuntiFerita(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var captured calcoli.RichiestaPuntiFerita
+		svc := &mockService{
+			puntiFeritaFunc: func(_ context.Context, richiesta calcoli.RichiestaPuntiFerita) (*calcoli.RisultatoPuntiFerita, error) {
+				captured = richiesta
+				return &calcoli.RisultatoPuntiFerita{Modalita: richiesta.Modalita, Totale: 12}, nil
+			},
+		}
+
+		body := `{"classi":[{"id-classe":"guerriero","livello":1}],"modificatore-costituzione":2,"modalità":"tirati","seme":3}`
+		req := httptest.NewRequest(http.MethodPost, "/calcoli/punti-ferita", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if captured.ModificatoreCostituzione != 2 || captured.Modalita != calcoli.Tirati || captured.Seme == nil || *captured.Seme != 3 {
+			t.Errorf("unexpected request %+v", captured)
+		}
+
+		var response calcoli.RisultatoPuntiFerita
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Totale != 12 {
+			t.Errorf("unexpected response %+v", response)
+		}
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/calcoli/punti-ferita", strings.NewReader(`{"classi":[],"costituzione":2}`))
+		rec := httptest.NewRecorder()
+
+		newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestHandler_ConvertiValuta(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var captured calcoli.RichiestaValuta
+		svc := &mockService{
+			convertiValutaFunc: func(_ context.Context, richiesta calcoli.RichiestaValuta) (*calcoli.RisultatoValuta, error) {
+				captured = richiesta
+				return &calcoli.RisultatoValuta{MoneteDiRame: 1570}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/calcoli/valuta?da=15+MO,7ma&a=ME&a=MA", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if len(captured.Da) != 2 || captured.Da[1] != (shared.Importo{Quantita: 7, Valuta: shared.MA}) {
+			t.Errorf("unexpected da %v", captured.Da)
+		}
+		if len(captured.A) != 2 || captured.A[0] != shared.ME {
+			t.Errorf("unexpected a %v", captured.A)
+		}
+
+		var response calcoli.RisultatoValuta
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.MoneteDiRame != 1570 {
+			t.Errorf("unexpected response %+v", response)
+		}
+	})
+
+	t.Run("campi", func(t *testing.T) {
+		svc := &mockService{
+			convertiValutaFunc: func(_ context.Context, _ calcoli.RichiestaValuta) (*calcoli.RisultatoValuta, error) {
+				return &calcoli.RisultatoValuta{MoneteDiRame: 1570, Tassi: map[shared.Valuta]int64{shared.MO: 100}}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/calcoli/valuta?da=15+MO&campi=monete-di-rame", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var response map[string]any
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response) != 1 || response["monete-di-rame"] != float64(1570) {
+			t.Errorf("expected only monete-di-rame, got %v", response)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing da", "?a=MO"},
+		{"invalid da", "?da=quindici+MO"},
+		{"invalid valuta in da", "?da=15+MX"},
+		{"invalid a", "?da=15+MO&a=MX"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/calcoli/valuta"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
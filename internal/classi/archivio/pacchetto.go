@@ -0,0 +1,183 @@
+package archivio
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+)
+
+// LeggiPacchetto reads a content pack from the .json, .yaml and .yml files
+// of fsys and its subdirectories, in lexical order. Each file holds a
+// classi.Pacchetto with the JSON field names; the YAML files are converted
+// to JSON, so that both formats are decoded the same way. The .ndjson files
+// of an export are read too, and the manifest.json of an extracted
+// snapshot is not a pack: the files it lists are checked against it
+// instead. Unknown fields are an error.
+func LeggiPacchetto(fsys fs.FS) (classi.Pacchetto, error) {
+	var pacchetto classi.Pacchetto
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		if path.Base(name) == manifestSnapshot {
+			return verificaManifest(fsys, name)
+		}
+
+		var data []byte
+		switch strings.ToLower(path.Ext(name)) {
+		case ".ndjson":
+			if data, err = fs.ReadFile(fsys, name); err != nil {
+				return err
+			}
+			if err := decodeEsportazione(data, &pacchetto); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			return nil
+		case ".json":
+			if data, err = fs.ReadFile(fsys, name); err != nil {
+				return err
+			}
+		case ".yaml", ".yml":
+			if data, err = fs.ReadFile(fsys, name); err != nil {
+				return err
+			}
+			if data, err = yamlToJSON(data); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		default:
+			return nil
+		}
+
+		parte, err := decodePacchetto(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		pacchetto.Classi = append(pacchetto.Classi, parte.Classi...)
+		pacchetto.Sottoclassi = append(pacchetto.Sottoclassi, parte.Sottoclassi...)
+		return nil
+	})
+	if err != nil {
+		return classi.Pacchetto{}, fmt.Errorf("read pacchetto: %w", err)
+	}
+	return pacchetto, nil
+}
+
+// verificaManifest checks the files listed by the manifest.json nome, which
+// sit next to it, against their checksum and number of records.
+func verificaManifest(fsys fs.FS, nome string) error {
+	data, err := fs.ReadFile(fsys, nome)
+	if err != nil {
+		return err
+	}
+	manifest, err := leggiManifest(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path.Dir(nome), err)
+	}
+	for _, f := range manifest.File {
+		v, err := verificaFile(fsys, path.Join(path.Dir(nome), f.Nome))
+		if err != nil {
+			return err
+		}
+		if err := f.controlla(v); err != nil {
+			return fmt.Errorf("%s: %w", path.Dir(nome), err)
+		}
+	}
+	return nil
+}
+
+// verificaFile hashes and counts the file nome, nil when it is missing.
+func verificaFile(fsys fs.FS, nome string) (*verifica, error) {
+	f, err := fsys.Open(nome)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	v := nuovaVerifica()
+	if _, err := io.Copy(v, f); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func decodePacchetto(data []byte) (classi.Pacchetto, error) {
+	var pacchetto classi.Pacchetto
+	if err := decodeStrict(data, &pacchetto); err != nil {
+		return classi.Pacchetto{}, err
+	}
+	return pacchetto, nil
+}
+
+// decodeStrict decodes a single JSON value, rejecting unknown fields.
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return fmt.Errorf("unexpected data after the JSON value")
+	}
+	return nil
+}
+
+// decodeEsportazione appends the records of an NDJSON export to
+// pacchetto.
+func decodeEsportazione(data []byte, pacchetto *classi.Pacchetto) error {
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var record struct {
+			Tipo classi.TipoEsportazione `json:"tipo"`
+			Dati json.RawMessage         `json:"dati"`
+		}
+		if err := decodeStrict(line, &record); err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		var err error
+		switch record.Tipo {
+		case classi.EsportazioneClassi:
+			var c classi.Classe
+			if err = decodeStrict(record.Dati, &c); err == nil {
+				pacchetto.Classi = append(pacchetto.Classi, c)
+			}
+		case classi.EsportazioneSottoclassi:
+			var s classi.SottoClasse
+			if err = decodeStrict(record.Dati, &s); err == nil {
+				pacchetto.Sottoclassi = append(pacchetto.Sottoclassi, s)
+			}
+		default:
+			err = fmt.Errorf("unknown tipo %q", record.Tipo)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,129 @@
+package archivio
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLeggiPacchetto(t *testing.T) {
+	t.Run("json and yaml files", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"classi/barbaro.json": {Data: []byte(`{"classi": [{"id": "barbaro", "nome": "Barbaro", "dado-vita": "d12"}]}`)},
+			"classi/mago.yaml": {Data: []byte(`
+classi:
+  - id: mago
+    nome: Mago
+    dado-vita: d6
+    tipo-incantatore: Completo
+    sotto-classi:
+      - id: evocatore
+        nome: Evocatore
+`)},
+			"sottoclassi.yml": {Data: []byte(`
+sotto-classi:
+  - id: berserker
+    nome: Berserker
+    id-classe-associata: barbaro
+`)},
+			"LEGGIMI.md": {Data: []byte("not a content pack")},
+		}
+
+		pacchetto, err := LeggiPacchetto(fsys)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pacchetto.Classi) != 2 || pacchetto.Classi[0].ID != "barbaro" || pacchetto.Classi[1].ID != "mago" {
+			t.Fatalf("unexpected classi %+v", pacchetto.Classi)
+		}
+		mago := pacchetto.Classi[1]
+		if mago.TipoIncantatore == nil || *mago.TipoIncantatore != "Completo" || len(mago.Sottoclassi) != 1 {
+			t.Errorf("unexpected mago %+v", mago)
+		}
+		if len(pacchetto.Sottoclassi) != 1 || pacchetto.Sottoclassi[0].IDClasseAssociata != "barbaro" {
+			t.Errorf("unexpected sottoclassi %+v", pacchetto.Sottoclassi)
+		}
+	})
+
+	t.Run("ndjson export", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"classi.ndjson": {Data: []byte(`{"tipo":"classi","dati":{"id":"mago","nome":"Mago","dado-vita":"d6","elenco-sottoclassi":[{"id-sottoclasse":"evocatore"}]}}
+{"tipo":"sottoclassi","dati":{"id":"evocatore","nome":"Evocatore","id-classe-associata":"mago"}}
+`)},
+			"altro.ndjson": {Data: []byte(`{"tipo":"mostri","dati":{}}` + "\n")},
+		}
+
+		_, err := LeggiPacchetto(fsys)
+		if err == nil || !strings.Contains(err.Error(), "altro.ndjson: line 1") {
+			t.Errorf("expected an error on the unknown tipo, got %v", err)
+		}
+
+		delete(fsys, "altro.ndjson")
+		pacchetto, err := LeggiPacchetto(fsys)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pacchetto.Classi) != 1 || len(pacchetto.Sottoclassi) != 1 || pacchetto.Sottoclassi[0].IDClasseAssociata != "mago" {
+			t.Errorf("unexpected pacchetto %+v", pacchetto)
+		}
+	})
+
+	t.Run("extracted snapshot with its manifest", func(t *testing.T) {
+		classi := []byte(`{"tipo":"classi","dati":{"id":"mago","nome":"Mago","dado-vita":"d6"}}` + "\n")
+		somma := sha256.Sum256(classi)
+		manifest := func(numeroElementi int) []byte {
+			return []byte(fmt.Sprintf(`{"versione-formato":1,"versione":"20261016T120000Z","file":[{"nome":"classi.ndjson","tipo":"classi","numero-elementi":%d,"sha256":%q}]}`,
+				numeroElementi, hex.EncodeToString(somma[:])))
+		}
+		fsys := fstest.MapFS{
+			"snapshot/classi.ndjson": {Data: classi},
+			"snapshot/manifest.json": {Data: manifest(1)},
+		}
+
+		pacchetto, err := LeggiPacchetto(fsys)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pacchetto.Classi) != 1 || pacchetto.Classi[0].ID != "mago" {
+			t.Errorf("unexpected pacchetto %+v", pacchetto)
+		}
+
+		fsys["snapshot/manifest.json"] = &fstest.MapFile{Data: manifest(2)}
+		if _, err := LeggiPacchetto(fsys); err == nil || !strings.Contains(err.Error(), "classi.ndjson: 1 records") {
+			t.Errorf("expected a record count mismatch, got %v", err)
+		}
+
+		delete(fsys, "snapshot/classi.ndjson")
+		if _, err := LeggiPacchetto(fsys); err == nil || !strings.Contains(err.Error(), "classi.ndjson is missing") {
+			t.Errorf("expected a missing file error, got %v", err)
+		}
+	})
+
+	t.Run("unknown fields", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"mago.yaml": {Data: []byte("classi:\n  - id: mago\n    dado: d6\n")},
+		}
+
+		_, err := LeggiPacchetto(fsys)
+
+		if err == nil || !strings.Contains(err.Error(), "mago.yaml") || !strings.Contains(err.Error(), "dado") {
+			t.Errorf("expected an error naming the file and the field, got %v", err)
+		}
+	})
+
+	t.Run("invalid yaml", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"mago.yml": {Data: []byte("classi: [\n")},
+		}
+
+		if _, err := LeggiPacchetto(fsys); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -0,0 +1,313 @@
+package archivio
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+)
+
+// VersioneFormatoSnapshot is the version of the layout of a snapshot,
+// raised when a change makes the old readers unable to load it.
+const VersioneFormatoSnapshot = 1
+
+// ManifestSnapshot is the manifest.json of a snapshot. Versione names the
+// snapshot after the instant it was taken.
+type ManifestSnapshot struct {
+	VersioneFormato int            `json:"versione-formato"`
+	Versione        string         `json:"versione"`
+	CreatoIl        time.Time      `json:"creato-il"`
+	File            []FileSnapshot `json:"file"`
+}
+
+// FileSnapshot is an NDJSON file of a snapshot with the number of its
+// records and the SHA-256 of its content.
+type FileSnapshot struct {
+	Nome           string                  `json:"nome"`
+	Tipo           classi.TipoEsportazione `json:"tipo"`
+	NumeroElementi int                     `json:"numero-elementi"`
+	SHA256         string                  `json:"sha256"`
+}
+
+const manifestSnapshot = "manifest.json"
+
+// Esportatore streams the classi dataset, as classi.Service does.
+type Esportatore interface {
+	Esporta(ctx context.Context, tipi []classi.TipoEsportazione, fn func(classi.RecordEsportazione) error) error
+}
+
+// VersioneSnapshot formats the instant a snapshot is taken as its version.
+func VersioneSnapshot(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// ScriviSnapshot writes a .tar.gz snapshot of the requested types to w: an
+// NDJSON file per type, in the format of the export route, and then
+// manifest.json. No tipi means all of them. Each type is streamed to a
+// temporary file, counted and hashed as it is written, since a tar entry
+// needs its size first; nothing is held in memory.
+func ScriviSnapshot(ctx context.Context, w io.Writer, esportatore Esportatore, tipi []classi.TipoEsportazione, creatoIl time.Time) (*ManifestSnapshot, error) {
+	tipi = classi.TipiDaEsportare(tipi)
+
+	tmp, err := os.MkdirTemp("", "qe-snapshot-")
+	if err != nil {
+		return nil, fmt.Errorf("write snapshot: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	file := make(map[classi.TipoEsportazione]*fileTemporaneo, len(tipi))
+	for _, tipo := range tipi {
+		f, err := creaFileTemporaneo(filepath.Join(tmp, string(tipo)+".ndjson"))
+		if err != nil {
+			return nil, fmt.Errorf("write snapshot: %w", err)
+		}
+		defer f.file.Close()
+		file[tipo] = f
+	}
+
+	err = esportatore.Esporta(ctx, tipi, func(record classi.RecordEsportazione) error {
+		f, ok := file[record.Tipo]
+		if !ok {
+			return fmt.Errorf("write snapshot: unexpected tipo %q", record.Tipo)
+		}
+		return f.enc.Encode(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &ManifestSnapshot{
+		VersioneFormato: VersioneFormatoSnapshot,
+		Versione:        VersioneSnapshot(creatoIl),
+		CreatoIl:        creatoIl.UTC(),
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, tipo := range tipi {
+		f := file[tipo]
+		nome := string(tipo) + ".ndjson"
+		if err := f.copia(tw, nome, manifest.CreatoIl); err != nil {
+			return nil, err
+		}
+		manifest.File = append(manifest.File, FileSnapshot{
+			Nome:           nome,
+			Tipo:           tipo,
+			NumeroElementi: f.verifica.righe,
+			SHA256:         f.verifica.sha256(),
+		})
+	}
+
+	datiManifest, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := scriviFileTar(tw, manifestSnapshot, bytes.NewReader(datiManifest), int64(len(datiManifest)), manifest.CreatoIl); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// fileTemporaneo is the NDJSON file of a type while the snapshot is
+// written.
+type fileTemporaneo struct {
+	file     *os.File
+	buf      *bufio.Writer
+	enc      *json.Encoder
+	verifica *verifica
+}
+
+func creaFileTemporaneo(nome string) (*fileTemporaneo, error) {
+	file, err := os.Create(nome)
+	if err != nil {
+		return nil, err
+	}
+	buf := bufio.NewWriter(file)
+	v := nuovaVerifica()
+	return &fileTemporaneo{file: file, buf: buf, enc: json.NewEncoder(io.MultiWriter(buf, v)), verifica: v}, nil
+}
+
+// copia writes the file to tw as nome.
+func (f *fileTemporaneo) copia(tw *tar.Writer, nome string, modificato time.Time) error {
+	if err := f.buf.Flush(); err != nil {
+		return fmt.Errorf("write %s: %w", nome, err)
+	}
+	dimensione, err := f.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", nome, err)
+	}
+	if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("write %s: %w", nome, err)
+	}
+	return scriviFileTar(tw, nome, f.file, dimensione, modificato)
+}
+
+func scriviFileTar(tw *tar.Writer, nome string, r io.Reader, dimensione int64, modificato time.Time) error {
+	header := &tar.Header{Name: nome, Mode: 0o644, Size: dimensione, ModTime: modificato, Typeflag: tar.TypeReg}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("write %s: %w", nome, err)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return fmt.Errorf("write %s: %w", nome, err)
+	}
+	return nil
+}
+
+// verifica hashes and counts the lines of what is written to it.
+type verifica struct {
+	somma hash.Hash
+	righe int
+}
+
+func nuovaVerifica() *verifica {
+	return &verifica{somma: sha256.New()}
+}
+
+func (v *verifica) Write(p []byte) (int, error) {
+	v.somma.Write(p)
+	v.righe += bytes.Count(p, []byte("\n"))
+	return len(p), nil
+}
+
+func (v *verifica) sha256() string {
+	return hex.EncodeToString(v.somma.Sum(nil))
+}
+
+// EstraiSnapshot checks a .tar.gz snapshot against its manifest and writes
+// its NDJSON files to dir, where LeggiPacchetto can load them. The files
+// are streamed to a temporary directory in dir, counted and hashed as
+// they are read, and moved to dir only once every file has the checksum
+// and the number of records of the manifest. The manifest may come before
+// or after the files.
+func EstraiSnapshot(r io.Reader, dir string) (*ManifestSnapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	defer gz.Close()
+
+	tmp, err := os.MkdirTemp(dir, ".snapshot-")
+	if err != nil {
+		return nil, fmt.Errorf("extract snapshot: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	var datiManifest []byte
+	verifiche := make(map[string]*verifica)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot: %w", err)
+		}
+		switch {
+		case header.Typeflag != tar.TypeReg:
+		case header.Name == manifestSnapshot:
+			if datiManifest, err = io.ReadAll(tr); err != nil {
+				return nil, fmt.Errorf("read snapshot: %s: %w", header.Name, err)
+			}
+		case nomeNDJSON(header.Name):
+			v, err := estraiFile(tr, filepath.Join(tmp, header.Name))
+			if err != nil {
+				return nil, fmt.Errorf("read snapshot: %s: %w", header.Name, err)
+			}
+			verifiche[header.Name] = v
+		}
+	}
+
+	if datiManifest == nil {
+		return nil, fmt.Errorf("read snapshot: %s is missing", manifestSnapshot)
+	}
+	manifest, err := leggiManifest(datiManifest)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	for _, f := range manifest.File {
+		if err := f.controlla(verifiche[f.Nome]); err != nil {
+			return nil, fmt.Errorf("read snapshot: %w", err)
+		}
+	}
+
+	for _, f := range manifest.File {
+		if err := os.Rename(filepath.Join(tmp, f.Nome), filepath.Join(dir, f.Nome)); err != nil {
+			return nil, fmt.Errorf("extract snapshot: %w", err)
+		}
+	}
+	return manifest, nil
+}
+
+// leggiManifest decodes a manifest.json and checks its format version and
+// file names.
+func leggiManifest(data []byte) (*ManifestSnapshot, error) {
+	var manifest ManifestSnapshot
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("%s: %w", manifestSnapshot, err)
+	}
+	if manifest.VersioneFormato != VersioneFormatoSnapshot {
+		return nil, fmt.Errorf("%s: unsupported versione-formato %d", manifestSnapshot, manifest.VersioneFormato)
+	}
+	for _, f := range manifest.File {
+		if !nomeNDJSON(f.Nome) {
+			return nil, fmt.Errorf("%s: invalid file name %q", manifestSnapshot, f.Nome)
+		}
+	}
+	return &manifest, nil
+}
+
+// controlla checks the checksum and the number of records of the file
+// against v, nil when the file is missing.
+func (f FileSnapshot) controlla(v *verifica) error {
+	if v == nil {
+		return fmt.Errorf("%s is missing", f.Nome)
+	}
+	if v.sha256() != f.SHA256 {
+		return fmt.Errorf("%s: checksum mismatch", f.Nome)
+	}
+	if v.righe != f.NumeroElementi {
+		return fmt.Errorf("%s: %d records, the manifest lists %d", f.Nome, v.righe, f.NumeroElementi)
+	}
+	return nil
+}
+
+// nomeNDJSON reports whether nome is an NDJSON file at the root of a
+// snapshot.
+func nomeNDJSON(nome string) bool {
+	return path.Base(nome) == nome && path.Ext(nome) == ".ndjson"
+}
+
+// estraiFile copies r to nome, hashing and counting it.
+func estraiFile(r io.Reader, nome string) (*verifica, error) {
+	f, err := os.Create(nome)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	v := nuovaVerifica()
+	if _, err := io.Copy(io.MultiWriter(f, v), r); err != nil {
+		return nil, err
+	}
+	return v, f.Close()
+}
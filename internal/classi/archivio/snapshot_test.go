@@ -0,0 +1,206 @@
+package archivio
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+)
+
+type mockEsportatore struct {
+	esportaFunc func(ctx context.Context, tipi []classi.TipoEsportazione, fn func(classi.RecordEsportazione) error) error
+}
+
+func (m *mockEsportatore) Esporta(ctx context.Context, tipi []classi.TipoEsportazione, fn func(classi.RecordEsportazione) error) error {
+	if m.esportaFunc != nil {
+		return m.esportaFunc(ctx, tipi, fn)
+	}
+	return nil
+}
+
+// esportaDataset exports a class with its subclass, restricted to tipi as
+// the service does.
+func esportaDataset(_ context.Context, tipi []classi.TipoEsportazione, fn func(classi.RecordEsportazione) error) error {
+	records := []classi.RecordEsportazione{
+		{Tipo: classi.EsportazioneClassi, Dati: classi.Classe{
+			ID: "mago", Nome: "Mago", DocumentazioneDiRiferimento: "DND 2024", DadoVita: classi.D6,
+			ElencoSottoclassi: []classi.RiferimentoSottoclasse{{IDSottoclasse: "evocatore"}},
+		}},
+		{Tipo: classi.EsportazioneSottoclassi, Dati: classi.SottoClasse{
+			ID: "evocatore", Nome: "Evocatore", DocumentazioneDiRiferimento: "DND 2024", IDClasseAssociata: "mago",
+		}},
+	}
+	for _, r := range records {
+		if !slices.Contains(classi.TipiDaEsportare(tipi), r.Tipo) {
+			continue
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	creatoIl := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+
+	scrivi := func(t *testing.T) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		manifest, err := ScriviSnapshot(ctx, &buf, &mockEsportatore{esportaFunc: esportaDataset}, nil, creatoIl)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if manifest.Versione != "20261016T123000Z" || len(manifest.File) != 2 {
+			t.Fatalf("unexpected manifest %+v", manifest)
+		}
+		if manifest.File[0].Nome != "classi.ndjson" || manifest.File[0].NumeroElementi != 1 || len(manifest.File[0].SHA256) != 64 {
+			t.Errorf("unexpected file %+v", manifest.File[0])
+		}
+		return buf.Bytes()
+	}
+
+	t.Run("round trip through the loader", func(t *testing.T) {
+		dir := t.TempDir()
+
+		manifest, err := EstraiSnapshot(bytes.NewReader(scrivi(t)), dir)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if manifest.Versione != "20261016T123000Z" {
+			t.Errorf("unexpected manifest %+v", manifest)
+		}
+		pacchetto, err := LeggiPacchetto(os.DirFS(dir))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pacchetto.Classi) != 1 || pacchetto.Classi[0].ID != "mago" ||
+			len(pacchetto.Sottoclassi) != 1 || pacchetto.Sottoclassi[0].IDClasseAssociata != "mago" {
+			t.Errorf("unexpected pacchetto %+v", pacchetto)
+		}
+	})
+
+	t.Run("manifest last", func(t *testing.T) {
+		var nomi []string
+		riscriviTar(t, scrivi(t), func(nome string, dati []byte) []byte {
+			nomi = append(nomi, nome)
+			return dati
+		})
+
+		if !slices.Equal(nomi, []string{"classi.ndjson", "sottoclassi.ndjson", "manifest.json"}) {
+			t.Errorf("unexpected entries %v", nomi)
+		}
+	})
+
+	t.Run("manifest first", func(t *testing.T) {
+		archivio := scrivi(t)
+		var manifest []byte
+		riscriviTar(t, archivio, func(nome string, dati []byte) []byte {
+			if nome == "manifest.json" {
+				manifest = dati
+			}
+			return dati
+		})
+		var primo bytes.Buffer
+		gw := gzip.NewWriter(&primo)
+		tw := tar.NewWriter(gw)
+		tw.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0o644, Size: int64(len(manifest)), Typeflag: tar.TypeReg})
+		tw.Write(manifest)
+		tw.Flush()
+		riscriviTarIn(t, archivio, tw, func(nome string, dati []byte) []byte {
+			if nome == "manifest.json" {
+				return nil
+			}
+			return dati
+		})
+		tw.Close()
+		gw.Close()
+		dir := t.TempDir()
+
+		if _, err := EstraiSnapshot(bytes.NewReader(primo.Bytes()), dir); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+			t.Errorf("expected 2 files extracted, got %d", len(entries))
+		}
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		alterato := riscriviTar(t, scrivi(t), func(nome string, dati []byte) []byte {
+			if nome == "classi.ndjson" {
+				return bytes.Replace(dati, []byte("Mago"), []byte("Mega"), 1)
+			}
+			return dati
+		})
+		dir := t.TempDir()
+
+		_, err := EstraiSnapshot(bytes.NewReader(alterato), dir)
+
+		if err == nil || !strings.Contains(err.Error(), "checksum") {
+			t.Errorf("expected a checksum error, got %v", err)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Errorf("expected nothing extracted, got %d files", len(entries))
+		}
+	})
+
+	t.Run("not a snapshot", func(t *testing.T) {
+		if _, err := EstraiSnapshot(strings.NewReader("not gzip"), t.TempDir()); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+// riscriviTar copies a .tar.gz archive, passing the content of every file
+// through modifica.
+func riscriviTar(t *testing.T, archivio []byte, modifica func(nome string, dati []byte) []byte) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	gw := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gw)
+	riscriviTarIn(t, archivio, tw, modifica)
+	tw.Close()
+	gw.Close()
+	return out.Bytes()
+}
+
+// riscriviTarIn copies the files of a .tar.gz archive to tw, passing their
+// content through modifica and dropping those it returns nil for.
+func riscriviTarIn(t *testing.T, archivio []byte, tw *tar.Writer, modifica func(nome string, dati []byte) []byte) {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(archivio))
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar: %v", err)
+		}
+		dati, _ := io.ReadAll(tr)
+		dati = modifica(header.Name, dati)
+		if dati == nil {
+			continue
+		}
+		header.Size = int64(len(dati))
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("tar: %v", err)
+		}
+		tw.Write(dati)
+	}
+}
@@ -0,0 +1,125 @@
+package classi
+
+import (
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+// Espansione names a part of a class that GET /classi/{id} can resolve
+// against other modules on request.
+type Espansione string
+
+const EspandiEquipaggiamento Espansione = "equipaggiamento"
+
+var Espansioni = []Espansione{EspandiEquipaggiamento}
+
+// OggettoPartenzaEspanso is a starting equipment entry resolved against the
+// item catalogue. Mancante marks an id that matches no item; such entries
+// keep only the data of the class.
+type OggettoPartenzaEspanso struct {
+	ID        string              `json:"id,omitempty"`
+	Nome      string              `json:"nome,omitempty"`
+	Quantita  int32               `json:"quantità"`
+	Tipo      oggetti.TipoOggetto `json:"tipo,omitempty"`
+	Categoria string              `json:"categoria,omitempty"`
+	Peso      *oggetti.Peso       `json:"peso,omitempty"`
+	Costo     *Importo            `json:"costo,omitempty"`
+	Mancante  bool                `json:"mancante,omitempty"`
+}
+
+// EquipaggiamentoPartenzaEspanso is the starting equipment with the items
+// of opzione A resolved. PesoTotale and ValoreInMO cover opzione A and
+// count each item times its quantità; items without peso or costo add
+// nothing. The catalogue mixes units, so PesoTotale holds a total per
+// unità di misura, in the order the units first appear.
+type EquipaggiamentoPartenzaEspanso struct {
+	OpzioneA            []OggettoPartenzaEspanso `json:"opzione-a,omitempty"`
+	OpzioneB            *Importo                 `json:"opzione-b,omitempty"`
+	PesoTotale          []oggetti.Peso           `json:"peso-totale"`
+	ValoreInMO          float64                  `json:"valore-in-mo"`
+	RiferimentiMancanti []string                 `json:"riferimenti-mancanti,omitempty"`
+}
+
+// ClasseEspansa is a class whose starting equipment has been expanded. The
+// expanded equipment replaces the class one on the wire.
+type ClasseEspansa struct {
+	Classe
+	EquipaggiamentoPartenza *EquipaggiamentoPartenzaEspanso `json:"equipaggiamento-id-partenza,omitempty"`
+}
+
+// IDOggetti returns the distinct item ids referenced by opzione A.
+func IDOggetti(eq EquipaggiamentoPartenza) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, o := range eq.OpzioneA {
+		if o.ID != "" && !seen[o.ID] {
+			seen[o.ID] = true
+			ids = append(ids, o.ID)
+		}
+	}
+	return ids
+}
+
+// EspandiEquipaggiamentoPartenza resolves the items of eq against
+// catalogo. An entry without quantità counts as one item.
+func EspandiEquipaggiamentoPartenza(eq EquipaggiamentoPartenza, catalogo []oggetti.Oggetto) EquipaggiamentoPartenzaEspanso {
+	byID := make(map[string]oggetti.Oggetto, len(catalogo))
+	for _, o := range catalogo {
+		byID[o.ID] = o
+	}
+
+	result := EquipaggiamentoPartenzaEspanso{OpzioneB: eq.OpzioneB}
+	var valoreInMR int64
+	for _, partenza := range eq.OpzioneA {
+		espanso := OggettoPartenzaEspanso{ID: partenza.ID, Nome: partenza.Nome, Quantita: max(partenza.Quantita, 1)}
+
+		oggetto, ok := byID[partenza.ID]
+		if !ok {
+			if partenza.ID != "" {
+				espanso.Mancante = true
+				result.RiferimentiMancanti = append(result.RiferimentiMancanti, partenza.ID)
+			}
+			result.OpzioneA = append(result.OpzioneA, espanso)
+			continue
+		}
+
+		if espanso.Nome == "" {
+			espanso.Nome = oggetto.Nome
+		}
+		espanso.Tipo = oggetto.Tipo
+		espanso.Categoria = categoria(oggetto)
+		espanso.Peso = oggetto.Peso
+		espanso.Costo = oggetto.Costo
+		if oggetto.Peso != nil {
+			result.PesoTotale = aggiungiPeso(result.PesoTotale, *oggetto.Peso, espanso.Quantita)
+		}
+		if oggetto.Costo != nil {
+			valoreInMR += oggetto.Costo.InMoneteDiRame() * int64(espanso.Quantita)
+		}
+		result.OpzioneA = append(result.OpzioneA, espanso)
+	}
+	result.ValoreInMO = float64(valoreInMR) / float64(shared.Importo{Quantita: 1, Valuta: shared.MO}.InMoneteDiRame())
+	return result
+}
+
+// aggiungiPeso adds quantita times peso to the total of its unit.
+func aggiungiPeso(totali []oggetti.Peso, peso oggetti.Peso, quantita int32) []oggetti.Peso {
+	for i := range totali {
+		if totali[i].UnitaDiMisura == peso.UnitaDiMisura {
+			totali[i].Valore += peso.Valore * float64(quantita)
+			return totali
+		}
+	}
+	return append(totali, oggetti.Peso{Valore: peso.Valore * float64(quantita), UnitaDiMisura: peso.UnitaDiMisura})
+}
+
+// categoria returns the weapon or armour category of o, if it has one.
+func categoria(o oggetti.Oggetto) string {
+	switch {
+	case o.Arma != nil:
+		return string(o.Arma.Categoria)
+	case o.Armatura != nil:
+		return string(o.Armatura.Categoria)
+	}
+	return ""
+}
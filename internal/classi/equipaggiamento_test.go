@@ -0,0 +1,89 @@
+package classi
+
+import (
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func catalogoDiProva() []oggetti.Oggetto {
+	return []oggetti.Oggetto{
+		{
+			ID: "spadone", Nome: "Spadone", Tipo: oggetti.TipoArma,
+			Peso:  &oggetti.Peso{Valore: 3, UnitaDiMisura: "kg"},
+			Costo: &shared.Importo{Quantita: 50, Valuta: shared.MO},
+			Arma:  &oggetti.Arma{Categoria: oggetti.Marziale},
+		},
+		{
+			ID: "giavellotto", Nome: "Giavellotto", Tipo: oggetti.TipoArma,
+			Peso:  &oggetti.Peso{Valore: 1, UnitaDiMisura: "kg"},
+			Costo: &shared.Importo{Quantita: 5, Valuta: shared.MA},
+			Arma:  &oggetti.Arma{Categoria: oggetti.Semplice},
+		},
+		{ID: "dotazione-da-esploratore", Nome: "Dotazione da Esploratore", Tipo: oggetti.TipoAttrezzaturaAvventuriero},
+		{
+			ID: "corda", Nome: "Corda", Tipo: oggetti.TipoAttrezzaturaAvventuriero,
+			Peso: &oggetti.Peso{Valore: 5, UnitaDiMisura: "lb"},
+		},
+	}
+}
+
+func TestIDOggetti(t *testing.T) {
+	eq := EquipaggiamentoPartenza{OpzioneA: []OggettoPartenza{
+		{ID: "giavellotto", Quantita: 4}, {Nome: "Abiti"}, {ID: "spadone"}, {ID: "giavellotto"},
+	}}
+
+	got := IDOggetti(eq)
+
+	if len(got) != 2 || got[0] != "giavellotto" || got[1] != "spadone" {
+		t.Errorf("unexpected ids %v", got)
+	}
+}
+
+func TestEspandiEquipaggiamentoPartenza(t *testing.T) {
+	eq := EquipaggiamentoPartenza{
+		OpzioneA: []OggettoPartenza{
+			{ID: "spadone", Nome: "Spadone"},
+			{ID: "giavellotto", Quantita: 8},
+			{ID: "dotazione-da-esploratore"},
+			{ID: "ascia-bipenne", Nome: "Ascia Bipenne"},
+			{Nome: "Abiti da viaggiatore"},
+			{ID: "corda", Quantita: 2},
+		},
+		OpzioneB: &Importo{Quantita: 75, Valuta: shared.MO},
+	}
+
+	got := EspandiEquipaggiamentoPartenza(eq, catalogoDiProva())
+
+	if len(got.OpzioneA) != 6 {
+		t.Fatalf("expected 6 items, got %d", len(got.OpzioneA))
+	}
+	spadone := got.OpzioneA[0]
+	if spadone.Quantita != 1 || spadone.Tipo != oggetti.TipoArma || spadone.Categoria != "Marziale" ||
+		spadone.Peso.Valore != 3 || spadone.Costo.Quantita != 50 || spadone.Mancante {
+		t.Errorf("unexpected spadone %+v", spadone)
+	}
+	if giavellotto := got.OpzioneA[1]; giavellotto.Nome != "Giavellotto" || giavellotto.Quantita != 8 {
+		t.Errorf("expected nome from the catalogue and quantità 8, got %+v", giavellotto)
+	}
+	if !got.OpzioneA[3].Mancante || got.OpzioneA[3].Nome != "Ascia Bipenne" {
+		t.Errorf("expected ascia-bipenne to be flagged as missing, got %+v", got.OpzioneA[3])
+	}
+	if got.OpzioneA[4].Mancante {
+		t.Errorf("an entry without id is not a missing reference: %+v", got.OpzioneA[4])
+	}
+	if len(got.RiferimentiMancanti) != 1 || got.RiferimentiMancanti[0] != "ascia-bipenne" {
+		t.Errorf("unexpected riferimenti mancanti %v", got.RiferimentiMancanti)
+	}
+	if len(got.PesoTotale) != 2 || got.PesoTotale[0] != (oggetti.Peso{Valore: 11, UnitaDiMisura: "kg"}) ||
+		got.PesoTotale[1] != (oggetti.Peso{Valore: 10, UnitaDiMisura: "lb"}) {
+		t.Errorf("expected peso totale 11 kg and 10 lb, got %+v", got.PesoTotale)
+	}
+	if got.ValoreInMO != 54 {
+		t.Errorf("expected valore 54 MO, got %v", got.ValoreInMO)
+	}
+	if got.OpzioneB == nil || got.OpzioneB.Quantita != 75 {
+		t.Errorf("expected opzione B to be kept, got %+v", got.OpzioneB)
+	}
+}
@@ -1,6 +1,8 @@
 package classi
 
 import (
+	"strconv"
+
 	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
 )
 
@@ -11,3 +13,23 @@ func ErrClasseNotFound(id string) *shared.AppError {
 func ErrSottoclasseNotFound(id string) *shared.AppError {
 	return shared.NewNotFoundError("SottoClasse", id)
 }
+
+func ErrClasseDuplicata(id string) *shared.AppError {
+	return shared.NewConflictError("Classe", id)
+}
+
+func ErrSottoclasseDuplicata(id string) *shared.AppError {
+	return shared.NewConflictError("SottoClasse", id)
+}
+
+func ErrClasseModificata(id string) *shared.AppError {
+	return shared.NewPreconditionFailedError("Classe", id)
+}
+
+func ErrSottoclasseModificata(id string) *shared.AppError {
+	return shared.NewPreconditionFailedError("SottoClasse", id)
+}
+
+func ErrRevisioneNotFound(numero int) *shared.AppError {
+	return shared.NewNotFoundError("Revisione", strconv.Itoa(numero))
+}
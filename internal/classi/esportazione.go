@@ -0,0 +1,46 @@
+package classi
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+// Esporta passes every class and subclass of the requested types to fn,
+// without the limit of the lists, from a consistent snapshot. The types
+// are exported in the order of TipiEsportazione whatever the order of
+// tipi; no tipi means all of them. An error of fn stops the export and is
+// returned as is.
+func (s *Service) Esporta(ctx context.Context, tipi []TipoEsportazione, fn func(RecordEsportazione) error) error {
+	richiesti := TipiDaEsportare(tipi)
+
+	var errFn error
+	err := s.repo.Esporta(ctx, richiesti, func(r RecordEsportazione) error {
+		if err := fn(r); err != nil {
+			errFn = err
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		if errFn != nil && errors.Is(err, errFn) {
+			return err
+		}
+		s.logger.Error("failed to export", "tipi", richiesti, "error", err)
+		return shared.NewInternalError(err)
+	}
+	return nil
+}
+
+// TipiDaEsportare returns the known types among tipi, once each and in the
+// order of TipiEsportazione; no tipi means all of them.
+func TipiDaEsportare(tipi []TipoEsportazione) []TipoEsportazione {
+	if len(tipi) == 0 {
+		return TipiEsportazione
+	}
+	return slices.DeleteFunc(slices.Clone(TipiEsportazione), func(t TipoEsportazione) bool {
+		return !slices.Contains(tipi, t)
+	})
+}
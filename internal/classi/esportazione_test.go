@@ -0,0 +1,66 @@
+package classi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestService_Esporta(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("tipi in export order", func(t *testing.T) {
+		var got []TipoEsportazione
+		repo := &MockRepository{
+			EsportaFunc: func(_ context.Context, tipi []TipoEsportazione, _ func(RecordEsportazione) error) error {
+				got = tipi
+				return nil
+			},
+		}
+		service := NewService(repo, nil, nil, logger)
+
+		if err := service.Esporta(ctx, []TipoEsportazione{EsportazioneSottoclassi, EsportazioneClassi, EsportazioneSottoclassi}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(got, TipiEsportazione) {
+			t.Errorf("expected %v, got %v", TipiEsportazione, got)
+		}
+
+		if err := service.Esporta(ctx, nil, nil); err != nil || !slices.Equal(got, TipiEsportazione) {
+			t.Errorf("expected every tipo without tipi, got %v, %v", got, err)
+		}
+	})
+
+	t.Run("error of fn is returned as is", func(t *testing.T) {
+		errScrittura := errors.New("broken pipe")
+		repo := &MockRepository{
+			EsportaFunc: func(_ context.Context, _ []TipoEsportazione, fn func(RecordEsportazione) error) error {
+				if err := fn(RecordEsportazione{Tipo: EsportazioneClassi, Dati: Classe{ID: "mago"}}); err != nil {
+					return fmt.Errorf("export classi: %w", err)
+				}
+				return nil
+			},
+		}
+
+		err := NewService(repo, nil, nil, logger).Esporta(ctx, nil, func(RecordEsportazione) error { return errScrittura })
+
+		if !errors.Is(err, errScrittura) {
+			t.Errorf("expected the error of fn, got %v", err)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			EsportaFunc: func(_ context.Context, _ []TipoEsportazione, _ func(RecordEsportazione) error) error {
+				return errors.New("database error")
+			},
+		}
+
+		err := NewService(repo, nil, nil, logger).Esporta(ctx, nil, func(RecordEsportazione) error { return nil })
+
+		assertStatus(t, err, 500)
+	})
+}
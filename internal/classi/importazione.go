@@ -0,0 +1,138 @@
+package classi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+// ImportaPacchetto validates a content pack and writes the classes and
+// subclasses that are new or differ from the stored ones. The comparison
+// and the writes run in a single transaction, so the report is what was
+// written. The writes ignore the version of the stored resources, so an
+// import replaces the earlier changes of the editors. A simulation only
+// reports what the import would do.
+func (s *Service) ImportaPacchetto(ctx context.Context, pacchetto Pacchetto, simulazione bool) (*ReportImportazione, error) {
+	elencoClassi, elencoSottoclassi, err := s.validaPacchetto(ctx, pacchetto)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReportImportazione{Simulazione: simulazione, Voci: []VoceImportazione{}}
+	confronto := ConfrontoImportazione{
+		Classe: func(corrente *Classe, c Classe) (bool, error) {
+			if corrente != nil {
+				corrente.ElencoSottoclassi = nil
+				corrente.Versione = 0
+			}
+			esito := esitoImportazione(corrente, c)
+			report.Voci = append(report.Voci, VoceImportazione{Tipo: RisorsaClasse, IDClasse: c.ID, ID: c.ID, Esito: esito})
+			return !simulazione && esito != ImportazioneInvariata, nil
+		},
+		Sottoclasse: func(corrente *SottoClasse, sc SottoClasse) (bool, error) {
+			if corrente != nil {
+				if corrente.IDClasseAssociata != sc.IDClasseAssociata {
+					err := fmt.Errorf("sottoclasse %q: belongs to classe %q", sc.ID, corrente.IDClasseAssociata)
+					return false, shared.NewBadRequestError(err.Error(), err)
+				}
+				corrente.Versione = 0
+			}
+			esito := esitoImportazione(corrente, sc)
+			report.Voci = append(report.Voci, VoceImportazione{Tipo: RisorsaSottoclasse, IDClasse: sc.IDClasseAssociata, ID: sc.ID, Esito: esito})
+			return !simulazione && esito != ImportazioneInvariata, nil
+		},
+	}
+
+	if err := s.repo.Importa(ctx, elencoClassi, elencoSottoclassi, confronto); err != nil {
+		var appErr *shared.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		s.logger.Error("failed to import pacchetto", "classi", len(elencoClassi), "sottoclassi", len(elencoSottoclassi), "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	return report, nil
+}
+
+// validaPacchetto validates every resource of the pack and returns the
+// classes and the subclasses to import, the nested ones first. The errors
+// of all the resources are reported together.
+func (s *Service) validaPacchetto(ctx context.Context, pacchetto Pacchetto) ([]Classe, []SottoClasse, error) {
+	var errs []error
+	elencoClassi := make([]Classe, 0, len(pacchetto.Classi))
+	var elencoSottoclassi []SottoClasse
+	idClassi := make(map[string]bool)
+	idSottoclassi := make(map[string]bool)
+
+	aggiungiSottoclasse := func(sc SottoClasse, classeID string) {
+		if err := validaSottoclasse(&sc, classeID); err != nil {
+			errs = append(errs, fmt.Errorf("sottoclasse %q: %w", sc.ID, err))
+			return
+		}
+		if idSottoclassi[sc.ID] {
+			errs = append(errs, fmt.Errorf("sottoclasse %q: duplicate id", sc.ID))
+			return
+		}
+		idSottoclassi[sc.ID] = true
+		elencoSottoclassi = append(elencoSottoclassi, sc)
+	}
+
+	for _, c := range pacchetto.Classi {
+		annidate := c.Sottoclassi
+		if err := validaClasse(&c); err != nil {
+			errs = append(errs, fmt.Errorf("classe %q: %w", c.ID, err))
+			continue
+		}
+		if idClassi[c.ID] {
+			errs = append(errs, fmt.Errorf("classe %q: duplicate id", c.ID))
+			continue
+		}
+		idClassi[c.ID] = true
+		elencoClassi = append(elencoClassi, c)
+		for _, sc := range annidate {
+			aggiungiSottoclasse(sc, c.ID)
+		}
+	}
+
+	for _, sc := range pacchetto.Sottoclassi {
+		if sc.IDClasseAssociata == "" {
+			errs = append(errs, fmt.Errorf("sottoclasse %q: id-classe-associata is required", sc.ID))
+			continue
+		}
+		if !idClassi[sc.IDClasseAssociata] {
+			current, err := s.repo.GetByID(ctx, sc.IDClasseAssociata)
+			if err != nil {
+				s.logger.Error("failed to get classe", "id", sc.IDClasseAssociata, "error", err)
+				return nil, nil, shared.NewInternalError(err)
+			}
+			if current == nil {
+				errs = append(errs, fmt.Errorf("sottoclasse %q: classe %q does not exist", sc.ID, sc.IDClasseAssociata))
+				continue
+			}
+		}
+		aggiungiSottoclasse(sc, sc.IDClasseAssociata)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, nil, shared.NewBadRequestError(err.Error(), err)
+	}
+	return elencoClassi, elencoSottoclassi, nil
+}
+
+// esitoImportazione compares the JSON of the stored resource with the one
+// to import, so that absent and empty fields are the same.
+func esitoImportazione[T Classe | SottoClasse](current *T, importata T) EsitoImportazione {
+	if current == nil {
+		return ImportazioneCreazione
+	}
+	a, errA := json.Marshal(*current)
+	b, errB := json.Marshal(importata)
+	if errA != nil || errB != nil || !bytes.Equal(a, b) {
+		return ImportazioneModifica
+	}
+	return ImportazioneInvariata
+}
@@ -0,0 +1,162 @@
+package classi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestService_ImportaPacchetto(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	stored := classeValida()
+	stored.DocumentazioneDiRiferimento = "DND 2024"
+	stored.ElencoSottoclassi = []RiferimentoSottoclasse{{IDSottoclasse: "berserker"}}
+	stored.Versione = 42
+
+	berserker := SottoClasse{ID: "berserker", Nome: "Berserker", DocumentazioneDiRiferimento: "DND 2024", IDClasseAssociata: "barbaro"}
+
+	newRepo := func(written *[]string) *MockRepository {
+		return &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				if id == "barbaro" {
+					c := stored
+					return &c, nil
+				}
+				return nil, nil
+			},
+			ImportaFunc: func(_ context.Context, classi []Classe, sottoclassi []SottoClasse, confronto ConfrontoImportazione) error {
+				for _, c := range classi {
+					var corrente *Classe
+					if c.ID == "barbaro" {
+						corrente = &Classe{}
+						*corrente = stored
+					}
+					scrivi, err := confronto.Classe(corrente, c)
+					if err != nil {
+						return err
+					}
+					if scrivi {
+						*written = append(*written, c.ID)
+					}
+				}
+				for _, s := range sottoclassi {
+					var corrente *SottoClasse
+					if s.ID == "berserker" {
+						corrente = &SottoClasse{}
+						*corrente = berserker
+					}
+					scrivi, err := confronto.Sottoclasse(corrente, s)
+					if err != nil {
+						return err
+					}
+					if scrivi {
+						*written = append(*written, s.ID)
+					}
+				}
+				return nil
+			},
+		}
+	}
+
+	pacchetto := Pacchetto{
+		Classi: []Classe{
+			classeValida(),
+			{ID: "monaco", Nome: "Monaco", DadoVita: D8, Sottoclassi: []SottoClasse{{ID: "via-ombra", Nome: "Via dell'Ombra"}}},
+		},
+		Sottoclassi: []SottoClasse{{ID: "berserker", Nome: "Berserker", Descrizione: "Furia primordiale", IDClasseAssociata: "barbaro"}},
+	}
+
+	t.Run("report", func(t *testing.T) {
+		var written []string
+
+		report, err := NewService(newRepo(&written), nil, nil, logger).ImportaPacchetto(ctx, pacchetto, false)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []VoceImportazione{
+			{Tipo: RisorsaClasse, IDClasse: "barbaro", ID: "barbaro", Esito: ImportazioneInvariata},
+			{Tipo: RisorsaClasse, IDClasse: "monaco", ID: "monaco", Esito: ImportazioneCreazione},
+			{Tipo: RisorsaSottoclasse, IDClasse: "monaco", ID: "via-ombra", Esito: ImportazioneCreazione},
+			{Tipo: RisorsaSottoclasse, IDClasse: "barbaro", ID: "berserker", Esito: ImportazioneModifica},
+		}
+		if len(report.Voci) != len(want) {
+			t.Fatalf("expected %d voci, got %+v", len(want), report.Voci)
+		}
+		for i := range want {
+			if report.Voci[i] != want[i] {
+				t.Errorf("voce %d: expected %+v, got %+v", i, want[i], report.Voci[i])
+			}
+		}
+		if strings.Join(written, ",") != "monaco,via-ombra,berserker" {
+			t.Errorf("expected only the changed resources to be written, got %v", written)
+		}
+	})
+
+	t.Run("simulation writes nothing", func(t *testing.T) {
+		var written []string
+
+		report, err := NewService(newRepo(&written), nil, nil, logger).ImportaPacchetto(ctx, pacchetto, true)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !report.Simulazione || len(report.Voci) != 4 {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if len(written) != 0 {
+			t.Errorf("expected nothing written, got %v", written)
+		}
+	})
+
+	t.Run("invalid resources are reported together", func(t *testing.T) {
+		invalid := Pacchetto{
+			Classi: []Classe{
+				{ID: "guerriero", Nome: "Guerriero", DadoVita: "d7"},
+				classeValida(),
+				classeValida(),
+			},
+			Sottoclassi: []SottoClasse{
+				{ID: "campione", Nome: "Campione"},
+				{ID: "giuramento", Nome: "Giuramento", IDClasseAssociata: "paladino"},
+			},
+		}
+
+		_, err := NewService(newRepo(new([]string)), nil, nil, logger).ImportaPacchetto(ctx, invalid, false)
+
+		assertStatus(t, err, 400)
+		for _, msg := range []string{
+			`classe "guerriero": dado-vita`,
+			`classe "barbaro": duplicate id`,
+			`sottoclasse "campione": id-classe-associata is required`,
+			`sottoclasse "giuramento": classe "paladino" does not exist`,
+		} {
+			if !strings.Contains(err.Error(), msg) {
+				t.Errorf("expected %q in %q", msg, err.Error())
+			}
+		}
+	})
+
+	t.Run("subclass of another class", func(t *testing.T) {
+		altra := Pacchetto{Classi: []Classe{{ID: "guerriero", Nome: "Guerriero", DadoVita: D10, Sottoclassi: []SottoClasse{berserker}}}}
+		altra.Classi[0].Sottoclassi[0].IDClasseAssociata = ""
+
+		_, err := NewService(newRepo(new([]string)), nil, nil, logger).ImportaPacchetto(ctx, altra, false)
+
+		assertStatus(t, err, 400)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := newRepo(new([]string))
+		repo.ImportaFunc = func(_ context.Context, _ []Classe, _ []SottoClasse, _ ConfrontoImportazione) error {
+			return errors.New("database error")
+		}
+
+		_, err := NewService(repo, nil, nil, logger).ImportaPacchetto(ctx, pacchetto, false)
+
+		assertStatus(t, err, 500)
+	})
+}
@@ -2,7 +2,9 @@ package classi
 
 import (
 	"context"
+	"time"
 
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
 	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
 )
 
@@ -11,4 +13,61 @@ type Repository interface {
 	GetByID(ctx context.Context, id string) (*Classe, error)
 	ListSottoclassi(ctx context.Context, classeID string, filter shared.ListFilter) ([]SottoClasse, int, error)
 	GetSottoclasseByID(ctx context.Context, classeID, sottoclasseID string) (*SottoClasse, error)
+	// GetSottoclasse returns the subclass with the given id whatever its
+	// class.
+	GetSottoclasse(ctx context.Context, sottoclasseID string) (*SottoClasse, error)
+	// ListAllSottoclassi lists the subclasses of every class, each with the
+	// name of its class.
+	ListAllSottoclassi(ctx context.Context, filter SottoclassiFilter) ([]SottoClasse, int, error)
+	// GetSottoclassiByClasseIDs returns the subclasses of the given classes
+	// keyed by class id, ordered by nome.
+	GetSottoclassiByClasseIDs(ctx context.Context, classeIDs []string) (map[string][]SottoClasse, error)
+
+	// CreateClasse and CreateSottoclasse report false when the id is taken.
+	CreateClasse(ctx context.Context, classe Classe) (bool, error)
+	// UpdateClasse and DeleteClasse report false when the class does not
+	// exist or its version is no longer versione. Deleting a class deletes
+	// its subclasses.
+	UpdateClasse(ctx context.Context, classe Classe, versione int64) (bool, error)
+	DeleteClasse(ctx context.Context, id string, versione int64) (bool, error)
+	// The subclass writes also change the version of the class.
+	CreateSottoclasse(ctx context.Context, sottoclasse SottoClasse) (bool, error)
+	// UpdateSottoclasse and DeleteSottoclasse report false when the
+	// subclass does not exist, belongs to another class or its version is
+	// no longer versione.
+	UpdateSottoclasse(ctx context.Context, sottoclasse SottoClasse, versione int64) (bool, error)
+	DeleteSottoclasse(ctx context.Context, classeID, sottoclasseID string, versione int64) (bool, error)
+
+	// Every write above adds a revision to the history of the resource.
+	// ListRevisioni and GetRevisione read it; the history outlives the
+	// resource.
+	ListRevisioni(ctx context.Context, risorsa Risorsa, filter shared.ListFilter) ([]Revisione, int, error)
+	GetRevisione(ctx context.Context, risorsa Risorsa, numero int) (*Revisione, error)
+	// GetClasseAl and GetSottoclasseAl return the resource as it was at the
+	// instant al, or nil when it did not exist then.
+	GetClasseAl(ctx context.Context, id string, al time.Time) (*Classe, error)
+	GetSottoclasseAl(ctx context.Context, classeID, sottoclasseID string, al time.Time) (*SottoClasse, error)
+	// RipristinaClasse and RipristinaSottoclasse write a resource back from
+	// its history: they update it at versione or, when versione is nil,
+	// create it again. They report false as the writes above.
+	RipristinaClasse(ctx context.Context, classe Classe, versione *int64) (bool, error)
+	RipristinaSottoclasse(ctx context.Context, sottoclasse SottoClasse, versione *int64) (bool, error)
+	// Importa reads the classes and then the subclasses of a pack in a
+	// single transaction, locking the stored rows, and creates or replaces
+	// whatever their version those that confronto selects, adding a
+	// revision for each. No concurrent write falls between the comparison
+	// and the write. It fails without writing anything when confronto
+	// fails, a subclass is stored under another class or a resource is
+	// created concurrently.
+	Importa(ctx context.Context, classi []Classe, sottoclassi []SottoClasse, confronto ConfrontoImportazione) error
+	// Esporta passes every resource of the given types to fn, in the order
+	// of tipi and then by id, read from a single snapshot of the database.
+	// It stops at the first error of fn and returns it.
+	Esporta(ctx context.Context, tipi []TipoEsportazione, fn func(RecordEsportazione) error) error
+}
+
+// OggettiRepository is the item catalogue the starting equipment is
+// expanded against.
+type OggettiRepository interface {
+	GetByIDs(ctx context.Context, ids []string) ([]oggetti.Oggetto, error)
 }
@@ -0,0 +1,115 @@
+package classi
+
+import (
+	"cmp"
+	"slices"
+)
+
+// MaxLivello is the highest class level.
+const MaxLivello = 20
+
+// Origini of a PrivilegioLivello.
+const (
+	OrigineClasse      = "classe"
+	OrigineSottoclasse = "sotto-classe"
+)
+
+// PrivilegioLivello is a feature gained at a level, tagged with whether it
+// comes from the class or the subclass.
+type PrivilegioLivello struct {
+	Tratto
+	Origine string `json:"origine"`
+}
+
+// Livello is a row of the class progression table: everything a character
+// of that class has at that level.
+type Livello struct {
+	Livello              int32               `json:"livello"`
+	BonusCompetenza      int32               `json:"bonus-competenza"`
+	Privilegi            []PrivilegioLivello `json:"privilegi"`
+	SlotIncantesimi      []SlotIncantesimo   `json:"slot-incantesimi,omitempty"`
+	TrucchettiConosciuti int32               `json:"trucchetti-conosciuti,omitempty"`
+	IncantesimiPreparati int32               `json:"incantesimi-preparati,omitempty"`
+}
+
+// BonusCompetenza returns the proficiency bonus at the given character
+// level: +2 at 1st level, increasing by one every four levels.
+func BonusCompetenza(livello int32) int32 {
+	return 2 + (max(livello, 1)-1)/4
+}
+
+// Progressione builds the 1-20 progression table of classe, merging the
+// features and spellcasting of sottoclasse when it is not nil.
+//
+// The incantesimi-di-classe of an entry are the totals at that level, so
+// levels without one carry the previous level's values forward. When both
+// the class and the subclass grant spellcasting at a level, slots of the
+// same level, cantrips and prepared spells are added together.
+func Progressione(classe *Classe, sottoclasse *SottoClasse) []Livello {
+	livelli := make([]Livello, MaxLivello)
+	for i := range livelli {
+		livelli[i] = Livello{
+			Livello:         int32(i + 1),
+			BonusCompetenza: BonusCompetenza(int32(i + 1)),
+			Privilegi:       []PrivilegioLivello{},
+		}
+	}
+
+	var classeIncantesimi, sottoclasseIncantesimi [MaxLivello]*IncantesimiClasse
+	aggiungi := func(proprieta []ProprietaLivello, origine string, incantesimi *[MaxLivello]*IncantesimiClasse) {
+		for _, p := range proprieta {
+			if p.LivelloClasse < 1 || p.LivelloClasse > MaxLivello {
+				continue
+			}
+			i := p.LivelloClasse - 1
+			if p.TrattoDiClasse != nil {
+				livelli[i].Privilegi = append(livelli[i].Privilegi, PrivilegioLivello{Tratto: *p.TrattoDiClasse, Origine: origine})
+			}
+			if p.IncantesimiClasse != nil {
+				incantesimi[i] = p.IncantesimiClasse
+			}
+		}
+	}
+	aggiungi(classe.ProprietaDiClasse, OrigineClasse, &classeIncantesimi)
+	if sottoclasse != nil {
+		aggiungi(sottoclasse.ProprietaDiSottoclasse, OrigineSottoclasse, &sottoclasseIncantesimi)
+	}
+
+	var classeCorrente, sottoclasseCorrente *IncantesimiClasse
+	for i := range livelli {
+		if classeIncantesimi[i] != nil {
+			classeCorrente = classeIncantesimi[i]
+		}
+		if sottoclasseIncantesimi[i] != nil {
+			sottoclasseCorrente = sottoclasseIncantesimi[i]
+		}
+		for _, inc := range []*IncantesimiClasse{classeCorrente, sottoclasseCorrente} {
+			if inc == nil {
+				continue
+			}
+			livelli[i].SlotIncantesimi = sommaSlot(livelli[i].SlotIncantesimi, inc.SlotIncantesimi)
+			livelli[i].TrucchettiConosciuti += inc.TrucchettiConosciuti
+			livelli[i].IncantesimiPreparati += inc.IncantesimiPreparati
+		}
+	}
+
+	return livelli
+}
+
+func sommaSlot(a, b []SlotIncantesimo) []SlotIncantesimo {
+	result := slices.Clone(a)
+	for _, s := range b {
+		i := slices.IndexFunc(result, func(r SlotIncantesimo) bool {
+			return r.LivelloSlotIncantesimo == s.LivelloSlotIncantesimo
+		})
+		if i < 0 {
+			result = append(result, s)
+			continue
+		}
+		result[i].NumeroSlot += s.NumeroSlot
+	}
+	slices.SortFunc(result, func(x, y SlotIncantesimo) int {
+		return cmp.Compare(x.LivelloSlotIncantesimo, y.LivelloSlotIncantesimo)
+	})
+	return result
+}
@@ -0,0 +1,103 @@
+package classi
+
+import "testing"
+
+func TestBonusCompetenza(t *testing.T) {
+	tests := map[int32]int32{1: 2, 4: 2, 5: 3, 8: 3, 9: 4, 13: 5, 17: 6, 20: 6}
+	for livello, want := range tests {
+		if got := BonusCompetenza(livello); got != want {
+			t.Errorf("BonusCompetenza(%d) = %d, want %d", livello, got, want)
+		}
+	}
+}
+
+func TestProgressione(t *testing.T) {
+	classe := &Classe{
+		ID: "paladino",
+		ProprietaDiClasse: []ProprietaLivello{
+			{LivelloClasse: 1, TrattoDiClasse: &Tratto{Nome: "Imposizione delle Mani"}},
+			{LivelloClasse: 1, TrattoDiClasse: &Tratto{Nome: "Incantesimi"}, IncantesimiClasse: &IncantesimiClasse{
+				SlotIncantesimi:      []SlotIncantesimo{{NumeroSlot: 2, LivelloSlotIncantesimo: 1}},
+				IncantesimiPreparati: 2,
+			}},
+			{LivelloClasse: 5, IncantesimiClasse: &IncantesimiClasse{
+				SlotIncantesimi: []SlotIncantesimo{
+					{NumeroSlot: 2, LivelloSlotIncantesimo: 2},
+					{NumeroSlot: 4, LivelloSlotIncantesimo: 1},
+				},
+				IncantesimiPreparati: 6,
+			}},
+			{LivelloClasse: 21, TrattoDiClasse: &Tratto{Nome: "Fuori scala"}},
+		},
+	}
+	sottoclasse := &SottoClasse{
+		ID: "giuramento-di-devozione",
+		ProprietaDiSottoclasse: []ProprietaLivello{
+			{LivelloClasse: 3, TrattoDiClasse: &Tratto{Nome: "Arma Sacra"}, IncantesimiClasse: &IncantesimiClasse{
+				SlotIncantesimi:      []SlotIncantesimo{{NumeroSlot: 1, LivelloSlotIncantesimo: 1}},
+				TrucchettiConosciuti: 1,
+			}},
+		},
+	}
+
+	t.Run("class only", func(t *testing.T) {
+		livelli := Progressione(classe, nil)
+
+		if len(livelli) != MaxLivello {
+			t.Fatalf("expected %d levels, got %d", MaxLivello, len(livelli))
+		}
+		if livelli[0].Livello != 1 || livelli[19].Livello != 20 {
+			t.Errorf("unexpected level numbers %d..%d", livelli[0].Livello, livelli[19].Livello)
+		}
+		if len(livelli[0].Privilegi) != 2 || livelli[0].Privilegi[1].Origine != OrigineClasse {
+			t.Errorf("unexpected level 1 privilegi %+v", livelli[0].Privilegi)
+		}
+		if livelli[1].Privilegi == nil || len(livelli[1].Privilegi) != 0 {
+			t.Errorf("expected empty privilegi at level 2, got %v", livelli[1].Privilegi)
+		}
+		if len(livelli[3].SlotIncantesimi) != 1 || livelli[3].IncantesimiPreparati != 2 {
+			t.Errorf("expected level 1 spellcasting carried to level 4, got %+v", livelli[3])
+		}
+		if s := livelli[19].SlotIncantesimi; len(s) != 2 || s[0].LivelloSlotIncantesimo != 1 || s[0].NumeroSlot != 4 {
+			t.Errorf("expected sorted level 5 slots at level 20, got %+v", s)
+		}
+	})
+
+	t.Run("with sottoclasse", func(t *testing.T) {
+		livelli := Progressione(classe, sottoclasse)
+
+		if p := livelli[2].Privilegi; len(p) != 1 || p[0].Nome != "Arma Sacra" || p[0].Origine != OrigineSottoclasse {
+			t.Errorf("unexpected level 3 privilegi %+v", p)
+		}
+		if s := livelli[1].SlotIncantesimi; len(s) != 1 || s[0].NumeroSlot != 2 {
+			t.Errorf("subclass spellcasting should start at level 3, got %+v", s)
+		}
+		if s := livelli[4].SlotIncantesimi; s[0].NumeroSlot != 5 || livelli[4].TrucchettiConosciuti != 1 {
+			t.Errorf("expected merged level 5 spellcasting, got %+v", livelli[4])
+		}
+		if len(classe.ProprietaDiClasse[2].IncantesimiClasse.SlotIncantesimi) != 2 ||
+			classe.ProprietaDiClasse[2].IncantesimiClasse.SlotIncantesimi[0].NumeroSlot != 2 {
+			t.Errorf("merging must not modify the class data")
+		}
+	})
+}
+
+func TestTipoIncantatore_LivelloIncantatore(t *testing.T) {
+	tests := []struct {
+		tipo    TipoIncantatore
+		livello int32
+		want    int32
+	}{
+		{IncantatoreCompleto, 7, 7},
+		{IncantatoreMezzo, 1, 1},
+		{IncantatoreMezzo, 5, 3},
+		{IncantatoreTerzo, 2, 0},
+		{IncantatoreTerzo, 8, 2},
+		{IncantatorePatto, 5, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.tipo.LivelloIncantatore(tt.livello); got != tt.want {
+			t.Errorf("%s.LivelloIncantatore(%d) = %d, want %d", tt.tipo, tt.livello, got, tt.want)
+		}
+	}
+}
@@ -2,15 +2,34 @@ package classi
 
 import (
 	"context"
+	"time"
 
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
 	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
 )
 
 type MockRepository struct {
-	ListFunc               func(ctx context.Context, filter shared.ListFilter) ([]Classe, int, error)
-	GetByIDFunc            func(ctx context.Context, id string) (*Classe, error)
-	ListSottoclassiFunc    func(ctx context.Context, classeID string, filter shared.ListFilter) ([]SottoClasse, int, error)
-	GetSottoclasseByIDFunc func(ctx context.Context, classeID, sottoclasseID string) (*SottoClasse, error)
+	ListFunc                      func(ctx context.Context, filter shared.ListFilter) ([]Classe, int, error)
+	GetByIDFunc                   func(ctx context.Context, id string) (*Classe, error)
+	ListSottoclassiFunc           func(ctx context.Context, classeID string, filter shared.ListFilter) ([]SottoClasse, int, error)
+	GetSottoclasseByIDFunc        func(ctx context.Context, classeID, sottoclasseID string) (*SottoClasse, error)
+	GetSottoclasseFunc            func(ctx context.Context, sottoclasseID string) (*SottoClasse, error)
+	ListAllSottoclassiFunc        func(ctx context.Context, filter SottoclassiFilter) ([]SottoClasse, int, error)
+	GetSottoclassiByClasseIDsFunc func(ctx context.Context, classeIDs []string) (map[string][]SottoClasse, error)
+	CreateClasseFunc              func(ctx context.Context, classe Classe) (bool, error)
+	UpdateClasseFunc              func(ctx context.Context, classe Classe, versione int64) (bool, error)
+	DeleteClasseFunc              func(ctx context.Context, id string, versione int64) (bool, error)
+	CreateSottoclasseFunc         func(ctx context.Context, sottoclasse SottoClasse) (bool, error)
+	UpdateSottoclasseFunc         func(ctx context.Context, sottoclasse SottoClasse, versione int64) (bool, error)
+	DeleteSottoclasseFunc         func(ctx context.Context, classeID, sottoclasseID string, versione int64) (bool, error)
+	ListRevisioniFunc             func(ctx context.Context, risorsa Risorsa, filter shared.ListFilter) ([]Revisione, int, error)
+	GetRevisioneFunc              func(ctx context.Context, risorsa Risorsa, numero int) (*Revisione, error)
+	GetClasseAlFunc               func(ctx context.Context, id string, al time.Time) (*Classe, error)
+	GetSottoclasseAlFunc          func(ctx context.Context, classeID, sottoclasseID string, al time.Time) (*SottoClasse, error)
+	RipristinaClasseFunc          func(ctx context.Context, classe Classe, versione *int64) (bool, error)
+	RipristinaSottoclasseFunc     func(ctx context.Context, sottoclasse SottoClasse, versione *int64) (bool, error)
+	ImportaFunc                   func(ctx context.Context, classi []Classe, sottoclassi []SottoClasse, confronto ConfrontoImportazione) error
+	EsportaFunc                   func(ctx context.Context, tipi []TipoEsportazione, fn func(RecordEsportazione) error) error
 }
 
 func (m *MockRepository) List(ctx context.Context, filter shared.ListFilter) ([]Classe, int, error) {
@@ -40,3 +59,133 @@ func (m *MockRepository) GetSottoclasseByID(ctx context.Context, classeID, sotto
 	}
 	return nil, nil
 }
+
+func (m *MockRepository) GetSottoclasse(ctx context.Context, sottoclasseID string) (*SottoClasse, error) {
+	if m.GetSottoclasseFunc != nil {
+		return m.GetSottoclasseFunc(ctx, sottoclasseID)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) ListAllSottoclassi(ctx context.Context, filter SottoclassiFilter) ([]SottoClasse, int, error) {
+	if m.ListAllSottoclassiFunc != nil {
+		return m.ListAllSottoclassiFunc(ctx, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockRepository) GetSottoclassiByClasseIDs(ctx context.Context, classeIDs []string) (map[string][]SottoClasse, error) {
+	if m.GetSottoclassiByClasseIDsFunc != nil {
+		return m.GetSottoclassiByClasseIDsFunc(ctx, classeIDs)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) CreateClasse(ctx context.Context, classe Classe) (bool, error) {
+	if m.CreateClasseFunc != nil {
+		return m.CreateClasseFunc(ctx, classe)
+	}
+	return false, nil
+}
+
+func (m *MockRepository) UpdateClasse(ctx context.Context, classe Classe, versione int64) (bool, error) {
+	if m.UpdateClasseFunc != nil {
+		return m.UpdateClasseFunc(ctx, classe, versione)
+	}
+	return false, nil
+}
+
+func (m *MockRepository) DeleteClasse(ctx context.Context, id string, versione int64) (bool, error) {
+	if m.DeleteClasseFunc != nil {
+		return m.DeleteClasseFunc(ctx, id, versione)
+	}
+	return false, nil
+}
+
+func (m *MockRepository) CreateSottoclasse(ctx context.Context, sottoclasse SottoClasse) (bool, error) {
+	if m.CreateSottoclasseFunc != nil {
+		return m.CreateSottoclasseFunc(ctx, sottoclasse)
+	}
+	return false, nil
+}
+
+func (m *MockRepository) UpdateSottoclasse(ctx context.Context, sottoclasse SottoClasse, versione int64) (bool, error) {
+	if m.UpdateSottoclasseFunc != nil {
+		return m.UpdateSottoclasseFunc(ctx, sottoclasse, versione)
+	}
+	return false, nil
+}
+
+func (m *MockRepository) DeleteSottoclasse(ctx context.Context, classeID, sottoclasseID string, versione int64) (bool, error) {
+	if m.DeleteSottoclasseFunc != nil {
+		return m.DeleteSottoclasseFunc(ctx, classeID, sottoclasseID, versione)
+	}
+	return false, nil
+}
+
+func (m *MockRepository) ListRevisioni(ctx context.Context, risorsa Risorsa, filter shared.ListFilter) ([]Revisione, int, error) {
+	if m.ListRevisioniFunc != nil {
+		return m.ListRevisioniFunc(ctx, risorsa, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockRepository) GetRevisione(ctx context.Context, risorsa Risorsa, numero int) (*Revisione, error) {
+	if m.GetRevisioneFunc != nil {
+		return m.GetRevisioneFunc(ctx, risorsa, numero)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) GetClasseAl(ctx context.Context, id string, al time.Time) (*Classe, error) {
+	if m.GetClasseAlFunc != nil {
+		return m.GetClasseAlFunc(ctx, id, al)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) GetSottoclasseAl(ctx context.Context, classeID, sottoclasseID string, al time.Time) (*SottoClasse, error) {
+	if m.GetSottoclasseAlFunc != nil {
+		return m.GetSottoclasseAlFunc(ctx, classeID, sottoclasseID, al)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) RipristinaClasse(ctx context.Context, classe Classe, versione *int64) (bool, error) {
+	if m.RipristinaClasseFunc != nil {
+		return m.RipristinaClasseFunc(ctx, classe, versione)
+	}
+	return false, nil
+}
+
+func (m *MockRepository) RipristinaSottoclasse(ctx context.Context, sottoclasse SottoClasse, versione *int64) (bool, error) {
+	if m.RipristinaSottoclasseFunc != nil {
+		return m.RipristinaSottoclasseFunc(ctx, sottoclasse, versione)
+	}
+	return false, nil
+}
+
+func (m *MockRepository) Importa(ctx context.Context, classi []Classe, sottoclassi []SottoClasse, confronto ConfrontoImportazione) error {
+	if m.ImportaFunc != nil {
+		return m.ImportaFunc(ctx, classi, sottoclassi, confronto)
+	}
+	return nil
+}
+
+func (m *MockRepository) Esporta(ctx context.Context, tipi []TipoEsportazione, fn func(RecordEsportazione) error) error {
+	if m.EsportaFunc != nil {
+		return m.EsportaFunc(ctx, tipi, fn)
+	}
+	return nil
+}
+
+type MockOggettiRepository struct {
+	GetByIDsFunc func(ctx context.Context, ids []string) ([]oggetti.Oggetto, error)
+}
+
+func (m *MockOggettiRepository) GetByIDs(ctx context.Context, ids []string) ([]oggetti.Oggetto, error) {
+	if m.GetByIDsFunc != nil {
+		return m.GetByIDsFunc(ctx, ids)
+	}
+	return nil, nil
+}
@@ -1,34 +1,37 @@
 package classi
 
-type TipoDiDado string
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+// TipoDiDado, TipoAzione and Tratto are shared with the other domain
+// modules; the aliases keep the classi API unchanged.
+type TipoDiDado = shared.TipoDiDado
 
 const (
-	D3  TipoDiDado = "d3"
-	D4  TipoDiDado = "d4"
-	D6  TipoDiDado = "d6"
-	D8  TipoDiDado = "d8"
-	D10 TipoDiDado = "d10"
-	D12 TipoDiDado = "d12"
-	D20 TipoDiDado = "d20"
+	D3  = shared.D3
+	D4  = shared.D4
+	D6  = shared.D6
+	D8  = shared.D8
+	D10 = shared.D10
+	D12 = shared.D12
+	D20 = shared.D20
 )
 
-type TipoAzione string
+type TipoAzione = shared.TipoAzione
 
 const (
-	Nessuna        TipoAzione = "Nessuna"
-	AzioneBonus    TipoAzione = "Azione Bonus"
-	Azione         TipoAzione = "Azione"
-	Reazione       TipoAzione = "Reazione"
-	AzioneGratuita TipoAzione = "Azione Gratuita"
+	Nessuna        = shared.Nessuna
+	AzioneBonus    = shared.AzioneBonus
+	Azione         = shared.Azione
+	Reazione       = shared.Reazione
+	AzioneGratuita = shared.AzioneGratuita
 )
 
-type Tratto struct {
-	ID             string     `json:"id,omitempty"`
-	Nome           string     `json:"nome"`
-	Descrizione    string     `json:"descrizione,omitempty"`
-	TipoAzione     TipoAzione `json:"tipo-azione,omitempty"`
-	TipoDiSorgente string     `json:"tipo-di-sorgente,omitempty"`
-}
+type Tratto = shared.Tratto
 
 type SlotIncantesimo struct {
 	NumeroSlot             int32 `json:"numero-slot"`
@@ -37,6 +40,7 @@ type SlotIncantesimo struct {
 
 type IncantesimiClasse struct {
 	SlotIncantesimi      []SlotIncantesimo `json:"slot-incantesimi,omitempty"`
+	TrucchettiConosciuti int32             `json:"trucchetti-conosciuti,omitempty"`
 	IncantesimiPreparati int32             `json:"incantesimi-preparati,omitempty"`
 }
 
@@ -46,52 +50,219 @@ type ProprietaLivello struct {
 	IncantesimiClasse *IncantesimiClasse `json:"incantesimi-di-classe,omitempty"`
 }
 
+// TipoIncantatore is how a class or subclass contributes to the
+// multiclass spellcaster level.
+type TipoIncantatore string
+
+const (
+	IncantatoreCompleto TipoIncantatore = "Completo"
+	IncantatoreMezzo    TipoIncantatore = "Mezzo"
+	IncantatoreTerzo    TipoIncantatore = "Terzo"
+	IncantatorePatto    TipoIncantatore = "Patto"
+)
+
+var TipiIncantatore = []TipoIncantatore{IncantatoreCompleto, IncantatoreMezzo, IncantatoreTerzo, IncantatorePatto}
+
+// LivelloIncantatore returns the spellcaster levels granted by livello
+// levels in a class of this type: all of them for full casters, half
+// rounded up for half casters and a third rounded down for third casters.
+// Pact magic does not count towards the spellcaster level.
+func (t TipoIncantatore) LivelloIncantatore(livello int32) int32 {
+	switch t {
+	case IncantatoreCompleto:
+		return livello
+	case IncantatoreMezzo:
+		return (livello + 1) / 2
+	case IncantatoreTerzo:
+		return livello / 3
+	default:
+		return 0
+	}
+}
+
 type RiferimentoSottoclasse struct {
 	IDSottoclasse string `json:"id-sottoclasse"`
 }
 
-type Valuta string
+// Valuta, Importo and the starting equipment types are shared with the
+// other domain modules.
+type Valuta = shared.Valuta
 
 const (
-	MR Valuta = "MR"
-	MA Valuta = "MA"
-	ME Valuta = "ME"
-	MO Valuta = "MO"
-	MP Valuta = "MP"
+	MR = shared.MR
+	MA = shared.MA
+	ME = shared.ME
+	MO = shared.MO
+	MP = shared.MP
 )
 
-type Importo struct {
-	Quantita int32  `json:"quantità"`
-	Valuta   Valuta `json:"valuta"`
+type Importo = shared.Importo
+
+type OggettoPartenza = shared.OggettoPartenza
+
+type EquipaggiamentoPartenza = shared.EquipaggiamentoPartenza
+
+type Classe struct {
+	ID                          string               `json:"id" db:"id"`
+	Nome                        string               `json:"nome" db:"nome"`
+	Descrizione                 string               `json:"descrizione" db:"descrizione"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty" db:"-"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento" db:"documentazione_di_riferimento"`
+	DadoVita                    TipoDiDado           `json:"dado-vita" db:"dado_vita"`
+	TipoIncantatore             *TipoIncantatore     `json:"tipo-incantatore,omitempty" db:"tipo_incantatore"`
+	// PrerequisitiMulticlasse must all be met to multiclass into or out of
+	// the class, e.g. Forza 13 and Carisma 13 for the paladino.
+	PrerequisitiMulticlasse []shared.PrerequisitoCaratteristica `json:"prerequisiti-multiclasse,omitempty"`
+	ElencoSottoclassi       []RiferimentoSottoclasse            `json:"elenco-sottoclassi,omitempty"`
+	// Sottoclassi embeds the subclasses listed in ElencoSottoclassi when
+	// they are requested with include=sotto-classi.
+	Sottoclassi             []SottoClasse            `json:"sotto-classi,omitempty" db:"-"`
+	EquipaggiamentoPartenza *EquipaggiamentoPartenza `json:"equipaggiamento-id-partenza,omitempty"`
+	ProprietaDiClasse       []ProprietaLivello       `json:"proprietà-di-classe,omitempty"`
+	// Versione identifies the stored row and changes with every write to
+	// the class or one of its subclasses. It is served as the ETag.
+	Versione int64 `json:"-" db:"-"`
 }
 
-type OggettoPartenza struct {
-	ID       string `json:"id,omitempty"`
-	Nome     string `json:"nome,omitempty"`
-	Quantita int32  `json:"quantità,omitempty"`
+type SottoClasse struct {
+	ID                          string               `json:"id" db:"id"`
+	Nome                        string               `json:"nome" db:"nome"`
+	Descrizione                 string               `json:"descrizione" db:"descrizione"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty" db:"-"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento" db:"documentazione_di_riferimento"`
+	IDClasseAssociata           string               `json:"id-classe-associata" db:"id_classe_associata"`
+	// NomeClasseAssociata is only set by the listing across all classes.
+	NomeClasseAssociata    string             `json:"nome-classe-associata,omitempty" db:"-"`
+	TipoIncantatore        *TipoIncantatore   `json:"tipo-incantatore,omitempty" db:"tipo_incantatore"`
+	ProprietaDiSottoclasse []ProprietaLivello `json:"proprietà-di-sottoclasse,omitempty"`
+	Versione               int64              `json:"-" db:"-"`
 }
 
-type EquipaggiamentoPartenza struct {
-	OpzioneA []OggettoPartenza `json:"opzione-a,omitempty"`
-	OpzioneB *Importo          `json:"opzione-b,omitempty"`
+// Inclusione names related resources that the classi routes can embed in
+// their responses on request.
+type Inclusione string
+
+const IncludiSottoclassi Inclusione = "sotto-classi"
+
+var Inclusioni = []Inclusione{IncludiSottoclassi}
+
+// SottoclassiFilter extends the shared list filter of the subclasses of
+// every class with the classes they must belong to.
+type SottoclassiFilter struct {
+	shared.ListFilter
+	IDClasse []string
 }
 
-type Classe struct {
-	ID                          string                   `json:"id" db:"id"`
-	Nome                        string                   `json:"nome" db:"nome"`
-	Descrizione                 string                   `json:"descrizione" db:"descrizione"`
-	DocumentazioneDiRiferimento string                   `json:"documentazione-di-riferimento" db:"documentazione_di_riferimento"`
-	DadoVita                    TipoDiDado               `json:"dado-vita" db:"dado_vita"`
-	ElencoSottoclassi           []RiferimentoSottoclasse `json:"elenco-sottoclassi,omitempty"`
-	EquipaggiamentoPartenza     *EquipaggiamentoPartenza `json:"equipaggiamento-id-partenza,omitempty"`
-	ProprietaDiClasse           []ProprietaLivello       `json:"proprietà-di-classe,omitempty"`
+// AttributiRicercaClasse and AttributiRicercaSottoClasse are the attributes
+// that can be used in a search perimeter and for sorting search results.
+var (
+	AttributiRicercaClasse      = []string{"id", "nome", "descrizione", "dado-vita", "documentazione-di-riferimento"}
+	AttributiRicercaSottoClasse = []string{"id", "nome", "descrizione", "id-classe-associata", "documentazione-di-riferimento"}
+)
+
+// TipoRisorsa is the kind of resource a revision belongs to.
+type TipoRisorsa string
+
+const (
+	RisorsaClasse      TipoRisorsa = "classe"
+	RisorsaSottoclasse TipoRisorsa = "sottoclasse"
+)
+
+// Risorsa identifies the class or subclass a revision belongs to. For a
+// class IDClasse and ID are the same.
+type Risorsa struct {
+	Tipo     TipoRisorsa
+	IDClasse string
+	ID       string
 }
 
-type SottoClasse struct {
-	ID                          string             `json:"id" db:"id"`
-	Nome                        string             `json:"nome" db:"nome"`
-	Descrizione                 string             `json:"descrizione" db:"descrizione"`
-	DocumentazioneDiRiferimento string             `json:"documentazione-di-riferimento" db:"documentazione_di_riferimento"`
-	IDClasseAssociata           string             `json:"id-classe-associata" db:"id_classe_associata"`
-	ProprietaDiSottoclasse      []ProprietaLivello `json:"proprietà-di-sottoclasse,omitempty"`
+// Operazione is the write that added a revision.
+type Operazione string
+
+const (
+	OperazioneCreazione    Operazione = "creazione"
+	OperazioneModifica     Operazione = "modifica"
+	OperazioneEliminazione Operazione = "eliminazione"
+	OperazioneRipristino   Operazione = "ripristino"
+)
+
+// Revisione is a write to a class or subclass, numbered from 1 for each
+// resource. Dati is the resource as written; it is absent for a deletion
+// and in the lists of revisions.
+type Revisione struct {
+	Numero     int             `json:"numero"`
+	Operazione Operazione      `json:"operazione"`
+	Autore     string          `json:"autore,omitempty"`
+	Data       time.Time       `json:"data"`
+	Dati       json.RawMessage `json:"dati,omitempty"`
+}
+
+// DiffRevisioni lists the changes between two revisions of a resource.
+type DiffRevisioni struct {
+	Da         int                 `json:"da"`
+	A          int                 `json:"a"`
+	Differenze []shared.Differenza `json:"differenze"`
+}
+
+// Pacchetto is a content pack of classes and subclasses. The subclasses
+// of a class in the pack can be nested in its sotto-classi; Sottoclassi
+// holds the others, each naming its class in id-classe-associata.
+type Pacchetto struct {
+	Classi      []Classe      `json:"classi,omitempty"`
+	Sottoclassi []SottoClasse `json:"sotto-classi,omitempty"`
+}
+
+// EsitoImportazione is what importing a content pack does to a resource.
+type EsitoImportazione string
+
+const (
+	ImportazioneCreazione EsitoImportazione = "creazione"
+	ImportazioneModifica  EsitoImportazione = "modifica"
+	ImportazioneInvariata EsitoImportazione = "invariata"
+)
+
+// VoceImportazione is the outcome of the import of a class or subclass.
+type VoceImportazione struct {
+	Tipo     TipoRisorsa       `json:"tipo"`
+	IDClasse string            `json:"id-classe"`
+	ID       string            `json:"id"`
+	Esito    EsitoImportazione `json:"esito"`
+}
+
+// ReportImportazione lists the outcome of every resource of a content
+// pack, classes first, in the order of the pack. Nothing is written by a
+// simulation.
+type ReportImportazione struct {
+	Simulazione bool               `json:"simulazione"`
+	Voci        []VoceImportazione `json:"voci"`
+}
+
+// ConfrontoImportazione decides whether an import writes each class and
+// subclass of a pack, given the stored resource or nil when it is missing.
+// An error stops the import and nothing is written.
+type ConfrontoImportazione struct {
+	Classe      func(corrente *Classe, importata Classe) (bool, error)
+	Sottoclasse func(corrente *SottoClasse, importata SottoClasse) (bool, error)
+}
+
+// TipoEsportazione is a resource type of a full export.
+type TipoEsportazione string
+
+const (
+	EsportazioneClassi      TipoEsportazione = "classi"
+	EsportazioneSottoclassi TipoEsportazione = "sottoclassi"
+)
+
+// TipiEsportazione are the exportable types, in the order they are
+// exported: a subclass always follows its class. The export covers only
+// the classi module; another module joins it with a TipoEsportazione of
+// its own, read by the Esporta of the repository and decoded by
+// archivio.LeggiPacchetto.
+var TipiEsportazione = []TipoEsportazione{EsportazioneClassi, EsportazioneSottoclassi}
+
+// RecordEsportazione is a resource of an export, a Classe or a
+// SottoClasse as served by the read routes.
+type RecordEsportazione struct {
+	Tipo TipoEsportazione `json:"tipo"`
+	Dati any              `json:"dati"`
 }
@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+)
+
+// Esporta reads in a read-only repeatable read transaction, so that every
+// subclass exported belongs to a class of the same export.
+func (r *PostgresRepository) Esporta(ctx context.Context, tipi []classi.TipoEsportazione, fn func(classi.RecordEsportazione) error) error {
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, tipo := range tipi {
+		switch tipo {
+		case classi.EsportazioneClassi:
+			err = esportaClassi(ctx, tx, fn)
+		case classi.EsportazioneSottoclassi:
+			err = esportaSottoclassi(ctx, tx, fn)
+		default:
+			err = fmt.Errorf("unknown tipo %q", tipo)
+		}
+		if err != nil {
+			return fmt.Errorf("export %s: %w", tipo, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func esportaClassi(ctx context.Context, tx *sqlx.Tx, fn func(classi.RecordEsportazione) error) error {
+	// The references are read first: the connection cannot run another
+	// query while the rows of the classes are open.
+	var refs []sottoclasseRef
+	if err := tx.SelectContext(ctx, &refs, `SELECT id, id_classe_associata FROM sottoclassi ORDER BY nome`); err != nil {
+		return err
+	}
+	refMap := make(map[string][]classi.RiferimentoSottoclasse)
+	for _, ref := range refs {
+		refMap[ref.IDClasseAssociata] = append(refMap[ref.IDClasseAssociata],
+			classi.RiferimentoSottoclasse{IDSottoclasse: ref.ID})
+	}
+
+	rows, err := tx.QueryxContext(ctx, selectClasse+` ORDER BY id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row classeRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+		if err := fn(classi.RecordEsportazione{Tipo: classi.EsportazioneClassi, Dati: row.toClasse(refMap[row.ID])}); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func esportaSottoclassi(ctx context.Context, tx *sqlx.Tx, fn func(classi.RecordEsportazione) error) error {
+	rows, err := tx.QueryxContext(ctx, selectSottoclasse+` ORDER BY id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row sottoclasseRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+		if err := fn(classi.RecordEsportazione{Tipo: classi.EsportazioneSottoclassi, Dati: row.toSottoClasse()}); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,125 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+)
+
+// The upserts report with xmax = 0 whether they inserted the row, to
+// record a creation or a change in the history.
+
+const upsertClasse = `
+	INSERT INTO classi (id, nome, descrizione, documentazione_di_riferimento, dado_vita,
+	                    tipo_incantatore, prerequisiti_multiclasse, equipaggiamento_partenza,
+	                    proprieta_di_classe)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (id) DO UPDATE
+	SET nome = EXCLUDED.nome, descrizione = EXCLUDED.descrizione,
+	    documentazione_di_riferimento = EXCLUDED.documentazione_di_riferimento,
+	    dado_vita = EXCLUDED.dado_vita, tipo_incantatore = EXCLUDED.tipo_incantatore,
+	    prerequisiti_multiclasse = EXCLUDED.prerequisiti_multiclasse,
+	    equipaggiamento_partenza = EXCLUDED.equipaggiamento_partenza,
+	    proprieta_di_classe = EXCLUDED.proprieta_di_classe, updated_at = clock_timestamp()
+	RETURNING xmax = 0`
+
+// upsertSottoclasse does not move a subclass stored under another class:
+// it returns no row instead.
+const upsertSottoclasse = `
+	INSERT INTO sottoclassi (id, nome, descrizione, documentazione_di_riferimento,
+	                         id_classe_associata, tipo_incantatore, proprieta_di_sottoclasse)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (id) DO UPDATE
+	SET nome = EXCLUDED.nome, descrizione = EXCLUDED.descrizione,
+	    documentazione_di_riferimento = EXCLUDED.documentazione_di_riferimento,
+	    tipo_incantatore = EXCLUDED.tipo_incantatore,
+	    proprieta_di_sottoclasse = EXCLUDED.proprieta_di_sottoclasse, updated_at = clock_timestamp()
+	WHERE sottoclassi.id_classe_associata = EXCLUDED.id_classe_associata
+	RETURNING xmax = 0`
+
+func (r *PostgresRepository) Importa(ctx context.Context, elencoClassi []classi.Classe, elencoSottoclassi []classi.SottoClasse, confronto classi.ConfrontoImportazione) error {
+	err := r.inTransazione(ctx, func(tx *sqlx.Tx) error {
+		for _, c := range elencoClassi {
+			var rows []classeRow
+			if err := tx.SelectContext(ctx, &rows, selectClasse+` WHERE id = $1 FOR UPDATE`, c.ID); err != nil {
+				return fmt.Errorf("classe %q: %w", c.ID, err)
+			}
+			var corrente *classi.Classe
+			if len(rows) > 0 {
+				classe := rows[0].toClasse(nil)
+				corrente = &classe
+			}
+			scrivi, err := confronto.Classe(corrente, c)
+			if err != nil {
+				return err
+			}
+			if !scrivi {
+				continue
+			}
+
+			var creata bool
+			if err := tx.GetContext(ctx, &creata, upsertClasse, classeArgs(c)...); err != nil {
+				return fmt.Errorf("classe %q: %w", c.ID, err)
+			}
+			// A missing row is not locked, so another writer may have
+			// created it since it was read.
+			if creata != (corrente == nil) {
+				return fmt.Errorf("classe %q was created concurrently", c.ID)
+			}
+			if err := aggiungiRevisione(ctx, tx, risorsaClasse(c.ID), operazioneImportazione(creata), c); err != nil {
+				return err
+			}
+		}
+
+		for _, s := range elencoSottoclassi {
+			var rows []sottoclasseRow
+			if err := tx.SelectContext(ctx, &rows, selectSottoclasse+` WHERE id = $1 FOR UPDATE`, s.ID); err != nil {
+				return fmt.Errorf("sottoclasse %q: %w", s.ID, err)
+			}
+			var corrente *classi.SottoClasse
+			if len(rows) > 0 {
+				sottoclasse := rows[0].toSottoClasse()
+				corrente = &sottoclasse
+			}
+			scrivi, err := confronto.Sottoclasse(corrente, s)
+			if err != nil {
+				return err
+			}
+			if !scrivi {
+				continue
+			}
+
+			var creata []bool
+			if err := tx.SelectContext(ctx, &creata, upsertSottoclasse, sottoclasseArgs(s)...); err != nil {
+				return fmt.Errorf("sottoclasse %q: %w", s.ID, err)
+			}
+			if len(creata) == 0 {
+				return fmt.Errorf("sottoclasse %q belongs to another class", s.ID)
+			}
+			if creata[0] != (corrente == nil) {
+				return fmt.Errorf("sottoclasse %q was created concurrently", s.ID)
+			}
+			if err := touchClasse(ctx, tx, s.IDClasseAssociata); err != nil {
+				return err
+			}
+			if err := aggiungiRevisione(ctx, tx, risorsaSottoclasse(s.IDClasseAssociata, s.ID), operazioneImportazione(creata[0]), s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+	return nil
+}
+
+func operazioneImportazione(creata bool) classi.Operazione {
+	if creata {
+		return classi.OperazioneCreazione
+	}
+	return classi.OperazioneModifica
+}
@@ -5,8 +5,8 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
@@ -23,19 +23,6 @@ func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
 	return &PostgresRepository{db: db}
 }
 
-// JSONB scanning helpers
-
-func scanJSON(src any, dest any) error {
-	if src == nil {
-		return nil
-	}
-	source, ok := src.([]byte)
-	if !ok {
-		return errors.New("scanJSON: source is not []byte")
-	}
-	return json.Unmarshal(source, dest)
-}
-
 type proprietaLivelloSlice []classi.ProprietaLivello
 
 func (p *proprietaLivelloSlice) Scan(src any) error {
@@ -43,7 +30,7 @@ func (p *proprietaLivelloSlice) Scan(src any) error {
 		*p = nil
 		return nil
 	}
-	return scanJSON(src, p)
+	return shared.ScanJSON(src, p)
 }
 
 func (p proprietaLivelloSlice) Value() (driver.Value, error) {
@@ -55,17 +42,89 @@ func (p proprietaLivelloSlice) Value() (driver.Value, error) {
 
 type equipaggiamentoPartenzaJSON classi.EquipaggiamentoPartenza
 
-func (e *equipaggiamentoPartenzaJSON) Scan(src any) error          { return scanJSON(src, e) }
+func (e *equipaggiamentoPartenzaJSON) Scan(src any) error          { return shared.ScanJSON(src, e) }
 func (e equipaggiamentoPartenzaJSON) Value() (driver.Value, error) { return json.Marshal(e) }
 
+// versione is the version of a row: its updated_at in microseconds. The
+// writes set updated_at and compare it to detect concurrent changes.
+const versione = `COALESCE((EXTRACT(EPOCH FROM updated_at) * 1000000)::bigint, 0)`
+
+const selectClasse = `
+	SELECT id, nome, descrizione, documentazione_di_riferimento, dado_vita,
+	       tipo_incantatore, prerequisiti_multiclasse, equipaggiamento_partenza,
+	       proprieta_di_classe, ` + versione + ` AS versione
+	FROM classi`
+
+const selectSottoclasse = `
+	SELECT id, nome, descrizione, documentazione_di_riferimento,
+	       id_classe_associata, tipo_incantatore, proprieta_di_sottoclasse,
+	       ` + versione + ` AS versione
+	FROM sottoclassi`
+
+// selectSottoclasseConClasse adds the name of the parent class with a
+// subquery, so that the shared filters on nome stay unambiguous.
+const selectSottoclasseConClasse = `
+	SELECT id, nome, descrizione, documentazione_di_riferimento,
+	       id_classe_associata, tipo_incantatore, proprieta_di_sottoclasse,
+	       ` + versione + ` AS versione,
+	       (SELECT c.nome FROM classi c WHERE c.id = sottoclassi.id_classe_associata) AS nome_classe_associata
+	FROM sottoclassi`
+
+// colonneClasse maps the JSON fields of a class to the columns they are
+// read from, for the lists restricted with campi.
+var colonneClasse = map[string]string{
+	"id":                            "id",
+	"nome":                          "nome",
+	"descrizione":                   "descrizione",
+	"documentazione-di-riferimento": "documentazione_di_riferimento",
+	"dado-vita":                     "dado_vita",
+	"tipo-incantatore":              "tipo_incantatore",
+	"prerequisiti-multiclasse":      "prerequisiti_multiclasse",
+	"equipaggiamento-id-partenza":   "equipaggiamento_partenza",
+	"proprietà-di-classe":           "proprieta_di_classe",
+}
+
+var colonneSottoclasse = map[string]string{
+	"id":                            "id",
+	"nome":                          "nome",
+	"descrizione":                   "descrizione",
+	"documentazione-di-riferimento": "documentazione_di_riferimento",
+	"id-classe-associata":           "id_classe_associata",
+	"tipo-incantatore":              "tipo_incantatore",
+	"proprietà-di-sottoclasse":      "proprieta_di_sottoclasse",
+}
+
+var colonneSottoclasseConClasse = map[string]string{
+	"nome-classe-associata": `(SELECT c.nome FROM classi c WHERE c.id = sottoclassi.id_classe_associata) AS nome_classe_associata`,
+}
+
+// selectCampi returns query when every field is requested, and otherwise
+// a select of id and the columns of the requested fields from table.
+func selectCampi(query, table string, campi shared.Campi, colonne ...map[string]string) string {
+	merged := make(map[string]string)
+	for _, c := range colonne {
+		for campo, col := range c {
+			merged[campo] = col
+		}
+	}
+	cols := campi.Colonne(merged, "id")
+	if cols == nil {
+		return query
+	}
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(cols, ", "), table)
+}
+
 type classeRow struct {
-	ID                          string                      `db:"id"`
-	Nome                        string                      `db:"nome"`
-	Descrizione                 sql.NullString              `db:"descrizione"`
-	DocumentazioneDiRiferimento string                      `db:"documentazione_di_riferimento"`
-	DadoVita                    string                      `db:"dado_vita"`
-	EquipaggiamentoPartenza     equipaggiamentoPartenzaJSON `db:"equipaggiamento_partenza"`
-	ProprietaDiClasse           proprietaLivelloSlice       `db:"proprieta_di_classe"`
+	ID                          string                                              `db:"id"`
+	Nome                        string                                              `db:"nome"`
+	Descrizione                 sql.NullString                                      `db:"descrizione"`
+	DocumentazioneDiRiferimento string                                              `db:"documentazione_di_riferimento"`
+	DadoVita                    string                                              `db:"dado_vita"`
+	TipoIncantatore             sql.NullString                                      `db:"tipo_incantatore"`
+	PrerequisitiMulticlasse     shared.JSONSlice[shared.PrerequisitoCaratteristica] `db:"prerequisiti_multiclasse"`
+	EquipaggiamentoPartenza     equipaggiamentoPartenzaJSON                         `db:"equipaggiamento_partenza"`
+	ProprietaDiClasse           proprietaLivelloSlice                               `db:"proprieta_di_classe"`
+	Versione                    int64                                               `db:"versione"`
 }
 
 func (r *classeRow) toClasse(sottoclassi []classi.RiferimentoSottoclasse) classi.Classe {
@@ -75,7 +134,10 @@ func (r *classeRow) toClasse(sottoclassi []classi.RiferimentoSottoclasse) classi
 		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
 		DadoVita:                    classi.TipoDiDado(r.DadoVita),
 		ElencoSottoclassi:           sottoclassi,
+		TipoIncantatore:             tipoIncantatore(r.TipoIncantatore),
+		PrerequisitiMulticlasse:     r.PrerequisitiMulticlasse,
 		ProprietaDiClasse:           r.ProprietaDiClasse,
+		Versione:                    r.Versione,
 	}
 	if r.Descrizione.Valid {
 		c.Descrizione = r.Descrizione.String
@@ -93,7 +155,9 @@ type sottoclasseRow struct {
 	Descrizione                 sql.NullString        `db:"descrizione"`
 	DocumentazioneDiRiferimento string                `db:"documentazione_di_riferimento"`
 	IDClasseAssociata           string                `db:"id_classe_associata"`
+	TipoIncantatore             sql.NullString        `db:"tipo_incantatore"`
 	ProprietaDiSottoclasse      proprietaLivelloSlice `db:"proprieta_di_sottoclasse"`
+	Versione                    int64                 `db:"versione"`
 }
 
 func (r *sottoclasseRow) toSottoClasse() classi.SottoClasse {
@@ -102,7 +166,9 @@ func (r *sottoclasseRow) toSottoClasse() classi.SottoClasse {
 		Nome:                        r.Nome,
 		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
 		IDClasseAssociata:           r.IDClasseAssociata,
+		TipoIncantatore:             tipoIncantatore(r.TipoIncantatore),
 		ProprietaDiSottoclasse:      r.ProprietaDiSottoclasse,
+		Versione:                    r.Versione,
 	}
 	if r.Descrizione.Valid {
 		s.Descrizione = r.Descrizione.String
@@ -110,91 +176,47 @@ func (r *sottoclasseRow) toSottoClasse() classi.SottoClasse {
 	return s
 }
 
-// paginatedQuery applies standard filters (nome, documentazione-di-riferimento),
-// sort order, and pagination to a base query and its count counterpart.
-type paginatedQuery struct {
-	query      string
-	countQuery string
-	args       map[string]any
-}
-
-func newPaginatedQuery(baseQuery, baseCountQuery string, args map[string]any, filter shared.ListFilter) *paginatedQuery {
-	if filter.Nome != nil {
-		baseQuery += ` AND nome ILIKE :nome`
-		baseCountQuery += ` AND nome ILIKE :nome`
-		args["nome"] = "%" + shared.EscapeLike(*filter.Nome) + "%"
-	}
-	if len(filter.DocumentazioneDiRiferimento) > 0 {
-		baseQuery += ` AND documentazione_di_riferimento = ANY(:docs)`
-		baseCountQuery += ` AND documentazione_di_riferimento = ANY(:docs)`
-		args["docs"] = pq.Array(filter.DocumentazioneDiRiferimento)
-	}
-
-	orderDir := "ASC"
-	if filter.Sort == shared.SortDesc {
-		orderDir = "DESC"
-	}
-	baseQuery += fmt.Sprintf(` ORDER BY nome %s`, orderDir)
-	baseQuery += ` LIMIT :limit OFFSET :offset`
-	args["limit"] = filter.Limit
-	args["offset"] = filter.Offset
-
-	return &paginatedQuery{query: baseQuery, countQuery: baseCountQuery, args: args}
-}
-
-func (q *paginatedQuery) count(ctx context.Context, db *sqlx.DB) (int, error) {
-	var total int
-	stmt, err := db.PrepareNamedContext(ctx, q.countQuery)
-	if err != nil {
-		return 0, fmt.Errorf("prepare count query: %w", err)
-	}
-	defer stmt.Close()
-	if err := stmt.GetContext(ctx, &total, q.args); err != nil {
-		return 0, fmt.Errorf("execute count query: %w", err)
-	}
-	return total, nil
+type sottoclasseConClasseRow struct {
+	sottoclasseRow
+	NomeClasseAssociata sql.NullString `db:"nome_classe_associata"`
 }
 
-func (q *paginatedQuery) selectRows(ctx context.Context, db *sqlx.DB, dest any) error {
-	stmt, err := db.PrepareNamedContext(ctx, q.query)
-	if err != nil {
-		return fmt.Errorf("prepare query: %w", err)
-	}
-	defer stmt.Close()
-	if err := stmt.SelectContext(ctx, dest, q.args); err != nil {
-		return fmt.Errorf("execute query: %w", err)
+func tipoIncantatore(s sql.NullString) *classi.TipoIncantatore {
+	if !s.Valid {
+		return nil
 	}
-	return nil
+	t := classi.TipoIncantatore(s.String)
+	return &t
 }
 
 func (r *PostgresRepository) List(ctx context.Context, filter shared.ListFilter) ([]classi.Classe, int, error) {
-	q := newPaginatedQuery(
-		`SELECT id, nome, descrizione, documentazione_di_riferimento, dado_vita,
-		        equipaggiamento_partenza, proprieta_di_classe
-		 FROM classi WHERE 1=1`,
+	q := shared.NewPaginatedQuery(
+		selectCampi(selectClasse, "classi", filter.Campi, colonneClasse)+` WHERE 1=1`,
 		`SELECT COUNT(*) FROM classi WHERE 1=1`,
 		make(map[string]any),
 		filter,
 	)
 
-	total, err := q.count(ctx, r.db)
+	total, err := q.Count(ctx, r.db)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	var rows []classeRow
-	if err := q.selectRows(ctx, r.db, &rows); err != nil {
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
 		return nil, 0, err
 	}
 
-	ids := make([]string, len(rows))
-	for i, row := range rows {
-		ids[i] = row.ID
-	}
+	refMap := make(map[string][]classi.RiferimentoSottoclasse)
+	if filter.Campi.Contiene("elenco-sottoclassi") {
+		ids := make([]string, len(rows))
+		for i, row := range rows {
+			ids[i] = row.ID
+		}
 
-	refMap, err := r.getSottoclassiRiferimentiByClasseIDs(ctx, ids)
-	if err != nil {
-		return nil, 0, err
+		if refMap, err = r.getSottoclassiRiferimentiByClasseIDs(ctx, ids); err != nil {
+			return nil, 0, err
+		}
 	}
 
 	result := make([]classi.Classe, 0, len(rows))
@@ -206,12 +228,7 @@ func (r *PostgresRepository) List(ctx context.Context, filter shared.ListFilter)
 }
 
 func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*classi.Classe, error) {
-	query := `
-		SELECT id, nome, descrizione, documentazione_di_riferimento, dado_vita,
-		       equipaggiamento_partenza, proprieta_di_classe
-		FROM classi
-		WHERE id = $1
-	`
+	query := selectClasse + ` WHERE id = $1`
 
 	var row classeRow
 	if err := r.db.GetContext(ctx, &row, query, id); err != nil {
@@ -270,23 +287,40 @@ func (r *PostgresRepository) getSottoclassiRiferimentiByClasseIDs(ctx context.Co
 	return result, nil
 }
 
+func (r *PostgresRepository) GetSottoclassiByClasseIDs(ctx context.Context, classeIDs []string) (map[string][]classi.SottoClasse, error) {
+	result := make(map[string][]classi.SottoClasse)
+	if len(classeIDs) == 0 {
+		return result, nil
+	}
+
+	query := selectSottoclasse + ` WHERE id_classe_associata = ANY($1) ORDER BY nome`
+
+	var rows []sottoclasseRow
+	if err := r.db.SelectContext(ctx, &rows, query, pq.Array(classeIDs)); err != nil {
+		return nil, fmt.Errorf("batch get sottoclassi: %w", err)
+	}
+
+	for _, row := range rows {
+		result[row.IDClasseAssociata] = append(result[row.IDClasseAssociata], row.toSottoClasse())
+	}
+	return result, nil
+}
+
 func (r *PostgresRepository) ListSottoclassi(ctx context.Context, classeID string, filter shared.ListFilter) ([]classi.SottoClasse, int, error) {
-	q := newPaginatedQuery(
-		`SELECT id, nome, descrizione, documentazione_di_riferimento,
-		        id_classe_associata, proprieta_di_sottoclasse
-		 FROM sottoclassi WHERE id_classe_associata = :classe_id`,
+	q := shared.NewPaginatedQuery(
+		selectCampi(selectSottoclasse, "sottoclassi", filter.Campi, colonneSottoclasse)+` WHERE id_classe_associata = :classe_id`,
 		`SELECT COUNT(*) FROM sottoclassi WHERE id_classe_associata = :classe_id`,
 		map[string]any{"classe_id": classeID},
 		filter,
 	)
 
-	total, err := q.count(ctx, r.db)
+	total, err := q.Count(ctx, r.db)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	var rows []sottoclasseRow
-	if err := q.selectRows(ctx, r.db, &rows); err != nil {
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
 		return nil, 0, err
 	}
 
@@ -298,13 +332,42 @@ func (r *PostgresRepository) ListSottoclassi(ctx context.Context, classeID strin
 	return result, total, nil
 }
 
+func (r *PostgresRepository) ListAllSottoclassi(ctx context.Context, filter classi.SottoclassiFilter) ([]classi.SottoClasse, int, error) {
+	var where string
+	args := make(map[string]any)
+	if len(filter.IDClasse) > 0 {
+		where = ` AND id_classe_associata = ANY(:id_classe)`
+		args["id_classe"] = pq.Array(filter.IDClasse)
+	}
+
+	q := shared.NewPaginatedQuery(
+		selectCampi(selectSottoclasseConClasse, "sottoclassi", filter.Campi, colonneSottoclasse, colonneSottoclasseConClasse)+` WHERE 1=1`+where,
+		`SELECT COUNT(*) FROM sottoclassi WHERE 1=1`+where,
+		args,
+		filter.ListFilter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []sottoclasseConClasseRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]classi.SottoClasse, len(rows))
+	for i, row := range rows {
+		result[i] = row.toSottoClasse()
+		result[i].NomeClasseAssociata = row.NomeClasseAssociata.String
+	}
+
+	return result, total, nil
+}
+
 func (r *PostgresRepository) GetSottoclasseByID(ctx context.Context, classeID, sottoclasseID string) (*classi.SottoClasse, error) {
-	query := `
-		SELECT id, nome, descrizione, documentazione_di_riferimento,
-		       id_classe_associata, proprieta_di_sottoclasse
-		FROM sottoclassi
-		WHERE id = $1 AND id_classe_associata = $2
-	`
+	query := selectSottoclasse + ` WHERE id = $1 AND id_classe_associata = $2`
 
 	var row sottoclasseRow
 	if err := r.db.GetContext(ctx, &row, query, sottoclasseID, classeID); err != nil {
@@ -317,3 +380,347 @@ func (r *PostgresRepository) GetSottoclasseByID(ctx context.Context, classeID, s
 	sottoclasse := row.toSottoClasse()
 	return &sottoclasse, nil
 }
+
+// GetSottoclasse returns the subclass with the given id whatever its
+// class.
+func (r *PostgresRepository) GetSottoclasse(ctx context.Context, sottoclasseID string) (*classi.SottoClasse, error) {
+	query := selectSottoclasse + ` WHERE id = $1`
+
+	var row sottoclasseRow
+	if err := r.db.GetContext(ctx, &row, query, sottoclasseID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get sottoclasse: %w", err)
+	}
+
+	sottoclasse := row.toSottoClasse()
+	return &sottoclasse, nil
+}
+
+// GetIncantatoriCompleti returns the full caster classes ordered by id.
+// The spell slots of a full caster are the multiclass spellcaster table.
+func (r *PostgresRepository) GetIncantatoriCompleti(ctx context.Context) ([]classi.Classe, error) {
+	query := selectClasse + ` WHERE tipo_incantatore = $1 ORDER BY id`
+
+	var rows []classeRow
+	if err := r.db.SelectContext(ctx, &rows, query, classi.IncantatoreCompleto); err != nil {
+		return nil, fmt.Errorf("get incantatori completi: %w", err)
+	}
+
+	result := make([]classi.Classe, len(rows))
+	for i, row := range rows {
+		result[i] = row.toClasse(nil)
+	}
+	return result, nil
+}
+
+var colonneRicercaClasse = map[string]string{
+	"id":                            "id",
+	"nome":                          "nome",
+	"descrizione":                   "descrizione",
+	"dado-vita":                     "dado_vita",
+	"documentazione-di-riferimento": "documentazione_di_riferimento",
+}
+
+var colonneRicercaSottoClasse = map[string]string{
+	"id":                            "id",
+	"nome":                          "nome",
+	"descrizione":                   "descrizione",
+	"id-classe-associata":           "id_classe_associata",
+	"documentazione-di-riferimento": "documentazione_di_riferimento",
+}
+
+func (r *PostgresRepository) SearchClassi(ctx context.Context, filtro shared.FiltroRicerca) ([]classi.Classe, error) {
+	query, args, err := filtro.Query(
+		selectClasse+` WHERE 1=1`,
+		colonneRicercaClasse,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []classeRow
+	if err := shared.SelectNamed(ctx, r.db, &rows, query, args); err != nil {
+		return nil, fmt.Errorf("search classi: %w", err)
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	refMap, err := r.getSottoclassiRiferimentiByClasseIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]classi.Classe, len(rows))
+	for i, row := range rows {
+		result[i] = row.toClasse(refMap[row.ID])
+	}
+	return result, nil
+}
+
+func (r *PostgresRepository) SearchSottoclassi(ctx context.Context, filtro shared.FiltroRicerca) ([]classi.SottoClasse, error) {
+	query, args, err := filtro.Query(
+		selectSottoclasse+` WHERE 1=1`,
+		colonneRicercaSottoClasse,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sottoclasseRow
+	if err := shared.SelectNamed(ctx, r.db, &rows, query, args); err != nil {
+		return nil, fmt.Errorf("search sottoclassi: %w", err)
+	}
+
+	result := make([]classi.SottoClasse, len(rows))
+	for i, row := range rows {
+		result[i] = row.toSottoClasse()
+	}
+	return result, nil
+}
+
+// nullString stores an empty string as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTipoIncantatore(t *classi.TipoIncantatore) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return nullString(string(*t))
+}
+
+// classeArgs returns the columns written for a class, in the order of
+// the INSERT and UPDATE statements below.
+func classeArgs(c classi.Classe) []any {
+	return []any{
+		c.ID, c.Nome, nullString(c.Descrizione), c.DocumentazioneDiRiferimento, string(c.DadoVita),
+		nullTipoIncantatore(c.TipoIncantatore),
+		shared.JSONSlice[shared.PrerequisitoCaratteristica](c.PrerequisitiMulticlasse),
+		shared.JSONObject[classi.EquipaggiamentoPartenza]{V: c.EquipaggiamentoPartenza},
+		proprietaLivelloSlice(c.ProprietaDiClasse),
+	}
+}
+
+const insertClasse = `
+	INSERT INTO classi (id, nome, descrizione, documentazione_di_riferimento, dado_vita,
+	                    tipo_incantatore, prerequisiti_multiclasse, equipaggiamento_partenza,
+	                    proprieta_di_classe)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (id) DO NOTHING`
+
+const updateClasse = `
+	UPDATE classi
+	SET nome = $2, descrizione = $3, documentazione_di_riferimento = $4, dado_vita = $5,
+	    tipo_incantatore = $6, prerequisiti_multiclasse = $7, equipaggiamento_partenza = $8,
+	    proprieta_di_classe = $9, updated_at = clock_timestamp()
+	WHERE id = $1 AND ` + versione + ` = $10`
+
+func (r *PostgresRepository) CreateClasse(ctx context.Context, classe classi.Classe) (bool, error) {
+	created, err := r.scriviClasse(ctx, classe, nil, classi.OperazioneCreazione)
+	if err != nil {
+		return false, fmt.Errorf("create classe: %w", err)
+	}
+	return created, nil
+}
+
+func (r *PostgresRepository) UpdateClasse(ctx context.Context, classe classi.Classe, versioneAttesa int64) (bool, error) {
+	updated, err := r.scriviClasse(ctx, classe, &versioneAttesa, classi.OperazioneModifica)
+	if err != nil {
+		return false, fmt.Errorf("update classe: %w", err)
+	}
+	return updated, nil
+}
+
+func (r *PostgresRepository) RipristinaClasse(ctx context.Context, classe classi.Classe, versioneAttesa *int64) (bool, error) {
+	restored, err := r.scriviClasse(ctx, classe, versioneAttesa, classi.OperazioneRipristino)
+	if err != nil {
+		return false, fmt.Errorf("restore classe: %w", err)
+	}
+	return restored, nil
+}
+
+// scriviClasse inserts the class when versioneAttesa is nil and updates it
+// otherwise, adding a revision for operazione.
+func (r *PostgresRepository) scriviClasse(ctx context.Context, classe classi.Classe, versioneAttesa *int64, operazione classi.Operazione) (bool, error) {
+	var written bool
+	err := r.inTransazione(ctx, func(tx *sqlx.Tx) error {
+		var res sql.Result
+		var err error
+		if versioneAttesa == nil {
+			res, err = tx.ExecContext(ctx, insertClasse, classeArgs(classe)...)
+		} else {
+			res, err = tx.ExecContext(ctx, updateClasse, append(classeArgs(classe), *versioneAttesa)...)
+		}
+		if err != nil {
+			return err
+		}
+		if written, err = affected(res); err != nil || !written {
+			return err
+		}
+		return aggiungiRevisione(ctx, tx, risorsaClasse(classe.ID), operazione, classe)
+	})
+	return written, err
+}
+
+// DeleteClasse deletes the subclasses explicitly rather than through the
+// cascade, so that their deletion is recorded in their history too.
+func (r *PostgresRepository) DeleteClasse(ctx context.Context, id string, versioneAttesa int64) (bool, error) {
+	var deleted bool
+	err := r.inTransazione(ctx, func(tx *sqlx.Tx) error {
+		var found []string
+		if err := tx.SelectContext(ctx, &found, `SELECT id FROM classi WHERE id = $1 AND `+versione+` = $2 FOR UPDATE`, id, versioneAttesa); err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			return nil
+		}
+
+		var sottoclassi []string
+		if err := tx.SelectContext(ctx, &sottoclassi, `DELETE FROM sottoclassi WHERE id_classe_associata = $1 RETURNING id`, id); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM classi WHERE id = $1`, id); err != nil {
+			return err
+		}
+		deleted = true
+
+		for _, sottoclasseID := range sottoclassi {
+			if err := aggiungiRevisione(ctx, tx, risorsaSottoclasse(id, sottoclasseID), classi.OperazioneEliminazione, nil); err != nil {
+				return err
+			}
+		}
+		return aggiungiRevisione(ctx, tx, risorsaClasse(id), classi.OperazioneEliminazione, nil)
+	})
+	if err != nil {
+		return false, fmt.Errorf("delete classe: %w", err)
+	}
+	return deleted, nil
+}
+
+func sottoclasseArgs(s classi.SottoClasse) []any {
+	return []any{
+		s.ID, s.Nome, nullString(s.Descrizione), s.DocumentazioneDiRiferimento, s.IDClasseAssociata,
+		nullTipoIncantatore(s.TipoIncantatore),
+		proprietaLivelloSlice(s.ProprietaDiSottoclasse),
+	}
+}
+
+const insertSottoclasse = `
+	INSERT INTO sottoclassi (id, nome, descrizione, documentazione_di_riferimento,
+	                         id_classe_associata, tipo_incantatore, proprieta_di_sottoclasse)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (id) DO NOTHING`
+
+const updateSottoclasse = `
+	UPDATE sottoclassi
+	SET nome = $2, descrizione = $3, documentazione_di_riferimento = $4,
+	    tipo_incantatore = $6, proprieta_di_sottoclasse = $7, updated_at = clock_timestamp()
+	WHERE id = $1 AND id_classe_associata = $5 AND ` + versione + ` = $8`
+
+// A subclass is part of the representation of its class, so every write to
+// a subclass also bumps the version of the class in the same transaction.
+
+func (r *PostgresRepository) CreateSottoclasse(ctx context.Context, sottoclasse classi.SottoClasse) (bool, error) {
+	created, err := r.scriviSottoclasse(ctx, sottoclasse, nil, classi.OperazioneCreazione)
+	if err != nil {
+		return false, fmt.Errorf("create sottoclasse: %w", err)
+	}
+	return created, nil
+}
+
+func (r *PostgresRepository) UpdateSottoclasse(ctx context.Context, sottoclasse classi.SottoClasse, versioneAttesa int64) (bool, error) {
+	updated, err := r.scriviSottoclasse(ctx, sottoclasse, &versioneAttesa, classi.OperazioneModifica)
+	if err != nil {
+		return false, fmt.Errorf("update sottoclasse: %w", err)
+	}
+	return updated, nil
+}
+
+func (r *PostgresRepository) RipristinaSottoclasse(ctx context.Context, sottoclasse classi.SottoClasse, versioneAttesa *int64) (bool, error) {
+	restored, err := r.scriviSottoclasse(ctx, sottoclasse, versioneAttesa, classi.OperazioneRipristino)
+	if err != nil {
+		return false, fmt.Errorf("restore sottoclasse: %w", err)
+	}
+	return restored, nil
+}
+
+// scriviSottoclasse inserts the subclass when versioneAttesa is nil and
+// updates it otherwise, adding a revision for operazione.
+func (r *PostgresRepository) scriviSottoclasse(ctx context.Context, sottoclasse classi.SottoClasse, versioneAttesa *int64, operazione classi.Operazione) (bool, error) {
+	var written bool
+	err := r.inTransazione(ctx, func(tx *sqlx.Tx) error {
+		var res sql.Result
+		var err error
+		if versioneAttesa == nil {
+			res, err = tx.ExecContext(ctx, insertSottoclasse, sottoclasseArgs(sottoclasse)...)
+		} else {
+			res, err = tx.ExecContext(ctx, updateSottoclasse, append(sottoclasseArgs(sottoclasse), *versioneAttesa)...)
+		}
+		if err != nil {
+			return err
+		}
+		if written, err = affected(res); err != nil || !written {
+			return err
+		}
+		if err := touchClasse(ctx, tx, sottoclasse.IDClasseAssociata); err != nil {
+			return err
+		}
+		return aggiungiRevisione(ctx, tx, risorsaSottoclasse(sottoclasse.IDClasseAssociata, sottoclasse.ID), operazione, sottoclasse)
+	})
+	return written, err
+}
+
+func (r *PostgresRepository) DeleteSottoclasse(ctx context.Context, classeID, sottoclasseID string, versioneAttesa int64) (bool, error) {
+	query := `DELETE FROM sottoclassi WHERE id = $1 AND id_classe_associata = $2 AND ` + versione + ` = $3`
+
+	var deleted bool
+	err := r.inTransazione(ctx, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx, query, sottoclasseID, classeID, versioneAttesa)
+		if err != nil {
+			return err
+		}
+		if deleted, err = affected(res); err != nil || !deleted {
+			return err
+		}
+		if err := touchClasse(ctx, tx, classeID); err != nil {
+			return err
+		}
+		return aggiungiRevisione(ctx, tx, risorsaSottoclasse(classeID, sottoclasseID), classi.OperazioneEliminazione, nil)
+	})
+	if err != nil {
+		return false, fmt.Errorf("delete sottoclasse: %w", err)
+	}
+	return deleted, nil
+}
+
+func touchClasse(ctx context.Context, tx *sqlx.Tx, classeID string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE classi SET updated_at = clock_timestamp() WHERE id = $1`, classeID)
+	return err
+}
+
+// inTransazione runs fn in a transaction, committed when fn succeeds.
+func (r *PostgresRepository) inTransazione(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func affected(res sql.Result) (bool, error) {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
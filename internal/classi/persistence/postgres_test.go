@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,6 +40,9 @@ func setupTestDB(t *testing.T) *sqlx.DB {
 		postgres.WithInitScripts(
 			filepath.Join(migrationsDir(), "000001_create_classi.up.sql"),
 			filepath.Join(migrationsDir(), "000002_create_sottoclassi.up.sql"),
+			filepath.Join(migrationsDir(), "000013_add_classi_tipo_incantatore.up.sql"),
+			filepath.Join(migrationsDir(), "000014_add_classi_prerequisiti_multiclasse.up.sql"),
+			filepath.Join(migrationsDir(), "000015_create_revisioni_classi.up.sql"),
 		),
 		testcontainers.WithWaitStrategy(
 			wait.ForLog("database system is ready to accept connections").
@@ -178,6 +183,25 @@ func TestPostgresRepository_List(t *testing.T) {
 		}
 	})
 
+	t.Run("reads only the requested fields", func(t *testing.T) {
+		filter := shared.ListFilter{Limit: 20, Sort: shared.SortAsc, Campi: shared.Campi{"dado-vita"}}
+
+		result, _, err := repo.List(ctx, filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result) != 3 {
+			t.Fatalf("expected 3 classi, got %d", len(result))
+		}
+		if result[0].ID != "barbaro" || result[0].DadoVita != "d12" {
+			t.Errorf("expected barbaro with d12, got %+v", result[0])
+		}
+		if result[0].Nome != "" || result[0].ElencoSottoclassi != nil {
+			t.Errorf("expected unrequested fields to be empty, got %+v", result[0])
+		}
+	})
+
 	t.Run("filters by nome", func(t *testing.T) {
 		nome := "mag"
 		filter := shared.ListFilter{Limit: 20, Offset: 0, Sort: shared.SortAsc, Nome: &nome}
@@ -560,6 +584,125 @@ func TestPostgresRepository_ListSottoclassi(t *testing.T) {
 	})
 }
 
+func TestPostgresRepository_ListAllSottoclassi(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	db := setupTestDB(t)
+	repo := NewPostgresRepository(db)
+	ctx := context.Background()
+
+	seedClasse(t, db, classeRow{ID: "barbaro", Nome: "Barbaro", DocumentazioneDiRiferimento: "DND 2024", DadoVita: "d12"})
+	seedClasse(t, db, classeRow{ID: "guerriero", Nome: "Guerriero", DocumentazioneDiRiferimento: "DND 2024", DadoVita: "d10"})
+
+	seedSottoclasse(t, db, sottoclasseRow{
+		ID: "berserker", Nome: "Berserker",
+		DocumentazioneDiRiferimento: "DND 2024",
+		IDClasseAssociata:           "barbaro",
+	})
+	seedSottoclasse(t, db, sottoclasseRow{
+		ID: "campione", Nome: "Campione",
+		DocumentazioneDiRiferimento: "DND 2024",
+		IDClasseAssociata:           "guerriero",
+	})
+	seedSottoclasse(t, db, sottoclasseRow{
+		ID: "cavaliere-runico", Nome: "Cavaliere Runico",
+		DocumentazioneDiRiferimento: "Homebrew",
+		IDClasseAssociata:           "guerriero",
+	})
+
+	t.Run("returns sottoclassi of every classe with its nome", func(t *testing.T) {
+		filter := classi.SottoclassiFilter{ListFilter: shared.ListFilter{Limit: 20, Sort: shared.SortAsc}}
+
+		result, total, err := repo.ListAllSottoclassi(ctx, filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if total != 3 || len(result) != 3 {
+			t.Fatalf("expected 3 sottoclassi, got %d (total %d)", len(result), total)
+		}
+		if result[0].ID != "berserker" || result[0].NomeClasseAssociata != "Barbaro" {
+			t.Errorf("unexpected first sottoclasse %+v", result[0])
+		}
+		if result[1].NomeClasseAssociata != "Guerriero" {
+			t.Errorf("unexpected second sottoclasse %+v", result[1])
+		}
+	})
+
+	t.Run("filters by id-classe and documentazione", func(t *testing.T) {
+		filter := classi.SottoclassiFilter{
+			ListFilter: shared.ListFilter{Limit: 20, Sort: shared.SortAsc, DocumentazioneDiRiferimento: []string{"DND 2024"}},
+			IDClasse:   []string{"guerriero"},
+		}
+
+		result, total, err := repo.ListAllSottoclassi(ctx, filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if total != 1 || len(result) != 1 || result[0].ID != "campione" {
+			t.Errorf("expected only 'campione', got %+v (total %d)", result, total)
+		}
+	})
+
+	t.Run("filters by nome", func(t *testing.T) {
+		nome := "runico"
+		filter := classi.SottoclassiFilter{ListFilter: shared.ListFilter{Limit: 20, Sort: shared.SortAsc, Nome: &nome}}
+
+		result, total, err := repo.ListAllSottoclassi(ctx, filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if total != 1 || len(result) != 1 || result[0].ID != "cavaliere-runico" {
+			t.Errorf("expected only 'cavaliere-runico', got %+v (total %d)", result, total)
+		}
+	})
+}
+
+func TestPostgresRepository_GetSottoclassiByClasseIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	db := setupTestDB(t)
+	repo := NewPostgresRepository(db)
+	ctx := context.Background()
+
+	seedClasse(t, db, classeRow{ID: "barbaro", Nome: "Barbaro", DocumentazioneDiRiferimento: "DND 2024", DadoVita: "d12"})
+	seedClasse(t, db, classeRow{ID: "mago", Nome: "Mago", DocumentazioneDiRiferimento: "DND 2024", DadoVita: "d6"})
+
+	seedSottoclasse(t, db, sottoclasseRow{
+		ID: "totemico", Nome: "Totemico",
+		DocumentazioneDiRiferimento: "DND 2024",
+		IDClasseAssociata:           "barbaro",
+	})
+	seedSottoclasse(t, db, sottoclasseRow{
+		ID: "berserker", Nome: "Berserker",
+		Descrizione:                 sql.NullString{String: "Furia incontrollata", Valid: true},
+		DocumentazioneDiRiferimento: "DND 2024",
+		IDClasseAssociata:           "barbaro",
+	})
+
+	result, err := repo.GetSottoclassiByClasseIDs(ctx, []string{"barbaro", "mago"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	barbaro := result["barbaro"]
+	if len(barbaro) != 2 || barbaro[0].ID != "berserker" || barbaro[1].ID != "totemico" {
+		t.Fatalf("expected berserker and totemico ordered by nome, got %+v", barbaro)
+	}
+	if barbaro[0].Descrizione != "Furia incontrollata" {
+		t.Errorf("expected full sottoclasse, got %+v", barbaro[0])
+	}
+	if len(result["mago"]) != 0 {
+		t.Errorf("expected no sottoclassi for mago, got %+v", result["mago"])
+	}
+}
+
 func TestPostgresRepository_GetSottoclasseByID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -643,40 +786,406 @@ func TestPostgresRepository_GetSottoclasseByID(t *testing.T) {
 	})
 }
 
-func TestScanJSON(t *testing.T) {
-	t.Run("nil source returns nil", func(t *testing.T) {
-		var dest []string
-		if err := scanJSON(nil, &dest); err != nil {
+func TestPostgresRepository_WriteClassi(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	db := setupTestDB(t)
+	repo := NewPostgresRepository(db)
+	ctx := context.Background()
+
+	completo := classi.IncantatoreCompleto
+	mago := classi.Classe{
+		ID: "mago", Nome: "Mago",
+		DocumentazioneDiRiferimento: "DND 2024",
+		DadoVita:                    classi.D6,
+		TipoIncantatore:             &completo,
+		PrerequisitiMulticlasse: []shared.PrerequisitoCaratteristica{
+			{Caratteristiche: []shared.Caratteristica{shared.Intelligenza}, ValoreMinimo: 13},
+		},
+		EquipaggiamentoPartenza: &classi.EquipaggiamentoPartenza{OpzioneB: &classi.Importo{Quantita: 55, Valuta: classi.MO}},
+		ProprietaDiClasse: []classi.ProprietaLivello{
+			{LivelloClasse: 1, TrattoDiClasse: &classi.Tratto{Nome: "Recupero arcano"}},
+		},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		created, err := repo.CreateClasse(ctx, mago)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !created {
+			t.Fatal("expected classe to be created")
+		}
+
+		got, err := repo.GetByID(ctx, "mago")
+		if err != nil || got == nil {
+			t.Fatalf("expected classe, got %v, %v", got, err)
+		}
+		if got.TipoIncantatore == nil || *got.TipoIncantatore != classi.IncantatoreCompleto ||
+			len(got.PrerequisitiMulticlasse) != 1 || got.EquipaggiamentoPartenza == nil ||
+			len(got.ProprietaDiClasse) != 1 || got.Descrizione != "" {
+			t.Errorf("unexpected classe %+v", got)
+		}
+	})
+
+	t.Run("create with a taken id", func(t *testing.T) {
+		created, err := repo.CreateClasse(ctx, mago)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if created {
+			t.Error("expected duplicate id not to be created")
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		updatedMago := mago
+		updatedMago.Descrizione = "Studioso di magia"
+		updatedMago.TipoIncantatore = nil
+		updatedMago.EquipaggiamentoPartenza = nil
+
+		current, _ := repo.GetByID(ctx, "mago")
+		updated, err := repo.UpdateClasse(ctx, updatedMago, current.Versione)
+		if err != nil || !updated {
+			t.Fatalf("expected classe to be updated, got %v, %v", updated, err)
+		}
+
+		got, _ := repo.GetByID(ctx, "mago")
+		if got.Descrizione != "Studioso di magia" || got.TipoIncantatore != nil || got.EquipaggiamentoPartenza != nil {
+			t.Errorf("unexpected classe %+v", got)
+		}
+		if got.Versione == current.Versione {
+			t.Error("expected the update to change the version")
+		}
+	})
+
+	t.Run("update a stale version", func(t *testing.T) {
+		current, _ := repo.GetByID(ctx, "mago")
+
+		updated, err := repo.UpdateClasse(ctx, mago, current.Versione-1)
+		if err != nil || updated {
+			t.Errorf("expected no update, got %v, %v", updated, err)
+		}
+	})
+
+	t.Run("update missing", func(t *testing.T) {
+		updated, err := repo.UpdateClasse(ctx, classi.Classe{ID: "bardo", Nome: "Bardo", DadoVita: classi.D8}, 0)
+		if err != nil || updated {
+			t.Errorf("expected no update, got %v, %v", updated, err)
+		}
+	})
+
+	t.Run("sottoclassi", func(t *testing.T) {
+		scuola := classi.SottoClasse{
+			ID: "evocatore", Nome: "Evocatore",
+			DocumentazioneDiRiferimento: "DND 2024",
+			IDClasseAssociata:           "mago",
+		}
+
+		before, _ := repo.GetByID(ctx, "mago")
+		if created, err := repo.CreateSottoclasse(ctx, scuola); err != nil || !created {
+			t.Fatalf("expected sottoclasse to be created, got %v, %v", created, err)
+		}
+		if after, _ := repo.GetByID(ctx, "mago"); after.Versione == before.Versione {
+			t.Error("expected the new sottoclasse to change the version of its classe")
+		}
+
+		current, _ := repo.GetSottoclasseByID(ctx, "mago", "evocatore")
+		scuola.Nome = "Scuola di Invocazione"
+		if updated, err := repo.UpdateSottoclasse(ctx, scuola, current.Versione); err != nil || !updated {
+			t.Fatalf("expected sottoclasse to be updated, got %v, %v", updated, err)
+		}
+		got, _ := repo.GetSottoclasseByID(ctx, "mago", "evocatore")
+		if got == nil || got.Nome != "Scuola di Invocazione" {
+			t.Errorf("unexpected sottoclasse %+v", got)
+		}
+		if updated, err := repo.UpdateSottoclasse(ctx, scuola, current.Versione); err != nil || updated {
+			t.Errorf("expected no update of a stale version, got %v, %v", updated, err)
+		}
+
+		if deleted, err := repo.DeleteSottoclasse(ctx, "barbaro", "evocatore", got.Versione); err != nil || deleted {
+			t.Errorf("expected no delete through another class, got %v, %v", deleted, err)
+		}
+		if deleted, err := repo.DeleteSottoclasse(ctx, "mago", "evocatore", got.Versione); err != nil || !deleted {
+			t.Errorf("expected sottoclasse to be deleted, got %v, %v", deleted, err)
+		}
+	})
+
+	t.Run("delete cascades to sottoclassi", func(t *testing.T) {
+		if _, err := repo.CreateSottoclasse(ctx, classi.SottoClasse{ID: "abiuratore", Nome: "Abiuratore", IDClasseAssociata: "mago", DocumentazioneDiRiferimento: "DND 2024"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		current, _ := repo.GetByID(ctx, "mago")
+		deleted, err := repo.DeleteClasse(ctx, "mago", current.Versione)
+		if err != nil || !deleted {
+			t.Fatalf("expected classe to be deleted, got %v, %v", deleted, err)
+		}
+		if got, _ := repo.GetSottoclasse(ctx, "abiuratore"); got != nil {
+			t.Errorf("expected sottoclasse to be deleted with its classe, got %+v", got)
+		}
+		if deleted, _ := repo.DeleteClasse(ctx, "mago", current.Versione); deleted {
+			t.Error("expected second delete to report false")
+		}
+	})
+}
+
+func TestPostgresRepository_Revisioni(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	db := setupTestDB(t)
+	repo := NewPostgresRepository(db)
+	ctx := shared.ConAutore(context.Background(), "Elminster")
+
+	chierico := classi.Classe{ID: "chierico", Nome: "Chierico", Descrizione: "Servitore divino", DocumentazioneDiRiferimento: "DND 2024", DadoVita: classi.D8}
+	dominio := classi.SottoClasse{ID: "dominio-vita", Nome: "Dominio della Vita", DocumentazioneDiRiferimento: "DND 2024", IDClasseAssociata: "chierico"}
+
+	if created, err := repo.CreateClasse(ctx, chierico); err != nil || !created {
+		t.Fatalf("expected classe to be created, got %v, %v", created, err)
+	}
+	if created, err := repo.CreateSottoclasse(ctx, dominio); err != nil || !created {
+		t.Fatalf("expected sottoclasse to be created, got %v, %v", created, err)
+	}
+	prima := time.Now()
+
+	modificato := chierico
+	modificato.Descrizione = "Tramite degli dei"
+	current, _ := repo.GetByID(ctx, "chierico")
+	if updated, err := repo.UpdateClasse(context.Background(), modificato, current.Versione); err != nil || !updated {
+		t.Fatalf("expected classe to be updated, got %v, %v", updated, err)
+	}
+
+	t.Run("history", func(t *testing.T) {
+		revisioni, total, err := repo.ListRevisioni(ctx, risorsaClasse("chierico"), shared.ListFilter{Limit: 20})
+		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if dest != nil {
-			t.Errorf("expected nil dest, got %v", dest)
+		if total != 2 || revisioni[0].Operazione != classi.OperazioneCreazione || revisioni[1].Operazione != classi.OperazioneModifica {
+			t.Fatalf("unexpected revisioni %+v", revisioni)
+		}
+		if revisioni[0].Autore != "Elminster" || revisioni[1].Autore != "" {
+			t.Errorf("unexpected autori %q %q", revisioni[0].Autore, revisioni[1].Autore)
+		}
+
+		revisione, err := repo.GetRevisione(ctx, risorsaClasse("chierico"), 2)
+		if err != nil || revisione == nil {
+			t.Fatalf("expected revisione, got %v, %v", revisione, err)
+		}
+		var dati classi.Classe
+		if err := json.Unmarshal(revisione.Dati, &dati); err != nil || dati.Descrizione != "Tramite degli dei" {
+			t.Errorf("unexpected dati %s: %v", revisione.Dati, err)
+		}
+	})
+
+	t.Run("point in time", func(t *testing.T) {
+		got, err := repo.GetClasseAl(ctx, "chierico", prima)
+		if err != nil || got == nil {
+			t.Fatalf("expected classe, got %v, %v", got, err)
+		}
+		if got.Descrizione != "Servitore divino" {
+			t.Errorf("expected the description before the update, got %q", got.Descrizione)
+		}
+		if len(got.ElencoSottoclassi) != 1 || got.ElencoSottoclassi[0].IDSottoclasse != "dominio-vita" {
+			t.Errorf("unexpected elenco sottoclassi %+v", got.ElencoSottoclassi)
+		}
+
+		if got, _ := repo.GetClasseAl(ctx, "chierico", prima.Add(-time.Hour)); got != nil {
+			t.Errorf("expected no classe before its creation, got %+v", got)
+		}
+	})
+
+	t.Run("delete and restore", func(t *testing.T) {
+		current, _ := repo.GetByID(ctx, "chierico")
+		if deleted, err := repo.DeleteClasse(ctx, "chierico", current.Versione); err != nil || !deleted {
+			t.Fatalf("expected classe to be deleted, got %v, %v", deleted, err)
+		}
+
+		revisione, _ := repo.GetRevisione(ctx, risorsaSottoclasse("chierico", "dominio-vita"), 2)
+		if revisione == nil || revisione.Operazione != classi.OperazioneEliminazione || revisione.Dati != nil {
+			t.Errorf("expected the deletion of the sottoclasse in its history, got %+v", revisione)
+		}
+		if got, _ := repo.GetClasseAl(ctx, "chierico", time.Now()); got != nil {
+			t.Errorf("expected no classe after its deletion, got %+v", got)
+		}
+
+		if restored, err := repo.RipristinaClasse(ctx, chierico, nil); err != nil || !restored {
+			t.Fatalf("expected classe to be restored, got %v, %v", restored, err)
+		}
+		got, _ := repo.GetByID(ctx, "chierico")
+		if got == nil || got.Descrizione != "Servitore divino" {
+			t.Errorf("unexpected restored classe %+v", got)
+		}
+		revisione, _ = repo.GetRevisione(ctx, risorsaClasse("chierico"), 4)
+		if revisione == nil || revisione.Operazione != classi.OperazioneRipristino {
+			t.Errorf("expected a ripristino revision, got %+v", revisione)
 		}
 	})
 
-	t.Run("valid JSON bytes", func(t *testing.T) {
-		var dest []string
-		src := []byte(`["a","b"]`)
-		if err := scanJSON(src, &dest); err != nil {
+	t.Run("subclass id reused under another class", func(t *testing.T) {
+		paladino := classi.Classe{ID: "paladino", Nome: "Paladino", DocumentazioneDiRiferimento: "DND 2024", DadoVita: classi.D10}
+		if created, err := repo.CreateClasse(ctx, paladino); err != nil || !created {
+			t.Fatalf("expected classe to be created, got %v, %v", created, err)
+		}
+		giuramento := dominio
+		giuramento.IDClasseAssociata = "paladino"
+		if created, err := repo.CreateSottoclasse(ctx, giuramento); err != nil || !created {
+			t.Fatalf("expected sottoclasse to be created, got %v, %v", created, err)
+		}
+
+		revisioni, total, err := repo.ListRevisioni(ctx, risorsaSottoclasse("paladino", "dominio-vita"), shared.ListFilter{Limit: 20})
+		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if len(dest) != 2 || dest[0] != "a" || dest[1] != "b" {
-			t.Errorf("unexpected result: %v", dest)
+		if total != 1 || revisioni[0].Numero != 1 {
+			t.Errorf("expected a history of its own starting from 1, got %+v", revisioni)
 		}
 	})
+}
 
-	t.Run("non-byte source returns error", func(t *testing.T) {
-		var dest []string
-		if err := scanJSON("not bytes", &dest); err == nil {
-			t.Fatal("expected error for non-byte source")
+func TestPostgresRepository_Importa(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	db := setupTestDB(t)
+	repo := NewPostgresRepository(db)
+	ctx := shared.ConAutore(context.Background(), "qe-import")
+
+	seedClasse(t, db, classeRow{ID: "druido", Nome: "Druido", DocumentazioneDiRiferimento: "DND 2024", DadoVita: "d8"})
+	seedClasse(t, db, classeRow{ID: "ranger", Nome: "Ranger", DocumentazioneDiRiferimento: "DND 2024", DadoVita: "d10"})
+	seedSottoclasse(t, db, sottoclasseRow{ID: "cacciatore", Nome: "Cacciatore", DocumentazioneDiRiferimento: "DND 2024", IDClasseAssociata: "ranger"})
+
+	var lette []string
+	tutto := classi.ConfrontoImportazione{
+		Classe: func(corrente *classi.Classe, _ classi.Classe) (bool, error) {
+			if corrente != nil {
+				lette = append(lette, corrente.ID)
+			}
+			return true, nil
+		},
+		Sottoclasse: func(corrente *classi.SottoClasse, _ classi.SottoClasse) (bool, error) {
+			if corrente != nil {
+				lette = append(lette, corrente.ID)
+			}
+			return true, nil
+		},
+	}
+
+	t.Run("creates and replaces", func(t *testing.T) {
+		druido := classi.Classe{ID: "druido", Nome: "Druido", Descrizione: "Custode della natura", DocumentazioneDiRiferimento: "DND 2024", DadoVita: classi.D8}
+		monaco := classi.Classe{ID: "monaco", Nome: "Monaco", DocumentazioneDiRiferimento: "DND 2024", DadoVita: classi.D8}
+		luna := classi.SottoClasse{ID: "circolo-luna", Nome: "Circolo della Luna", DocumentazioneDiRiferimento: "DND 2024", IDClasseAssociata: "druido"}
+
+		lette = nil
+		if err := repo.Importa(ctx, []classi.Classe{druido, monaco}, []classi.SottoClasse{luna}, tutto); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(lette) != 1 || lette[0] != "druido" {
+			t.Errorf("expected only druido to be stored before the import, got %v", lette)
+		}
+
+		got, _ := repo.GetByID(ctx, "druido")
+		if got == nil || got.Descrizione != "Custode della natura" || len(got.ElencoSottoclassi) != 1 {
+			t.Errorf("unexpected druido %+v", got)
+		}
+		if got, _ := repo.GetByID(ctx, "monaco"); got == nil {
+			t.Error("expected monaco to be created")
+		}
+
+		revisioni, _, _ := repo.ListRevisioni(ctx, risorsaClasse("druido"), shared.ListFilter{Limit: 20})
+		if len(revisioni) == 0 || revisioni[len(revisioni)-1].Operazione != classi.OperazioneModifica {
+			t.Errorf("expected a modifica revision for druido, got %+v", revisioni)
+		}
+		revisioni, _, _ = repo.ListRevisioni(ctx, risorsaClasse("monaco"), shared.ListFilter{Limit: 20})
+		if len(revisioni) != 1 || revisioni[0].Operazione != classi.OperazioneCreazione || revisioni[0].Autore != "qe-import" {
+			t.Errorf("expected a creazione revision for monaco, got %+v", revisioni)
+		}
+	})
+
+	t.Run("subclass of another class rolls back", func(t *testing.T) {
+		paladino := classi.Classe{ID: "paladino", Nome: "Paladino", DocumentazioneDiRiferimento: "DND 2024", DadoVita: classi.D10}
+		cacciatore := classi.SottoClasse{ID: "cacciatore", Nome: "Cacciatore", DocumentazioneDiRiferimento: "DND 2024", IDClasseAssociata: "druido"}
+
+		if err := repo.Importa(ctx, []classi.Classe{paladino}, []classi.SottoClasse{cacciatore}, tutto); err == nil {
+			t.Fatal("expected an error")
 		}
+
+		if got, _ := repo.GetByID(ctx, "paladino"); got != nil {
+			t.Errorf("expected the import to be rolled back, got %+v", got)
+		}
+		if got, _ := repo.GetSottoclasseByID(ctx, "ranger", "cacciatore"); got == nil {
+			t.Error("expected cacciatore to stay under ranger")
+		}
+	})
+
+	t.Run("skips what confronto does not select", func(t *testing.T) {
+		bardo := classi.Classe{ID: "bardo", Nome: "Bardo", DocumentazioneDiRiferimento: "DND 2024", DadoVita: classi.D8}
+		nessuna := classi.ConfrontoImportazione{
+			Classe:      func(*classi.Classe, classi.Classe) (bool, error) { return false, nil },
+			Sottoclasse: func(*classi.SottoClasse, classi.SottoClasse) (bool, error) { return false, nil },
+		}
+
+		if err := repo.Importa(ctx, []classi.Classe{bardo}, nil, nessuna); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got, _ := repo.GetByID(ctx, "bardo"); got != nil {
+			t.Errorf("expected bardo not to be written, got %+v", got)
+		}
+	})
+}
+
+func TestPostgresRepository_Esporta(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	db := setupTestDB(t)
+	repo := NewPostgresRepository(db)
+	ctx := context.Background()
+
+	seedClasse(t, db, classeRow{ID: "warlock", Nome: "Warlock", DocumentazioneDiRiferimento: "DND 2024", DadoVita: "d8"})
+	seedClasse(t, db, classeRow{ID: "bardo", Nome: "Bardo", DocumentazioneDiRiferimento: "DND 2024", DadoVita: "d8"})
+	seedSottoclasse(t, db, sottoclasseRow{ID: "collegio-sapienza", Nome: "Collegio della Sapienza", DocumentazioneDiRiferimento: "DND 2024", IDClasseAssociata: "bardo"})
+
+	var records []classi.RecordEsportazione
+	err := repo.Esporta(ctx, classi.TipiEsportazione, func(r classi.RecordEsportazione) error {
+		records = append(records, r)
+		return nil
 	})
 
-	t.Run("invalid JSON returns error", func(t *testing.T) {
-		var dest []string
-		src := []byte(`{invalid}`)
-		if err := scanJSON(src, &dest); err == nil {
-			t.Fatal("expected error for invalid JSON")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %+v", records)
+	}
+	bardo, ok := records[0].Dati.(classi.Classe)
+	if !ok || bardo.ID != "bardo" || len(bardo.ElencoSottoclassi) != 1 {
+		t.Errorf("expected bardo with its sottoclasse first, got %+v", records[0])
+	}
+	if records[1].Dati.(classi.Classe).ID != "warlock" {
+		t.Errorf("expected classi ordered by id, got %+v", records[1])
+	}
+	if s, ok := records[2].Dati.(classi.SottoClasse); !ok || s.IDClasseAssociata != "bardo" {
+		t.Errorf("expected the sottoclasse last, got %+v", records[2])
+	}
+
+	t.Run("error of fn stops the export", func(t *testing.T) {
+		stop := errors.New("stop")
+		var n int
+		err := repo.Esporta(ctx, classi.TipiEsportazione, func(classi.RecordEsportazione) error {
+			n++
+			return stop
+		})
+
+		if !errors.Is(err, stop) || n != 1 {
+			t.Errorf("expected the export to stop at the first record, got %v after %d", err, n)
 		}
 	})
 }
@@ -726,63 +1235,40 @@ func TestProprietaLivelloSlice_ScanValue(t *testing.T) {
 	})
 }
 
-func TestNewPaginatedQuery(t *testing.T) {
-	t.Run("basic query without filters", func(t *testing.T) {
-		args := make(map[string]any)
-		filter := shared.ListFilter{Limit: 20, Offset: 0, Sort: shared.SortAsc}
-
-		q := newPaginatedQuery("SELECT * FROM t WHERE 1=1", "SELECT COUNT(*) FROM t WHERE 1=1", args, filter)
-
-		if q.args["limit"] != 20 {
-			t.Errorf("expected limit 20, got %v", q.args["limit"])
+func TestColonneRicerca(t *testing.T) {
+	for _, attr := range classi.AttributiRicercaClasse {
+		if _, ok := colonneRicercaClasse[attr]; !ok {
+			t.Errorf("classe search attribute %q has no column", attr)
 		}
-		if q.args["offset"] != 0 {
-			t.Errorf("expected offset 0, got %v", q.args["offset"])
+	}
+	for _, attr := range classi.AttributiRicercaSottoClasse {
+		if _, ok := colonneRicercaSottoClasse[attr]; !ok {
+			t.Errorf("sotto-classe search attribute %q has no column", attr)
 		}
-	})
-
-	t.Run("with nome filter", func(t *testing.T) {
-		args := make(map[string]any)
-		nome := "test%val"
-		filter := shared.ListFilter{Limit: 20, Offset: 0, Sort: shared.SortAsc, Nome: &nome}
-
-		q := newPaginatedQuery("SELECT * FROM t WHERE 1=1", "SELECT COUNT(*) FROM t WHERE 1=1", args, filter)
+	}
+}
 
-		nomeArg, ok := q.args["nome"].(string)
-		if !ok {
-			t.Fatal("expected nome arg to be string")
-		}
-		// % in the user input should be escaped
-		if nomeArg != `%test\%val%` {
-			t.Errorf("expected escaped nome arg, got %q", nomeArg)
+func TestSelectCampi(t *testing.T) {
+	t.Run("every field keeps the full select", func(t *testing.T) {
+		if got := selectCampi(selectClasse, "classi", nil, colonneClasse); got != selectClasse {
+			t.Errorf("expected the full select, got %q", got)
 		}
 	})
 
-	t.Run("desc sort", func(t *testing.T) {
-		args := make(map[string]any)
-		filter := shared.ListFilter{Limit: 20, Offset: 0, Sort: shared.SortDesc}
-
-		q := newPaginatedQuery("SELECT * FROM t WHERE 1=1", "SELECT COUNT(*) FROM t WHERE 1=1", args, filter)
+	t.Run("selects id and the requested columns", func(t *testing.T) {
+		got := selectCampi(selectClasse, "classi", shared.Campi{"nome", "dado-vita"}, colonneClasse)
 
-		if q.query == "" {
-			t.Fatal("expected non-empty query")
-		}
-		// The query should contain DESC
-		if !contains(q.query, "DESC") {
-			t.Errorf("expected query to contain DESC, got %q", q.query)
+		if want := "SELECT id, nome, dado_vita FROM classi"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
 		}
 	})
-}
 
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && searchString(s, substr)
-}
+	t.Run("parent class name of a subclass", func(t *testing.T) {
+		got := selectCampi(selectSottoclasseConClasse, "sottoclassi", shared.Campi{"nome-classe-associata"},
+			colonneSottoclasse, colonneSottoclasseConClasse)
 
-func searchString(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+		if !strings.Contains(got, "AS nome_classe_associata") || strings.Contains(got, "descrizione") {
+			t.Errorf("unexpected select %q", got)
 		}
-	}
-	return false
+	})
 }
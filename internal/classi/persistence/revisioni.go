@@ -0,0 +1,185 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func risorsaClasse(id string) classi.Risorsa {
+	return classi.Risorsa{Tipo: classi.RisorsaClasse, IDClasse: id, ID: id}
+}
+
+func risorsaSottoclasse(classeID, id string) classi.Risorsa {
+	return classi.Risorsa{Tipo: classi.RisorsaSottoclasse, IDClasse: classeID, ID: id}
+}
+
+// aggiungiRevisione records a write in the history of the resource, with
+// the editor carried by ctx. dati is nil for a deletion.
+func aggiungiRevisione(ctx context.Context, tx *sqlx.Tx, risorsa classi.Risorsa, operazione classi.Operazione, dati any) error {
+	var raw sql.NullString
+	if dati != nil {
+		b, err := json.Marshal(dati)
+		if err != nil {
+			return err
+		}
+		raw = sql.NullString{String: string(b), Valid: true}
+	}
+
+	// Every history belongs to a class, so locking the class row serialises
+	// the numbering. A class deleted by this transaction has no row, but
+	// the delete already holds its lock.
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM classi WHERE id = $1 FOR UPDATE`, risorsa.IDClasse); err != nil {
+		return fmt.Errorf("lock classe: %w", err)
+	}
+
+	query := `
+		INSERT INTO revisioni_classi (tipo_risorsa, id_risorsa, id_classe, numero, operazione, autore, dati)
+		SELECT $1, $2, $3, COALESCE(MAX(numero), 0) + 1, $4, $5, $6
+		FROM revisioni_classi` + whereRisorsa
+
+	_, err := tx.ExecContext(ctx, query,
+		string(risorsa.Tipo), risorsa.ID, risorsa.IDClasse, string(operazione),
+		nullString(shared.Autore(ctx)), raw)
+	if err != nil {
+		return fmt.Errorf("add revisione: %w", err)
+	}
+	return nil
+}
+
+type revisioneRow struct {
+	Numero     int            `db:"numero"`
+	Operazione string         `db:"operazione"`
+	Autore     sql.NullString `db:"autore"`
+	Data       time.Time      `db:"created_at"`
+	Dati       []byte         `db:"dati"`
+}
+
+func (r *revisioneRow) toRevisione() classi.Revisione {
+	return classi.Revisione{
+		Numero:     r.Numero,
+		Operazione: classi.Operazione(r.Operazione),
+		Autore:     r.Autore.String,
+		Data:       r.Data.UTC(),
+		Dati:       r.Dati,
+	}
+}
+
+const whereRisorsa = ` WHERE tipo_risorsa = $1 AND id_risorsa = $2 AND id_classe = $3`
+
+func (r *PostgresRepository) ListRevisioni(ctx context.Context, risorsa classi.Risorsa, filter shared.ListFilter) ([]classi.Revisione, int, error) {
+	args := []any{string(risorsa.Tipo), risorsa.ID, risorsa.IDClasse}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM revisioni_classi`+whereRisorsa, args...); err != nil {
+		return nil, 0, fmt.Errorf("count revisioni: %w", err)
+	}
+
+	order := "ASC"
+	if filter.Sort == shared.SortDesc {
+		order = "DESC"
+	}
+	query := `SELECT numero, operazione, autore, created_at FROM revisioni_classi` + whereRisorsa +
+		` ORDER BY numero ` + order + ` LIMIT $4 OFFSET $5`
+
+	var rows []revisioneRow
+	if err := r.db.SelectContext(ctx, &rows, query, append(args, filter.Limit, filter.Offset)...); err != nil {
+		return nil, 0, fmt.Errorf("list revisioni: %w", err)
+	}
+
+	revisioni := make([]classi.Revisione, len(rows))
+	for i := range rows {
+		revisioni[i] = rows[i].toRevisione()
+	}
+	return revisioni, total, nil
+}
+
+func (r *PostgresRepository) GetRevisione(ctx context.Context, risorsa classi.Risorsa, numero int) (*classi.Revisione, error) {
+	query := `SELECT numero, operazione, autore, created_at, dati FROM revisioni_classi` + whereRisorsa + ` AND numero = $4`
+
+	var row revisioneRow
+	if err := r.db.GetContext(ctx, &row, query, string(risorsa.Tipo), risorsa.ID, risorsa.IDClasse, numero); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get revisione: %w", err)
+	}
+
+	revisione := row.toRevisione()
+	return &revisione, nil
+}
+
+// datiAl returns the data of the last revision of the resource up to al:
+// nil when the resource did not exist yet or had been deleted.
+func (r *PostgresRepository) datiAl(ctx context.Context, risorsa classi.Risorsa, al time.Time) ([]byte, error) {
+	query := `SELECT dati FROM revisioni_classi` + whereRisorsa + ` AND created_at <= $4 ORDER BY numero DESC LIMIT 1`
+
+	var dati []byte
+	if err := r.db.GetContext(ctx, &dati, query, string(risorsa.Tipo), risorsa.ID, risorsa.IDClasse, al); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return dati, nil
+}
+
+func (r *PostgresRepository) GetClasseAl(ctx context.Context, id string, al time.Time) (*classi.Classe, error) {
+	dati, err := r.datiAl(ctx, risorsaClasse(id), al)
+	if err != nil {
+		return nil, fmt.Errorf("get classe al: %w", err)
+	}
+	if dati == nil {
+		return nil, nil
+	}
+
+	var classe classi.Classe
+	if err := json.Unmarshal(dati, &classe); err != nil {
+		return nil, fmt.Errorf("get classe al: decode revisione: %w", err)
+	}
+
+	// The subclasses of the class at al are those whose last revision up
+	// to al is not a deletion.
+	query := `
+		SELECT id_risorsa FROM (
+			SELECT DISTINCT ON (id_risorsa) id_risorsa, dati
+			FROM revisioni_classi
+			WHERE tipo_risorsa = $1 AND id_classe = $2 AND created_at <= $3
+			ORDER BY id_risorsa, numero DESC
+		) ultime
+		WHERE dati IS NOT NULL
+		ORDER BY dati->>'nome'`
+
+	var ids []string
+	if err := r.db.SelectContext(ctx, &ids, query, string(classi.RisorsaSottoclasse), id, al); err != nil {
+		return nil, fmt.Errorf("get sottoclassi riferimenti al: %w", err)
+	}
+	classe.ElencoSottoclassi = make([]classi.RiferimentoSottoclasse, len(ids))
+	for i, sottoclasseID := range ids {
+		classe.ElencoSottoclassi[i] = classi.RiferimentoSottoclasse{IDSottoclasse: sottoclasseID}
+	}
+	return &classe, nil
+}
+
+func (r *PostgresRepository) GetSottoclasseAl(ctx context.Context, classeID, sottoclasseID string, al time.Time) (*classi.SottoClasse, error) {
+	dati, err := r.datiAl(ctx, risorsaSottoclasse(classeID, sottoclasseID), al)
+	if err != nil {
+		return nil, fmt.Errorf("get sottoclasse al: %w", err)
+	}
+	if dati == nil {
+		return nil, nil
+	}
+
+	var sottoclasse classi.SottoClasse
+	if err := json.Unmarshal(dati, &sottoclasse); err != nil {
+		return nil, fmt.Errorf("get sottoclasse al: decode revisione: %w", err)
+	}
+	return &sottoclasse, nil
+}
@@ -11,3 +11,14 @@ type ListSottoclassiResponse struct {
 	shared.PaginationMeta
 	Sottoclassi []SottoClasse `json:"sottoclassi"`
 }
+
+type ListRevisioniResponse struct {
+	shared.PaginationMeta
+	Revisioni []Revisione `json:"revisioni"`
+}
+
+type ListLivelliResponse struct {
+	IDClasse      string    `json:"id-classe"`
+	IDSottoclasse string    `json:"id-sotto-classe,omitempty"`
+	Livelli       []Livello `json:"livelli"`
+}
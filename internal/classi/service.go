@@ -2,24 +2,33 @@ package classi
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
+	"slices"
+	"time"
 
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
 	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
 )
 
 type Service struct {
-	repo   Repository
-	logger *slog.Logger
+	repo      Repository
+	oggetti   OggettiRepository
+	glossario shared.Glossario
+	logger    *slog.Logger
 }
 
-func NewService(repo Repository, logger *slog.Logger) *Service {
+func NewService(repo Repository, oggettiRepo OggettiRepository, glossario shared.Glossario, logger *slog.Logger) *Service {
 	if logger == nil {
 		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 	}
 	return &Service{
-		repo:   repo,
-		logger: logger,
+		repo:      repo,
+		oggetti:   oggettiRepo,
+		glossario: glossario,
+		logger:    logger,
 	}
 }
 
@@ -36,7 +45,27 @@ func (s *Service) ListClassi(ctx context.Context, filter shared.ListFilter) (*Li
 	}, nil
 }
 
-func (s *Service) GetClasse(ctx context.Context, id string) (*Classe, error) {
+// GetClasse returns the class with the given id, with its riferimenti when
+// campi includes them.
+func (s *Service) GetClasse(ctx context.Context, id string, campi shared.Campi) (*Classe, error) {
+	classe, err := s.getClasse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	classe.Riferimenti = s.riferimenti(ctx, classe, campi)
+	return classe, nil
+}
+
+// riferimenti returns the rules and conditions named in the descriptions of
+// risorsa when campi includes them; none without a glossary.
+func (s *Service) riferimenti(ctx context.Context, risorsa any, campi shared.Campi) []shared.Riferimento {
+	if s.glossario == nil || !campi.Contiene("riferimenti") {
+		return nil
+	}
+	return s.glossario.Riferimenti(ctx, risorsa)
+}
+
+func (s *Service) getClasse(ctx context.Context, id string) (*Classe, error) {
 	classe, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		s.logger.Error("failed to get classe", "id", id, "error", err)
@@ -48,6 +77,53 @@ func (s *Service) GetClasse(ctx context.Context, id string) (*Classe, error) {
 	return classe, nil
 }
 
+// GetClasseEspansa returns the class with the parts listed in espandi
+// resolved against the other modules. The expanded equipment replaces the
+// class one, so espandi is expected to name it. The riferimenti are those of
+// the class, when campi includes them.
+func (s *Service) GetClasseEspansa(ctx context.Context, id string, espandi []Espansione, campi shared.Campi) (*ClasseEspansa, error) {
+	classe, err := s.getClasse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ClasseEspansa{Classe: *classe}
+	if slices.Contains(espandi, EspandiEquipaggiamento) && classe.EquipaggiamentoPartenza != nil {
+		var catalogo []oggetti.Oggetto
+		if ids := IDOggetti(*classe.EquipaggiamentoPartenza); len(ids) > 0 {
+			catalogo, err = s.oggetti.GetByIDs(ctx, ids)
+			if err != nil {
+				s.logger.Error("failed to get equipaggiamento oggetti", "id", id, "error", err)
+				return nil, shared.NewInternalError(err)
+			}
+		}
+		eq := EspandiEquipaggiamentoPartenza(*classe.EquipaggiamentoPartenza, catalogo)
+		result.EquipaggiamentoPartenza = &eq
+	}
+	result.Riferimenti = s.riferimenti(ctx, &result.Classe, campi)
+	return result, nil
+}
+
+// CaricaSottoclassi embeds their subclasses in elenco, loading those of
+// every class with a single repository query.
+func (s *Service) CaricaSottoclassi(ctx context.Context, elenco ...*Classe) error {
+	ids := make([]string, len(elenco))
+	for i, c := range elenco {
+		ids[i] = c.ID
+	}
+
+	sottoclassi, err := s.repo.GetSottoclassiByClasseIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("failed to get sottoclassi", "classeIDs", ids, "error", err)
+		return shared.NewInternalError(err)
+	}
+
+	for _, c := range elenco {
+		c.Sottoclassi = sottoclassi[c.ID]
+	}
+	return nil
+}
+
 func (s *Service) verifyClasseExists(ctx context.Context, classeID string) error {
 	classe, err := s.repo.GetByID(ctx, classeID)
 	if err != nil {
@@ -77,7 +153,31 @@ func (s *Service) ListSottoclassi(ctx context.Context, classeID string, filter s
 	}, nil
 }
 
-func (s *Service) GetSottoclasse(ctx context.Context, classeID, sottoclasseID string) (*SottoClasse, error) {
+func (s *Service) ListAllSottoclassi(ctx context.Context, filter SottoclassiFilter) (*ListSottoclassiResponse, error) {
+	sottoclassi, total, err := s.repo.ListAllSottoclassi(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list all sottoclassi", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListSottoclassiResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Sottoclassi:    sottoclassi,
+	}, nil
+}
+
+// GetSottoclasse returns a subclass of the class classeID, with its
+// riferimenti when campi includes them.
+func (s *Service) GetSottoclasse(ctx context.Context, classeID, sottoclasseID string, campi shared.Campi) (*SottoClasse, error) {
+	sottoclasse, err := s.getSottoclasse(ctx, classeID, sottoclasseID)
+	if err != nil {
+		return nil, err
+	}
+	sottoclasse.Riferimenti = s.riferimenti(ctx, sottoclasse, campi)
+	return sottoclasse, nil
+}
+
+func (s *Service) getSottoclasse(ctx context.Context, classeID, sottoclasseID string) (*SottoClasse, error) {
 	if err := s.verifyClasseExists(ctx, classeID); err != nil {
 		return nil, err
 	}
@@ -92,3 +192,483 @@ func (s *Service) GetSottoclasse(ctx context.Context, classeID, sottoclasseID st
 	}
 	return sottoclasse, nil
 }
+
+func (s *Service) progressione(ctx context.Context, classeID string, sottoclasseID *string) ([]Livello, error) {
+	classe, err := s.getClasse(ctx, classeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sottoclasse *SottoClasse
+	if sottoclasseID != nil {
+		sottoclasse, err = s.repo.GetSottoclasseByID(ctx, classeID, *sottoclasseID)
+		if err != nil {
+			s.logger.Error("failed to get sottoclasse", "classeID", classeID, "sottoclasseID", *sottoclasseID, "error", err)
+			return nil, shared.NewInternalError(err)
+		}
+		if sottoclasse == nil {
+			return nil, ErrSottoclasseNotFound(*sottoclasseID)
+		}
+	}
+
+	return Progressione(classe, sottoclasse), nil
+}
+
+func (s *Service) ListLivelli(ctx context.Context, classeID string, sottoclasseID *string) (*ListLivelliResponse, error) {
+	livelli, err := s.progressione(ctx, classeID, sottoclasseID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListLivelliResponse{IDClasse: classeID, Livelli: livelli}
+	if sottoclasseID != nil {
+		response.IDSottoclasse = *sottoclasseID
+	}
+	return response, nil
+}
+
+func (s *Service) GetLivello(ctx context.Context, classeID string, livello int32, sottoclasseID *string) (*Livello, error) {
+	if livello < 1 || livello > MaxLivello {
+		return nil, shared.NewBadRequestError(fmt.Sprintf("livello must be between 1 and %d", MaxLivello), nil)
+	}
+
+	livelli, err := s.progressione(ctx, classeID, sottoclasseID)
+	if err != nil {
+		return nil, err
+	}
+	return &livelli[livello-1], nil
+}
+
+// CreateClasse validates and stores a new class. The id must not be taken.
+func (s *Service) CreateClasse(ctx context.Context, classe Classe) (*Classe, error) {
+	if err := validaClasse(&classe); err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	created, err := s.repo.CreateClasse(ctx, classe)
+	if err != nil {
+		s.logger.Error("failed to create classe", "id", classe.ID, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if !created {
+		return nil, ErrClasseDuplicata(classe.ID)
+	}
+	return s.getClasse(ctx, classe.ID)
+}
+
+// UpdateClasse replaces the class with the given id, which cannot change,
+// if its current version meets the precondition.
+func (s *Service) UpdateClasse(ctx context.Context, id string, classe Classe, precondizione shared.Precondition) (*Classe, error) {
+	current, err := s.classeCorrente(ctx, id, precondizione)
+	if err != nil {
+		return nil, err
+	}
+	return s.scriviClasse(ctx, id, classe, current.Versione)
+}
+
+// PatchClasse applies a JSON Merge Patch to the class with the given id if
+// its current version meets the precondition.
+func (s *Service) PatchClasse(ctx context.Context, id string, patch []byte, precondizione shared.Precondition) (*Classe, error) {
+	current, err := s.classeCorrente(ctx, id, precondizione)
+	if err != nil {
+		return nil, err
+	}
+	current.ElencoSottoclassi = nil
+
+	var classe Classe
+	if err := shared.ApplyMergePatch(current, patch, &classe); err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+	return s.scriviClasse(ctx, id, classe, current.Versione)
+}
+
+// DeleteClasse deletes the class with the given id and its subclasses if
+// its current version meets the precondition.
+func (s *Service) DeleteClasse(ctx context.Context, id string, precondizione shared.Precondition) error {
+	current, err := s.classeCorrente(ctx, id, precondizione)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeleteClasse(ctx, id, current.Versione)
+	if err != nil {
+		s.logger.Error("failed to delete classe", "id", id, "error", err)
+		return shared.NewInternalError(err)
+	}
+	if !deleted {
+		return ErrClasseModificata(id)
+	}
+	return nil
+}
+
+// classeCorrente returns the class with the given id if its version meets
+// the precondition of a write.
+func (s *Service) classeCorrente(ctx context.Context, id string, precondizione shared.Precondition) (*Classe, error) {
+	current, err := s.getClasse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !precondizione.Matches(shared.ETag(current.Versione)) {
+		return nil, ErrClasseModificata(id)
+	}
+	return current, nil
+}
+
+// scriviClasse stores the class read at versione. The write fails with 412
+// when another request changed the class in the meantime.
+func (s *Service) scriviClasse(ctx context.Context, id string, classe Classe, versione int64) (*Classe, error) {
+	if classe.ID == "" {
+		classe.ID = id
+	}
+	if classe.ID != id {
+		err := fmt.Errorf("id cannot be changed from %q", id)
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+	if err := validaClasse(&classe); err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	updated, err := s.repo.UpdateClasse(ctx, classe, versione)
+	if err != nil {
+		s.logger.Error("failed to update classe", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if !updated {
+		return nil, ErrClasseModificata(id)
+	}
+	return s.getClasse(ctx, id)
+}
+
+// CreateSottoclasse validates and stores a new subclass of the class
+// classeID. Subclass ids are unique across all classes.
+func (s *Service) CreateSottoclasse(ctx context.Context, classeID string, sottoclasse SottoClasse) (*SottoClasse, error) {
+	if err := validaSottoclasse(&sottoclasse, classeID); err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+	if err := s.verifyClasseExists(ctx, classeID); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.CreateSottoclasse(ctx, sottoclasse)
+	if err != nil {
+		s.logger.Error("failed to create sottoclasse", "classeID", classeID, "sottoclasseID", sottoclasse.ID, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if !created {
+		return nil, ErrSottoclasseDuplicata(sottoclasse.ID)
+	}
+	return s.getSottoclasse(ctx, classeID, sottoclasse.ID)
+}
+
+// UpdateSottoclasse replaces a subclass of the class classeID if its
+// current version meets the precondition. Neither its id nor its class can
+// change.
+func (s *Service) UpdateSottoclasse(ctx context.Context, classeID, sottoclasseID string, sottoclasse SottoClasse, precondizione shared.Precondition) (*SottoClasse, error) {
+	current, err := s.sottoclasseCorrente(ctx, classeID, sottoclasseID, precondizione)
+	if err != nil {
+		return nil, err
+	}
+	return s.scriviSottoclasse(ctx, classeID, sottoclasseID, sottoclasse, current.Versione)
+}
+
+// PatchSottoclasse applies a JSON Merge Patch to a subclass of the class
+// classeID if its current version meets the precondition.
+func (s *Service) PatchSottoclasse(ctx context.Context, classeID, sottoclasseID string, patch []byte, precondizione shared.Precondition) (*SottoClasse, error) {
+	current, err := s.sottoclasseCorrente(ctx, classeID, sottoclasseID, precondizione)
+	if err != nil {
+		return nil, err
+	}
+
+	var sottoclasse SottoClasse
+	if err := shared.ApplyMergePatch(current, patch, &sottoclasse); err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+	return s.scriviSottoclasse(ctx, classeID, sottoclasseID, sottoclasse, current.Versione)
+}
+
+// DeleteSottoclasse deletes a subclass of the class classeID if its current
+// version meets the precondition.
+func (s *Service) DeleteSottoclasse(ctx context.Context, classeID, sottoclasseID string, precondizione shared.Precondition) error {
+	current, err := s.sottoclasseCorrente(ctx, classeID, sottoclasseID, precondizione)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeleteSottoclasse(ctx, classeID, sottoclasseID, current.Versione)
+	if err != nil {
+		s.logger.Error("failed to delete sottoclasse", "classeID", classeID, "sottoclasseID", sottoclasseID, "error", err)
+		return shared.NewInternalError(err)
+	}
+	if !deleted {
+		return ErrSottoclasseModificata(sottoclasseID)
+	}
+	return nil
+}
+
+// sottoclasseCorrente returns a subclass of the class classeID if its
+// version meets the precondition of a write.
+func (s *Service) sottoclasseCorrente(ctx context.Context, classeID, sottoclasseID string, precondizione shared.Precondition) (*SottoClasse, error) {
+	current, err := s.getSottoclasse(ctx, classeID, sottoclasseID)
+	if err != nil {
+		return nil, err
+	}
+	if !precondizione.Matches(shared.ETag(current.Versione)) {
+		return nil, ErrSottoclasseModificata(sottoclasseID)
+	}
+	return current, nil
+}
+
+// scriviSottoclasse stores the subclass read at versione. The write fails
+// with 412 when another request changed the subclass in the meantime.
+func (s *Service) scriviSottoclasse(ctx context.Context, classeID, sottoclasseID string, sottoclasse SottoClasse, versione int64) (*SottoClasse, error) {
+	if sottoclasse.ID == "" {
+		sottoclasse.ID = sottoclasseID
+	}
+	if sottoclasse.ID != sottoclasseID {
+		err := fmt.Errorf("id cannot be changed from %q", sottoclasseID)
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+	if err := validaSottoclasse(&sottoclasse, classeID); err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	updated, err := s.repo.UpdateSottoclasse(ctx, sottoclasse, versione)
+	if err != nil {
+		s.logger.Error("failed to update sottoclasse", "classeID", classeID, "sottoclasseID", sottoclasseID, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if !updated {
+		return nil, ErrSottoclasseModificata(sottoclasseID)
+	}
+	return s.getSottoclasse(ctx, classeID, sottoclasseID)
+}
+
+// GetClasseAl returns the class with the given id as it was at the instant
+// al, according to its revision history, with its riferimenti when campi
+// includes them.
+func (s *Service) GetClasseAl(ctx context.Context, id string, al time.Time, campi shared.Campi) (*Classe, error) {
+	classe, err := s.getClasseAl(ctx, id, al)
+	if err != nil {
+		return nil, err
+	}
+	classe.Riferimenti = s.riferimenti(ctx, classe, campi)
+	return classe, nil
+}
+
+func (s *Service) getClasseAl(ctx context.Context, id string, al time.Time) (*Classe, error) {
+	classe, err := s.repo.GetClasseAl(ctx, id, al)
+	if err != nil {
+		s.logger.Error("failed to get classe al", "id", id, "al", al, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if classe == nil {
+		return nil, ErrClasseNotFound(id)
+	}
+	return classe, nil
+}
+
+// GetSottoclasseAl returns a subclass of the class classeID as it was at the
+// instant al, with its riferimenti when campi includes them.
+func (s *Service) GetSottoclasseAl(ctx context.Context, classeID, sottoclasseID string, al time.Time, campi shared.Campi) (*SottoClasse, error) {
+	if _, err := s.getClasseAl(ctx, classeID, al); err != nil {
+		return nil, err
+	}
+
+	sottoclasse, err := s.repo.GetSottoclasseAl(ctx, classeID, sottoclasseID, al)
+	if err != nil {
+		s.logger.Error("failed to get sottoclasse al", "classeID", classeID, "sottoclasseID", sottoclasseID, "al", al, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if sottoclasse == nil {
+		return nil, ErrSottoclasseNotFound(sottoclasseID)
+	}
+	sottoclasse.Riferimenti = s.riferimenti(ctx, sottoclasse, campi)
+	return sottoclasse, nil
+}
+
+// risorsa identifies the class classeID or, when sottoclasseID is set, its
+// subclass.
+func risorsa(classeID string, sottoclasseID *string) Risorsa {
+	if sottoclasseID != nil {
+		return Risorsa{Tipo: RisorsaSottoclasse, IDClasse: classeID, ID: *sottoclasseID}
+	}
+	return Risorsa{Tipo: RisorsaClasse, IDClasse: classeID, ID: classeID}
+}
+
+func risorsaNotFound(classeID string, sottoclasseID *string) *shared.AppError {
+	if sottoclasseID != nil {
+		return ErrSottoclasseNotFound(*sottoclasseID)
+	}
+	return ErrClasseNotFound(classeID)
+}
+
+// ListRevisioni lists the revisions of the class classeID or, when
+// sottoclasseID is set, of its subclass. The history of a deleted resource
+// is still listed, so that it can be restored.
+func (s *Service) ListRevisioni(ctx context.Context, classeID string, sottoclasseID *string, filter shared.ListFilter) (*ListRevisioniResponse, error) {
+	ris := risorsa(classeID, sottoclasseID)
+	revisioni, total, err := s.repo.ListRevisioni(ctx, ris, filter)
+	if err != nil {
+		s.logger.Error("failed to list revisioni", "tipo", ris.Tipo, "id", ris.ID, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if total == 0 {
+		return nil, risorsaNotFound(classeID, sottoclasseID)
+	}
+
+	return &ListRevisioniResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Revisioni:      revisioni,
+	}, nil
+}
+
+// GetRevisione returns a revision of the class classeID or of its subclass,
+// with the resource as it was written.
+func (s *Service) GetRevisione(ctx context.Context, classeID string, sottoclasseID *string, numero int) (*Revisione, error) {
+	ris := risorsa(classeID, sottoclasseID)
+	revisione, err := s.repo.GetRevisione(ctx, ris, numero)
+	if err != nil {
+		s.logger.Error("failed to get revisione", "tipo", ris.Tipo, "id", ris.ID, "numero", numero, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if revisione == nil {
+		return nil, ErrRevisioneNotFound(numero)
+	}
+	return revisione, nil
+}
+
+// DiffRevisioni compares two revisions of the class classeID or of its
+// subclass. A deletion compares as an empty resource.
+func (s *Service) DiffRevisioni(ctx context.Context, classeID string, sottoclasseID *string, da, a int) (*DiffRevisioni, error) {
+	prima, err := s.GetRevisione(ctx, classeID, sottoclasseID, da)
+	if err != nil {
+		return nil, err
+	}
+	dopo, err := s.GetRevisione(ctx, classeID, sottoclasseID, a)
+	if err != nil {
+		return nil, err
+	}
+
+	differenze, err := shared.DiffJSON(datiRevisione(prima), datiRevisione(dopo))
+	if err != nil {
+		ris := risorsa(classeID, sottoclasseID)
+		s.logger.Error("failed to diff revisioni", "tipo", ris.Tipo, "id", ris.ID, "da", da, "a", a, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	return &DiffRevisioni{Da: da, A: a, Differenze: differenze}, nil
+}
+
+func datiRevisione(r *Revisione) []byte {
+	if r.Dati == nil {
+		return []byte("{}")
+	}
+	return r.Dati
+}
+
+// revisioneDaRipristinare returns the data of a revision to restore. A
+// deletion has nothing to restore.
+func (s *Service) revisioneDaRipristinare(ctx context.Context, classeID string, sottoclasseID *string, numero int, dst any) error {
+	revisione, err := s.GetRevisione(ctx, classeID, sottoclasseID, numero)
+	if err != nil {
+		return err
+	}
+	if revisione.Dati == nil {
+		err := fmt.Errorf("revisione %d is a deletion and cannot be restored", numero)
+		return shared.NewBadRequestError(err.Error(), err)
+	}
+	if err := json.Unmarshal(revisione.Dati, dst); err != nil {
+		ris := risorsa(classeID, sottoclasseID)
+		s.logger.Error("failed to decode revisione", "tipo", ris.Tipo, "id", ris.ID, "numero", numero, "error", err)
+		return shared.NewInternalError(err)
+	}
+	return nil
+}
+
+// versioneDaRipristinare checks the precondition of a restore against the
+// current version of a resource, nil when the resource does not exist. A
+// missing resource has no current representation, so If-Match never
+// matches it and only If-None-Match: * does.
+func versioneDaRipristinare(versione *int64, precondizione shared.Precondition) bool {
+	if versione == nil {
+		return precondizione.MatchesAssente()
+	}
+	return precondizione.Matches(shared.ETag(*versione))
+}
+
+// RipristinaClasse writes the class with the given id back as it was at a
+// revision, creating it again if it was deleted. Its subclasses are not
+// restored.
+func (s *Service) RipristinaClasse(ctx context.Context, id string, numero int, precondizione shared.Precondition) (*Classe, error) {
+	var classe Classe
+	if err := s.revisioneDaRipristinare(ctx, id, nil, numero, &classe); err != nil {
+		return nil, err
+	}
+	if err := validaClasse(&classe); err != nil {
+		err = fmt.Errorf("revisione %d is no longer valid: %w", numero, err)
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get classe", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	var versione *int64
+	if current != nil {
+		versione = &current.Versione
+	}
+	if !versioneDaRipristinare(versione, precondizione) {
+		return nil, ErrClasseModificata(id)
+	}
+
+	restored, err := s.repo.RipristinaClasse(ctx, classe, versione)
+	if err != nil {
+		s.logger.Error("failed to restore classe", "id", id, "numero", numero, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if !restored {
+		return nil, ErrClasseModificata(id)
+	}
+	return s.getClasse(ctx, id)
+}
+
+// RipristinaSottoclasse writes a subclass of the class classeID back as it
+// was at a revision, creating it again if it was deleted. The class must
+// exist.
+func (s *Service) RipristinaSottoclasse(ctx context.Context, classeID, sottoclasseID string, numero int, precondizione shared.Precondition) (*SottoClasse, error) {
+	if err := s.verifyClasseExists(ctx, classeID); err != nil {
+		return nil, err
+	}
+
+	var sottoclasse SottoClasse
+	if err := s.revisioneDaRipristinare(ctx, classeID, &sottoclasseID, numero, &sottoclasse); err != nil {
+		return nil, err
+	}
+	if err := validaSottoclasse(&sottoclasse, classeID); err != nil {
+		err = fmt.Errorf("revisione %d is no longer valid: %w", numero, err)
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	current, err := s.repo.GetSottoclasseByID(ctx, classeID, sottoclasseID)
+	if err != nil {
+		s.logger.Error("failed to get sottoclasse", "classeID", classeID, "sottoclasseID", sottoclasseID, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	var versione *int64
+	if current != nil {
+		versione = &current.Versione
+	}
+	if !versioneDaRipristinare(versione, precondizione) {
+		return nil, ErrSottoclasseModificata(sottoclasseID)
+	}
+
+	restored, err := s.repo.RipristinaSottoclasse(ctx, sottoclasse, versione)
+	if err != nil {
+		s.logger.Error("failed to restore sottoclasse", "classeID", classeID, "sottoclasseID", sottoclasseID, "numero", numero, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if !restored {
+		return nil, ErrSottoclasseModificata(sottoclasseID)
+	}
+	return s.getSottoclasse(ctx, classeID, sottoclasseID)
+}
@@ -31,7 +31,7 @@ func BenchmarkService_ListClassi(b *testing.B) {
 			return benchClassi, len(benchClassi), nil
 		},
 	}
-	svc := NewService(repo, newTestLogger())
+	svc := NewService(repo, nil, nil, newTestLogger())
 	ctx := context.Background()
 	filter := shared.ListFilter{Limit: 20, Offset: 0}
 
@@ -47,11 +47,11 @@ func BenchmarkService_GetClasse(b *testing.B) {
 			return classe, nil
 		},
 	}
-	svc := NewService(repo, newTestLogger())
+	svc := NewService(repo, nil, nil, newTestLogger())
 	ctx := context.Background()
 
 	for b.Loop() {
-		_, _ = svc.GetClasse(ctx, "barbaro")
+		_, _ = svc.GetClasse(ctx, "barbaro", nil)
 	}
 }
 
@@ -65,7 +65,7 @@ func BenchmarkService_ListSottoclassi(b *testing.B) {
 			return benchSottoclassi, len(benchSottoclassi), nil
 		},
 	}
-	svc := NewService(repo, newTestLogger())
+	svc := NewService(repo, nil, nil, newTestLogger())
 	ctx := context.Background()
 	filter := shared.ListFilter{Limit: 20, Offset: 0}
 
@@ -85,10 +85,10 @@ func BenchmarkService_GetSottoclasse(b *testing.B) {
 			return sottoclasse, nil
 		},
 	}
-	svc := NewService(repo, newTestLogger())
+	svc := NewService(repo, nil, nil, newTestLogger())
 	ctx := context.Background()
 
 	for b.Loop() {
-		_, _ = svc.GetSottoclasse(ctx, "barbaro", "berserker")
+		_, _ = svc.GetSottoclasse(ctx, "barbaro", "berserker", nil)
 	}
 }
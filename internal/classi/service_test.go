@@ -2,11 +2,16 @@ package classi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
 	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
 )
 
@@ -30,7 +35,7 @@ func TestService_ListClassi(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 		filter := shared.ListFilter{Limit: 20, Offset: 0}
 
 		result, err := service.ListClassi(ctx, filter)
@@ -56,7 +61,7 @@ func TestService_ListClassi(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 		filter := shared.ListFilter{Limit: 20, Offset: 0}
 
 		_, err := service.ListClassi(ctx, filter)
@@ -80,7 +85,7 @@ func TestService_ListClassi(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 		filter := shared.ListFilter{Limit: 20, Offset: 0}
 
 		result, err := service.ListClassi(ctx, filter)
@@ -117,9 +122,9 @@ func TestService_GetClasse(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 
-		result, err := service.GetClasse(ctx, "barbaro")
+		result, err := service.GetClasse(ctx, "barbaro", nil)
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -139,9 +144,9 @@ func TestService_GetClasse(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 
-		_, err := service.GetClasse(ctx, "nonexistent")
+		_, err := service.GetClasse(ctx, "nonexistent", nil)
 
 		if err == nil {
 			t.Fatal("expected error, got nil")
@@ -162,9 +167,9 @@ func TestService_GetClasse(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 
-		_, err := service.GetClasse(ctx, "barbaro")
+		_, err := service.GetClasse(ctx, "barbaro", nil)
 
 		if err == nil {
 			t.Fatal("expected error, got nil")
@@ -179,6 +184,149 @@ func TestService_GetClasse(t *testing.T) {
 	})
 }
 
+func TestService_GetClasseEspansa(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	repo := &MockRepository{
+		GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+			return &Classe{ID: id, EquipaggiamentoPartenza: &EquipaggiamentoPartenza{
+				OpzioneA: []OggettoPartenza{{ID: "spadone"}, {ID: "giavellotto", Quantita: 8}},
+				OpzioneB: &Importo{Quantita: 75, Valuta: shared.MO},
+			}}, nil
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var capturedIDs []string
+		oggettiRepo := &MockOggettiRepository{
+			GetByIDsFunc: func(_ context.Context, ids []string) ([]oggetti.Oggetto, error) {
+				capturedIDs = ids
+				return catalogoDiProva(), nil
+			},
+		}
+
+		service := NewService(repo, oggettiRepo, nil, logger)
+
+		result, err := service.GetClasseEspansa(ctx, "barbaro", []Espansione{EspandiEquipaggiamento}, nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(capturedIDs) != 2 {
+			t.Errorf("expected the 2 item ids to be looked up at once, got %v", capturedIDs)
+		}
+		if result.ID != "barbaro" || result.EquipaggiamentoPartenza == nil {
+			t.Fatalf("unexpected result %+v", result)
+		}
+		if result.EquipaggiamentoPartenza.ValoreInMO != 54 {
+			t.Errorf("expected valore 54 MO, got %v", result.EquipaggiamentoPartenza.ValoreInMO)
+		}
+	})
+
+	t.Run("class without equipment", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				return &Classe{ID: id}, nil
+			},
+		}
+
+		service := NewService(repo, nil, nil, logger)
+
+		result, err := service.GetClasseEspansa(ctx, "barbaro", []Espansione{EspandiEquipaggiamento}, nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.EquipaggiamentoPartenza != nil {
+			t.Errorf("expected no equipment, got %+v", result.EquipaggiamentoPartenza)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := NewService(&MockRepository{}, nil, nil, logger)
+
+		_, err := service.GetClasseEspansa(ctx, "nonexistent", []Espansione{EspandiEquipaggiamento}, nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != 404 {
+			t.Errorf("expected 404 AppError, got %v", err)
+		}
+	})
+
+	t.Run("oggetti repository error", func(t *testing.T) {
+		oggettiRepo := &MockOggettiRepository{
+			GetByIDsFunc: func(_ context.Context, _ []string) ([]oggetti.Oggetto, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, oggettiRepo, nil, logger)
+
+		_, err := service.GetClasseEspansa(ctx, "barbaro", []Espansione{EspandiEquipaggiamento}, nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != 500 {
+			t.Errorf("expected 500 AppError, got %v", err)
+		}
+	})
+}
+
+func TestService_CaricaSottoclassi(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("single query for every classe", func(t *testing.T) {
+		calls := 0
+		repo := &MockRepository{
+			GetSottoclassiByClasseIDsFunc: func(_ context.Context, ids []string) (map[string][]SottoClasse, error) {
+				calls++
+				if len(ids) != 2 || ids[0] != "barbaro" || ids[1] != "mago" {
+					t.Errorf("unexpected ids %v", ids)
+				}
+				return map[string][]SottoClasse{
+					"barbaro": {{ID: "berserker", IDClasseAssociata: "barbaro"}, {ID: "totemico", IDClasseAssociata: "barbaro"}},
+				}, nil
+			},
+		}
+		barbaro, mago := &Classe{ID: "barbaro"}, &Classe{ID: "mago"}
+
+		service := NewService(repo, nil, nil, logger)
+
+		err := service.CaricaSottoclassi(ctx, barbaro, mago)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 repository call, got %d", calls)
+		}
+		if len(barbaro.Sottoclassi) != 2 || barbaro.Sottoclassi[1].ID != "totemico" {
+			t.Errorf("unexpected barbaro sottoclassi %+v", barbaro.Sottoclassi)
+		}
+		if mago.Sottoclassi != nil {
+			t.Errorf("expected no sottoclassi for mago, got %+v", mago.Sottoclassi)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			GetSottoclassiByClasseIDsFunc: func(_ context.Context, _ []string) (map[string][]SottoClasse, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, nil, logger)
+
+		err := service.CaricaSottoclassi(ctx, &Classe{ID: "barbaro"})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != 500 {
+			t.Errorf("expected 500 AppError, got %v", err)
+		}
+	})
+}
+
 func TestService_ListSottoclassi(t *testing.T) {
 	ctx := context.Background()
 	logger := newTestLogger()
@@ -205,7 +353,7 @@ func TestService_ListSottoclassi(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 		filter := shared.ListFilter{Limit: 20, Offset: 0}
 
 		result, err := service.ListSottoclassi(ctx, "barbaro", filter)
@@ -228,7 +376,7 @@ func TestService_ListSottoclassi(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 		filter := shared.ListFilter{Limit: 20, Offset: 0}
 
 		_, err := service.ListSottoclassi(ctx, "nonexistent", filter)
@@ -252,7 +400,7 @@ func TestService_ListSottoclassi(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 		filter := shared.ListFilter{Limit: 20, Offset: 0}
 
 		_, err := service.ListSottoclassi(ctx, "barbaro", filter)
@@ -281,7 +429,7 @@ func TestService_ListSottoclassi(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 		filter := shared.ListFilter{Limit: 20, Offset: 0}
 
 		_, err := service.ListSottoclassi(ctx, "barbaro", filter)
@@ -299,6 +447,59 @@ func TestService_ListSottoclassi(t *testing.T) {
 	})
 }
 
+func TestService_ListAllSottoclassi(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		var captured SottoclassiFilter
+		repo := &MockRepository{
+			ListAllSottoclassiFunc: func(_ context.Context, filter SottoclassiFilter) ([]SottoClasse, int, error) {
+				captured = filter
+				return []SottoClasse{
+					{ID: "berserker", Nome: "Berserker", IDClasseAssociata: "barbaro", NomeClasseAssociata: "Barbaro"},
+					{ID: "campione", Nome: "Campione", IDClasseAssociata: "guerriero", NomeClasseAssociata: "Guerriero"},
+				}, 12, nil
+			},
+		}
+
+		service := NewService(repo, nil, nil, logger)
+
+		filter := SottoclassiFilter{ListFilter: shared.ListFilter{Limit: 2, Offset: 2}, IDClasse: []string{"barbaro", "guerriero"}}
+		result, err := service.ListAllSottoclassi(ctx, filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(captured.IDClasse) != 2 {
+			t.Errorf("expected the id-classe filter to reach the repository, got %v", captured.IDClasse)
+		}
+		if result.NumeroDiElementi != 12 || result.Pagina != 2 {
+			t.Errorf("unexpected pagination %+v", result.PaginationMeta)
+		}
+		if len(result.Sottoclassi) != 2 || result.Sottoclassi[1].NomeClasseAssociata != "Guerriero" {
+			t.Errorf("unexpected sottoclassi %+v", result.Sottoclassi)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			ListAllSottoclassiFunc: func(_ context.Context, _ SottoclassiFilter) ([]SottoClasse, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, nil, logger)
+
+		_, err := service.ListAllSottoclassi(ctx, SottoclassiFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != 500 {
+			t.Errorf("expected 500 AppError, got %v", err)
+		}
+	})
+}
+
 func TestService_GetSottoclasse(t *testing.T) {
 	ctx := context.Background()
 	logger := newTestLogger()
@@ -326,9 +527,9 @@ func TestService_GetSottoclasse(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 
-		result, err := service.GetSottoclasse(ctx, "barbaro", "berserker")
+		result, err := service.GetSottoclasse(ctx, "barbaro", "berserker", nil)
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -348,9 +549,9 @@ func TestService_GetSottoclasse(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 
-		_, err := service.GetSottoclasse(ctx, "nonexistent", "berserker")
+		_, err := service.GetSottoclasse(ctx, "nonexistent", "berserker", nil)
 
 		if err == nil {
 			t.Fatal("expected error, got nil")
@@ -376,9 +577,9 @@ func TestService_GetSottoclasse(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 
-		_, err := service.GetSottoclasse(ctx, "barbaro", "nonexistent")
+		_, err := service.GetSottoclasse(ctx, "barbaro", "nonexistent", nil)
 
 		if err == nil {
 			t.Fatal("expected error, got nil")
@@ -399,9 +600,9 @@ func TestService_GetSottoclasse(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 
-		_, err := service.GetSottoclasse(ctx, "barbaro", "berserker")
+		_, err := service.GetSottoclasse(ctx, "barbaro", "berserker", nil)
 
 		if err == nil {
 			t.Fatal("expected error, got nil")
@@ -427,9 +628,9 @@ func TestService_GetSottoclasse(t *testing.T) {
 			},
 		}
 
-		service := NewService(repo, logger)
+		service := NewService(repo, nil, nil, logger)
 
-		_, err := service.GetSottoclasse(ctx, "barbaro", "berserker")
+		_, err := service.GetSottoclasse(ctx, "barbaro", "berserker", nil)
 
 		if err == nil {
 			t.Fatal("expected error, got nil")
@@ -443,3 +644,707 @@ func TestService_GetSottoclasse(t *testing.T) {
 		}
 	})
 }
+
+func TestService_ListLivelli(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	repo := &MockRepository{
+		GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+			if id != "barbaro" {
+				return nil, nil
+			}
+			return &Classe{ID: "barbaro", ProprietaDiClasse: []ProprietaLivello{
+				{LivelloClasse: 1, TrattoDiClasse: &Tratto{Nome: "Ira"}},
+			}}, nil
+		},
+		GetSottoclasseByIDFunc: func(_ context.Context, classeID, sottoclasseID string) (*SottoClasse, error) {
+			if classeID == "barbaro" && sottoclasseID == "berserker" {
+				return &SottoClasse{ID: "berserker", ProprietaDiSottoclasse: []ProprietaLivello{
+					{LivelloClasse: 3, TrattoDiClasse: &Tratto{Nome: "Frenesia"}},
+				}}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	t.Run("class only", func(t *testing.T) {
+		service := NewService(repo, nil, nil, logger)
+
+		result, err := service.ListLivelli(ctx, "barbaro", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Livelli) != MaxLivello || result.IDSottoclasse != "" {
+			t.Errorf("unexpected result %+v", result)
+		}
+		if len(result.Livelli[2].Privilegi) != 0 {
+			t.Errorf("expected no subclass features, got %+v", result.Livelli[2].Privilegi)
+		}
+	})
+
+	t.Run("with sottoclasse", func(t *testing.T) {
+		service := NewService(repo, nil, nil, logger)
+		sottoclasse := "berserker"
+
+		result, err := service.GetLivello(ctx, "barbaro", 3, &sottoclasse)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Privilegi) != 1 || result.Privilegi[0].Nome != "Frenesia" {
+			t.Errorf("unexpected privilegi %+v", result.Privilegi)
+		}
+	})
+
+	notFound := []struct {
+		name        string
+		classe      string
+		sottoclasse string
+	}{
+		{"classe not found", "nonexistent", ""},
+		{"sottoclasse not found", "barbaro", "nonexistent"},
+		{"sottoclasse of another classe", "mago", "berserker"},
+	}
+	for _, tt := range notFound {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(repo, nil, nil, logger)
+			var sottoclasse *string
+			if tt.sottoclasse != "" {
+				sottoclasse = &tt.sottoclasse
+			}
+
+			_, err := service.ListLivelli(ctx, tt.classe, sottoclasse)
+
+			var appErr *shared.AppError
+			if !errors.As(err, &appErr) || appErr.HTTPStatus != 404 {
+				t.Errorf("expected 404 AppError, got %v", err)
+			}
+		})
+	}
+
+	t.Run("livello out of range", func(t *testing.T) {
+		service := NewService(repo, nil, nil, logger)
+
+		_, err := service.GetLivello(ctx, "barbaro", 21, nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != 400 {
+			t.Errorf("expected 400 AppError, got %v", err)
+		}
+	})
+}
+
+func assertStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var appErr *shared.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected AppError, got %T (%v)", err, err)
+	}
+	if appErr.HTTPStatus != status {
+		t.Errorf("expected status %d, got %d", status, appErr.HTTPStatus)
+	}
+}
+
+func TestService_CreateClasse(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		var stored Classe
+		repo := &MockRepository{
+			CreateClasseFunc: func(_ context.Context, c Classe) (bool, error) {
+				stored = c
+				return true, nil
+			},
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				return &stored, nil
+			},
+		}
+
+		result, err := NewService(repo, nil, nil, logger).CreateClasse(ctx, classeValida())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "barbaro" || stored.DocumentazioneDiRiferimento != "DND 2024" {
+			t.Errorf("unexpected stored classe %+v", stored)
+		}
+	})
+
+	t.Run("invalid classe", func(t *testing.T) {
+		c := classeValida()
+		c.DadoVita = "d7"
+
+		_, err := NewService(&MockRepository{}, nil, nil, logger).CreateClasse(ctx, c)
+
+		assertStatus(t, err, 400)
+	})
+
+	t.Run("duplicate id", func(t *testing.T) {
+		repo := &MockRepository{
+			CreateClasseFunc: func(_ context.Context, _ Classe) (bool, error) {
+				return false, nil
+			},
+		}
+
+		_, err := NewService(repo, nil, nil, logger).CreateClasse(ctx, classeValida())
+
+		assertStatus(t, err, 409)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			CreateClasseFunc: func(_ context.Context, _ Classe) (bool, error) {
+				return false, errors.New("database error")
+			},
+		}
+
+		_, err := NewService(repo, nil, nil, logger).CreateClasse(ctx, classeValida())
+
+		assertStatus(t, err, 500)
+	})
+}
+
+// ifMatch returns the precondition of a request with If-Match: etag.
+func ifMatch(t *testing.T, etag string) shared.Precondition {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPut, "/", nil)
+	r.Header.Set("If-Match", etag)
+	p, err := shared.IfMatch(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestService_UpdateClasse(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	newRepo := func(stored *Classe) *MockRepository {
+		return &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				c := *stored
+				return &c, nil
+			},
+			UpdateClasseFunc: func(_ context.Context, c Classe, versione int64) (bool, error) {
+				if versione != stored.Versione {
+					return false, nil
+				}
+				*stored = c
+				stored.Versione = versione + 1
+				return true, nil
+			},
+		}
+	}
+
+	t.Run("id from the path", func(t *testing.T) {
+		stored := Classe{ID: "barbaro", Versione: 7}
+		c := classeValida()
+		c.ID = ""
+
+		result, err := NewService(newRepo(&stored), nil, nil, logger).UpdateClasse(ctx, "barbaro", c, ifMatch(t, shared.ETag(7)))
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stored.ID != "barbaro" {
+			t.Errorf("expected id barbaro, got %q", stored.ID)
+		}
+		if result.Versione != 8 {
+			t.Errorf("expected the new version 8, got %d", result.Versione)
+		}
+	})
+
+	t.Run("id mismatch", func(t *testing.T) {
+		stored := Classe{ID: "mago"}
+
+		_, err := NewService(newRepo(&stored), nil, nil, logger).UpdateClasse(ctx, "mago", classeValida(), shared.IfMatchAny())
+
+		assertStatus(t, err, 400)
+	})
+
+	t.Run("stale etag", func(t *testing.T) {
+		stored := Classe{ID: "barbaro", Versione: 8}
+
+		_, err := NewService(newRepo(&stored), nil, nil, logger).UpdateClasse(ctx, "barbaro", classeValida(), ifMatch(t, shared.ETag(7)))
+
+		assertStatus(t, err, 412)
+		if stored.Nome != "" {
+			t.Errorf("expected the class not to be written, got %+v", stored)
+		}
+	})
+
+	t.Run("concurrent write", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				return &Classe{ID: id, Versione: 7}, nil
+			},
+			UpdateClasseFunc: func(_ context.Context, _ Classe, _ int64) (bool, error) {
+				return false, nil
+			},
+		}
+
+		_, err := NewService(repo, nil, nil, logger).UpdateClasse(ctx, "barbaro", classeValida(), ifMatch(t, shared.ETag(7)))
+
+		assertStatus(t, err, 412)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := NewService(&MockRepository{}, nil, nil, logger).UpdateClasse(ctx, "barbaro", classeValida(), shared.IfMatchAny())
+
+		assertStatus(t, err, 404)
+	})
+}
+
+func TestService_PatchClasse(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	newRepo := func(stored *Classe) *MockRepository {
+		return &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				c := *stored
+				c.ElencoSottoclassi = []RiferimentoSottoclasse{{IDSottoclasse: "berserker"}}
+				return &c, nil
+			},
+			UpdateClasseFunc: func(_ context.Context, c Classe, _ int64) (bool, error) {
+				*stored = c
+				return true, nil
+			},
+		}
+	}
+
+	t.Run("merges the patch", func(t *testing.T) {
+		stored := classeValida()
+		patch := []byte(`{"nome":"Barbaro furioso","equipaggiamento-id-partenza":{"opzione-b":null}}`)
+
+		_, err := NewService(newRepo(&stored), nil, nil, logger).PatchClasse(ctx, "barbaro", patch, shared.IfMatchAny())
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stored.Nome != "Barbaro furioso" || stored.DadoVita != D12 {
+			t.Errorf("unexpected nome/dado-vita %q/%q", stored.Nome, stored.DadoVita)
+		}
+		if stored.EquipaggiamentoPartenza == nil || stored.EquipaggiamentoPartenza.OpzioneB != nil ||
+			len(stored.EquipaggiamentoPartenza.OpzioneA) != 1 {
+			t.Errorf("unexpected equipaggiamento %+v", stored.EquipaggiamentoPartenza)
+		}
+		if stored.ElencoSottoclassi != nil {
+			t.Errorf("expected computed fields not to be stored, got %v", stored.ElencoSottoclassi)
+		}
+	})
+
+	t.Run("invalid result", func(t *testing.T) {
+		stored := classeValida()
+
+		_, err := NewService(newRepo(&stored), nil, nil, logger).PatchClasse(ctx, "barbaro", []byte(`{"dado-vita":"d7"}`), shared.IfMatchAny())
+
+		assertStatus(t, err, 400)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		stored := classeValida()
+
+		_, err := NewService(newRepo(&stored), nil, nil, logger).PatchClasse(ctx, "barbaro", []byte(`{"colore":"rosso"}`), shared.IfMatchAny())
+
+		assertStatus(t, err, 400)
+	})
+
+	t.Run("stale etag", func(t *testing.T) {
+		stored := classeValida()
+		stored.Versione = 2
+
+		_, err := NewService(newRepo(&stored), nil, nil, logger).PatchClasse(ctx, "barbaro", []byte(`{"nome":"Barbaro furioso"}`), ifMatch(t, shared.ETag(1)))
+
+		assertStatus(t, err, 412)
+		if stored.Nome != "Barbaro" {
+			t.Errorf("expected the class not to be written, got nome %q", stored.Nome)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := NewService(&MockRepository{}, nil, nil, logger).PatchClasse(ctx, "barbaro", []byte(`{}`), shared.IfMatchAny())
+
+		assertStatus(t, err, 404)
+	})
+}
+
+func TestService_DeleteClasse(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	getByID := func(_ context.Context, id string) (*Classe, error) {
+		return &Classe{ID: id, Versione: 3}, nil
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var captured int64
+		repo := &MockRepository{
+			GetByIDFunc: getByID,
+			DeleteClasseFunc: func(_ context.Context, _ string, versione int64) (bool, error) {
+				captured = versione
+				return true, nil
+			},
+		}
+
+		if err := NewService(repo, nil, nil, logger).DeleteClasse(ctx, "barbaro", ifMatch(t, shared.ETag(3))); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if captured != 3 {
+			t.Errorf("expected version 3, got %d", captured)
+		}
+	})
+
+	t.Run("stale etag", func(t *testing.T) {
+		repo := &MockRepository{GetByIDFunc: getByID}
+
+		err := NewService(repo, nil, nil, logger).DeleteClasse(ctx, "barbaro", ifMatch(t, shared.ETag(2)))
+
+		assertStatus(t, err, 412)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := NewService(&MockRepository{}, nil, nil, logger).DeleteClasse(ctx, "barbaro", shared.IfMatchAny())
+
+		assertStatus(t, err, 404)
+	})
+}
+
+func TestService_CreateSottoclasse(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		var stored SottoClasse
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				return &Classe{ID: id}, nil
+			},
+			CreateSottoclasseFunc: func(_ context.Context, s SottoClasse) (bool, error) {
+				stored = s
+				return true, nil
+			},
+			GetSottoclasseByIDFunc: func(_ context.Context, _, _ string) (*SottoClasse, error) {
+				return &stored, nil
+			},
+		}
+
+		result, err := NewService(repo, nil, nil, logger).CreateSottoclasse(ctx, "barbaro", SottoClasse{ID: "berserker", Nome: "Berserker"})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.IDClasseAssociata != "barbaro" {
+			t.Errorf("expected id-classe-associata barbaro, got %q", result.IDClasseAssociata)
+		}
+	})
+
+	t.Run("class not found", func(t *testing.T) {
+		_, err := NewService(&MockRepository{}, nil, nil, logger).CreateSottoclasse(ctx, "barbaro", SottoClasse{ID: "berserker", Nome: "Berserker"})
+
+		assertStatus(t, err, 404)
+	})
+
+	t.Run("duplicate id", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				return &Classe{ID: id}, nil
+			},
+		}
+
+		_, err := NewService(repo, nil, nil, logger).CreateSottoclasse(ctx, "barbaro", SottoClasse{ID: "berserker", Nome: "Berserker"})
+
+		assertStatus(t, err, 409)
+	})
+}
+
+func TestService_DeleteSottoclasse(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	newRepo := func() *MockRepository {
+		return &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				return &Classe{ID: id}, nil
+			},
+			GetSottoclasseByIDFunc: func(_ context.Context, classeID, id string) (*SottoClasse, error) {
+				if classeID != "barbaro" {
+					return nil, nil
+				}
+				return &SottoClasse{ID: id, IDClasseAssociata: classeID, Versione: 5}, nil
+			},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var captured int64
+		repo := newRepo()
+		repo.DeleteSottoclasseFunc = func(_ context.Context, _, _ string, versione int64) (bool, error) {
+			captured = versione
+			return true, nil
+		}
+
+		if err := NewService(repo, nil, nil, logger).DeleteSottoclasse(ctx, "barbaro", "berserker", ifMatch(t, shared.ETag(5))); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if captured != 5 {
+			t.Errorf("expected version 5, got %d", captured)
+		}
+	})
+
+	t.Run("other class", func(t *testing.T) {
+		err := NewService(newRepo(), nil, nil, logger).DeleteSottoclasse(ctx, "mago", "berserker", shared.IfMatchAny())
+
+		assertStatus(t, err, 404)
+	})
+
+	t.Run("stale etag", func(t *testing.T) {
+		err := NewService(newRepo(), nil, nil, logger).DeleteSottoclasse(ctx, "barbaro", "berserker", ifMatch(t, shared.ETag(4)))
+
+		assertStatus(t, err, 412)
+	})
+
+	t.Run("concurrent write", func(t *testing.T) {
+		err := NewService(newRepo(), nil, nil, logger).DeleteSottoclasse(ctx, "barbaro", "berserker", shared.IfMatchAny())
+
+		assertStatus(t, err, 412)
+	})
+}
+
+func TestService_GetClasseAl(t *testing.T) {
+	ctx := context.Background()
+	al := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		var capturedAl time.Time
+		repo := &MockRepository{
+			GetClasseAlFunc: func(_ context.Context, id string, al time.Time) (*Classe, error) {
+				capturedAl = al
+				return &Classe{ID: id, Nome: "Mago"}, nil
+			},
+		}
+
+		result, err := NewService(repo, nil, nil, newTestLogger()).GetClasseAl(ctx, "mago", al, nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Nome != "Mago" || !capturedAl.Equal(al) {
+			t.Errorf("unexpected classe %+v at %v", result, capturedAl)
+		}
+	})
+
+	t.Run("not existing yet", func(t *testing.T) {
+		_, err := NewService(&MockRepository{}, nil, nil, newTestLogger()).GetClasseAl(ctx, "mago", al, nil)
+
+		assertStatus(t, err, 404)
+	})
+
+	t.Run("sottoclasse of a class not existing yet", func(t *testing.T) {
+		repo := &MockRepository{
+			GetSottoclasseAlFunc: func(_ context.Context, _, id string, _ time.Time) (*SottoClasse, error) {
+				return &SottoClasse{ID: id}, nil
+			},
+		}
+
+		_, err := NewService(repo, nil, nil, newTestLogger()).GetSottoclasseAl(ctx, "mago", "evocatore", al, nil)
+
+		assertStatus(t, err, 404)
+	})
+}
+
+func TestService_ListRevisioni(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sottoclasse", func(t *testing.T) {
+		var captured Risorsa
+		repo := &MockRepository{
+			ListRevisioniFunc: func(_ context.Context, risorsa Risorsa, _ shared.ListFilter) ([]Revisione, int, error) {
+				captured = risorsa
+				return []Revisione{{Numero: 1, Operazione: OperazioneCreazione}}, 1, nil
+			},
+		}
+		sottoclasseID := "evocatore"
+
+		result, err := NewService(repo, nil, nil, newTestLogger()).ListRevisioni(ctx, "mago", &sottoclasseID, shared.ListFilter{Limit: 20})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if captured != (Risorsa{Tipo: RisorsaSottoclasse, IDClasse: "mago", ID: "evocatore"}) {
+			t.Errorf("unexpected risorsa %+v", captured)
+		}
+		if result.NumeroDiElementi != 1 || len(result.Revisioni) != 1 {
+			t.Errorf("unexpected result %+v", result)
+		}
+	})
+
+	t.Run("no history", func(t *testing.T) {
+		_, err := NewService(&MockRepository{}, nil, nil, newTestLogger()).ListRevisioni(ctx, "mago", nil, shared.ListFilter{Limit: 20})
+
+		assertStatus(t, err, 404)
+	})
+}
+
+func TestService_DiffRevisioni(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockRepository{
+		GetRevisioneFunc: func(_ context.Context, _ Risorsa, numero int) (*Revisione, error) {
+			switch numero {
+			case 1:
+				return &Revisione{Numero: 1, Dati: json.RawMessage(`{"id":"mago","nome":"Mago","descrizione":"Studioso"}`)}, nil
+			case 2:
+				return &Revisione{Numero: 2, Dati: json.RawMessage(`{"id":"mago","nome":"Mago","descrizione":"Studioso di magia"}`)}, nil
+			case 3:
+				return &Revisione{Numero: 3, Operazione: OperazioneEliminazione}, nil
+			}
+			return nil, nil
+		},
+	}
+	service := NewService(repo, nil, nil, newTestLogger())
+
+	t.Run("changed field", func(t *testing.T) {
+		result, err := service.DiffRevisioni(ctx, "mago", nil, 1, 2)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Differenze) != 1 || result.Differenze[0].Percorso != "descrizione" {
+			t.Errorf("unexpected differenze %+v", result.Differenze)
+		}
+	})
+
+	t.Run("deletion removes every field", func(t *testing.T) {
+		result, err := service.DiffRevisioni(ctx, "mago", nil, 2, 3)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Differenze) != 3 || result.Differenze[0].Dopo != nil {
+			t.Errorf("unexpected differenze %+v", result.Differenze)
+		}
+	})
+
+	t.Run("missing revision", func(t *testing.T) {
+		_, err := service.DiffRevisioni(ctx, "mago", nil, 1, 9)
+
+		assertStatus(t, err, 404)
+	})
+}
+
+func TestService_RipristinaClasse(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	revisioni := func(_ context.Context, _ Risorsa, numero int) (*Revisione, error) {
+		switch numero {
+		case 1:
+			dati, _ := json.Marshal(classeValida())
+			return &Revisione{Numero: 1, Operazione: OperazioneCreazione, Dati: dati}, nil
+		case 2:
+			return &Revisione{Numero: 2, Operazione: OperazioneEliminazione}, nil
+		}
+		return nil, nil
+	}
+
+	t.Run("existing class", func(t *testing.T) {
+		var captured *int64
+		repo := &MockRepository{
+			GetRevisioneFunc: revisioni,
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				return &Classe{ID: id, Versione: 4}, nil
+			},
+			RipristinaClasseFunc: func(_ context.Context, _ Classe, versione *int64) (bool, error) {
+				captured = versione
+				return true, nil
+			},
+		}
+
+		_, err := NewService(repo, nil, nil, logger).RipristinaClasse(ctx, "barbaro", 1, ifMatch(t, shared.ETag(4)))
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if captured == nil || *captured != 4 {
+			t.Errorf("expected the restore at version 4, got %v", captured)
+		}
+	})
+
+	t.Run("existing class with If-None-Match", func(t *testing.T) {
+		repo := &MockRepository{
+			GetRevisioneFunc: revisioni,
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				return &Classe{ID: id, Versione: 4}, nil
+			},
+		}
+
+		_, err := NewService(repo, nil, nil, logger).RipristinaClasse(ctx, "barbaro", 1, shared.IfNoneMatchAny())
+
+		assertStatus(t, err, 412)
+	})
+
+	t.Run("existing class with a stale etag", func(t *testing.T) {
+		repo := &MockRepository{
+			GetRevisioneFunc: revisioni,
+			GetByIDFunc: func(_ context.Context, id string) (*Classe, error) {
+				return &Classe{ID: id, Versione: 5}, nil
+			},
+		}
+
+		_, err := NewService(repo, nil, nil, logger).RipristinaClasse(ctx, "barbaro", 1, ifMatch(t, shared.ETag(4)))
+
+		assertStatus(t, err, 412)
+	})
+
+	t.Run("deleted class", func(t *testing.T) {
+		var stored *Classe
+		repo := &MockRepository{
+			GetRevisioneFunc: revisioni,
+			GetByIDFunc: func(_ context.Context, _ string) (*Classe, error) {
+				return stored, nil
+			},
+			RipristinaClasseFunc: func(_ context.Context, c Classe, versione *int64) (bool, error) {
+				if versione != nil {
+					t.Errorf("expected the class to be created again, got version %d", *versione)
+				}
+				stored = &c
+				return true, nil
+			},
+		}
+		service := NewService(repo, nil, nil, logger)
+
+		_, err := service.RipristinaClasse(ctx, "barbaro", 1, ifMatch(t, shared.ETag(4)))
+		assertStatus(t, err, 412)
+
+		_, err = service.RipristinaClasse(ctx, "barbaro", 1, shared.IfMatchAny())
+		assertStatus(t, err, 412)
+
+		result, err := service.RipristinaClasse(ctx, "barbaro", 1, shared.IfNoneMatchAny())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Nome != "Barbaro" {
+			t.Errorf("unexpected classe %+v", result)
+		}
+	})
+
+	t.Run("deletion revision", func(t *testing.T) {
+		repo := &MockRepository{GetRevisioneFunc: revisioni}
+
+		_, err := NewService(repo, nil, nil, logger).RipristinaClasse(ctx, "barbaro", 2, shared.IfMatchAny())
+
+		assertStatus(t, err, 400)
+	})
+
+	t.Run("missing revision", func(t *testing.T) {
+		repo := &MockRepository{GetRevisioneFunc: revisioni}
+
+		_, err := NewService(repo, nil, nil, logger).RipristinaClasse(ctx, "barbaro", 7, shared.IfMatchAny())
+
+		assertStatus(t, err, 404)
+	})
+}
@@ -0,0 +1,329 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+// ClassiAdminService is the write side of the classi module, used by the
+// content editors. The writes to existing resources take the If-Match
+// precondition, so that two editors cannot overwrite each other.
+type ClassiAdminService interface {
+	CreateClasse(ctx context.Context, classe classi.Classe) (*classi.Classe, error)
+	UpdateClasse(ctx context.Context, id string, classe classi.Classe, precondizione shared.Precondition) (*classi.Classe, error)
+	PatchClasse(ctx context.Context, id string, patch []byte, precondizione shared.Precondition) (*classi.Classe, error)
+	DeleteClasse(ctx context.Context, id string, precondizione shared.Precondition) error
+	CreateSottoclasse(ctx context.Context, classeID string, sottoclasse classi.SottoClasse) (*classi.SottoClasse, error)
+	UpdateSottoclasse(ctx context.Context, classeID, sottoclasseID string, sottoclasse classi.SottoClasse, precondizione shared.Precondition) (*classi.SottoClasse, error)
+	PatchSottoclasse(ctx context.Context, classeID, sottoclasseID string, patch []byte, precondizione shared.Precondition) (*classi.SottoClasse, error)
+	DeleteSottoclasse(ctx context.Context, classeID, sottoclasseID string, precondizione shared.Precondition) error
+	RipristinaClasse(ctx context.Context, id string, numero int, precondizione shared.Precondition) (*classi.Classe, error)
+	RipristinaSottoclasse(ctx context.Context, classeID, sottoclasseID string, numero int, precondizione shared.Precondition) (*classi.SottoClasse, error)
+}
+
+type AdminHandler struct {
+	service ClassiAdminService
+}
+
+func NewAdminHandler(service ClassiAdminService) *AdminHandler {
+	return &AdminHandler{service: service}
+}
+
+func (h *AdminHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Use(shared.AutoreDaRichiesta)
+
+	r.Post("/", h.CreateClasse)
+	r.Put("/{id-classe}", h.UpdateClasse)
+	r.Patch("/{id-classe}", h.PatchClasse)
+	r.Delete("/{id-classe}", h.DeleteClasse)
+	r.Post("/{id-classe}/sotto-classi", h.CreateSottoclasse)
+	r.Put("/{id-classe}/sotto-classi/{id-sotto-classe}", h.UpdateSottoclasse)
+	r.Patch("/{id-classe}/sotto-classi/{id-sotto-classe}", h.PatchSottoclasse)
+	r.Delete("/{id-classe}/sotto-classi/{id-sotto-classe}", h.DeleteSottoclasse)
+	r.Post("/{id-classe}/revisioni/{revisione}/ripristino", h.RipristinaClasse)
+	r.Post("/{id-classe}/sotto-classi/{id-sotto-classe}/revisioni/{revisione}/ripristino", h.RipristinaSottoclasse)
+
+	return r
+}
+
+func (h *AdminHandler) CreateClasse(w http.ResponseWriter, r *http.Request) {
+	var classe classi.Classe
+	if err := shared.DecodeJSON(w, r, &classe); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	created, err := h.service.CreateClasse(r.Context(), classe)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/v1/classi/"+created.ID)
+	w.Header().Set("ETag", shared.ETag(created.Versione))
+	shared.WriteJSON(w, http.StatusCreated, created)
+}
+
+func (h *AdminHandler) UpdateClasse(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-classe")
+	if err := shared.ValidateID("id-classe", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	precondizione, err := shared.IfMatch(r)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	var classe classi.Classe
+	if err := shared.DecodeJSON(w, r, &classe); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	updated, err := h.service.UpdateClasse(r.Context(), id, classe, precondizione)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", shared.ETag(updated.Versione))
+	shared.WriteJSON(w, http.StatusOK, updated)
+}
+
+func (h *AdminHandler) PatchClasse(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-classe")
+	if err := shared.ValidateID("id-classe", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	precondizione, err := shared.IfMatch(r)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	patch, err := shared.ReadMergePatch(w, r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	updated, err := h.service.PatchClasse(r.Context(), id, patch, precondizione)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", shared.ETag(updated.Versione))
+	shared.WriteJSON(w, http.StatusOK, updated)
+}
+
+func (h *AdminHandler) DeleteClasse(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-classe")
+	if err := shared.ValidateID("id-classe", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	precondizione, err := shared.IfMatch(r)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	if err := h.service.DeleteClasse(r.Context(), id, precondizione); err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminHandler) CreateSottoclasse(w http.ResponseWriter, r *http.Request) {
+	classeID := chi.URLParam(r, "id-classe")
+	if err := shared.ValidateID("id-classe", classeID); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	var sottoclasse classi.SottoClasse
+	if err := shared.DecodeJSON(w, r, &sottoclasse); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	created, err := h.service.CreateSottoclasse(r.Context(), classeID, sottoclasse)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/v1/classi/"+classeID+"/sotto-classi/"+created.ID)
+	w.Header().Set("ETag", shared.ETag(created.Versione))
+	shared.WriteJSON(w, http.StatusCreated, created)
+}
+
+// sottoclasseParams reads and validates the class and subclass ids of the
+// nested routes.
+func sottoclasseParams(r *http.Request) (string, string, error) {
+	classeID := chi.URLParam(r, "id-classe")
+	if err := shared.ValidateID("id-classe", classeID); err != nil {
+		return "", "", err
+	}
+	sottoclasseID := chi.URLParam(r, "id-sotto-classe")
+	if err := shared.ValidateID("id-sotto-classe", sottoclasseID); err != nil {
+		return "", "", err
+	}
+	return classeID, sottoclasseID, nil
+}
+
+func (h *AdminHandler) UpdateSottoclasse(w http.ResponseWriter, r *http.Request) {
+	classeID, sottoclasseID, err := sottoclasseParams(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	precondizione, err := shared.IfMatch(r)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	var sottoclasse classi.SottoClasse
+	if err := shared.DecodeJSON(w, r, &sottoclasse); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	updated, err := h.service.UpdateSottoclasse(r.Context(), classeID, sottoclasseID, sottoclasse, precondizione)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", shared.ETag(updated.Versione))
+	shared.WriteJSON(w, http.StatusOK, updated)
+}
+
+func (h *AdminHandler) PatchSottoclasse(w http.ResponseWriter, r *http.Request) {
+	classeID, sottoclasseID, err := sottoclasseParams(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	precondizione, err := shared.IfMatch(r)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	patch, err := shared.ReadMergePatch(w, r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	updated, err := h.service.PatchSottoclasse(r.Context(), classeID, sottoclasseID, patch, precondizione)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", shared.ETag(updated.Versione))
+	shared.WriteJSON(w, http.StatusOK, updated)
+}
+
+func (h *AdminHandler) DeleteSottoclasse(w http.ResponseWriter, r *http.Request) {
+	classeID, sottoclasseID, err := sottoclasseParams(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	precondizione, err := shared.IfMatch(r)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	if err := h.service.DeleteSottoclasse(r.Context(), classeID, sottoclasseID, precondizione); err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// A restore writes an existing resource or creates a deleted one again. The
+// former requires If-Match as the other writes, the latter
+// If-None-Match: *.
+
+func (h *AdminHandler) RipristinaClasse(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-classe")
+	if err := shared.ValidateID("id-classe", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	numero, err := numeroRevisione("revisione", chi.URLParam(r, "revisione"))
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	precondizione, err := shared.IfMatchOrNoneMatch(r)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	restored, err := h.service.RipristinaClasse(r.Context(), id, numero, precondizione)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", shared.ETag(restored.Versione))
+	shared.WriteJSON(w, http.StatusOK, restored)
+}
+
+func (h *AdminHandler) RipristinaSottoclasse(w http.ResponseWriter, r *http.Request) {
+	classeID, sottoclasseID, err := sottoclasseParams(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	numero, err := numeroRevisione("revisione", chi.URLParam(r, "revisione"))
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	precondizione, err := shared.IfMatchOrNoneMatch(r)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	restored, err := h.service.RipristinaSottoclasse(r.Context(), classeID, sottoclasseID, numero, precondizione)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", shared.ETag(restored.Versione))
+	shared.WriteJSON(w, http.StatusOK, restored)
+}
@@ -0,0 +1,503 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockAdminService struct {
+	createClasseFunc          func(ctx context.Context, classe classi.Classe) (*classi.Classe, error)
+	updateClasseFunc          func(ctx context.Context, id string, classe classi.Classe, precondizione shared.Precondition) (*classi.Classe, error)
+	patchClasseFunc           func(ctx context.Context, id string, patch []byte, precondizione shared.Precondition) (*classi.Classe, error)
+	deleteClasseFunc          func(ctx context.Context, id string, precondizione shared.Precondition) error
+	createSottoclasseFunc     func(ctx context.Context, classeID string, sottoclasse classi.SottoClasse) (*classi.SottoClasse, error)
+	updateSottoclasseFunc     func(ctx context.Context, classeID, sottoclasseID string, sottoclasse classi.SottoClasse, precondizione shared.Precondition) (*classi.SottoClasse, error)
+	patchSottoclasseFunc      func(ctx context.Context, classeID, sottoclasseID string, patch []byte, precondizione shared.Precondition) (*classi.SottoClasse, error)
+	deleteSottoclasseFunc     func(ctx context.Context, classeID, sottoclasseID string, precondizione shared.Precondition) error
+	ripristinaClasseFunc      func(ctx context.Context, id string, numero int, precondizione shared.Precondition) (*classi.Classe, error)
+	ripristinaSottoclasseFunc func(ctx context.Context, classeID, sottoclasseID string, numero int, precondizione shared.Precondition) (*classi.SottoClasse, error)
+}
+
+func (m *mockAdminService) CreateClasse(ctx context.Context, classe classi.Classe) (*classi.Classe, error) {
+	if m.createClasseFunc != nil {
+		return m.createClasseFunc(ctx, classe)
+	}
+	return &classe, nil
+}
+
+func (m *mockAdminService) UpdateClasse(ctx context.Context, id string, classe classi.Classe, precondizione shared.Precondition) (*classi.Classe, error) {
+	if m.updateClasseFunc != nil {
+		return m.updateClasseFunc(ctx, id, classe, precondizione)
+	}
+	return &classe, nil
+}
+
+func (m *mockAdminService) PatchClasse(ctx context.Context, id string, patch []byte, precondizione shared.Precondition) (*classi.Classe, error) {
+	if m.patchClasseFunc != nil {
+		return m.patchClasseFunc(ctx, id, patch, precondizione)
+	}
+	return &classi.Classe{ID: id}, nil
+}
+
+func (m *mockAdminService) DeleteClasse(ctx context.Context, id string, precondizione shared.Precondition) error {
+	if m.deleteClasseFunc != nil {
+		return m.deleteClasseFunc(ctx, id, precondizione)
+	}
+	return nil
+}
+
+func (m *mockAdminService) CreateSottoclasse(ctx context.Context, classeID string, sottoclasse classi.SottoClasse) (*classi.SottoClasse, error) {
+	if m.createSottoclasseFunc != nil {
+		return m.createSottoclasseFunc(ctx, classeID, sottoclasse)
+	}
+	return &sottoclasse, nil
+}
+
+func (m *mockAdminService) UpdateSottoclasse(ctx context.Context, classeID, sottoclasseID string, sottoclasse classi.SottoClasse, precondizione shared.Precondition) (*classi.SottoClasse, error) {
+	if m.updateSottoclasseFunc != nil {
+		return m.updateSottoclasseFunc(ctx, classeID, sottoclasseID, sottoclasse, precondizione)
+	}
+	return &sottoclasse, nil
+}
+
+func (m *mockAdminService) PatchSottoclasse(ctx context.Context, classeID, sottoclasseID string, patch []byte, precondizione shared.Precondition) (*classi.SottoClasse, error) {
+	if m.patchSottoclasseFunc != nil {
+		return m.patchSottoclasseFunc(ctx, classeID, sottoclasseID, patch, precondizione)
+	}
+	return &classi.SottoClasse{ID: sottoclasseID, IDClasseAssociata: classeID}, nil
+}
+
+func (m *mockAdminService) DeleteSottoclasse(ctx context.Context, classeID, sottoclasseID string, precondizione shared.Precondition) error {
+	if m.deleteSottoclasseFunc != nil {
+		return m.deleteSottoclasseFunc(ctx, classeID, sottoclasseID, precondizione)
+	}
+	return nil
+}
+
+func (m *mockAdminService) RipristinaClasse(ctx context.Context, id string, numero int, precondizione shared.Precondition) (*classi.Classe, error) {
+	if m.ripristinaClasseFunc != nil {
+		return m.ripristinaClasseFunc(ctx, id, numero, precondizione)
+	}
+	return &classi.Classe{ID: id}, nil
+}
+
+func (m *mockAdminService) RipristinaSottoclasse(ctx context.Context, classeID, sottoclasseID string, numero int, precondizione shared.Precondition) (*classi.SottoClasse, error) {
+	if m.ripristinaSottoclasseFunc != nil {
+		return m.ripristinaSottoclasseFunc(ctx, classeID, sottoclasseID, numero, precondizione)
+	}
+	return &classi.SottoClasse{ID: sottoclasseID, IDClasseAssociata: classeID}, nil
+}
+
+func newAdminRouter(svc ClassiAdminService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/admin/classi", NewAdminHandler(svc).Routes())
+	return r
+}
+
+func TestAdminHandler_CreateClasse(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		var captured classi.Classe
+		svc := &mockAdminService{
+			createClasseFunc: func(_ context.Context, classe classi.Classe) (*classi.Classe, error) {
+				captured = classe
+				return &classe, nil
+			},
+		}
+
+		body := `{"id":"barbaro","nome":"Barbaro","dado-vita":"d12"}`
+		req := httptest.NewRequest(http.MethodPost, "/admin/classi", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if loc := rec.Header().Get("Location"); loc != "/v1/classi/barbaro" {
+			t.Errorf("unexpected Location %q", loc)
+		}
+		if captured.DadoVita != classi.D12 {
+			t.Errorf("unexpected classe %+v", captured)
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/admin/classi", strings.NewReader(`{"id":"barbaro","colore":"rosso"}`))
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(&mockAdminService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		svc := &mockAdminService{
+			createClasseFunc: func(_ context.Context, classe classi.Classe) (*classi.Classe, error) {
+				return nil, classi.ErrClasseDuplicata(classe.ID)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/classi", strings.NewReader(`{"id":"barbaro"}`))
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", rec.Code)
+		}
+	})
+}
+
+func TestAdminHandler_UpdateClasse(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var capturedID string
+		svc := &mockAdminService{
+			updateClasseFunc: func(_ context.Context, id string, classe classi.Classe, p shared.Precondition) (*classi.Classe, error) {
+				capturedID = id
+				if !p.Matches(`"1"`) {
+					t.Errorf("expected the If-Match precondition, got %+v", p)
+				}
+				classe.Versione = 2
+				return &classe, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/classi/barbaro", strings.NewReader(`{"nome":"Barbaro","dado-vita":"d12"}`))
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if capturedID != "barbaro" {
+			t.Errorf("expected id barbaro, got %q", capturedID)
+		}
+		if etag := rec.Header().Get("ETag"); etag != shared.ETag(2) {
+			t.Errorf("expected the ETag of the new version, got %q", etag)
+		}
+	})
+
+	t.Run("missing If-Match", func(t *testing.T) {
+		svc := &mockAdminService{
+			updateClasseFunc: func(_ context.Context, _ string, _ classi.Classe, _ shared.Precondition) (*classi.Classe, error) {
+				t.Error("expected the service not to be called")
+				return nil, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/classi/barbaro", strings.NewReader(`{"nome":"Barbaro"}`))
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusPreconditionRequired {
+			t.Errorf("expected status 428, got %d", rec.Code)
+		}
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		svc := &mockAdminService{
+			updateClasseFunc: func(_ context.Context, id string, _ classi.Classe, _ shared.Precondition) (*classi.Classe, error) {
+				return nil, classi.ErrClasseModificata(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/classi/barbaro", strings.NewReader(`{"nome":"Barbaro"}`))
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusPreconditionFailed {
+			t.Errorf("expected status 412, got %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/admin/classi/inv@lid", strings.NewReader(`{}`))
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(&mockAdminService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestAdminHandler_PatchClasse(t *testing.T) {
+	t.Run("passes the patch through", func(t *testing.T) {
+		var captured string
+		svc := &mockAdminService{
+			patchClasseFunc: func(_ context.Context, id string, patch []byte, _ shared.Precondition) (*classi.Classe, error) {
+				captured = string(patch)
+				return &classi.Classe{ID: id, Nome: "Barbaro furioso"}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodPatch, "/admin/classi/barbaro", strings.NewReader(`{"nome":"Barbaro furioso"}`))
+		req.Header.Set("If-Match", `"1"`)
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if captured != `{"nome":"Barbaro furioso"}` {
+			t.Errorf("unexpected patch %q", captured)
+		}
+		var got classi.Classe
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.Nome != "Barbaro furioso" {
+			t.Errorf("unexpected nome %q", got.Nome)
+		}
+	})
+
+	t.Run("patch not an object", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/admin/classi/barbaro", strings.NewReader(`"nome"`))
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(&mockAdminService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestAdminHandler_DeleteClasse(t *testing.T) {
+	t.Run("no content", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/admin/classi/barbaro", nil)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(&mockAdminService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status 204, got %d", rec.Code)
+		}
+	})
+
+	t.Run("missing If-Match", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/admin/classi/barbaro", nil)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(&mockAdminService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusPreconditionRequired {
+			t.Errorf("expected status 428, got %d", rec.Code)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockAdminService{
+			deleteClasseFunc: func(_ context.Context, id string, _ shared.Precondition) error {
+				return classi.ErrClasseNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodDelete, "/admin/classi/barbaro", nil)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
+
+func TestAdminHandler_Sottoclassi(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		var capturedClasse string
+		svc := &mockAdminService{
+			createSottoclasseFunc: func(_ context.Context, classeID string, s classi.SottoClasse) (*classi.SottoClasse, error) {
+				capturedClasse = classeID
+				s.IDClasseAssociata = classeID
+				return &s, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/classi/barbaro/sotto-classi", strings.NewReader(`{"id":"berserker","nome":"Berserker"}`))
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d", rec.Code)
+		}
+		if capturedClasse != "barbaro" {
+			t.Errorf("expected class barbaro, got %q", capturedClasse)
+		}
+		if loc := rec.Header().Get("Location"); loc != "/v1/classi/barbaro/sotto-classi/berserker" {
+			t.Errorf("unexpected Location %q", loc)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/admin/classi/barbaro/sotto-classi/berserker", strings.NewReader(`{"nome":"Berserker"}`))
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(&mockAdminService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/admin/classi/barbaro/sotto-classi/berserker", strings.NewReader(`{"descrizione":null}`))
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(&mockAdminService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("delete with invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/admin/classi/barbaro/sotto-classi/inv@lid", nil)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(&mockAdminService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		svc := &mockAdminService{
+			deleteSottoclasseFunc: func(_ context.Context, _, _ string, _ shared.Precondition) error {
+				return shared.NewInternalError(nil)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodDelete, "/admin/classi/barbaro/sotto-classi/berserker", nil)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", rec.Code)
+		}
+	})
+}
+
+func TestAdminHandler_Ripristino(t *testing.T) {
+	t.Run("classe", func(t *testing.T) {
+		var capturedNumero int
+		svc := &mockAdminService{
+			ripristinaClasseFunc: func(_ context.Context, id string, numero int, p shared.Precondition) (*classi.Classe, error) {
+				capturedNumero = numero
+				if !p.Matches(shared.ETag(9)) {
+					t.Errorf("expected If-Match: *, got %+v", p)
+				}
+				return &classi.Classe{ID: id, Versione: 10}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/classi/mago/revisioni/2/ripristino", nil)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if capturedNumero != 2 {
+			t.Errorf("expected revisione 2, got %d", capturedNumero)
+		}
+		if etag := rec.Header().Get("ETag"); etag != shared.ETag(10) {
+			t.Errorf("unexpected ETag %q", etag)
+		}
+	})
+
+	t.Run("deleted sottoclasse with If-None-Match", func(t *testing.T) {
+		svc := &mockAdminService{
+			ripristinaSottoclasseFunc: func(_ context.Context, classeID, sottoclasseID string, _ int, p shared.Precondition) (*classi.SottoClasse, error) {
+				if !p.MatchesAssente() || p.Matches(shared.ETag(9)) {
+					t.Errorf("expected If-None-Match: *, got %+v", p)
+				}
+				return &classi.SottoClasse{ID: sottoclasseID, IDClasseAssociata: classeID, Versione: 10}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/classi/mago/sotto-classi/evocatore/revisioni/2/ripristino", nil)
+		req.Header.Set("If-None-Match", "*")
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("sottoclasse without If-Match", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/admin/classi/mago/sotto-classi/evocatore/revisioni/2/ripristino", nil)
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(&mockAdminService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusPreconditionRequired {
+			t.Errorf("expected status 428, got %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid revisione", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/admin/classi/mago/revisioni/ultima/ripristino", nil)
+		req.Header.Set("If-Match", "*")
+		rec := httptest.NewRecorder()
+
+		newAdminRouter(&mockAdminService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestAdminHandler_Autore(t *testing.T) {
+	var captured string
+	svc := &mockAdminService{
+		createClasseFunc: func(ctx context.Context, classe classi.Classe) (*classi.Classe, error) {
+			captured = shared.Autore(ctx)
+			return &classe, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/classi", strings.NewReader(`{"id":"mago"}`))
+	req.Header.Set(shared.AutoreHeader, "Elminster")
+	rec := httptest.NewRecorder()
+
+	newAdminRouter(svc).ServeHTTP(rec, req)
+
+	if captured != "Elminster" {
+		t.Errorf("expected autore Elminster in the context, got %q", captured)
+	}
+}
@@ -0,0 +1,90 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+// EsportazioneService streams the whole classi dataset.
+type EsportazioneService interface {
+	Esporta(ctx context.Context, tipi []classi.TipoEsportazione, fn func(classi.RecordEsportazione) error) error
+}
+
+// FormatoEsportazione is the encoding of an export.
+type FormatoEsportazione string
+
+// FormatoNDJSON writes one RecordEsportazione per line.
+const FormatoNDJSON FormatoEsportazione = "ndjson"
+
+// scadenzaScrittura is the time a client has to read each record of an
+// export. It replaces the write timeout of the server, which would cut a
+// long export however fast the client reads it.
+const scadenzaScrittura = 30 * time.Second
+
+type EsportazioneHandler struct {
+	service EsportazioneService
+}
+
+func NewEsportazioneHandler(service EsportazioneService) *EsportazioneHandler {
+	return &EsportazioneHandler{service: service}
+}
+
+func (h *EsportazioneHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.Esporta)
+
+	return r
+}
+
+// Esporta streams the export as it is read, flushing each record. Once the
+// first record is written the status can no longer change, so a later
+// error aborts the response and the client sees it truncated rather than
+// complete.
+func (h *EsportazioneHandler) Esporta(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	tipi, err := shared.QueryEnumList(query, "tipi", classi.TipiEsportazione...)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+	if _, err := shared.QueryEnum(query, "formato", FormatoNDJSON); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	var scritti int
+	enc := json.NewEncoder(w)
+	rc := http.NewResponseController(w)
+	err = h.service.Esporta(r.Context(), tipi, func(record classi.RecordEsportazione) error {
+		if scritti == 0 {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+		}
+		scritti++
+		// Not every writer has a deadline, so the error is ignored.
+		_ = rc.SetWriteDeadline(time.Now().Add(scadenzaScrittura))
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+		return rc.Flush()
+	})
+	if err != nil {
+		if scritti > 0 {
+			panic(http.ErrAbortHandler)
+		}
+		shared.WriteError(w, err)
+		return
+	}
+	if scritti == 0 {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,176 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockEsportazioneService struct {
+	esportaFunc func(ctx context.Context, tipi []classi.TipoEsportazione, fn func(classi.RecordEsportazione) error) error
+}
+
+func (m *mockEsportazioneService) Esporta(ctx context.Context, tipi []classi.TipoEsportazione, fn func(classi.RecordEsportazione) error) error {
+	if m.esportaFunc != nil {
+		return m.esportaFunc(ctx, tipi, fn)
+	}
+	return nil
+}
+
+// esportaDataset exports a class with its subclass, restricted to tipi as
+// the service does.
+func esportaDataset(_ context.Context, tipi []classi.TipoEsportazione, fn func(classi.RecordEsportazione) error) error {
+	records := []classi.RecordEsportazione{
+		{Tipo: classi.EsportazioneClassi, Dati: classi.Classe{
+			ID: "mago", Nome: "Mago", DocumentazioneDiRiferimento: "DND 2024", DadoVita: classi.D6,
+			ElencoSottoclassi: []classi.RiferimentoSottoclasse{{IDSottoclasse: "evocatore"}},
+		}},
+		{Tipo: classi.EsportazioneSottoclassi, Dati: classi.SottoClasse{
+			ID: "evocatore", Nome: "Evocatore", DocumentazioneDiRiferimento: "DND 2024", IDClasseAssociata: "mago",
+		}},
+	}
+	for _, r := range records {
+		if !slices.Contains(classi.TipiDaEsportare(tipi), r.Tipo) {
+			continue
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestEsportazioneHandler_Esporta(t *testing.T) {
+	t.Run("ndjson", func(t *testing.T) {
+		h := NewEsportazioneHandler(&mockEsportazioneService{esportaFunc: esportaDataset})
+
+		req := httptest.NewRequest(http.MethodGet, "/?tipi=classi,sottoclassi&formato=ndjson", nil)
+		rec := httptest.NewRecorder()
+		h.Routes().ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected 2 lines, got %q", rec.Body.String())
+		}
+		var record struct {
+			Tipo string         `json:"tipo"`
+			Dati map[string]any `json:"dati"`
+		}
+		if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+			t.Fatalf("invalid line %q: %v", lines[1], err)
+		}
+		if record.Tipo != "sottoclassi" || record.Dati["id-classe-associata"] != "mago" {
+			t.Errorf("unexpected record %+v", record)
+		}
+	})
+
+	t.Run("flushes each record", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		var flushed []bool
+		h := NewEsportazioneHandler(&mockEsportazioneService{
+			esportaFunc: func(ctx context.Context, tipi []classi.TipoEsportazione, fn func(classi.RecordEsportazione) error) error {
+				return esportaDataset(ctx, tipi, func(record classi.RecordEsportazione) error {
+					rec.Flushed = false
+					err := fn(record)
+					flushed = append(flushed, rec.Flushed)
+					return err
+				})
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		h.Routes().ServeHTTP(rec, req)
+
+		if !slices.Equal(flushed, []bool{true, true}) {
+			t.Errorf("expected every record to be flushed, got %v", flushed)
+		}
+	})
+
+	t.Run("passes tipi", func(t *testing.T) {
+		var got []classi.TipoEsportazione
+		h := NewEsportazioneHandler(&mockEsportazioneService{
+			esportaFunc: func(_ context.Context, tipi []classi.TipoEsportazione, _ func(classi.RecordEsportazione) error) error {
+				got = tipi
+				return nil
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/?tipi=sottoclassi", nil)
+		rec := httptest.NewRecorder()
+		h.Routes().ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+			t.Errorf("expected an empty 200, got %d: %q", rec.Code, rec.Body.String())
+		}
+		if len(got) != 1 || got[0] != classi.EsportazioneSottoclassi {
+			t.Errorf("unexpected tipi %v", got)
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		h := NewEsportazioneHandler(&mockEsportazioneService{})
+
+		for _, q := range []string{"tipi=mostri", "formato=csv"} {
+			req := httptest.NewRequest(http.MethodGet, "/?"+q, nil)
+			rec := httptest.NewRecorder()
+			h.Routes().ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected 400, got %d", q, rec.Code)
+			}
+		}
+	})
+
+	t.Run("error before the first record", func(t *testing.T) {
+		h := NewEsportazioneHandler(&mockEsportazioneService{
+			esportaFunc: func(_ context.Context, _ []classi.TipoEsportazione, _ func(classi.RecordEsportazione) error) error {
+				return shared.NewInternalError(errors.New("database error"))
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		h.Routes().ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("expected 500, got %d", rec.Code)
+		}
+	})
+
+	t.Run("error while streaming aborts the response", func(t *testing.T) {
+		h := NewEsportazioneHandler(&mockEsportazioneService{
+			esportaFunc: func(ctx context.Context, tipi []classi.TipoEsportazione, fn func(classi.RecordEsportazione) error) error {
+				if err := esportaDataset(ctx, tipi, fn); err != nil {
+					return err
+				}
+				return shared.NewInternalError(errors.New("database error"))
+			},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+
+		defer func() {
+			if r := recover(); r != http.ErrAbortHandler {
+				t.Errorf("expected http.ErrAbortHandler, got %v", r)
+			}
+		}()
+		middleware.Recoverer(h.Routes()).ServeHTTP(rec, req)
+	})
+}
@@ -2,7 +2,11 @@ package transports
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -12,9 +16,19 @@ import (
 
 type ClassiService interface {
 	ListClassi(ctx context.Context, filter shared.ListFilter) (*classi.ListClassiResponse, error)
-	GetClasse(ctx context.Context, id string) (*classi.Classe, error)
+	GetClasse(ctx context.Context, id string, campi shared.Campi) (*classi.Classe, error)
+	GetClasseEspansa(ctx context.Context, id string, espandi []classi.Espansione, campi shared.Campi) (*classi.ClasseEspansa, error)
+	CaricaSottoclassi(ctx context.Context, elenco ...*classi.Classe) error
 	ListSottoclassi(ctx context.Context, classeID string, filter shared.ListFilter) (*classi.ListSottoclassiResponse, error)
-	GetSottoclasse(ctx context.Context, classeID, sottoclasseID string) (*classi.SottoClasse, error)
+	GetSottoclasse(ctx context.Context, classeID, sottoclasseID string, campi shared.Campi) (*classi.SottoClasse, error)
+	ListAllSottoclassi(ctx context.Context, filter classi.SottoclassiFilter) (*classi.ListSottoclassiResponse, error)
+	ListLivelli(ctx context.Context, classeID string, sottoclasseID *string) (*classi.ListLivelliResponse, error)
+	GetLivello(ctx context.Context, classeID string, livello int32, sottoclasseID *string) (*classi.Livello, error)
+	GetClasseAl(ctx context.Context, id string, al time.Time, campi shared.Campi) (*classi.Classe, error)
+	GetSottoclasseAl(ctx context.Context, classeID, sottoclasseID string, al time.Time, campi shared.Campi) (*classi.SottoClasse, error)
+	ListRevisioni(ctx context.Context, classeID string, sottoclasseID *string, filter shared.ListFilter) (*classi.ListRevisioniResponse, error)
+	GetRevisione(ctx context.Context, classeID string, sottoclasseID *string, numero int) (*classi.Revisione, error)
+	DiffRevisioni(ctx context.Context, classeID string, sottoclasseID *string, da, a int) (*classi.DiffRevisioni, error)
 }
 
 type Handler struct {
@@ -32,10 +46,63 @@ func (h *Handler) Routes() chi.Router {
 	r.Get("/{id-classe}", h.GetClasse)
 	r.Get("/{id-classe}/sotto-classi", h.ListSottoclassi)
 	r.Get("/{id-classe}/sotto-classi/{id-sotto-classe}", h.GetSottoclasse)
+	r.Get("/{id-classe}/livelli", h.ListLivelli)
+	r.Get("/{id-classe}/livelli/{livello}", h.GetLivello)
+	r.Get("/{id-classe}/revisioni", h.ListRevisioni)
+	r.Get("/{id-classe}/revisioni/diff", h.DiffRevisioni)
+	r.Get("/{id-classe}/revisioni/{revisione}", h.GetRevisione)
+	r.Get("/{id-classe}/sotto-classi/{id-sotto-classe}/revisioni", h.ListRevisioni)
+	r.Get("/{id-classe}/sotto-classi/{id-sotto-classe}/revisioni/diff", h.DiffRevisioni)
+	r.Get("/{id-classe}/sotto-classi/{id-sotto-classe}/revisioni/{revisione}", h.GetRevisione)
 
 	return r
 }
 
+// SottoclassiRoutes serves the subclasses of every class.
+func (h *Handler) SottoclassiRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListAllSottoclassi)
+
+	return r
+}
+
+func queryInclude(r *http.Request) (bool, error) {
+	include, err := shared.QueryEnumList(r.URL.Query(), "include", classi.Inclusioni...)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(include, classi.IncludiSottoclassi), nil
+}
+
+// queryAl reads the al parameter of the detail routes, the instant at which
+// to read the resource from its revision history.
+func queryAl(r *http.Request) (*time.Time, error) {
+	return shared.QueryTime(r.URL.Query(), "al")
+}
+
+// senzaAl rejects the al parameter on the routes that do not read from the
+// revision history, rather than silently returning the current data.
+func senzaAl(r *http.Request) error {
+	if r.URL.Query().Has("al") {
+		return fmt.Errorf("al is only supported on the detail routes of classi and sotto-classi")
+	}
+	return nil
+}
+
+// checkNotModified tags a detail response with its version and the data
+// embedded from other sources, items and glossary references, which change
+// without a new version of the class. It reports whether the response has
+// already been written, a 304 or an error.
+func checkNotModified(w http.ResponseWriter, r *http.Request, versione int64, dipendenze ...any) bool {
+	etag, err := shared.ETagRappresentazione(versione, dipendenze...)
+	if err != nil {
+		shared.WriteError(w, shared.NewInternalError(err))
+		return true
+	}
+	return shared.CheckNotModified(w, r, etag)
+}
+
 func (h *Handler) ListClassi(w http.ResponseWriter, r *http.Request) {
 	filter, err := shared.NewListFilterFromRequest(r)
 	if err != nil {
@@ -43,13 +110,35 @@ func (h *Handler) ListClassi(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := senzaAl(r); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	includiSottoclassi, err := queryInclude(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
 	response, err := h.service.ListClassi(r.Context(), filter)
 	if err != nil {
 		shared.WriteError(w, err)
 		return
 	}
 
-	shared.WriteJSON(w, http.StatusOK, response)
+	if includiSottoclassi && len(response.Classi) > 0 {
+		elenco := make([]*classi.Classe, len(response.Classi))
+		for i := range response.Classi {
+			elenco[i] = &response.Classi[i]
+		}
+		if err := h.service.CaricaSottoclassi(r.Context(), elenco...); err != nil {
+			shared.WriteError(w, err)
+			return
+		}
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
 }
 
 func (h *Handler) GetClasse(w http.ResponseWriter, r *http.Request) {
@@ -59,13 +148,86 @@ func (h *Handler) GetClasse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	classe, err := h.service.GetClasse(r.Context(), id)
+	espandi, err := shared.QueryEnumList(r.URL.Query(), "espandi", classi.Espansioni...)
 	if err != nil {
-		shared.WriteError(w, err)
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	includiSottoclassi, err := queryInclude(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	// espandi and include read the current items and subclasses, which may
+	// not match a past version of the class.
+	al, err := queryAl(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+	if al != nil && (len(espandi) > 0 || includiSottoclassi) {
+		err := fmt.Errorf("al cannot be combined with espandi or include")
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	// With espandi the response is the expanded class, which classe points
+	// into. dipendenze collects what the body reads besides the class row,
+	// which the ETag must cover.
+	var (
+		classe     *classi.Classe
+		response   any
+		dipendenze []any
+	)
+	switch {
+	case al != nil:
+		if classe, err = h.service.GetClasseAl(r.Context(), id, *al, campi); err != nil {
+			shared.WriteError(w, err)
+			return
+		}
+		response = classe
+	case len(espandi) > 0:
+		espansa, err := h.service.GetClasseEspansa(r.Context(), id, espandi, campi)
+		if err != nil {
+			shared.WriteError(w, err)
+			return
+		}
+		classe, response = &espansa.Classe, espansa
+		dipendenze = append(dipendenze, espansa.EquipaggiamentoPartenza)
+	default:
+		if classe, err = h.service.GetClasse(r.Context(), id, campi); err != nil {
+			shared.WriteError(w, err)
+			return
+		}
+		response = classe
+	}
+
+	if classe.Riferimenti != nil {
+		dipendenze = append(dipendenze, classe.Riferimenti)
+	}
+
+	if includiSottoclassi {
+		if err := h.service.CaricaSottoclassi(r.Context(), classe); err != nil {
+			shared.WriteError(w, err)
+			return
+		}
+		dipendenze = append(dipendenze, classe.Sottoclassi)
+	}
+
+	// A past version has no tag.
+	if al == nil && checkNotModified(w, r, classe.Versione, dipendenze...) {
 		return
 	}
 
-	shared.WriteJSON(w, http.StatusOK, classe)
+	shared.WriteJSONCampi(w, http.StatusOK, response, campi)
 }
 
 func (h *Handler) ListSottoclassi(w http.ResponseWriter, r *http.Request) {
@@ -75,6 +237,11 @@ func (h *Handler) ListSottoclassi(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := senzaAl(r); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
 	filter, err := shared.NewListFilterFromRequest(r)
 	if err != nil {
 		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
@@ -87,7 +254,46 @@ func (h *Handler) ListSottoclassi(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shared.WriteJSON(w, http.StatusOK, response)
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func newSottoclassiFilterFromRequest(r *http.Request) (classi.SottoclassiFilter, error) {
+	base, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		return classi.SottoclassiFilter{}, err
+	}
+	filter := classi.SottoclassiFilter{ListFilter: base}
+
+	if filter.IDClasse, err = shared.QueryList(r.URL.Query(), "id-classe"); err != nil {
+		return filter, err
+	}
+	for _, id := range filter.IDClasse {
+		if err := shared.ValidateID("id-classe", id); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+func (h *Handler) ListAllSottoclassi(w http.ResponseWriter, r *http.Request) {
+	filter, err := newSottoclassiFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	if err := senzaAl(r); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListAllSottoclassi(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
 }
 
 func (h *Handler) GetSottoclasse(w http.ResponseWriter, r *http.Request) {
@@ -103,11 +309,223 @@ func (h *Handler) GetSottoclasse(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sottoclasse, err := h.service.GetSottoclasse(r.Context(), classeID, sottoclasseID)
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	al, err := queryAl(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	var sottoclasse *classi.SottoClasse
+	if al != nil {
+		sottoclasse, err = h.service.GetSottoclasseAl(r.Context(), classeID, sottoclasseID, *al, campi)
+	} else {
+		sottoclasse, err = h.service.GetSottoclasse(r.Context(), classeID, sottoclasseID, campi)
+	}
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	var dipendenze []any
+	if sottoclasse.Riferimenti != nil {
+		dipendenze = append(dipendenze, sottoclasse.Riferimenti)
+	}
+
+	if al == nil && checkNotModified(w, r, sottoclasse.Versione, dipendenze...) {
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, sottoclasse, campi)
+}
+
+// livelliParams reads the class id and the optional sotto-classe query
+// parameter shared by the progression endpoints.
+func livelliParams(r *http.Request) (string, *string, error) {
+	classeID := chi.URLParam(r, "id-classe")
+	if err := shared.ValidateID("id-classe", classeID); err != nil {
+		return "", nil, err
+	}
+	if err := senzaAl(r); err != nil {
+		return "", nil, err
+	}
+
+	sottoclasseID, err := shared.QueryString(r.URL.Query(), "sotto-classe")
+	if err != nil {
+		return "", nil, err
+	}
+	if sottoclasseID != nil {
+		if err := shared.ValidateID("sotto-classe", *sottoclasseID); err != nil {
+			return "", nil, err
+		}
+	}
+	return classeID, sottoclasseID, nil
+}
+
+func (h *Handler) ListLivelli(w http.ResponseWriter, r *http.Request) {
+	classeID, sottoclasseID, err := livelliParams(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	// The levels are not a paginated list, whose items campi would project.
+	if r.URL.Query().Has("campi") {
+		err := fmt.Errorf("campi is not supported by the list of livelli; use GET /v1/classi/{id}/livelli/{livello}")
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListLivelli(r.Context(), classeID, sottoclasseID)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) GetLivello(w http.ResponseWriter, r *http.Request) {
+	classeID, sottoclasseID, err := livelliParams(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	livello, err := strconv.Atoi(chi.URLParam(r, "livello"))
+	if err != nil || livello < 1 || livello > classi.MaxLivello {
+		err = fmt.Errorf("livello must be an integer between 1 and %d", classi.MaxLivello)
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.GetLivello(r.Context(), classeID, int32(livello), sottoclasseID)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, campi)
+}
+
+// revisioniParams reads the class id and, on the subclass routes, the
+// subclass id of the revision history endpoints.
+func revisioniParams(r *http.Request) (string, *string, error) {
+	classeID := chi.URLParam(r, "id-classe")
+	if err := shared.ValidateID("id-classe", classeID); err != nil {
+		return "", nil, err
+	}
+
+	sottoclasseID := chi.URLParam(r, "id-sotto-classe")
+	if sottoclasseID == "" {
+		return classeID, nil, nil
+	}
+	if err := shared.ValidateID("id-sotto-classe", sottoclasseID); err != nil {
+		return "", nil, err
+	}
+	return classeID, &sottoclasseID, nil
+}
+
+// numeroRevisione parses a revision number, which starts from 1.
+func numeroRevisione(name, value string) (int, error) {
+	numero, err := strconv.Atoi(value)
+	if err != nil || numero < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return numero, nil
+}
+
+func (h *Handler) ListRevisioni(w http.ResponseWriter, r *http.Request) {
+	classeID, sottoclasseID, err := revisioniParams(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	filter, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListRevisioni(r.Context(), classeID, sottoclasseID, filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetRevisione(w http.ResponseWriter, r *http.Request) {
+	classeID, sottoclasseID, err := revisioniParams(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	numero, err := numeroRevisione("revisione", chi.URLParam(r, "revisione"))
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	revisione, err := h.service.GetRevisione(r.Context(), classeID, sottoclasseID, numero)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, revisione, campi)
+}
+
+func (h *Handler) DiffRevisioni(w http.ResponseWriter, r *http.Request) {
+	classeID, sottoclasseID, err := revisioniParams(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	query := r.URL.Query()
+	da, err := numeroRevisione("da", query.Get("da"))
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+	a, err := numeroRevisione("a", query.Get("a"))
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.DiffRevisioni(r.Context(), classeID, sottoclasseID, da, a)
 	if err != nil {
 		shared.WriteError(w, err)
 		return
 	}
 
-	shared.WriteJSON(w, http.StatusOK, sottoclasse)
+	shared.WriteJSONCampi(w, http.StatusOK, response, campi)
 }
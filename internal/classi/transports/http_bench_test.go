@@ -45,13 +45,13 @@ func setupBenchHandler() http.Handler {
 		listClassiFunc: func(_ context.Context, _ shared.ListFilter) (*classi.ListClassiResponse, error) {
 			return benchListResponse, nil
 		},
-		getClasseFunc: func(_ context.Context, _ string) (*classi.Classe, error) {
+		getClasseFunc: func(_ context.Context, _ string, _ shared.Campi) (*classi.Classe, error) {
 			return &benchClassi[0], nil
 		},
 		listSottoclassiFunc: func(_ context.Context, _ string, _ shared.ListFilter) (*classi.ListSottoclassiResponse, error) {
 			return benchSottoclassiResponse, nil
 		},
-		getSottoclasseFunc: func(_ context.Context, _, _ string) (*classi.SottoClasse, error) {
+		getSottoclasseFunc: func(_ context.Context, _, _ string, _ shared.Campi) (*classi.SottoClasse, error) {
 			return &benchSottoclassi[0], nil
 		},
 	}
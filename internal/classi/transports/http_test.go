@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -14,10 +16,20 @@ import (
 )
 
 type mockService struct {
-	listClassiFunc      func(ctx context.Context, filter shared.ListFilter) (*classi.ListClassiResponse, error)
-	getClasseFunc       func(ctx context.Context, id string) (*classi.Classe, error)
-	listSottoclassiFunc func(ctx context.Context, classeID string, filter shared.ListFilter) (*classi.ListSottoclassiResponse, error)
-	getSottoclasseFunc  func(ctx context.Context, classeID, sottoclasseID string) (*classi.SottoClasse, error)
+	listClassiFunc         func(ctx context.Context, filter shared.ListFilter) (*classi.ListClassiResponse, error)
+	getClasseFunc          func(ctx context.Context, id string, campi shared.Campi) (*classi.Classe, error)
+	getClasseEspansaFunc   func(ctx context.Context, id string, espandi []classi.Espansione, campi shared.Campi) (*classi.ClasseEspansa, error)
+	caricaSottoclassiFunc  func(ctx context.Context, elenco ...*classi.Classe) error
+	listSottoclassiFunc    func(ctx context.Context, classeID string, filter shared.ListFilter) (*classi.ListSottoclassiResponse, error)
+	getSottoclasseFunc     func(ctx context.Context, classeID, sottoclasseID string, campi shared.Campi) (*classi.SottoClasse, error)
+	listAllSottoclassiFunc func(ctx context.Context, filter classi.SottoclassiFilter) (*classi.ListSottoclassiResponse, error)
+	listLivelliFunc        func(ctx context.Context, classeID string, sottoclasseID *string) (*classi.ListLivelliResponse, error)
+	getLivelloFunc         func(ctx context.Context, classeID string, livello int32, sottoclasseID *string) (*classi.Livello, error)
+	getClasseAlFunc        func(ctx context.Context, id string, al time.Time, campi shared.Campi) (*classi.Classe, error)
+	getSottoclasseAlFunc   func(ctx context.Context, classeID, sottoclasseID string, al time.Time, campi shared.Campi) (*classi.SottoClasse, error)
+	listRevisioniFunc      func(ctx context.Context, classeID string, sottoclasseID *string, filter shared.ListFilter) (*classi.ListRevisioniResponse, error)
+	getRevisioneFunc       func(ctx context.Context, classeID string, sottoclasseID *string, numero int) (*classi.Revisione, error)
+	diffRevisioniFunc      func(ctx context.Context, classeID string, sottoclasseID *string, da, a int) (*classi.DiffRevisioni, error)
 }
 
 func (m *mockService) ListClassi(ctx context.Context, filter shared.ListFilter) (*classi.ListClassiResponse, error) {
@@ -27,13 +39,27 @@ func (m *mockService) ListClassi(ctx context.Context, filter shared.ListFilter)
 	return &classi.ListClassiResponse{}, nil
 }
 
-func (m *mockService) GetClasse(ctx context.Context, id string) (*classi.Classe, error) {
+func (m *mockService) GetClasse(ctx context.Context, id string, campi shared.Campi) (*classi.Classe, error) {
 	if m.getClasseFunc != nil {
-		return m.getClasseFunc(ctx, id)
+		return m.getClasseFunc(ctx, id, campi)
 	}
 	return nil, nil
 }
 
+func (m *mockService) GetClasseEspansa(ctx context.Context, id string, espandi []classi.Espansione, campi shared.Campi) (*classi.ClasseEspansa, error) {
+	if m.getClasseEspansaFunc != nil {
+		return m.getClasseEspansaFunc(ctx, id, espandi, campi)
+	}
+	return nil, nil
+}
+
+func (m *mockService) CaricaSottoclassi(ctx context.Context, elenco ...*classi.Classe) error {
+	if m.caricaSottoclassiFunc != nil {
+		return m.caricaSottoclassiFunc(ctx, elenco...)
+	}
+	return nil
+}
+
 func (m *mockService) ListSottoclassi(ctx context.Context, classeID string, filter shared.ListFilter) (*classi.ListSottoclassiResponse, error) {
 	if m.listSottoclassiFunc != nil {
 		return m.listSottoclassiFunc(ctx, classeID, filter)
@@ -41,13 +67,69 @@ func (m *mockService) ListSottoclassi(ctx context.Context, classeID string, filt
 	return &classi.ListSottoclassiResponse{}, nil
 }
 
-func (m *mockService) GetSottoclasse(ctx context.Context, classeID, sottoclasseID string) (*classi.SottoClasse, error) {
+func (m *mockService) GetSottoclasse(ctx context.Context, classeID, sottoclasseID string, campi shared.Campi) (*classi.SottoClasse, error) {
 	if m.getSottoclasseFunc != nil {
-		return m.getSottoclasseFunc(ctx, classeID, sottoclasseID)
+		return m.getSottoclasseFunc(ctx, classeID, sottoclasseID, campi)
+	}
+	return nil, nil
+}
+
+func (m *mockService) ListAllSottoclassi(ctx context.Context, filter classi.SottoclassiFilter) (*classi.ListSottoclassiResponse, error) {
+	if m.listAllSottoclassiFunc != nil {
+		return m.listAllSottoclassiFunc(ctx, filter)
+	}
+	return &classi.ListSottoclassiResponse{}, nil
+}
+
+func (m *mockService) ListLivelli(ctx context.Context, classeID string, sottoclasseID *string) (*classi.ListLivelliResponse, error) {
+	if m.listLivelliFunc != nil {
+		return m.listLivelliFunc(ctx, classeID, sottoclasseID)
+	}
+	return &classi.ListLivelliResponse{}, nil
+}
+
+func (m *mockService) GetLivello(ctx context.Context, classeID string, livello int32, sottoclasseID *string) (*classi.Livello, error) {
+	if m.getLivelloFunc != nil {
+		return m.getLivelloFunc(ctx, classeID, livello, sottoclasseID)
+	}
+	return nil, nil
+}
+
+func (m *mockService) GetClasseAl(ctx context.Context, id string, al time.Time, campi shared.Campi) (*classi.Classe, error) {
+	if m.getClasseAlFunc != nil {
+		return m.getClasseAlFunc(ctx, id, al, campi)
+	}
+	return nil, nil
+}
+
+func (m *mockService) GetSottoclasseAl(ctx context.Context, classeID, sottoclasseID string, al time.Time, campi shared.Campi) (*classi.SottoClasse, error) {
+	if m.getSottoclasseAlFunc != nil {
+		return m.getSottoclasseAlFunc(ctx, classeID, sottoclasseID, al, campi)
+	}
+	return nil, nil
+}
+
+func (m *mockService) ListRevisioni(ctx context.Context, classeID string, sottoclasseID *string, filter shared.ListFilter) (*classi.ListRevisioniResponse, error) {
+	if m.listRevisioniFunc != nil {
+		return m.listRevisioniFunc(ctx, classeID, sottoclasseID, filter)
+	}
+	return &classi.ListRevisioniResponse{}, nil
+}
+
+func (m *mockService) GetRevisione(ctx context.Context, classeID string, sottoclasseID *string, numero int) (*classi.Revisione, error) {
+	if m.getRevisioneFunc != nil {
+		return m.getRevisioneFunc(ctx, classeID, sottoclasseID, numero)
 	}
 	return nil, nil
 }
 
+func (m *mockService) DiffRevisioni(ctx context.Context, classeID string, sottoclasseID *string, da, a int) (*classi.DiffRevisioni, error) {
+	if m.diffRevisioniFunc != nil {
+		return m.diffRevisioniFunc(ctx, classeID, sottoclasseID, da, a)
+	}
+	return &classi.DiffRevisioni{Da: da, A: a}, nil
+}
+
 func TestHandler_ListClassi(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		svc := &mockService{
@@ -147,10 +229,228 @@ func TestHandler_ListClassi(t *testing.T) {
 	})
 }
 
+func TestHandler_IncludeSottoclassi(t *testing.T) {
+	caricaSottoclassi := func(_ context.Context, elenco ...*classi.Classe) error {
+		for _, c := range elenco {
+			c.Sottoclassi = []classi.SottoClasse{{ID: "sottoclasse-" + c.ID, IDClasseAssociata: c.ID}}
+		}
+		return nil
+	}
+
+	t.Run("list", func(t *testing.T) {
+		svc := &mockService{
+			listClassiFunc: func(_ context.Context, _ shared.ListFilter) (*classi.ListClassiResponse, error) {
+				return &classi.ListClassiResponse{Classi: []classi.Classe{{ID: "barbaro"}, {ID: "mago"}}}, nil
+			},
+			caricaSottoclassiFunc: caricaSottoclassi,
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi?include=sotto-classi", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		var response classi.ListClassiResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Classi) != 2 || len(response.Classi[1].Sottoclassi) != 1 || response.Classi[1].Sottoclassi[0].ID != "sottoclasse-mago" {
+			t.Errorf("expected embedded sottoclassi, got %+v", response.Classi)
+		}
+	})
+
+	t.Run("list without include", func(t *testing.T) {
+		svc := &mockService{
+			listClassiFunc: func(_ context.Context, _ shared.ListFilter) (*classi.ListClassiResponse, error) {
+				return &classi.ListClassiResponse{Classi: []classi.Classe{{ID: "barbaro"}}}, nil
+			},
+			caricaSottoclassiFunc: func(_ context.Context, _ ...*classi.Classe) error {
+				t.Error("sottoclassi loaded without include")
+				return nil
+			},
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/classi", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("detail with espandi", func(t *testing.T) {
+		svc := &mockService{
+			getClasseEspansaFunc: func(_ context.Context, id string, _ []classi.Espansione, _ shared.Campi) (*classi.ClasseEspansa, error) {
+				return &classi.ClasseEspansa{
+					Classe:                  classi.Classe{ID: id},
+					EquipaggiamentoPartenza: &classi.EquipaggiamentoPartenzaEspanso{},
+				}, nil
+			},
+			caricaSottoclassiFunc: caricaSottoclassi,
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro?include=sotto-classi&espandi=equipaggiamento", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		var response classi.Classe
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Sottoclassi) != 1 || response.Sottoclassi[0].ID != "sottoclasse-barbaro" {
+			t.Errorf("expected embedded sottoclassi, got %+v", response.Sottoclassi)
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		svc := &mockService{
+			getClasseFunc: func(_ context.Context, id string, _ shared.Campi) (*classi.Classe, error) {
+				return &classi.Classe{ID: id}, nil
+			},
+			caricaSottoclassiFunc: func(_ context.Context, _ ...*classi.Classe) error {
+				return shared.NewInternalError(nil)
+			},
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/classi/barbaro?include=sotto-classi", nil))
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", rec.Code)
+		}
+	})
+
+	for _, path := range []string{"/classi?include=incantesimi", "/classi/barbaro?include=livelli"} {
+		t.Run(path+" returns 400", func(t *testing.T) {
+			handler := NewHandler(&mockService{})
+			r := chi.NewRouter()
+			r.Mount("/classi", handler.Routes())
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_Campi(t *testing.T) {
+	svc := &mockService{
+		listClassiFunc: func(_ context.Context, filter shared.ListFilter) (*classi.ListClassiResponse, error) {
+			return &classi.ListClassiResponse{
+				PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+				Classi:         []classi.Classe{{ID: "barbaro", Nome: "Barbaro", DadoVita: classi.D12}},
+			}, nil
+		},
+		getClasseFunc: func(_ context.Context, id string, _ shared.Campi) (*classi.Classe, error) {
+			return &classi.Classe{ID: id, Nome: "Barbaro", DadoVita: classi.D12}, nil
+		},
+	}
+
+	handler := NewHandler(svc)
+	r := chi.NewRouter()
+	r.Mount("/classi", handler.Routes())
+
+	t.Run("list keeps pagination and projects each classe", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/classi?campi=id,dado-vita,sconosciuto", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var response struct {
+			NumeroDiElementi int              `json:"numero-di-elementi"`
+			Classi           []map[string]any `json:"classi"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.NumeroDiElementi != 1 || len(response.Classi) != 1 {
+			t.Fatalf("unexpected response %+v", response)
+		}
+		if got := response.Classi[0]; len(got) != 2 || got["id"] != "barbaro" || got["dado-vita"] != "d12" {
+			t.Errorf("unexpected classe %v", got)
+		}
+	})
+
+	t.Run("detail", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro?campi=nome", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var response map[string]any
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response) != 1 || response["nome"] != "Barbaro" {
+			t.Errorf("unexpected response %v", response)
+		}
+	})
+
+	t.Run("star returns every field", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro?campi=*", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		var response map[string]any
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response["descrizione"] == nil || response["documentazione-di-riferimento"] == nil {
+			t.Errorf("expected every field, got %v", response)
+		}
+	})
+
+	t.Run("too many fields", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro?campi=a,b,c,d,e,f,g,h,i,j,k", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
 func TestHandler_GetClasse(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		svc := &mockService{
-			getClasseFunc: func(_ context.Context, id string) (*classi.Classe, error) {
+			getClasseFunc: func(_ context.Context, id string, _ shared.Campi) (*classi.Classe, error) {
 				if id == "barbaro" {
 					return &classi.Classe{ID: "barbaro", Nome: "Barbaro", DadoVita: classi.D12}, nil
 				}
@@ -183,7 +483,7 @@ func TestHandler_GetClasse(t *testing.T) {
 
 	t.Run("not found", func(t *testing.T) {
 		svc := &mockService{
-			getClasseFunc: func(_ context.Context, id string) (*classi.Classe, error) {
+			getClasseFunc: func(_ context.Context, id string, _ shared.Campi) (*classi.Classe, error) {
 				return nil, classi.ErrClasseNotFound(id)
 			},
 		}
@@ -201,6 +501,198 @@ func TestHandler_GetClasse(t *testing.T) {
 			t.Errorf("expected status 404, got %d", rec.Code)
 		}
 	})
+
+	t.Run("etag", func(t *testing.T) {
+		svc := &mockService{
+			getClasseFunc: func(_ context.Context, id string, _ shared.Campi) (*classi.Classe, error) {
+				return &classi.Classe{ID: id, Nome: "Barbaro", Versione: 42}, nil
+			},
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		etag := rec.Header().Get("ETag")
+		if etag != shared.ETag(42) {
+			t.Fatalf("expected ETag %s, got %q", shared.ETag(42), etag)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/classi/barbaro", nil)
+		req.Header.Set("If-None-Match", etag)
+		rec = httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("expected status 304, got %d", rec.Code)
+		}
+		if rec.Body.Len() != 0 {
+			t.Errorf("expected an empty body, got %q", rec.Body.String())
+		}
+	})
+
+	t.Run("espandi equipaggiamento", func(t *testing.T) {
+		var capturedEspandi []classi.Espansione
+		svc := &mockService{
+			getClasseEspansaFunc: func(_ context.Context, id string, espandi []classi.Espansione, _ shared.Campi) (*classi.ClasseEspansa, error) {
+				capturedEspandi = espandi
+				return &classi.ClasseEspansa{
+					Classe: classi.Classe{ID: id, EquipaggiamentoPartenza: &classi.EquipaggiamentoPartenza{
+						OpzioneA: []classi.OggettoPartenza{{ID: "ascia-bipenne"}},
+					}},
+					EquipaggiamentoPartenza: &classi.EquipaggiamentoPartenzaEspanso{
+						OpzioneA:            []classi.OggettoPartenzaEspanso{{ID: "ascia-bipenne", Quantita: 1, Mancante: true}},
+						RiferimentiMancanti: []string{"ascia-bipenne"},
+					},
+				}, nil
+			},
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro?espandi=equipaggiamento", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if len(capturedEspandi) != 1 || capturedEspandi[0] != classi.EspandiEquipaggiamento {
+			t.Errorf("unexpected espandi %v", capturedEspandi)
+		}
+
+		var response struct {
+			ID                      string `json:"id"`
+			EquipaggiamentoPartenza struct {
+				OpzioneA []struct {
+					Mancante bool `json:"mancante"`
+				} `json:"opzione-a"`
+				RiferimentiMancanti []string `json:"riferimenti-mancanti"`
+			} `json:"equipaggiamento-id-partenza"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.ID != "barbaro" || len(response.EquipaggiamentoPartenza.OpzioneA) != 1 ||
+			!response.EquipaggiamentoPartenza.OpzioneA[0].Mancante {
+			t.Errorf("expected the expanded equipment, got %+v", response)
+		}
+	})
+
+	t.Run("etag of espandi follows the items", func(t *testing.T) {
+		nome := "Ascia bipenne"
+		svc := &mockService{
+			getClasseEspansaFunc: func(_ context.Context, id string, _ []classi.Espansione, _ shared.Campi) (*classi.ClasseEspansa, error) {
+				return &classi.ClasseEspansa{
+					Classe: classi.Classe{ID: id, Versione: 42},
+					EquipaggiamentoPartenza: &classi.EquipaggiamentoPartenzaEspanso{
+						OpzioneA: []classi.OggettoPartenzaEspanso{{ID: "ascia-bipenne", Nome: nome, Quantita: 1}},
+					},
+				}, nil
+			},
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro?espandi=equipaggiamento", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		etag := rec.Header().Get("ETag")
+		if etag == "" || etag == shared.ETag(42) {
+			t.Fatalf("expected an ETag covering the items, got %q", etag)
+		}
+
+		nome = "Ascia bipenne pesante"
+
+		req = httptest.NewRequest(http.MethodGet, "/classi/barbaro?espandi=equipaggiamento", nil)
+		req.Header.Set("If-None-Match", etag)
+		rec = httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200 after the item changed, got %d", rec.Code)
+		}
+		if got := rec.Header().Get("ETag"); got == etag {
+			t.Errorf("expected a new ETag, got %q", got)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/classi/barbaro?espandi=equipaggiamento", nil)
+		req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+		rec = httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("expected status 304, got %d", rec.Code)
+		}
+	})
+
+	t.Run("etag follows the riferimenti", func(t *testing.T) {
+		riferimenti := []shared.Riferimento{{Tipo: shared.RiferimentoRegola, ID: "vantaggio", Nome: "Vantaggio"}}
+		svc := &mockService{
+			getClasseFunc: func(_ context.Context, id string, campi shared.Campi) (*classi.Classe, error) {
+				classe := &classi.Classe{ID: id, Descrizione: "Ira e vantaggio", Versione: 42}
+				if campi.Contiene("riferimenti") {
+					classe.Riferimenti = slices.Clone(riferimenti)
+				}
+				return classe, nil
+			},
+		}
+
+		r := chi.NewRouter()
+		r.Mount("/classi", NewHandler(svc).Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		etag := rec.Header().Get("ETag")
+
+		riferimenti[0].Nome = "Vantaggio e svantaggio"
+
+		req = httptest.NewRequest(http.MethodGet, "/classi/barbaro", nil)
+		req.Header.Set("If-None-Match", etag)
+		rec = httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200 after the glossary changed, got %d", rec.Code)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/classi/barbaro?campi=id,nome", nil)
+		req.Header.Set("If-None-Match", shared.ETag(42))
+		rec = httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotModified {
+			t.Errorf("expected status 304 without riferimenti, got %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid espandi", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro?espandi=incantesimi", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
 }
 
 func TestHandler_ListSottoclassi(t *testing.T) {
@@ -264,10 +756,73 @@ func TestHandler_ListSottoclassi(t *testing.T) {
 	})
 }
 
+func TestHandler_ListAllSottoclassi(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var captured classi.SottoclassiFilter
+		svc := &mockService{
+			listAllSottoclassiFunc: func(_ context.Context, filter classi.SottoclassiFilter) (*classi.ListSottoclassiResponse, error) {
+				captured = filter
+				return &classi.ListSottoclassiResponse{
+					PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+					Sottoclassi: []classi.SottoClasse{
+						{ID: "berserker", Nome: "Berserker", IDClasseAssociata: "barbaro", NomeClasseAssociata: "Barbaro"},
+					},
+				}, nil
+			},
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/sotto-classi", handler.SottoclassiRoutes())
+
+		req := httptest.NewRequest(http.MethodGet, "/sotto-classi?id-classe=barbaro,guerriero&documentazione-di-riferimento=DND+2024&$limit=5", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if len(captured.IDClasse) != 2 || captured.IDClasse[1] != "guerriero" {
+			t.Errorf("unexpected id-classe %v", captured.IDClasse)
+		}
+		if len(captured.DocumentazioneDiRiferimento) != 1 || captured.Limit != 5 {
+			t.Errorf("unexpected list filter %+v", captured.ListFilter)
+		}
+
+		var response struct {
+			Sottoclassi []map[string]any `json:"sottoclassi"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Sottoclassi) != 1 || response.Sottoclassi[0]["nome-classe-associata"] != "Barbaro" {
+			t.Errorf("unexpected response %+v", response)
+		}
+	})
+
+	for _, query := range []string{"?id-classe=inv@lid", "?$limit=0"} {
+		t.Run(query+" returns 400", func(t *testing.T) {
+			handler := NewHandler(&mockService{})
+			r := chi.NewRouter()
+			r.Mount("/sotto-classi", handler.SottoclassiRoutes())
+
+			req := httptest.NewRequest(http.MethodGet, "/sotto-classi"+query, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
 func TestHandler_GetSottoclasse(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		svc := &mockService{
-			getSottoclasseFunc: func(_ context.Context, classeID, sottoclasseID string) (*classi.SottoClasse, error) {
+			getSottoclasseFunc: func(_ context.Context, classeID, sottoclasseID string, _ shared.Campi) (*classi.SottoClasse, error) {
 				if classeID == "barbaro" && sottoclasseID == "berserker" {
 					return &classi.SottoClasse{
 						ID:                "berserker",
@@ -302,9 +857,34 @@ func TestHandler_GetSottoclasse(t *testing.T) {
 		}
 	})
 
+	t.Run("if-none-match of an older version", func(t *testing.T) {
+		svc := &mockService{
+			getSottoclasseFunc: func(_ context.Context, classeID, sottoclasseID string, _ shared.Campi) (*classi.SottoClasse, error) {
+				return &classi.SottoClasse{ID: sottoclasseID, IDClasseAssociata: classeID, Versione: 2}, nil
+			},
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro/sotto-classi/berserker", nil)
+		req.Header.Set("If-None-Match", shared.ETag(1))
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+		if etag := rec.Header().Get("ETag"); etag != shared.ETag(2) {
+			t.Errorf("expected ETag %s, got %q", shared.ETag(2), etag)
+		}
+	})
+
 	t.Run("parent not found", func(t *testing.T) {
 		svc := &mockService{
-			getSottoclasseFunc: func(_ context.Context, classeID, _ string) (*classi.SottoClasse, error) {
+			getSottoclasseFunc: func(_ context.Context, classeID, _ string, _ shared.Campi) (*classi.SottoClasse, error) {
 				return nil, classi.ErrClasseNotFound(classeID)
 			},
 		}
@@ -325,7 +905,7 @@ func TestHandler_GetSottoclasse(t *testing.T) {
 
 	t.Run("sottoclasse not found", func(t *testing.T) {
 		svc := &mockService{
-			getSottoclasseFunc: func(_ context.Context, _, sottoclasseID string) (*classi.SottoClasse, error) {
+			getSottoclasseFunc: func(_ context.Context, _, sottoclasseID string, _ shared.Campi) (*classi.SottoClasse, error) {
 				return nil, classi.ErrSottoclasseNotFound(sottoclasseID)
 			},
 		}
@@ -344,3 +924,329 @@ func TestHandler_GetSottoclasse(t *testing.T) {
 		}
 	})
 }
+
+func TestHandler_ListLivelli(t *testing.T) {
+	t.Run("passes the sotto-classe", func(t *testing.T) {
+		var captured *string
+		svc := &mockService{
+			listLivelliFunc: func(_ context.Context, classeID string, sottoclasseID *string) (*classi.ListLivelliResponse, error) {
+				captured = sottoclasseID
+				return &classi.ListLivelliResponse{IDClasse: classeID, Livelli: make([]classi.Livello, classi.MaxLivello)}, nil
+			},
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro/livelli?sotto-classe=berserker", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if captured == nil || *captured != "berserker" {
+			t.Errorf("expected sotto-classe 'berserker', got %v", captured)
+		}
+
+		var response classi.ListLivelliResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.IDClasse != "barbaro" || len(response.Livelli) != classi.MaxLivello {
+			t.Errorf("unexpected response %+v", response)
+		}
+	})
+
+	t.Run("invalid sotto-classe", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro/livelli?sotto-classe=inv@lid", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("campi", func(t *testing.T) {
+		handler := NewHandler(&mockService{})
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/barbaro/livelli?campi=livello", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestHandler_GetLivello(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := &mockService{
+			getLivelloFunc: func(_ context.Context, _ string, livello int32, sottoclasseID *string) (*classi.Livello, error) {
+				if sottoclasseID != nil {
+					t.Errorf("expected no sotto-classe, got %q", *sottoclasseID)
+				}
+				return &classi.Livello{Livello: livello, BonusCompetenza: classi.BonusCompetenza(livello)}, nil
+			},
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/mago/livelli/5", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var response classi.Livello
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Livello != 5 || response.BonusCompetenza != 3 {
+			t.Errorf("unexpected response %+v", response)
+		}
+	})
+
+	for _, path := range []string{"/classi/mago/livelli/0", "/classi/mago/livelli/21", "/classi/mago/livelli/primo", "/classi/inv@lid/livelli/1"} {
+		t.Run(path+" returns 400", func(t *testing.T) {
+			handler := NewHandler(&mockService{})
+			r := chi.NewRouter()
+			r.Mount("/classi", handler.Routes())
+
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+
+	t.Run("campi", func(t *testing.T) {
+		svc := &mockService{
+			getLivelloFunc: func(_ context.Context, _ string, livello int32, _ *string) (*classi.Livello, error) {
+				return &classi.Livello{Livello: livello, BonusCompetenza: 3}, nil
+			},
+		}
+
+		r := chi.NewRouter()
+		r.Mount("/classi", NewHandler(svc).Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/mago/livelli/5?campi=bonus-competenza", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		var response map[string]any
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response) != 1 || response["bonus-competenza"] != float64(3) {
+			t.Errorf("expected only bonus-competenza, got %v", response)
+		}
+	})
+
+	t.Run("sottoclasse not found", func(t *testing.T) {
+		svc := &mockService{
+			getLivelloFunc: func(_ context.Context, _ string, _ int32, sottoclasseID *string) (*classi.Livello, error) {
+				return nil, classi.ErrSottoclasseNotFound(*sottoclasseID)
+			},
+		}
+
+		handler := NewHandler(svc)
+		r := chi.NewRouter()
+		r.Mount("/classi", handler.Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/mago/livelli/3?sotto-classe=berserker", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
+
+func TestHandler_Al(t *testing.T) {
+	svc := &mockService{
+		getClasseFunc: func(_ context.Context, id string, _ shared.Campi) (*classi.Classe, error) {
+			t.Error("expected the current class not to be read")
+			return &classi.Classe{ID: id}, nil
+		},
+		getClasseAlFunc: func(_ context.Context, id string, al time.Time, _ shared.Campi) (*classi.Classe, error) {
+			if !al.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("unexpected al %v", al)
+			}
+			return &classi.Classe{ID: id, Nome: "Mago"}, nil
+		},
+		getSottoclasseAlFunc: func(_ context.Context, classeID, sottoclasseID string, _ time.Time, _ shared.Campi) (*classi.SottoClasse, error) {
+			return &classi.SottoClasse{ID: sottoclasseID, IDClasseAssociata: classeID}, nil
+		},
+	}
+	r := chi.NewRouter()
+	r.Mount("/classi", NewHandler(svc).Routes())
+
+	t.Run("classe", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/classi/mago?al=2026-01-01T00:00:00Z", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if etag := rec.Header().Get("ETag"); etag != "" {
+			t.Errorf("expected no ETag for a past version, got %q", etag)
+		}
+	})
+
+	t.Run("sottoclasse", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/classi/mago/sotto-classi/evocatore?al=2026-01-01T00:00:00Z", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	for _, query := range []string{"al=ieri", "al=2026-01-01T00:00:00Z&espandi=equipaggiamento", "al=2026-01-01T00:00:00Z&include=sotto-classi"} {
+		t.Run(query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/classi/mago?"+query, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+
+	for _, path := range []string{"/classi", "/classi/mago/sotto-classi", "/sotto-classi", "/classi/mago/livelli", "/classi/mago/livelli/3"} {
+		t.Run(path+" rejects al", func(t *testing.T) {
+			handler := NewHandler(&mockService{})
+			r := chi.NewRouter()
+			r.Mount("/classi", handler.Routes())
+			r.Mount("/sotto-classi", handler.SottoclassiRoutes())
+
+			req := httptest.NewRequest(http.MethodGet, path+"?al=2026-01-01T00:00:00Z", nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_Revisioni(t *testing.T) {
+	t.Run("list of a sottoclasse", func(t *testing.T) {
+		var capturedClasse string
+		var capturedSottoclasse *string
+		svc := &mockService{
+			listRevisioniFunc: func(_ context.Context, classeID string, sottoclasseID *string, _ shared.ListFilter) (*classi.ListRevisioniResponse, error) {
+				capturedClasse, capturedSottoclasse = classeID, sottoclasseID
+				return &classi.ListRevisioniResponse{Revisioni: []classi.Revisione{{Numero: 1, Operazione: classi.OperazioneCreazione}}}, nil
+			},
+		}
+		r := chi.NewRouter()
+		r.Mount("/classi", NewHandler(svc).Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/mago/sotto-classi/evocatore/revisioni", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if capturedClasse != "mago" || capturedSottoclasse == nil || *capturedSottoclasse != "evocatore" {
+			t.Errorf("unexpected params %q %v", capturedClasse, capturedSottoclasse)
+		}
+	})
+
+	t.Run("get of a classe", func(t *testing.T) {
+		var capturedNumero int
+		svc := &mockService{
+			getRevisioneFunc: func(_ context.Context, _ string, sottoclasseID *string, numero int) (*classi.Revisione, error) {
+				if sottoclasseID != nil {
+					t.Errorf("expected no sottoclasse, got %q", *sottoclasseID)
+				}
+				capturedNumero = numero
+				return &classi.Revisione{Numero: numero}, nil
+			},
+		}
+		r := chi.NewRouter()
+		r.Mount("/classi", NewHandler(svc).Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/mago/revisioni/3", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK || capturedNumero != 3 {
+			t.Errorf("expected revisione 3, got %d %d", rec.Code, capturedNumero)
+		}
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		r := chi.NewRouter()
+		r.Mount("/classi", NewHandler(&mockService{}).Routes())
+
+		req := httptest.NewRequest(http.MethodGet, "/classi/mago/revisioni/diff?da=1&a=2", nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		var response classi.DiffRevisioni
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Da != 1 || response.A != 2 {
+			t.Errorf("unexpected diff %+v", response)
+		}
+	})
+
+	for _, path := range []string{"/classi/mago/revisioni/0", "/classi/mago/revisioni/diff?da=1", "/classi/mago/revisioni/diff?da=x&a=2"} {
+		t.Run(path, func(t *testing.T) {
+			r := chi.NewRouter()
+			r.Mount("/classi", NewHandler(&mockService{}).Routes())
+
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
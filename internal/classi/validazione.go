@@ -0,0 +1,173 @@
+package classi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+const (
+	maxLunghezzaNome           = 255
+	maxLunghezzaDocumentazione = 50
+	maxLivelloIncantesimo      = 9
+	documentazionePredefinita  = "DND 2024"
+)
+
+// validaClasse checks a class before it is written and fills in the
+// reference documentation when missing. The fields computed on read
+// (elenco-sottoclassi, sotto-classi, riferimenti) are cleared.
+func validaClasse(c *Classe) error {
+	if err := validaIntestazione(c.ID, c.Nome, &c.DocumentazioneDiRiferimento); err != nil {
+		return err
+	}
+	if !slices.Contains(shared.TipiDiDado, c.DadoVita) {
+		return enumError("dado-vita", shared.TipiDiDado)
+	}
+	if err := validaTipoIncantatore(c.TipoIncantatore); err != nil {
+		return err
+	}
+	for i, p := range c.PrerequisitiMulticlasse {
+		if len(p.Caratteristiche) == 0 {
+			return fmt.Errorf("prerequisiti-multiclasse[%d].caratteristiche is required", i)
+		}
+		for _, car := range p.Caratteristiche {
+			if !slices.Contains(shared.Caratteristiche, car) {
+				return enumError(fmt.Sprintf("prerequisiti-multiclasse[%d].caratteristiche", i), shared.Caratteristiche)
+			}
+		}
+		if p.ValoreMinimo < 1 || p.ValoreMinimo > 30 {
+			return fmt.Errorf("prerequisiti-multiclasse[%d].valore-minimo must be between 1 and 30", i)
+		}
+	}
+	if err := validaEquipaggiamento(c.EquipaggiamentoPartenza); err != nil {
+		return err
+	}
+	if err := validaProprieta("proprietà-di-classe", c.ProprietaDiClasse); err != nil {
+		return err
+	}
+
+	c.ElencoSottoclassi = nil
+	c.Sottoclassi = nil
+	c.Riferimenti = nil
+	return nil
+}
+
+// validaSottoclasse checks a subclass of the class classeID before it is
+// written.
+func validaSottoclasse(s *SottoClasse, classeID string) error {
+	if err := validaIntestazione(s.ID, s.Nome, &s.DocumentazioneDiRiferimento); err != nil {
+		return err
+	}
+	if s.IDClasseAssociata != "" && s.IDClasseAssociata != classeID {
+		return fmt.Errorf("id-classe-associata must be %q", classeID)
+	}
+	if err := validaTipoIncantatore(s.TipoIncantatore); err != nil {
+		return err
+	}
+	if err := validaProprieta("proprietà-di-sottoclasse", s.ProprietaDiSottoclasse); err != nil {
+		return err
+	}
+
+	s.IDClasseAssociata = classeID
+	s.NomeClasseAssociata = ""
+	s.Riferimenti = nil
+	return nil
+}
+
+func validaIntestazione(id, nome string, documentazione *string) error {
+	if err := shared.ValidateID("id", id); err != nil {
+		return err
+	}
+	if strings.TrimSpace(nome) == "" {
+		return fmt.Errorf("nome is required")
+	}
+	if len(nome) > maxLunghezzaNome {
+		return fmt.Errorf("nome cannot exceed %d characters", maxLunghezzaNome)
+	}
+	if *documentazione == "" {
+		*documentazione = documentazionePredefinita
+	}
+	if len(*documentazione) > maxLunghezzaDocumentazione {
+		return fmt.Errorf("documentazione-di-riferimento cannot exceed %d characters", maxLunghezzaDocumentazione)
+	}
+	return nil
+}
+
+func validaTipoIncantatore(t *TipoIncantatore) error {
+	if t != nil && !slices.Contains(TipiIncantatore, *t) {
+		return enumError("tipo-incantatore", TipiIncantatore)
+	}
+	return nil
+}
+
+func validaEquipaggiamento(eq *EquipaggiamentoPartenza) error {
+	if eq == nil {
+		return nil
+	}
+	for i, o := range eq.OpzioneA {
+		if o.ID != "" {
+			if err := shared.ValidateID(fmt.Sprintf("equipaggiamento-id-partenza.opzione-a[%d].id", i), o.ID); err != nil {
+				return err
+			}
+		}
+		if o.Quantita < 0 {
+			return fmt.Errorf("equipaggiamento-id-partenza.opzione-a[%d].quantità cannot be negative", i)
+		}
+	}
+	if eq.OpzioneB != nil {
+		if !slices.Contains(shared.Valute, eq.OpzioneB.Valuta) {
+			return enumError("equipaggiamento-id-partenza.opzione-b.valuta", shared.Valute)
+		}
+		if eq.OpzioneB.Quantita < 0 {
+			return fmt.Errorf("equipaggiamento-id-partenza.opzione-b.quantità cannot be negative")
+		}
+	}
+	return nil
+}
+
+// validaProprieta checks the level features of a class or subclass: levels
+// between 1 and MaxLivello, known action types and spell slots between the
+// 1st and the 9th level.
+func validaProprieta(campo string, proprieta []ProprietaLivello) error {
+	for i, p := range proprieta {
+		prefix := fmt.Sprintf("%s[%d]", campo, i)
+		if p.LivelloClasse < 1 || p.LivelloClasse > MaxLivello {
+			return fmt.Errorf("%s.livello-classe must be between 1 and %d", prefix, MaxLivello)
+		}
+		if t := p.TrattoDiClasse; t != nil {
+			if strings.TrimSpace(t.Nome) == "" {
+				return fmt.Errorf("%s.tratto-di-classe.nome is required", prefix)
+			}
+			if t.TipoAzione != "" && !slices.Contains(shared.TipiAzione, t.TipoAzione) {
+				return enumError(prefix+".tratto-di-classe.tipo-azione", shared.TipiAzione)
+			}
+			if t.Livello < 0 || t.Livello > MaxLivello {
+				return fmt.Errorf("%s.tratto-di-classe.livello must be between 1 and %d when set", prefix, MaxLivello)
+			}
+		}
+		if inc := p.IncantesimiClasse; inc != nil {
+			for j, slot := range inc.SlotIncantesimi {
+				if slot.LivelloSlotIncantesimo < 1 || slot.LivelloSlotIncantesimo > maxLivelloIncantesimo {
+					return fmt.Errorf("%s.incantesimi-di-classe.slot-incantesimi[%d].livello-slot-incantesimo must be between 1 and %d", prefix, j, maxLivelloIncantesimo)
+				}
+				if slot.NumeroSlot < 0 {
+					return fmt.Errorf("%s.incantesimi-di-classe.slot-incantesimi[%d].numero-slot cannot be negative", prefix, j)
+				}
+			}
+			if inc.TrucchettiConosciuti < 0 || inc.IncantesimiPreparati < 0 {
+				return fmt.Errorf("%s.incantesimi-di-classe cannot have negative counts", prefix)
+			}
+		}
+	}
+	return nil
+}
+
+func enumError[T ~string](campo string, allowed []T) error {
+	names := make([]string, len(allowed))
+	for i, a := range allowed {
+		names[i] = string(a)
+	}
+	return fmt.Errorf("%s must be one of: %s", campo, strings.Join(names, ", "))
+}
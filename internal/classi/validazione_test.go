@@ -0,0 +1,111 @@
+package classi
+
+import (
+	"strings"
+	"testing"
+)
+
+func classeValida() Classe {
+	return Classe{
+		ID:       "barbaro",
+		Nome:     "Barbaro",
+		DadoVita: D12,
+		EquipaggiamentoPartenza: &EquipaggiamentoPartenza{
+			OpzioneA: []OggettoPartenza{{ID: "ascia-bipenne", Quantita: 1}},
+			OpzioneB: &Importo{Quantita: 75, Valuta: MO},
+		},
+		ProprietaDiClasse: []ProprietaLivello{
+			{LivelloClasse: 1, TrattoDiClasse: &Tratto{Nome: "Ira", TipoAzione: AzioneBonus}},
+		},
+	}
+}
+
+func TestValidaClasse(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Classe)
+		wantErr string
+	}{
+		{"valid", func(c *Classe) {}, ""},
+		{"invalid id", func(c *Classe) { c.ID = "inv@lid" }, "id contains invalid characters"},
+		{"missing nome", func(c *Classe) { c.Nome = " " }, "nome is required"},
+		{"unknown dado vita", func(c *Classe) { c.DadoVita = "d7" }, "dado-vita must be one of"},
+		{"unknown tipo incantatore", func(c *Classe) {
+			t := TipoIncantatore("Quarto")
+			c.TipoIncantatore = &t
+		}, "tipo-incantatore must be one of"},
+		{"unknown valuta", func(c *Classe) { c.EquipaggiamentoPartenza.OpzioneB.Valuta = "MX" }, "opzione-b.valuta must be one of"},
+		{"level 0", func(c *Classe) { c.ProprietaDiClasse[0].LivelloClasse = 0 }, "proprietà-di-classe[0].livello-classe must be between 1 and 20"},
+		{"level 21", func(c *Classe) { c.ProprietaDiClasse[0].LivelloClasse = 21 }, "livello-classe must be between 1 and 20"},
+		{"unknown tipo azione", func(c *Classe) { c.ProprietaDiClasse[0].TrattoDiClasse.TipoAzione = "Azione Lenta" }, "tipo-azione must be one of"},
+		{"spell slot level 10", func(c *Classe) {
+			c.ProprietaDiClasse[0].IncantesimiClasse = &IncantesimiClasse{
+				SlotIncantesimi: []SlotIncantesimo{{NumeroSlot: 1, LivelloSlotIncantesimo: 10}},
+			}
+		}, "livello-slot-incantesimo must be between 1 and 9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := classeValida()
+			tt.modify(&c)
+
+			err := validaClasse(&c)
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("defaults documentazione and clears computed fields", func(t *testing.T) {
+		c := classeValida()
+		c.ElencoSottoclassi = []RiferimentoSottoclasse{{IDSottoclasse: "berserker"}}
+
+		if err := validaClasse(&c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c.DocumentazioneDiRiferimento != "DND 2024" {
+			t.Errorf("expected default documentazione, got %q", c.DocumentazioneDiRiferimento)
+		}
+		if c.ElencoSottoclassi != nil {
+			t.Errorf("expected elenco-sottoclassi to be cleared, got %v", c.ElencoSottoclassi)
+		}
+	})
+}
+
+func TestValidaSottoclasse(t *testing.T) {
+	t.Run("sets the class", func(t *testing.T) {
+		s := SottoClasse{ID: "berserker", Nome: "Berserker"}
+
+		if err := validaSottoclasse(&s, "barbaro"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s.IDClasseAssociata != "barbaro" {
+			t.Errorf("expected id-classe-associata barbaro, got %q", s.IDClasseAssociata)
+		}
+	})
+
+	t.Run("other class", func(t *testing.T) {
+		s := SottoClasse{ID: "berserker", Nome: "Berserker", IDClasseAssociata: "mago"}
+
+		if err := validaSottoclasse(&s, "barbaro"); err == nil {
+			t.Fatal("expected error for a different class")
+		}
+	})
+
+	t.Run("level out of range", func(t *testing.T) {
+		s := SottoClasse{ID: "berserker", Nome: "Berserker", ProprietaDiSottoclasse: []ProprietaLivello{{LivelloClasse: 25}}}
+
+		err := validaSottoclasse(&s, "barbaro")
+		if err == nil || !strings.Contains(err.Error(), "proprietà-di-sottoclasse[0].livello-classe") {
+			t.Errorf("expected level error, got %v", err)
+		}
+	})
+}
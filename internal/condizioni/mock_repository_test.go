@@ -0,0 +1,26 @@
+package condizioni
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type MockRepository struct {
+	ListFunc    func(ctx context.Context, filter shared.ListFilter) ([]Condizione, int, error)
+	GetByIDFunc func(ctx context.Context, id string) (*Condizione, error)
+}
+
+func (m *MockRepository) List(ctx context.Context, filter shared.ListFilter) ([]Condizione, int, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockRepository) GetByID(ctx context.Context, id string) (*Condizione, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
@@ -0,0 +1,15 @@
+package condizioni
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+// Condizione is a condition glossary entry. Sinonimi lists the other forms
+// its name takes in text ("prona", "avvelenati"), used to find mentions in
+// the descrizione of other resources.
+type Condizione struct {
+	ID                          string               `json:"id"`
+	Nome                        string               `json:"nome"`
+	Descrizione                 string               `json:"descrizione"`
+	Sinonimi                    []string             `json:"sinonimi,omitempty"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento"`
+}
@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/condizioni"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type condizioneRow struct {
+	ID                          string         `db:"id"`
+	Nome                        string         `db:"nome"`
+	Descrizione                 string         `db:"descrizione"`
+	Sinonimi                    pq.StringArray `db:"sinonimi"`
+	DocumentazioneDiRiferimento string         `db:"documentazione_di_riferimento"`
+}
+
+func (r *condizioneRow) toCondizione() condizioni.Condizione {
+	return condizioni.Condizione{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Descrizione:                 r.Descrizione,
+		Sinonimi:                    r.Sinonimi,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+}
+
+const selectCondizione = `
+	SELECT id, nome, descrizione, sinonimi, documentazione_di_riferimento
+	FROM condizioni`
+
+func (r *PostgresRepository) List(ctx context.Context, filter shared.ListFilter) ([]condizioni.Condizione, int, error) {
+	q := shared.NewPaginatedQuery(
+		selectCondizione+` WHERE 1=1`,
+		`SELECT COUNT(*) FROM condizioni WHERE 1=1`,
+		make(map[string]any),
+		filter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []condizioneRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]condizioni.Condizione, len(rows))
+	for i, row := range rows {
+		result[i] = row.toCondizione()
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*condizioni.Condizione, error) {
+	var row condizioneRow
+	if err := r.db.GetContext(ctx, &row, selectCondizione+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get condizione by id: %w", err)
+	}
+
+	condizione := row.toCondizione()
+	return &condizione, nil
+}
@@ -0,0 +1,24 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestCondizioneRow_ToCondizione(t *testing.T) {
+	row := condizioneRow{
+		ID:       "avvelenato",
+		Nome:     "Avvelenato",
+		Sinonimi: pq.StringArray{"avvelenata"},
+	}
+
+	got := row.toCondizione()
+
+	if got.ID != "avvelenato" || got.Nome != "Avvelenato" {
+		t.Errorf("unexpected condizione %+v", got)
+	}
+	if len(got.Sinonimi) != 1 || got.Sinonimi[0] != "avvelenata" {
+		t.Errorf("unexpected sinonimi %v", got.Sinonimi)
+	}
+}
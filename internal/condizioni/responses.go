@@ -0,0 +1,8 @@
+package condizioni
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type ListCondizioniResponse struct {
+	shared.PaginationMeta
+	Condizioni []Condizione `json:"condizioni"`
+}
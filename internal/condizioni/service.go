@@ -0,0 +1,58 @@
+package condizioni
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	repo      Repository
+	glossario shared.Glossario
+	logger    *slog.Logger
+}
+
+func NewService(repo Repository, glossario shared.Glossario, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:      repo,
+		glossario: glossario,
+		logger:    logger,
+	}
+}
+
+func (s *Service) ListCondizioni(ctx context.Context, filter shared.ListFilter) (*ListCondizioniResponse, error) {
+	condizioni, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list condizioni", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListCondizioniResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Condizioni:     condizioni,
+	}, nil
+}
+
+func (s *Service) GetCondizione(ctx context.Context, id string, campi shared.Campi) (*Condizione, error) {
+	condizione, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get condizione", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if condizione == nil {
+		return nil, ErrCondizioneNotFound(id)
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		// A condition always mentions itself; only links to other entries are useful.
+		condizione.Riferimenti = slices.DeleteFunc(s.glossario.Riferimenti(ctx, condizione), func(rif shared.Riferimento) bool {
+			return rif.Tipo == shared.RiferimentoCondizione && rif.ID == condizione.ID
+		})
+	}
+	return condizione, nil
+}
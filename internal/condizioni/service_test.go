@@ -0,0 +1,149 @@
+package condizioni
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+type mockGlossario []shared.Riferimento
+
+func (m mockGlossario) Riferimenti(_ context.Context, _ any) []shared.Riferimento {
+	return append([]shared.Riferimento(nil), m...)
+}
+
+func TestService_ListCondizioni(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ shared.ListFilter) ([]Condizione, int, error) {
+				return []Condizione{
+					{ID: "accecato", Nome: "Accecato"},
+					{ID: "prono", Nome: "Prono"},
+				}, 2, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.ListCondizioni(ctx, shared.ListFilter{Limit: 20})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 2 || len(result.Condizioni) != 2 {
+			t.Errorf("expected 2 condizioni, got %d/%d", result.NumeroDiElementi, len(result.Condizioni))
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ shared.ListFilter) ([]Condizione, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.ListCondizioni(ctx, shared.ListFilter{Limit: 20})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestService_GetCondizione(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Condizione, error) {
+				return &Condizione{ID: id, Nome: "Prono"}, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.GetCondizione(ctx, "prono", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "prono" {
+			t.Errorf("expected id 'prono', got '%s'", result.ID)
+		}
+	})
+
+	t.Run("riferimenti exclude the condition itself", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Condizione, error) {
+				return &Condizione{ID: id, Nome: "Prono", Descrizione: "Una creatura Prona può solo strisciare; rialzarsi costa metà del Movimento."}, nil
+			},
+		}
+		glossario := mockGlossario{
+			{Tipo: shared.RiferimentoCondizione, ID: "prono", Nome: "Prono", Link: "/v1/condizioni/prono"},
+			{Tipo: shared.RiferimentoRegola, ID: "movimento", Nome: "Movimento", Link: "/v1/regole/movimento"},
+		}
+
+		service := NewService(repo, glossario, logger)
+
+		result, err := service.GetCondizione(ctx, "prono", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Riferimenti) != 1 || result.Riferimenti[0].ID != "movimento" {
+			t.Errorf("unexpected riferimenti %+v", result.Riferimenti)
+		}
+	})
+
+	t.Run("campi without riferimenti skip the glossario", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Condizione, error) {
+				return &Condizione{ID: id, Nome: "Prono", Descrizione: "Una creatura Prona può solo strisciare; rialzarsi costa metà del Movimento."}, nil
+			},
+		}
+		glossario := mockGlossario{{Tipo: shared.RiferimentoRegola, ID: "movimento", Nome: "Movimento", Link: "/v1/regole/movimento"}}
+
+		service := NewService(repo, glossario, logger)
+
+		result, err := service.GetCondizione(ctx, "prono", shared.Campi{"nome"})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Riferimenti != nil {
+			t.Errorf("expected no riferimenti, got %+v", result.Riferimenti)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := NewService(&MockRepository{}, nil, logger)
+
+		_, err := service.GetCondizione(ctx, "nonexistent", nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/condizioni"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type CondizioniService interface {
+	ListCondizioni(ctx context.Context, filter shared.ListFilter) (*condizioni.ListCondizioniResponse, error)
+	GetCondizione(ctx context.Context, id string, campi shared.Campi) (*condizioni.Condizione, error)
+}
+
+type Handler struct {
+	service CondizioniService
+}
+
+func NewHandler(service CondizioniService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListCondizioni)
+	r.Get("/{id-condizione}", h.GetCondizione)
+
+	return r
+}
+
+func (h *Handler) ListCondizioni(w http.ResponseWriter, r *http.Request) {
+	filter, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListCondizioni(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetCondizione(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-condizione")
+	if err := shared.ValidateID("id-condizione", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	condizione, err := h.service.GetCondizione(r.Context(), id, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, condizione, campi)
+}
@@ -0,0 +1,97 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/condizioni"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	listCondizioniFunc func(ctx context.Context, filter shared.ListFilter) (*condizioni.ListCondizioniResponse, error)
+	getCondizioneFunc  func(ctx context.Context, id string, campi shared.Campi) (*condizioni.Condizione, error)
+}
+
+func (m *mockService) ListCondizioni(ctx context.Context, filter shared.ListFilter) (*condizioni.ListCondizioniResponse, error) {
+	if m.listCondizioniFunc != nil {
+		return m.listCondizioniFunc(ctx, filter)
+	}
+	return &condizioni.ListCondizioniResponse{}, nil
+}
+
+func (m *mockService) GetCondizione(ctx context.Context, id string, campi shared.Campi) (*condizioni.Condizione, error) {
+	if m.getCondizioneFunc != nil {
+		return m.getCondizioneFunc(ctx, id, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc CondizioniService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/condizioni", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_ListCondizioni(t *testing.T) {
+	svc := &mockService{
+		listCondizioniFunc: func(_ context.Context, _ shared.ListFilter) (*condizioni.ListCondizioniResponse, error) {
+			return &condizioni.ListCondizioniResponse{
+				PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+				Condizioni:     []condizioni.Condizione{{ID: "prono", Nome: "Prono"}},
+			}, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/condizioni", nil)
+	rec := httptest.NewRecorder()
+
+	newTestRouter(svc).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var response condizioni.ListCondizioniResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Condizioni) != 1 {
+		t.Errorf("expected 1 condizione, got %d", len(response.Condizioni))
+	}
+}
+
+func TestHandler_GetCondizione(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getCondizioneFunc: func(_ context.Context, id string, _ shared.Campi) (*condizioni.Condizione, error) {
+				return nil, condizioni.ErrCondizioneNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/condizioni/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid id returns 400", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/condizioni/inv@lid", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
@@ -15,6 +15,9 @@ type Config struct {
 	CORS      CORSConfig
 	RateLimit RateLimitConfig
 	APIKey    string
+	// AdminAPIKey guards the /v1/admin write routes. Without it they are
+	// not mounted.
+	AdminAPIKey string
 }
 
 type ServerConfig struct {
@@ -22,6 +25,9 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	// ExportTimeout bounds /v1/export, which streams the whole dataset and
+	// so is exempt from the 30 second deadline of the other routes.
+	ExportTimeout time.Duration
 }
 
 type DatabaseConfig struct {
@@ -46,10 +52,11 @@ func Load() (*Config, error) {
 	cfg := &Config{
 		Version: getEnv("APP_VERSION", "dev"),
 		Server: ServerConfig{
-			Port:         getEnv("API_PORT", "8080"),
-			ReadTimeout:  getDurationEnv("SERVER_READ_TIMEOUT", 10*time.Second),
-			WriteTimeout: getDurationEnv("SERVER_WRITE_TIMEOUT", 30*time.Second),
-			IdleTimeout:  getDurationEnv("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			Port:          getEnv("API_PORT", "8080"),
+			ReadTimeout:   getDurationEnv("SERVER_READ_TIMEOUT", 10*time.Second),
+			WriteTimeout:  getDurationEnv("SERVER_WRITE_TIMEOUT", 30*time.Second),
+			IdleTimeout:   getDurationEnv("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			ExportTimeout: getDurationEnv("SERVER_EXPORT_TIMEOUT", 10*time.Minute),
 		},
 		Database: DatabaseConfig{
 			URL:             os.Getenv("DATABASE_URL"),
@@ -59,14 +66,15 @@ func Load() (*Config, error) {
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: getSliceEnv("CORS_ALLOWED_ORIGINS", []string{"*"}),
-			AllowedMethods: getSliceEnv("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
+			AllowedMethods: getSliceEnv("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
 			MaxAge:         getIntEnv("CORS_MAX_AGE", 300),
 		},
 		RateLimit: RateLimitConfig{
 			RequestsPerMinute: getIntEnv("RATE_LIMIT_RPM", 60),
 			Enabled:           getBoolEnv("RATE_LIMIT_ENABLED", true),
 		},
-		APIKey: os.Getenv("API_KEY"),
+		APIKey:      os.Getenv("API_KEY"),
+		AdminAPIKey: os.Getenv("ADMIN_API_KEY"),
 	}
 
 	if err := cfg.validate(); err != nil {
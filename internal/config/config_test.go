@@ -48,6 +48,22 @@ func TestLoad(t *testing.T) {
 		}
 	})
 
+	t.Run("ADMIN_API_KEY is optional", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "postgres://localhost/test")
+		t.Setenv("API_KEY", "secret")
+		t.Setenv("APP_VERSION", "1.0.0")
+		t.Setenv("ADMIN_API_KEY", "")
+
+		cfg, err := Load()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.AdminAPIKey != "" {
+			t.Errorf("expected empty admin key, got %q", cfg.AdminAPIKey)
+		}
+	})
+
 	t.Run("defaults are applied", func(t *testing.T) {
 		t.Setenv("DATABASE_URL", "postgres://localhost/test")
 		t.Setenv("APP_VERSION", "dev")
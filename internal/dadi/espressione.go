@@ -0,0 +1,272 @@
+package dadi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+const (
+	maxLunghezza = 100
+	maxTermini   = 20
+	// maxDadi bounds the dice of a whole expression and maxFacce the faces
+	// of a die, so that the exact distribution stays cheap to compute.
+	maxDadi  = 100
+	maxFacce = 100
+	// maxDadiMantieni bounds the dice of a kh/kl term, whose distribution
+	// is the most expensive to compute.
+	maxDadiMantieni = 20
+)
+
+// Mantieni selects which dice of a term count towards its total.
+type Mantieni string
+
+const (
+	MantieniTutti Mantieni = ""
+	MantieniAlti  Mantieni = "kh"
+	MantieniBassi Mantieni = "kl"
+)
+
+// Termine is a signed term of an expression: a group of dice, a constant
+// or a variable such as @FOR.
+type Termine struct {
+	Segno     int
+	Numero    int
+	Facce     int
+	Mantieni  Mantieni
+	Mantenuti int
+	Variabile string
+}
+
+// IsDado reports whether t is a group of dice.
+func (t Termine) IsDado() bool {
+	return t.Facce > 0
+}
+
+// Dado returns the die rolled by t, e.g. "d6".
+func (t Termine) Dado() shared.TipoDiDado {
+	return shared.TipoDiDado("d" + strconv.Itoa(t.Facce))
+}
+
+func (t Termine) String() string {
+	var b strings.Builder
+	if t.Segno < 0 {
+		b.WriteByte('-')
+	}
+	switch {
+	case t.IsDado():
+		fmt.Fprintf(&b, "%d%s", t.Numero, t.Dado())
+		if t.Mantieni != MantieniTutti {
+			fmt.Fprintf(&b, "%s%d", t.Mantieni, t.Mantenuti)
+		}
+	case t.Variabile != "":
+		b.WriteString("@" + t.Variabile)
+	default:
+		b.WriteString(strconv.Itoa(t.Numero))
+	}
+	return b.String()
+}
+
+// Espressione is a parsed dice expression.
+type Espressione struct {
+	Termini []Termine
+}
+
+func (e *Espressione) String() string {
+	var b strings.Builder
+	for i, t := range e.Termini {
+		if i > 0 && t.Segno > 0 {
+			b.WriteByte('+')
+		}
+		b.WriteString(t.String())
+	}
+	return b.String()
+}
+
+// Variabili returns the names of the variables used by e.
+func (e *Espressione) Variabili() []string {
+	var result []string
+	for _, t := range e.Termini {
+		if t.Variabile != "" {
+			result = append(result, t.Variabile)
+		}
+	}
+	return result
+}
+
+// Analizza parses a dice expression: terms joined by + or -, where a term
+// is NdS (N defaults to 1), optionally followed by khK or klK to keep the
+// K highest or lowest dice (K defaults to 1), an integer constant or a
+// variable such as @FOR. Whitespace is allowed around the operators and
+// variable names are case insensitive.
+func Analizza(s string) (*Espressione, error) {
+	if len(s) > maxLunghezza {
+		return nil, fmt.Errorf("espressione cannot exceed %d characters", maxLunghezza)
+	}
+	p := &parser{input: strings.TrimSpace(s)}
+	if p.input == "" {
+		return nil, fmt.Errorf("espressione is required")
+	}
+
+	e := &Espressione{}
+	dadi := 0
+	for !p.fine() {
+		segno := 1
+		switch c := p.peek(); {
+		case c == '+' || c == '-':
+			if c == '-' {
+				segno = -1
+			}
+			p.pos++
+			p.spazi()
+		case len(e.Termini) > 0:
+			return nil, p.errore("expected + or -")
+		}
+
+		t, err := p.termine()
+		if err != nil {
+			return nil, err
+		}
+		p.spazi()
+		t.Segno = segno
+		e.Termini = append(e.Termini, t)
+
+		if len(e.Termini) > maxTermini {
+			return nil, fmt.Errorf("espressione cannot have more than %d terms", maxTermini)
+		}
+		if t.IsDado() {
+			dadi += t.Numero
+			if dadi > maxDadi {
+				return nil, fmt.Errorf("espressione cannot roll more than %d dice", maxDadi)
+			}
+		}
+	}
+	return e, nil
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) fine() bool { return p.pos >= len(p.input) }
+
+func (p *parser) spazi() {
+	for !p.fine() && unicode.IsSpace(rune(p.peek())) {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	if p.fine() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) errore(msg string) error {
+	if p.fine() {
+		return fmt.Errorf("invalid espressione %q: %s at end", p.input, msg)
+	}
+	return fmt.Errorf("invalid espressione %q: %s at position %d", p.input, msg, p.pos+1)
+}
+
+// numero reads an unsigned integer, reporting whether there was one.
+func (p *parser) numero() (int, bool, error) {
+	start := p.pos
+	for !p.fine() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(p.input[start:p.pos])
+	if err != nil || n > 10000 {
+		p.pos = start
+		return 0, false, p.errore("number too large")
+	}
+	return n, true, nil
+}
+
+func isLettera(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func (p *parser) termine() (Termine, error) {
+	if p.peek() == '@' {
+		p.pos++
+		start := p.pos
+		for !p.fine() && isLettera(p.peek()) {
+			p.pos++
+		}
+		if start == p.pos {
+			return Termine{}, p.errore("expected variable name")
+		}
+		return Termine{Variabile: strings.ToUpper(p.input[start:p.pos])}, nil
+	}
+
+	numero, ok, err := p.numero()
+	if err != nil {
+		return Termine{}, err
+	}
+	if p.peek() != 'd' && p.peek() != 'D' {
+		if !ok {
+			return Termine{}, p.errore("expected a number, a die or a variable")
+		}
+		return Termine{Numero: numero}, nil
+	}
+	p.pos++
+	if !ok {
+		numero = 1
+	}
+
+	facce, ok, err := p.numero()
+	if err != nil {
+		return Termine{}, err
+	}
+	if !ok {
+		return Termine{}, p.errore("expected number of faces")
+	}
+	t := Termine{Numero: numero, Facce: facce}
+	if numero < 1 {
+		return Termine{}, fmt.Errorf("%s: number of dice must be at least 1", t)
+	}
+	if facce < 2 || facce > maxFacce {
+		return Termine{}, fmt.Errorf("%s: number of faces must be between 2 and %d", t, maxFacce)
+	}
+
+	if p.peek() != 'k' && p.peek() != 'K' {
+		return t, nil
+	}
+	if p.pos+1 >= len(p.input) {
+		p.pos++
+		return Termine{}, p.errore("expected kh or kl")
+	}
+	switch strings.ToLower(p.input[p.pos : p.pos+2]) {
+	case string(MantieniAlti):
+		t.Mantieni = MantieniAlti
+	case string(MantieniBassi):
+		t.Mantieni = MantieniBassi
+	default:
+		return Termine{}, p.errore("expected kh or kl")
+	}
+	p.pos += 2
+
+	t.Mantenuti, ok, err = p.numero()
+	if err != nil {
+		return Termine{}, err
+	}
+	if !ok {
+		t.Mantenuti = 1
+	}
+	if t.Mantenuti < 1 || t.Mantenuti > t.Numero {
+		return Termine{}, fmt.Errorf("%s: dice kept must be between 1 and %d", t, t.Numero)
+	}
+	if t.Numero > maxDadiMantieni {
+		return Termine{}, fmt.Errorf("%s: cannot keep from more than %d dice", t, maxDadiMantieni)
+	}
+	return t, nil
+}
@@ -0,0 +1,61 @@
+package dadi
+
+import "testing"
+
+func TestAnalizza(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"4d6kh3+2", "4d6kh3+2"},
+		{"2d20kl1", "2d20kl1"},
+		{"1d8+@FOR", "1d8+@FOR"},
+		{"d20", "1d20"},
+		{" 2D6 - 1 ", "2d6-1"},
+		{"-1+d4", "-1+1d4"},
+		{"2d20kh", "2d20kh1"},
+		{"1d8+@for", "1d8+@FOR"},
+		{"3", "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			e, err := Analizza(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := e.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("terms", func(t *testing.T) {
+		e, err := Analizza("4d6kh3-@DES")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(e.Termini) != 2 {
+			t.Fatalf("expected 2 terms, got %d", len(e.Termini))
+		}
+		d := e.Termini[0]
+		if d.Numero != 4 || d.Facce != 6 || d.Mantieni != MantieniAlti || d.Mantenuti != 3 || d.Dado() != "d6" {
+			t.Errorf("unexpected dice term %+v", d)
+		}
+		if v := e.Termini[1]; v.Segno != -1 || v.Variabile != "DES" {
+			t.Errorf("unexpected variable term %+v", v)
+		}
+	})
+
+	invalid := []string{
+		"", "   ", "d", "2d", "0d6", "1d1", "1d101", "4d6kh5", "4d6kh0", "4d6kx3", "4d6k",
+		"1d6+", "1d6++2", "1d6 2", "@", "1d6*2", "101d6", "60d6+60d6", "21d6kh1",
+		"99999999999999999999", "1+1+1+1+1+1+1+1+1+1+1+1+1+1+1+1+1+1+1+1+1",
+	}
+	for _, input := range invalid {
+		t.Run("invalid "+input, func(t *testing.T) {
+			if _, err := Analizza(input); err == nil {
+				t.Errorf("expected error for %q", input)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package dadi
+
+type Modalita string
+
+const (
+	ModalitaTiro        Modalita = "tiro"
+	ModalitaStatistiche Modalita = "statistiche"
+)
+
+// RichiestaTiro asks to roll an expression, or with ModalitaStatistiche to
+// describe its outcomes. Variabili gives the values of the variables used
+// by the expression, e.g. {"FOR": 3} for @FOR. Seme makes the roll
+// reproducible.
+type RichiestaTiro struct {
+	Espressione string         `json:"espressione"`
+	Variabili   map[string]int `json:"variabili,omitempty"`
+	Modalita    Modalita       `json:"modalità,omitempty"`
+	Seme        *uint64        `json:"seme,omitempty"`
+}
+
+type RisultatoTiro struct {
+	Espressione string       `json:"espressione"`
+	Tiro        *Tiro        `json:"tiro,omitempty"`
+	Statistiche *Statistiche `json:"statistiche,omitempty"`
+}
@@ -0,0 +1,78 @@
+package dadi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"sync"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	mu     sync.Mutex
+	rng    *rand.Rand
+	logger *slog.Logger
+}
+
+// NewService returns a Service rolling with rng, or with a randomly seeded
+// generator when rng is nil.
+func NewService(rng *rand.Rand, logger *slog.Logger) *Service {
+	if rng == nil {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		rng:    rng,
+		logger: logger,
+	}
+}
+
+func (s *Service) Tira(_ context.Context, richiesta RichiestaTiro) (*RisultatoTiro, error) {
+	espressione, err := Analizza(richiesta.Espressione)
+	if err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	variabili := make(map[string]int, len(richiesta.Variabili))
+	for k, v := range richiesta.Variabili {
+		variabili[strings.ToUpper(strings.TrimPrefix(k, "@"))] = v
+	}
+	for _, v := range espressione.Variabili() {
+		if _, ok := variabili[v]; !ok {
+			err := fmt.Errorf("variable @%s has no value", v)
+			return nil, shared.NewBadRequestError(err.Error(), err)
+		}
+	}
+
+	result := &RisultatoTiro{Espressione: espressione.String()}
+	switch richiesta.Modalita {
+	case ModalitaTiro, "":
+		result.Tiro, err = s.tira(espressione, variabili, richiesta.Seme)
+	case ModalitaStatistiche:
+		result.Statistiche, err = espressione.Statistiche(variabili)
+	default:
+		err = fmt.Errorf("modalità must be one of: %s, %s", ModalitaTiro, ModalitaStatistiche)
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+	if err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+	return result, nil
+}
+
+// tira rolls with a generator seeded by seme when given, with the shared
+// generator otherwise.
+func (s *Service) tira(espressione *Espressione, variabili map[string]int, seme *uint64) (*Tiro, error) {
+	if seme != nil {
+		return espressione.Tira(rand.New(rand.NewPCG(*seme, *seme)), variabili)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return espressione.Tira(s.rng, variabili)
+}
@@ -0,0 +1,86 @@
+package dadi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestService_Tira(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("rolls with the injected generator", func(t *testing.T) {
+		a, err := NewService(newTestRand(), logger).Tira(ctx, RichiestaTiro{Espressione: "4d6kh3 + 2"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, _ := NewService(newTestRand(), logger).Tira(ctx, RichiestaTiro{Espressione: "4d6kh3 + 2"})
+
+		if a.Espressione != "4d6kh3+2" || a.Tiro == nil || a.Statistiche != nil {
+			t.Fatalf("unexpected result %+v", a)
+		}
+		if a.Tiro.Totale != b.Tiro.Totale || !slices.Equal(a.Tiro.Termini[0].Tiri, b.Tiro.Termini[0].Tiri) {
+			t.Errorf("expected reproducible rolls, got %+v and %+v", a.Tiro, b.Tiro)
+		}
+	})
+
+	t.Run("seme overrides the generator", func(t *testing.T) {
+		seme := uint64(42)
+		richiesta := RichiestaTiro{Espressione: "10d20", Seme: &seme}
+
+		a, _ := NewService(nil, logger).Tira(ctx, richiesta)
+		b, _ := NewService(nil, logger).Tira(ctx, richiesta)
+
+		if !slices.Equal(a.Tiro.Termini[0].Tiri, b.Tiro.Termini[0].Tiri) {
+			t.Errorf("expected the same rolls for the same seed")
+		}
+	})
+
+	t.Run("variables are case insensitive", func(t *testing.T) {
+		service := NewService(newTestRand(), logger)
+
+		result, err := service.Tira(ctx, RichiestaTiro{
+			Espressione: "1d8+@FOR",
+			Variabili:   map[string]int{"for": 4},
+			Modalita:    ModalitaStatistiche,
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Tiro != nil || result.Statistiche == nil || result.Statistiche.Minimo != 5 || result.Statistiche.Massimo != 12 {
+			t.Errorf("unexpected result %+v", result)
+		}
+	})
+
+	invalid := []struct {
+		name      string
+		richiesta RichiestaTiro
+	}{
+		{"empty expression", RichiestaTiro{}},
+		{"invalid expression", RichiestaTiro{Espressione: "4d6kh5"}},
+		{"missing variable", RichiestaTiro{Espressione: "1d8+@DES", Variabili: map[string]int{"FOR": 1}}},
+		{"missing variable in statistics", RichiestaTiro{Espressione: "1d8+@DES", Modalita: ModalitaStatistiche}},
+		{"unknown modalita", RichiestaTiro{Espressione: "1d8", Modalita: "media"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			_, err := NewService(newTestRand(), logger).Tira(ctx, tt.richiesta)
+
+			var appErr *shared.AppError
+			if !errors.As(err, &appErr) || appErr.HTTPStatus != 400 {
+				t.Errorf("expected 400 AppError, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package dadi
+
+import "math"
+
+// Probabilita is the probability of a total.
+type Probabilita struct {
+	Totale      int     `json:"totale"`
+	Probabilita float64 `json:"probabilità"`
+}
+
+// Statistiche describes every possible outcome of an expression.
+type Statistiche struct {
+	Minimo        int           `json:"minimo"`
+	Massimo       int           `json:"massimo"`
+	Media         float64       `json:"media"`
+	Distribuzione []Probabilita `json:"distribuzione"`
+}
+
+// distribuzione is a probability distribution over the integers from min
+// to min+len(p)-1.
+type distribuzione struct {
+	min int
+	p   []float64
+}
+
+func puntuale(v int) distribuzione {
+	return distribuzione{min: v, p: []float64{1}}
+}
+
+func (d distribuzione) somma(o distribuzione) distribuzione {
+	p := make([]float64, len(d.p)+len(o.p)-1)
+	for i, a := range d.p {
+		if a == 0 {
+			continue
+		}
+		for j, b := range o.p {
+			p[i+j] += a * b
+		}
+	}
+	return distribuzione{min: d.min + o.min, p: p}
+}
+
+func (d distribuzione) negata() distribuzione {
+	p := make([]float64, len(d.p))
+	for i, v := range d.p {
+		p[len(p)-1-i] = v
+	}
+	return distribuzione{min: -(d.min + len(d.p) - 1), p: p}
+}
+
+// Statistiche computes the exact distribution of the totals of e by
+// convolving the distributions of its terms. variabili maps the upper case
+// variable names to their values.
+func (e *Espressione) Statistiche(variabili map[string]int) (*Statistiche, error) {
+	d := puntuale(0)
+	for _, t := range e.Termini {
+		var dt distribuzione
+		if t.IsDado() {
+			dt = distribuzioneDadi(t)
+		} else {
+			v, err := t.valore(variabili)
+			if err != nil {
+				return nil, err
+			}
+			dt = puntuale(v)
+		}
+		if t.Segno < 0 {
+			dt = dt.negata()
+		}
+		d = d.somma(dt)
+	}
+
+	result := &Statistiche{Minimo: d.min, Massimo: d.min + len(d.p) - 1}
+	for i, p := range d.p {
+		if p == 0 {
+			continue
+		}
+		result.Media += float64(d.min+i) * p
+		result.Distribuzione = append(result.Distribuzione, Probabilita{Totale: d.min + i, Probabilita: p})
+	}
+	return result, nil
+}
+
+func distribuzioneDadi(t Termine) distribuzione {
+	if t.Mantieni != MantieniTutti {
+		return distribuzioneMantieni(t)
+	}
+	uniforme := make([]float64, t.Facce)
+	for i := range uniforme {
+		uniforme[i] = 1 / float64(t.Facce)
+	}
+	dado := distribuzione{min: 1, p: uniforme}
+	d := dado
+	for range t.Numero - 1 {
+		d = d.somma(dado)
+	}
+	return d
+}
+
+// distribuzioneMantieni computes the distribution of a kh/kl term by
+// assigning the faces to the dice in the order they are kept: highest
+// first for kh, lowest first for kl. Once Mantenuti dice have a face the
+// remaining ones are discarded, so the state is just the number of dice
+// assigned and the kept sum; the ways to pick which c of the remaining
+// dice show a face are counted with binomials.
+func distribuzioneMantieni(t Termine) distribuzione {
+	maxSomma := t.Mantenuti * t.Facce
+	// ways[a][s]: outcomes with a dice assigned and a kept sum of s.
+	ways := make([][]float64, t.Numero+1)
+	for a := range ways {
+		ways[a] = make([]float64, maxSomma+1)
+	}
+	ways[0][0] = 1
+
+	for f := range t.Facce {
+		faccia := t.Facce - f
+		if t.Mantieni == MantieniBassi {
+			faccia = f + 1
+		}
+		next := make([][]float64, t.Numero+1)
+		for a := range next {
+			next[a] = make([]float64, maxSomma+1)
+		}
+		for a := 0; a <= t.Numero; a++ {
+			for s, w := range ways[a] {
+				if w == 0 {
+					continue
+				}
+				for c := 0; a+c <= t.Numero; c++ {
+					tenuti := min(c, max(t.Mantenuti-a, 0))
+					next[a+c][s+tenuti*faccia] += w * binomiale(t.Numero-a, c)
+				}
+			}
+		}
+		ways = next
+	}
+
+	totale := math.Pow(float64(t.Facce), float64(t.Numero))
+	p := make([]float64, maxSomma-t.Mantenuti+1)
+	for s := t.Mantenuti; s <= maxSomma; s++ {
+		p[s-t.Mantenuti] = ways[t.Numero][s] / totale
+	}
+	return distribuzione{min: t.Mantenuti, p: p}
+}
+
+func binomiale(n, k int) float64 {
+	result := 1.0
+	for i := range k {
+		result = result * float64(n-i) / float64(i+1)
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package dadi
+
+import (
+	"math"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func statistiche(t *testing.T, input string, variabili map[string]int) *Statistiche {
+	t.Helper()
+	e, err := Analizza(input)
+	if err != nil {
+		t.Fatalf("Analizza(%q): %v", input, err)
+	}
+	s, err := e.Statistiche(variabili)
+	if err != nil {
+		t.Fatalf("Statistiche(%q): %v", input, err)
+	}
+	return s
+}
+
+func probabilita(s *Statistiche, totale int) float64 {
+	for _, p := range s.Distribuzione {
+		if p.Totale == totale {
+			return p.Probabilita
+		}
+	}
+	return 0
+}
+
+func TestEspressione_Statistiche(t *testing.T) {
+	tests := []struct {
+		input   string
+		minimo  int
+		massimo int
+		media   float64
+	}{
+		{"1d6", 1, 6, 3.5},
+		{"2d6+3", 5, 15, 10},
+		{"4d6kh3", 3, 18, 15869.0 / 1296},
+		{"2d20kh1", 1, 20, 13.825},
+		{"2d20kl1", 1, 20, 7.175},
+		{"1d8+@FOR", 4, 11, 7.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			s := statistiche(t, tt.input, map[string]int{"FOR": 3})
+
+			if s.Minimo != tt.minimo || s.Massimo != tt.massimo {
+				t.Errorf("expected range %d..%d, got %d..%d", tt.minimo, tt.massimo, s.Minimo, s.Massimo)
+			}
+			if math.Abs(s.Media-tt.media) > epsilon {
+				t.Errorf("expected mean %v, got %v", tt.media, s.Media)
+			}
+			totale := 0.0
+			for _, p := range s.Distribuzione {
+				totale += p.Probabilita
+			}
+			if math.Abs(totale-1) > epsilon {
+				t.Errorf("probabilities sum to %v", totale)
+			}
+		})
+	}
+
+	t.Run("exact probabilities", func(t *testing.T) {
+		if p := probabilita(statistiche(t, "2d6", nil), 7); math.Abs(p-6.0/36) > epsilon {
+			t.Errorf("P(2d6=7) = %v, want 1/6", p)
+		}
+		if p := probabilita(statistiche(t, "2d20kh1", nil), 20); math.Abs(p-39.0/400) > epsilon {
+			t.Errorf("P(2d20kh1=20) = %v, want 39/400", p)
+		}
+		if p := probabilita(statistiche(t, "4d6kh3", nil), 18); math.Abs(p-21.0/1296) > epsilon {
+			t.Errorf("P(4d6kh3=18) = %v, want 21/1296", p)
+		}
+		if p := probabilita(statistiche(t, "4d6kh3", nil), 3); math.Abs(p-1.0/1296) > epsilon {
+			t.Errorf("P(4d6kh3=3) = %v, want 1/1296", p)
+		}
+	})
+
+	t.Run("keep matches enumeration", func(t *testing.T) {
+		for _, input := range []string{"3d4kl2", "3d4kh2", "4d6kh3", "5d3kl3", "3d6kh1"} {
+			e, _ := Analizza(input)
+			want := enumera(e.Termini[0])
+			s := statistiche(t, input, nil)
+			if len(s.Distribuzione) != len(want) {
+				t.Errorf("%s: expected %d totals, got %d", input, len(want), len(s.Distribuzione))
+			}
+			for totale, p := range want {
+				if got := probabilita(s, totale); math.Abs(got-p) > epsilon {
+					t.Errorf("%s: P(%d) = %v, want %v", input, totale, got, p)
+				}
+			}
+		}
+	})
+
+	t.Run("largest expressions", func(t *testing.T) {
+		s := statistiche(t, "100d100", nil)
+		if s.Minimo != 100 || s.Massimo != 10000 || math.Abs(s.Media-5050) > 1e-6 {
+			t.Errorf("unexpected 100d100 statistics %d..%d mean %v", s.Minimo, s.Massimo, s.Media)
+		}
+		s = statistiche(t, "20d100kh10", nil)
+		if s.Minimo != 10 || s.Massimo != 1000 {
+			t.Errorf("unexpected 20d100kh10 range %d..%d", s.Minimo, s.Massimo)
+		}
+	})
+}
+
+// enumera computes the distribution of a dice term by rolling every
+// combination.
+func enumera(t Termine) map[int]float64 {
+	result := make(map[int]float64)
+	tiri := make([]int, t.Numero)
+	casi := math.Pow(float64(t.Facce), float64(t.Numero))
+	var visita func(i int)
+	visita = func(i int) {
+		if i == len(tiri) {
+			tenuti, _ := mantieni(t, tiri)
+			somma := 0
+			for _, v := range tenuti {
+				somma += v
+			}
+			result[somma] += 1 / casi
+			return
+		}
+		for f := 1; f <= t.Facce; f++ {
+			tiri[i] = f
+			visita(i + 1)
+		}
+	}
+	visita(0)
+	return result
+}
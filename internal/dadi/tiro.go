@@ -0,0 +1,81 @@
+package dadi
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+// TiroTermine is the outcome of a term: the dice rolled, in order, with
+// the ones discarded by kh/kl, and its signed contribution to the total.
+type TiroTermine struct {
+	Termine  string            `json:"termine"`
+	Dado     shared.TipoDiDado `json:"dado,omitempty"`
+	Tiri     []int             `json:"tiri,omitempty"`
+	Scartati []int             `json:"scartati,omitempty"`
+	Totale   int               `json:"totale"`
+}
+
+// Tiro is the outcome of rolling an expression.
+type Tiro struct {
+	Termini []TiroTermine `json:"termini"`
+	Totale  int           `json:"totale"`
+}
+
+// valore returns the value of a constant or variable term.
+func (t Termine) valore(variabili map[string]int) (int, error) {
+	if t.Variabile == "" {
+		return t.Numero, nil
+	}
+	v, ok := variabili[t.Variabile]
+	if !ok {
+		return 0, fmt.Errorf("variable @%s has no value", t.Variabile)
+	}
+	return v, nil
+}
+
+// Tira rolls e with rng. variabili maps the upper case variable names to
+// their values.
+func (e *Espressione) Tira(rng *rand.Rand, variabili map[string]int) (*Tiro, error) {
+	result := &Tiro{Termini: make([]TiroTermine, len(e.Termini))}
+	for i, t := range e.Termini {
+		tt := TiroTermine{Termine: t.String()}
+		if t.IsDado() {
+			tt.Dado = t.Dado()
+			tt.Tiri = make([]int, t.Numero)
+			for j := range tt.Tiri {
+				tt.Tiri[j] = rng.IntN(t.Facce) + 1
+			}
+			tenuti, scartati := mantieni(t, tt.Tiri)
+			tt.Scartati = scartati
+			for _, v := range tenuti {
+				tt.Totale += v
+			}
+		} else {
+			v, err := t.valore(variabili)
+			if err != nil {
+				return nil, err
+			}
+			tt.Totale = v
+		}
+		tt.Totale *= t.Segno
+		result.Termini[i] = tt
+		result.Totale += tt.Totale
+	}
+	return result, nil
+}
+
+// mantieni splits tiri into the dice kept and discarded by t.
+func mantieni(t Termine, tiri []int) (tenuti, scartati []int) {
+	if t.Mantieni == MantieniTutti {
+		return tiri, nil
+	}
+	ordinati := slices.Clone(tiri)
+	slices.Sort(ordinati)
+	if t.Mantieni == MantieniAlti {
+		slices.Reverse(ordinati)
+	}
+	return ordinati[:t.Mantenuti], ordinati[t.Mantenuti:]
+}
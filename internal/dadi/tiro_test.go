@@ -0,0 +1,82 @@
+package dadi
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func newTestRand() *rand.Rand {
+	return rand.New(rand.NewPCG(1, 2))
+}
+
+func TestEspressione_Tira(t *testing.T) {
+	t.Run("keep highest", func(t *testing.T) {
+		e, _ := Analizza("4d6kh3+2")
+
+		got, err := e.Tira(newTestRand(), nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		dadi := got.Termini[0]
+		if len(dadi.Tiri) != 4 || len(dadi.Scartati) != 1 || dadi.Dado != "d6" {
+			t.Fatalf("unexpected dice %+v", dadi)
+		}
+		somma := 0
+		for _, v := range dadi.Tiri {
+			if v < 1 || v > 6 {
+				t.Errorf("roll %d out of range", v)
+			}
+			somma += v
+		}
+		if dadi.Scartati[0] != slices.Min(dadi.Tiri) {
+			t.Errorf("expected the lowest die discarded, got %+v", dadi)
+		}
+		if dadi.Totale != somma-dadi.Scartati[0] || got.Totale != dadi.Totale+2 {
+			t.Errorf("unexpected totals %+v", got)
+		}
+	})
+
+	t.Run("keep lowest", func(t *testing.T) {
+		e, _ := Analizza("2d20kl1")
+
+		got, _ := e.Tira(newTestRand(), nil)
+
+		if got.Totale != slices.Min(got.Termini[0].Tiri) {
+			t.Errorf("expected the lowest die, got %+v", got)
+		}
+	})
+
+	t.Run("variables and negative terms", func(t *testing.T) {
+		e, _ := Analizza("1d8+@FOR-1")
+
+		got, err := e.Tira(newTestRand(), map[string]int{"FOR": 3})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Termini[1].Totale != 3 || got.Termini[2].Totale != -1 || got.Totale != got.Termini[0].Totale+2 {
+			t.Errorf("unexpected totals %+v", got)
+		}
+	})
+
+	t.Run("same seed same rolls", func(t *testing.T) {
+		e, _ := Analizza("10d20")
+
+		a, _ := e.Tira(newTestRand(), nil)
+		b, _ := e.Tira(newTestRand(), nil)
+
+		if !slices.Equal(a.Termini[0].Tiri, b.Termini[0].Tiri) {
+			t.Errorf("expected reproducible rolls, got %v and %v", a.Termini[0].Tiri, b.Termini[0].Tiri)
+		}
+	})
+
+	t.Run("missing variable", func(t *testing.T) {
+		e, _ := Analizza("1d8+@FOR")
+
+		if _, err := e.Tira(newTestRand(), nil); err == nil {
+			t.Fatal("expected error for missing variable")
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/dadi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type DadiService interface {
+	Tira(ctx context.Context, richiesta dadi.RichiestaTiro) (*dadi.RisultatoTiro, error)
+}
+
+type Handler struct {
+	service DadiService
+}
+
+func NewHandler(service DadiService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/tira", h.Tira)
+
+	return r
+}
+
+func (h *Handler) Tira(w http.ResponseWriter, r *http.Request) {
+	var richiesta dadi.RichiestaTiro
+	if err := shared.DecodeJSON(w, r, &richiesta); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	result, err := h.service.Tira(r.Context(), richiesta)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,89 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/dadi"
+)
+
+type mockService struct {
+	tiraFunc func(ctx context.Context, richiesta dadi.RichiestaTiro) (*dadi.RisultatoTiro, error)
+}
+
+func (m *mockService) Tira(ctx context.Context, richiesta dadi.RichiestaTiro) (*dadi.RisultatoTiro, error) {
+	if m.tiraFunc != nil {
+		return m.tiraFunc(ctx, richiesta)
+	}
+	return &dadi.RisultatoTiro{}, nil
+}
+
+func newTestRouter(svc DadiService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/dadi", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_Tira(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var captured dadi.RichiestaTiro
+		svc := &mockService{
+			tiraFunc: func(_ context.Context, richiesta dadi.RichiestaTiro) (*dadi.RisultatoTiro, error) {
+				captured = richiesta
+				return &dadi.RisultatoTiro{Espressione: richiesta.Espressione, Tiro: &dadi.Tiro{Totale: 11}}, nil
+			},
+		}
+
+		body := `{"espressione":"1d8+@FOR","variabili":{"FOR":3},"modalità":"tiro","seme":7}`
+		req := httptest.NewRequest(http.MethodPost, "/dadi/tira", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if captured.Espressione != "1d8+@FOR" || captured.Variabili["FOR"] != 3 ||
+			captured.Modalita != dadi.ModalitaTiro || captured.Seme == nil || *captured.Seme != 7 {
+			t.Errorf("unexpected request %+v", captured)
+		}
+
+		var response dadi.RisultatoTiro
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Tiro == nil || response.Tiro.Totale != 11 {
+			t.Errorf("unexpected response %+v", response)
+		}
+	})
+
+	t.Run("invalid expression through the real service", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/dadi/tira", strings.NewReader(`{"espressione":"4d6kx3"}`))
+		rec := httptest.NewRecorder()
+
+		newTestRouter(dadi.NewService(nil, nil)).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	for _, body := range []string{``, `{"espressione":`, `{"expression":"1d6"}`} {
+		t.Run("invalid body "+body, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/dadi/tira", strings.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
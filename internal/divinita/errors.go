@@ -0,0 +1,9 @@
+package divinita
+
+import (
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func ErrDivinitaNotFound(id string) *shared.AppError {
+	return shared.NewNotFoundError("Divinità", id)
+}
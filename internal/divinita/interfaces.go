@@ -0,0 +1,8 @@
+package divinita
+
+import "context"
+
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Divinita, int, error)
+	GetByID(ctx context.Context, id string) (*Divinita, error)
+}
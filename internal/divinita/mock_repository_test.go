@@ -0,0 +1,24 @@
+package divinita
+
+import (
+	"context"
+)
+
+type MockRepository struct {
+	ListFunc    func(ctx context.Context, filter ListFilter) ([]Divinita, int, error)
+	GetByIDFunc func(ctx context.Context, id string) (*Divinita, error)
+}
+
+func (m *MockRepository) List(ctx context.Context, filter ListFilter) ([]Divinita, int, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockRepository) GetByID(ctx context.Context, id string) (*Divinita, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
@@ -0,0 +1,50 @@
+package divinita
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type DominioDivino string
+
+const (
+	Arcano     DominioDivino = "Arcano"
+	Conoscenza DominioDivino = "Conoscenza"
+	Crepuscolo DominioDivino = "Crepuscolo"
+	Forgia     DominioDivino = "Forgia"
+	Guerra     DominioDivino = "Guerra"
+	Inganno    DominioDivino = "Inganno"
+	Luce       DominioDivino = "Luce"
+	Morte      DominioDivino = "Morte"
+	Natura     DominioDivino = "Natura"
+	Ordine     DominioDivino = "Ordine"
+	Pace       DominioDivino = "Pace"
+	Tempesta   DominioDivino = "Tempesta"
+	Tomba      DominioDivino = "Tomba"
+	Vita       DominioDivino = "Vita"
+)
+
+var DominiDivini = []DominioDivino{
+	Arcano, Conoscenza, Crepuscolo, Forgia, Guerra, Inganno, Luce,
+	Morte, Natura, Ordine, Pace, Tempesta, Tomba, Vita,
+}
+
+// Divinita is a deity of a pantheon, with the domains it grants to its
+// clerics.
+type Divinita struct {
+	ID                          string               `json:"id"`
+	Nome                        string               `json:"nome"`
+	Pantheon                    string               `json:"pantheon"`
+	Allineamento                shared.Allineamento  `json:"allineamento"`
+	Domini                      []DominioDivino      `json:"domini"`
+	Simbolo                     string               `json:"simbolo,omitempty"`
+	Descrizione                 string               `json:"descrizione,omitempty"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento"`
+}
+
+// ListFilter extends the shared list filter with the pantheon filters.
+// Dominio and Allineamento match deities with any of the given values.
+type ListFilter struct {
+	shared.ListFilter
+	Pantheon     *string
+	Dominio      []DominioDivino
+	Allineamento []shared.Allineamento
+}
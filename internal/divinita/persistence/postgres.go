@@ -0,0 +1,125 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/divinita"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type divinitaRow struct {
+	ID                          string         `db:"id"`
+	Nome                        string         `db:"nome"`
+	Pantheon                    string         `db:"pantheon"`
+	Allineamento                string         `db:"allineamento"`
+	Domini                      pq.StringArray `db:"domini"`
+	Simbolo                     sql.NullString `db:"simbolo"`
+	Descrizione                 sql.NullString `db:"descrizione"`
+	DocumentazioneDiRiferimento string         `db:"documentazione_di_riferimento"`
+}
+
+func (r *divinitaRow) toDivinita() divinita.Divinita {
+	d := divinita.Divinita{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Pantheon:                    r.Pantheon,
+		Allineamento:                shared.Allineamento(r.Allineamento),
+		Domini:                      make([]divinita.DominioDivino, len(r.Domini)),
+		Simbolo:                     r.Simbolo.String,
+		Descrizione:                 r.Descrizione.String,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+	for i, dominio := range r.Domini {
+		d.Domini[i] = divinita.DominioDivino(dominio)
+	}
+	return d
+}
+
+const selectDivinita = `
+	SELECT id, nome, pantheon, allineamento, domini, simbolo, descrizione,
+	       documentazione_di_riferimento
+	FROM divinita`
+
+// filterConditions translates the pantheon filters into SQL conditions and
+// their named arguments. Dominio uses array overlap so that a deity
+// matches when it grants any of the requested domains.
+func filterConditions(filter divinita.ListFilter) (string, map[string]any) {
+	var where string
+	args := make(map[string]any)
+
+	if filter.Pantheon != nil {
+		where += ` AND pantheon = :pantheon`
+		args["pantheon"] = *filter.Pantheon
+	}
+	if len(filter.Dominio) > 0 {
+		domini := make([]string, len(filter.Dominio))
+		for i, d := range filter.Dominio {
+			domini[i] = string(d)
+		}
+		where += ` AND domini && :dominio`
+		args["dominio"] = pq.Array(domini)
+	}
+	if len(filter.Allineamento) > 0 {
+		allineamenti := make([]string, len(filter.Allineamento))
+		for i, a := range filter.Allineamento {
+			allineamenti[i] = string(a)
+		}
+		where += ` AND allineamento = ANY(:allineamento)`
+		args["allineamento"] = pq.Array(allineamenti)
+	}
+
+	return where, args
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filter divinita.ListFilter) ([]divinita.Divinita, int, error) {
+	where, args := filterConditions(filter)
+	q := shared.NewPaginatedQuery(
+		selectDivinita+` WHERE 1=1`+where,
+		`SELECT COUNT(*) FROM divinita WHERE 1=1`+where,
+		args,
+		filter.ListFilter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []divinitaRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]divinita.Divinita, len(rows))
+	for i, row := range rows {
+		result[i] = row.toDivinita()
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*divinita.Divinita, error) {
+	var row divinitaRow
+	if err := r.db.GetContext(ctx, &row, selectDivinita+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get divinità by id: %w", err)
+	}
+
+	d := row.toDivinita()
+	return &d, nil
+}
@@ -0,0 +1,64 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/divinita"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func TestFilterConditions(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		where, args := filterConditions(divinita.ListFilter{})
+
+		if where != "" || len(args) != 0 {
+			t.Errorf("expected no conditions, got %q %v", where, args)
+		}
+	})
+
+	t.Run("pantheon filters", func(t *testing.T) {
+		pantheon := "Forgotten Realms"
+		filter := divinita.ListFilter{
+			Pantheon:     &pantheon,
+			Dominio:      []divinita.DominioDivino{divinita.Luce, divinita.Vita},
+			Allineamento: []shared.Allineamento{shared.NeutraleBuono},
+		}
+
+		where, args := filterConditions(filter)
+
+		for _, cond := range []string{"pantheon = :pantheon", "domini && :dominio", "allineamento = ANY(:allineamento)"} {
+			if !strings.Contains(where, cond) {
+				t.Errorf("expected %q in %q", cond, where)
+			}
+		}
+		domini := args["dominio"].(*pq.StringArray)
+		if len(*domini) != 2 || (*domini)[0] != "Luce" {
+			t.Errorf("unexpected dominio arg %v", *domini)
+		}
+		allineamenti := args["allineamento"].(*pq.StringArray)
+		if len(*allineamenti) != 1 || (*allineamenti)[0] != "Neutrale Buono" {
+			t.Errorf("unexpected allineamento arg %v", *allineamenti)
+		}
+	})
+}
+
+func TestDivinitaRow_ToDivinita(t *testing.T) {
+	row := divinitaRow{
+		ID:           "lathander",
+		Nome:         "Lathander",
+		Allineamento: "Neutrale Buono",
+		Domini:       pq.StringArray{"Luce", "Vita"},
+	}
+
+	got := row.toDivinita()
+
+	if got.Allineamento != shared.NeutraleBuono {
+		t.Errorf("unexpected allineamento %q", got.Allineamento)
+	}
+	if len(got.Domini) != 2 || got.Domini[1] != divinita.Vita {
+		t.Errorf("unexpected domini %v", got.Domini)
+	}
+}
@@ -0,0 +1,8 @@
+package divinita
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type ListDivinitaResponse struct {
+	shared.PaginationMeta
+	Divinita []Divinita `json:"divinità"`
+}
@@ -0,0 +1,54 @@
+package divinita
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	repo      Repository
+	glossario shared.Glossario
+	logger    *slog.Logger
+}
+
+func NewService(repo Repository, glossario shared.Glossario, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:      repo,
+		glossario: glossario,
+		logger:    logger,
+	}
+}
+
+func (s *Service) ListDivinita(ctx context.Context, filter ListFilter) (*ListDivinitaResponse, error) {
+	divinita, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list divinità", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListDivinitaResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Divinita:       divinita,
+	}, nil
+}
+
+func (s *Service) GetDivinita(ctx context.Context, id string, campi shared.Campi) (*Divinita, error) {
+	divinita, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get divinità", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if divinita == nil {
+		return nil, ErrDivinitaNotFound(id)
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		divinita.Riferimenti = s.glossario.Riferimenti(ctx, divinita)
+	}
+	return divinita, nil
+}
@@ -0,0 +1,100 @@
+package divinita
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestService_ListDivinita(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Divinita, int, error) {
+				return []Divinita{
+					{ID: "tyr", Nome: "Tyr", Domini: []DominioDivino{Guerra}},
+					{ID: "lathander", Nome: "Lathander", Domini: []DominioDivino{Luce, Vita}},
+				}, 2, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.ListDivinita(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 2 || len(result.Divinita) != 2 {
+			t.Errorf("expected 2 divinità, got %d/%d", result.NumeroDiElementi, len(result.Divinita))
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Divinita, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.ListDivinita(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestService_GetDivinita(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Divinita, error) {
+				return &Divinita{ID: id, Nome: "Tyr"}, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.GetDivinita(ctx, "tyr", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "tyr" {
+			t.Errorf("expected id 'tyr', got '%s'", result.ID)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := NewService(&MockRepository{}, nil, logger)
+
+		_, err := service.GetDivinita(ctx, "nonexistent", nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/divinita"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type DivinitaService interface {
+	ListDivinita(ctx context.Context, filter divinita.ListFilter) (*divinita.ListDivinitaResponse, error)
+	GetDivinita(ctx context.Context, id string, campi shared.Campi) (*divinita.Divinita, error)
+}
+
+type Handler struct {
+	service DivinitaService
+}
+
+func NewHandler(service DivinitaService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListDivinita)
+	r.Get("/{id-divinità}", h.GetDivinita)
+
+	return r
+}
+
+func newListFilterFromRequest(r *http.Request) (divinita.ListFilter, error) {
+	base, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		return divinita.ListFilter{}, err
+	}
+	filter := divinita.ListFilter{ListFilter: base}
+	query := r.URL.Query()
+
+	if filter.Pantheon, err = shared.QueryString(query, "pantheon"); err != nil {
+		return filter, err
+	}
+	if filter.Dominio, err = shared.QueryEnumList(query, "dominio", divinita.DominiDivini...); err != nil {
+		return filter, err
+	}
+	if filter.Allineamento, err = shared.QueryEnumList(query, "allineamento", shared.Allineamenti...); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) ListDivinita(w http.ResponseWriter, r *http.Request) {
+	filter, err := newListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListDivinita(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetDivinita(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-divinità")
+	if err := shared.ValidateID("id-divinità", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	d, err := h.service.GetDivinita(r.Context(), id, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, d, campi)
+}
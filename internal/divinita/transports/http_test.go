@@ -0,0 +1,142 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/divinita"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	listDivinitaFunc func(ctx context.Context, filter divinita.ListFilter) (*divinita.ListDivinitaResponse, error)
+	getDivinitaFunc  func(ctx context.Context, id string, campi shared.Campi) (*divinita.Divinita, error)
+}
+
+func (m *mockService) ListDivinita(ctx context.Context, filter divinita.ListFilter) (*divinita.ListDivinitaResponse, error) {
+	if m.listDivinitaFunc != nil {
+		return m.listDivinitaFunc(ctx, filter)
+	}
+	return &divinita.ListDivinitaResponse{}, nil
+}
+
+func (m *mockService) GetDivinita(ctx context.Context, id string, campi shared.Campi) (*divinita.Divinita, error) {
+	if m.getDivinitaFunc != nil {
+		return m.getDivinitaFunc(ctx, id, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc DivinitaService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/divinità", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_ListDivinita(t *testing.T) {
+	t.Run("with pantheon filters", func(t *testing.T) {
+		var captured divinita.ListFilter
+		svc := &mockService{
+			listDivinitaFunc: func(_ context.Context, filter divinita.ListFilter) (*divinita.ListDivinitaResponse, error) {
+				captured = filter
+				return &divinita.ListDivinitaResponse{
+					PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+					Divinita:       []divinita.Divinita{{ID: "lathander", Nome: "Lathander"}},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/divinit%C3%A0?pantheon=Forgotten%20Realms&dominio=Luce,Vita&allineamento=Neutrale%20Buono", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if captured.Pantheon == nil || *captured.Pantheon != "Forgotten Realms" {
+			t.Errorf("unexpected pantheon %v", captured.Pantheon)
+		}
+		if len(captured.Dominio) != 2 || captured.Dominio[1] != divinita.Vita {
+			t.Errorf("unexpected dominio %v", captured.Dominio)
+		}
+		if len(captured.Allineamento) != 1 || captured.Allineamento[0] != shared.NeutraleBuono {
+			t.Errorf("unexpected allineamento %v", captured.Allineamento)
+		}
+
+		var response map[string]json.RawMessage
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if _, ok := response["divinità"]; !ok {
+			t.Errorf("expected divinità key in %v", response)
+		}
+	})
+
+	invalid := []struct {
+		name  string
+		query string
+	}{
+		{"unknown dominio", "dominio=Cucina"},
+		{"unknown allineamento", "allineamento=Buono"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/divinit%C3%A0?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_GetDivinita(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var requested string
+		svc := &mockService{
+			getDivinitaFunc: func(_ context.Context, id string, _ shared.Campi) (*divinita.Divinita, error) {
+				requested = id
+				return &divinita.Divinita{ID: id, Nome: "Tyr"}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/divinit%C3%A0/tyr", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+		if requested != "tyr" {
+			t.Errorf("expected id tyr, got %q", requested)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getDivinitaFunc: func(_ context.Context, id string, _ shared.Campi) (*divinita.Divinita, error) {
+				return nil, divinita.ErrDivinitaNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/divinit%C3%A0/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
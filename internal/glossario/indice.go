@@ -0,0 +1,100 @@
+package glossario
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type termine struct {
+	testo string
+	voce  int
+}
+
+// Indice matches glossary entries in free text. Matching is case
+// insensitive and only considers whole words; when terms overlap the
+// longest wins, so "Privo di sensi" is not also reported as "sensi".
+type Indice struct {
+	voci    []Voce
+	termini []termine
+}
+
+func NewIndice(voci []Voce) *Indice {
+	idx := &Indice{voci: voci}
+	for i, v := range voci {
+		for _, t := range append([]string{v.Nome}, v.Sinonimi...) {
+			if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+				idx.termini = append(idx.termini, termine{testo: t, voce: i})
+			}
+		}
+	}
+	slices.SortStableFunc(idx.termini, func(a, b termine) int {
+		return cmp.Compare(len(b.testo), len(a.testo))
+	})
+	return idx
+}
+
+type occorrenza struct {
+	n, pos, voce int
+}
+
+// Trova returns the entries mentioned in testi, once each, in order of
+// first appearance.
+func (idx *Indice) Trova(testi ...string) []shared.Riferimento {
+	var trovate []occorrenza
+	for n, testo := range testi {
+		testo = strings.ToLower(testo)
+		occupato := make([]bool, len(testo))
+		for _, t := range idx.termini {
+			for start := 0; ; {
+				i := strings.Index(testo[start:], t.testo)
+				if i < 0 {
+					break
+				}
+				i += start
+				end := i + len(t.testo)
+				start = end
+				if !confineDiParola(testo, i, end) || slices.Contains(occupato[i:end], true) {
+					continue
+				}
+				for j := i; j < end; j++ {
+					occupato[j] = true
+				}
+				trovate = append(trovate, occorrenza{n: n, pos: i, voce: t.voce})
+			}
+		}
+	}
+
+	slices.SortFunc(trovate, func(a, b occorrenza) int {
+		return cmp.Or(cmp.Compare(a.n, b.n), cmp.Compare(a.pos, b.pos))
+	})
+
+	var result []shared.Riferimento
+	visti := make(map[int]bool)
+	for _, o := range trovate {
+		if visti[o.voce] {
+			continue
+		}
+		visti[o.voce] = true
+		result = append(result, idx.voci[o.voce].riferimento())
+	}
+	return result
+}
+
+func confineDiParola(testo string, start, end int) bool {
+	if r, _ := utf8.DecodeLastRuneInString(testo[:start]); start > 0 && parola(r) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(testo[end:]); end < len(testo) && parola(r) {
+		return false
+	}
+	return true
+}
+
+func parola(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
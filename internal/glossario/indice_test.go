@@ -0,0 +1,58 @@
+package glossario
+
+import (
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func testVoci() []Voce {
+	return []Voce{
+		{Tipo: shared.RiferimentoCondizione, ID: "prono", Nome: "Prono", Sinonimi: []string{"prona"}},
+		{Tipo: shared.RiferimentoCondizione, ID: "avvelenato", Nome: "Avvelenato", Sinonimi: []string{"avvelenata", "avvelenati"}},
+		{Tipo: shared.RiferimentoCondizione, ID: "privo-di-sensi", Nome: "Privo di sensi"},
+		{Tipo: shared.RiferimentoRegola, ID: "sensi", Nome: "Sensi"},
+		{Tipo: shared.RiferimentoRegola, ID: "vantaggio", Nome: "Vantaggio"},
+	}
+}
+
+func TestIndice_Trova(t *testing.T) {
+	idx := NewIndice(testVoci())
+
+	tests := []struct {
+		name  string
+		testi []string
+		want  []string
+	}{
+		{"no mentions", []string{"Il bersaglio subisce 2d6 danni."}, nil},
+		{"case insensitive", []string{"Il bersaglio cade prono."}, []string{"prono"}},
+		{"synonyms", []string{"La creatura è avvelenata per 1 minuto."}, []string{"avvelenato"}},
+		{"whole words only", []string{"Ha svantaggio ai tiri."}, nil},
+		{"longest term wins", []string{"Se è Privo di sensi cade."}, []string{"privo-di-sensi"}},
+		{"order of first appearance", []string{"Con vantaggio contro un bersaglio prono.", "Resta Avvelenato e Prono."}, []string{"vantaggio", "prono", "avvelenato"}},
+		{"accented neighbours", []string{"Pronoà non conta."}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := idx.Trova(tt.testi...)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %+v", tt.want, got)
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("position %d: expected %q, got %q", i, id, got[i].ID)
+				}
+			}
+		})
+	}
+
+	t.Run("link", func(t *testing.T) {
+		got := idx.Trova("cade prono con vantaggio")
+
+		if got[0].Link != "/v1/condizioni/prono" || got[1].Link != "/v1/regole/vantaggio" {
+			t.Errorf("unexpected links %+v", got)
+		}
+	})
+}
@@ -0,0 +1,7 @@
+package glossario
+
+import "context"
+
+type Repository interface {
+	ListVoci(ctx context.Context) ([]Voce, error)
+}
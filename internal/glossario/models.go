@@ -0,0 +1,27 @@
+package glossario
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+// Voce is a glossary entry: a rule or condition that can be mentioned by
+// name in free text. Sinonimi lists the other forms the name takes in
+// text, such as the inflections "avvelenata" or "avvelenati".
+type Voce struct {
+	Tipo     shared.TipoRiferimento
+	ID       string
+	Nome     string
+	Sinonimi []string
+}
+
+var percorsi = map[shared.TipoRiferimento]string{
+	shared.RiferimentoRegola:     "/v1/regole/",
+	shared.RiferimentoCondizione: "/v1/condizioni/",
+}
+
+func (v Voce) riferimento() shared.Riferimento {
+	return shared.Riferimento{
+		Tipo: v.Tipo,
+		ID:   v.ID,
+		Nome: v.Nome,
+		Link: percorsi[v.Tipo] + v.ID,
+	}
+}
@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/glossario"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type voceRow struct {
+	Tipo     string         `db:"tipo"`
+	ID       string         `db:"id"`
+	Nome     string         `db:"nome"`
+	Sinonimi pq.StringArray `db:"sinonimi"`
+}
+
+func (r *voceRow) toVoce() glossario.Voce {
+	return glossario.Voce{
+		Tipo:     shared.TipoRiferimento(r.Tipo),
+		ID:       r.ID,
+		Nome:     r.Nome,
+		Sinonimi: r.Sinonimi,
+	}
+}
+
+const selectVoci = `
+	SELECT 'regola' AS tipo, id, nome, sinonimi FROM regole
+	UNION ALL
+	SELECT 'condizione' AS tipo, id, nome, sinonimi FROM condizioni`
+
+func (r *PostgresRepository) ListVoci(ctx context.Context) ([]glossario.Voce, error) {
+	var rows []voceRow
+	if err := r.db.SelectContext(ctx, &rows, selectVoci); err != nil {
+		return nil, fmt.Errorf("list voci glossario: %w", err)
+	}
+
+	result := make([]glossario.Voce, len(rows))
+	for i, row := range rows {
+		result[i] = row.toVoce()
+	}
+	return result, nil
+}
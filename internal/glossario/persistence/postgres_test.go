@@ -0,0 +1,22 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func TestVoceRow_ToVoce(t *testing.T) {
+	row := voceRow{Tipo: "condizione", ID: "prono", Nome: "Prono", Sinonimi: pq.StringArray{"prona"}}
+
+	got := row.toVoce()
+
+	if got.Tipo != shared.RiferimentoCondizione || got.ID != "prono" {
+		t.Errorf("unexpected voce %+v", got)
+	}
+	if len(got.Sinonimi) != 1 || got.Sinonimi[0] != "prona" {
+		t.Errorf("unexpected sinonimi %v", got.Sinonimi)
+	}
+}
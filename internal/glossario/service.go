@@ -0,0 +1,153 @@
+package glossario
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+const (
+	// durataCache bounds how long edits to rules and conditions take to
+	// show up in the references of other resources.
+	durataCache = 5 * time.Minute
+	// attesaDopoErrore is how long a failed load holds off the next one.
+	attesaDopoErrore = 30 * time.Second
+	// timeoutCaricamento bounds a load, which runs on its own context.
+	timeoutCaricamento = 10 * time.Second
+)
+
+// Service implements shared.Glossario. The index is loaded lazily and
+// cached. A load runs in the background, one at a time, detached from the
+// request that triggered it: once the cache expires the previous index
+// keeps being served until the new one is ready, and only the requests
+// that find no index at all wait for it. If a load fails the previous
+// index keeps being used and the next load is held off for
+// attesaDopoErrore.
+type Service struct {
+	repo   Repository
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	indice   *Indice
+	scadenza time.Time
+	// caricamento is closed when the load in progress ends; nil when no
+	// load is in progress.
+	caricamento chan struct{}
+}
+
+func NewService(repo Repository, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+func (s *Service) Riferimenti(ctx context.Context, risorsa any) []shared.Riferimento {
+	testi, err := descrizioni(risorsa)
+	if err != nil {
+		s.logger.Error("failed to collect descrizioni", "error", err)
+		return nil
+	}
+	if len(testi) == 0 {
+		return nil
+	}
+
+	indice := s.indiceCorrente(ctx)
+	if indice == nil {
+		return nil
+	}
+	return indice.Trova(testi...)
+}
+
+func (s *Service) indiceCorrente(ctx context.Context) *Indice {
+	s.mu.Lock()
+	if s.now().Before(s.scadenza) {
+		defer s.mu.Unlock()
+		return s.indice
+	}
+	if s.caricamento == nil {
+		s.caricamento = make(chan struct{})
+		go s.carica(s.caricamento)
+	}
+	indice, caricamento := s.indice, s.caricamento
+	s.mu.Unlock()
+
+	if indice != nil {
+		return indice
+	}
+	select {
+	case <-caricamento:
+	case <-ctx.Done():
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.indice
+}
+
+// carica loads the index and closes fatto when it is done.
+func (s *Service) carica(fatto chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutCaricamento)
+	defer cancel()
+	voci, err := s.repo.ListVoci(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer close(fatto)
+	s.caricamento = nil
+	if err != nil {
+		s.logger.Error("failed to load glossario", "error", err)
+		s.scadenza = s.now().Add(attesaDopoErrore)
+		return
+	}
+	s.indice = NewIndice(voci)
+	s.scadenza = s.now().Add(durataCache)
+}
+
+// descrizioni collects every "descrizione" string in the JSON form of
+// risorsa, at any depth, so nested tratti and azioni are covered too. Keys
+// are visited in sorted order to keep the result deterministic.
+func descrizioni(risorsa any) ([]string, error) {
+	raw, err := json.Marshal(risorsa)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	var testi []string
+	var visita func(v any)
+	visita = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for _, k := range slices.Sorted(maps.Keys(v)) {
+				child := v[k]
+				if s, ok := child.(string); ok && k == "descrizione" && s != "" {
+					testi = append(testi, s)
+					continue
+				}
+				visita(child)
+			}
+		case []any:
+			for _, child := range v {
+				visita(child)
+			}
+		}
+	}
+	visita(doc)
+	return testi, nil
+}
@@ -0,0 +1,199 @@
+package glossario
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+type mockRepository struct {
+	mu    sync.Mutex
+	calls int
+	voci  []Voce
+	err   error
+}
+
+func (m *mockRepository) ListVoci(ctx context.Context) ([]Voce, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.voci, m.err
+}
+
+func (m *mockRepository) set(voci []Voce, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.voci, m.err = voci, err
+}
+
+func (m *mockRepository) numeroChiamate() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+// orologio is a clock the test moves forward while loads may be running.
+type orologio struct {
+	mu  sync.Mutex
+	ora time.Time
+}
+
+func nuovoOrologio(service *Service) *orologio {
+	o := &orologio{ora: time.Now()}
+	service.now = o.now
+	return o
+}
+
+func (o *orologio) now() time.Time {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.ora
+}
+
+func (o *orologio) avanza(d time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.ora = o.ora.Add(d)
+}
+
+// attendiCaricamento waits for the load in progress, if any.
+func attendiCaricamento(service *Service) {
+	service.mu.Lock()
+	caricamento := service.caricamento
+	service.mu.Unlock()
+	if caricamento != nil {
+		<-caricamento
+	}
+}
+
+type tratto struct {
+	Nome        string `json:"nome"`
+	Descrizione string `json:"descrizione"`
+}
+
+type risorsa struct {
+	Descrizione string   `json:"descrizione"`
+	Tratti      []tratto `json:"tratti"`
+}
+
+func TestService_Riferimenti(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nested descrizioni", func(t *testing.T) {
+		service := NewService(&mockRepository{voci: testVoci()}, newTestLogger())
+
+		got := service.Riferimenti(ctx, risorsa{
+			Descrizione: "Un guerriero esperto.",
+			Tratti:      []tratto{{Nome: "Spinta", Descrizione: "Il bersaglio cade Prono."}},
+		})
+
+		if len(got) != 1 || got[0].ID != "prono" {
+			t.Errorf("unexpected riferimenti %+v", got)
+		}
+	})
+
+	t.Run("index is cached and reloaded in the background", func(t *testing.T) {
+		repo := &mockRepository{voci: testVoci()}
+		service := NewService(repo, newTestLogger())
+		clock := nuovoOrologio(service)
+
+		service.Riferimenti(ctx, risorsa{Descrizione: "prono"})
+		service.Riferimenti(ctx, risorsa{Descrizione: "prono"})
+		if calls := repo.numeroChiamate(); calls != 1 {
+			t.Errorf("expected 1 load, got %d", calls)
+		}
+
+		repo.set(nil, nil)
+		clock.avanza(durataCache)
+		if got := service.Riferimenti(ctx, risorsa{Descrizione: "prono"}); len(got) != 1 {
+			t.Errorf("expected the previous index while reloading, got %+v", got)
+		}
+		attendiCaricamento(service)
+		if calls := repo.numeroChiamate(); calls != 2 {
+			t.Errorf("expected reload after expiry, got %d loads", calls)
+		}
+		if got := service.Riferimenti(ctx, risorsa{Descrizione: "prono"}); len(got) != 0 {
+			t.Errorf("expected the reloaded index, got %+v", got)
+		}
+	})
+
+	t.Run("reload failure keeps previous index and backs off", func(t *testing.T) {
+		repo := &mockRepository{voci: testVoci()}
+		service := NewService(repo, newTestLogger())
+		clock := nuovoOrologio(service)
+		service.Riferimenti(ctx, risorsa{Descrizione: "prono"})
+
+		repo.set(nil, errors.New("database error"))
+		clock.avanza(durataCache)
+		service.Riferimenti(ctx, risorsa{Descrizione: "prono"})
+		attendiCaricamento(service)
+		got := service.Riferimenti(ctx, risorsa{Descrizione: "prono"})
+
+		if len(got) != 1 {
+			t.Errorf("expected stale index to be used, got %+v", got)
+		}
+		if calls := repo.numeroChiamate(); calls != 2 {
+			t.Errorf("expected no reload right after a failure, got %d loads", calls)
+		}
+
+		clock.avanza(attesaDopoErrore)
+		service.Riferimenti(ctx, risorsa{Descrizione: "prono"})
+		attendiCaricamento(service)
+		if calls := repo.numeroChiamate(); calls != 3 {
+			t.Errorf("expected a reload after the back-off, got %d loads", calls)
+		}
+	})
+
+	t.Run("load failure yields no riferimenti and backs off", func(t *testing.T) {
+		repo := &mockRepository{err: errors.New("database error")}
+		service := NewService(repo, newTestLogger())
+		nuovoOrologio(service)
+
+		if got := service.Riferimenti(ctx, risorsa{Descrizione: "prono"}); got != nil {
+			t.Errorf("expected nil, got %+v", got)
+		}
+		service.Riferimenti(ctx, risorsa{Descrizione: "prono"})
+		if calls := repo.numeroChiamate(); calls != 1 {
+			t.Errorf("expected no reload right after a failure, got %d loads", calls)
+		}
+	})
+
+	t.Run("load outlives a canceled request", func(t *testing.T) {
+		repo := &mockRepository{voci: testVoci()}
+		service := NewService(repo, newTestLogger())
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		service.Riferimenti(canceled, risorsa{Descrizione: "prono"})
+		attendiCaricamento(service)
+
+		if got := service.Riferimenti(ctx, risorsa{Descrizione: "prono"}); len(got) != 1 {
+			t.Errorf("expected the index loaded for the canceled request, got %+v", got)
+		}
+		if calls := repo.numeroChiamate(); calls != 1 {
+			t.Errorf("expected 1 load, got %d", calls)
+		}
+	})
+
+	t.Run("no descrizione skips the lookup", func(t *testing.T) {
+		repo := &mockRepository{voci: testVoci()}
+		service := NewService(repo, newTestLogger())
+
+		service.Riferimenti(ctx, risorsa{})
+
+		if calls := repo.numeroChiamate(); calls != 0 {
+			t.Errorf("expected no load, got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,8 @@
+package incantesimi
+
+import "context"
+
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Incantesimo, int, error)
+	GetByID(ctx context.Context, id string) (*Incantesimo, error)
+}
@@ -0,0 +1,144 @@
+package incantesimi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type ScuolaDiMagia string
+
+const (
+	Abiurazione   ScuolaDiMagia = "Abiurazione"
+	Divinazione   ScuolaDiMagia = "Divinazione"
+	Evocazione    ScuolaDiMagia = "Evocazione"
+	Invocazione   ScuolaDiMagia = "Invocazione"
+	Necromanzia   ScuolaDiMagia = "Necromanzia"
+	Illusione     ScuolaDiMagia = "Illusione"
+	Trasmutazione ScuolaDiMagia = "Trasmutazione"
+	Incantamento  ScuolaDiMagia = "Incantamento"
+)
+
+var ScuoleDiMagia = []ScuolaDiMagia{
+	Abiurazione, Divinazione, Evocazione, Invocazione,
+	Necromanzia, Illusione, Trasmutazione, Incantamento,
+}
+
+type Componente string
+
+const (
+	Verbale   Componente = "V"
+	Somatica  Componente = "S"
+	Materiale Componente = "M"
+)
+
+// TempoDiLancioRituale is the tempo-di-lancio filter value that selects
+// spells castable as rituals rather than matching the casting time text.
+const TempoDiLancioRituale = "Rituale"
+
+// EffettoIncantesimo describes a spell effect parametrically. On the wire
+// "effetto" is one of a Danno list, a TiroSalvezzaEffetto or a Cura; in Go
+// exactly one of Danni, TiroSalvezza and Cura is set.
+type EffettoIncantesimo struct {
+	RipetizioneEffetto int32
+	Danni              []shared.Danno
+	TiroSalvezza       *shared.TiroSalvezzaEffetto
+	Cura               *shared.Cura
+}
+
+type effettoIncantesimoJSON struct {
+	RipetizioneEffetto int32           `json:"ripetizione-effetto,omitempty"`
+	Effetto            json.RawMessage `json:"effetto,omitempty"`
+}
+
+func (e EffettoIncantesimo) MarshalJSON() ([]byte, error) {
+	var effetto any
+	switch {
+	case e.Danni != nil:
+		effetto = e.Danni
+	case e.TiroSalvezza != nil:
+		effetto = e.TiroSalvezza
+	case e.Cura != nil:
+		effetto = e.Cura
+	}
+
+	out := effettoIncantesimoJSON{RipetizioneEffetto: e.RipetizioneEffetto}
+	if effetto != nil {
+		raw, err := json.Marshal(effetto)
+		if err != nil {
+			return nil, err
+		}
+		out.Effetto = raw
+	}
+	return json.Marshal(out)
+}
+
+func (e *EffettoIncantesimo) UnmarshalJSON(data []byte) error {
+	var in effettoIncantesimoJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*e = EffettoIncantesimo{RipetizioneEffetto: in.RipetizioneEffetto}
+
+	raw := bytes.TrimSpace(in.Effetto)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+
+	switch raw[0] {
+	case '[':
+		return json.Unmarshal(raw, &e.Danni)
+	case '{':
+		var keys map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &keys); err != nil {
+			return err
+		}
+		if _, ok := keys["tiro-salvezza"]; ok {
+			e.TiroSalvezza = &shared.TiroSalvezzaEffetto{}
+			return json.Unmarshal(raw, e.TiroSalvezza)
+		}
+		e.Cura = &shared.Cura{}
+		return json.Unmarshal(raw, e.Cura)
+	default:
+		return errors.New("effetto must be a Danno list or an object")
+	}
+}
+
+type Incantesimo struct {
+	ID                          string               `json:"id" db:"id"`
+	Nome                        string               `json:"nome" db:"nome"`
+	Livello                     int32                `json:"livello" db:"livello"`
+	ScuolaDiMagia               ScuolaDiMagia        `json:"scuola-di-magia" db:"scuola_di_magia"`
+	TempoDiLancio               string               `json:"tempo-di-lancio" db:"tempo_di_lancio"`
+	Gittata                     string               `json:"gittata" db:"gittata"`
+	Area                        string               `json:"area,omitempty" db:"area"`
+	Concentrazione              bool                 `json:"concentrazione" db:"concentrazione"`
+	SemprePreparato             bool                 `json:"sempre-preparato" db:"sempre_preparato"`
+	Rituale                     bool                 `json:"rituale" db:"rituale"`
+	EffettoIncantesimo          *EffettoIncantesimo  `json:"effetto-incantesimo,omitempty"`
+	Componenti                  []Componente         `json:"componenti"`
+	ComponentiMateriali         string               `json:"componenti-materiali,omitempty" db:"componenti_materiali"`
+	Durata                      string               `json:"durata" db:"durata"`
+	Descrizione                 string               `json:"descrizione" db:"descrizione"`
+	EffettoLivelloMaggiore      *EffettoIncantesimo  `json:"effetto-livello-maggiore,omitempty"`
+	Classi                      []string             `json:"classi"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty" db:"-"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento" db:"documentazione_di_riferimento"`
+}
+
+// ListFilter extends the shared list filter with the spell-specific
+// filters. Componenti and Classi use AND semantics.
+type ListFilter struct {
+	shared.ListFilter
+	Livello        *int32
+	ScuolaDiMagia  *ScuolaDiMagia
+	TempoDiLancio  *string
+	Concentrazione *bool
+	Rituale        *bool
+	Componenti     []Componente
+	Classi         []string
+	Durata         *string
+}
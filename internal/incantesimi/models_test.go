@@ -0,0 +1,99 @@
+package incantesimi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func TestEffettoIncantesimo_JSON(t *testing.T) {
+	t.Run("danni round trip", func(t *testing.T) {
+		in := EffettoIncantesimo{
+			RipetizioneEffetto: 3,
+			Danni: []shared.Danno{
+				{TipoDiDanno: []shared.TipoDiDanno{shared.DannoForza}, NumeroDiDadi: 1, TipoDiDado: shared.D4},
+			},
+		}
+
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+
+		var out EffettoIncantesimo
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if out.RipetizioneEffetto != 3 {
+			t.Errorf("expected ripetizione 3, got %d", out.RipetizioneEffetto)
+		}
+		if len(out.Danni) != 1 || out.Danni[0].TipoDiDado != shared.D4 {
+			t.Errorf("unexpected danni: %+v", out.Danni)
+		}
+		if out.TiroSalvezza != nil || out.Cura != nil {
+			t.Error("expected only danni to be set")
+		}
+	})
+
+	t.Run("tiro salvezza decoded from object with tiro-salvezza key", func(t *testing.T) {
+		data := []byte(`{"effetto":{"tiro-salvezza":"Destrezza",
+			"successo":{"descrizione":"metà danni"},
+			"fallimento":{"danni":[{"tipo-di-danno":["Fuoco"],"numero-di-dadi":8,"tipo-di-dado":"d6"}]}}}`)
+
+		var out EffettoIncantesimo
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if out.TiroSalvezza == nil {
+			t.Fatal("expected tiro salvezza to be set")
+		}
+		if out.TiroSalvezza.TiroSalvezza != shared.Destrezza {
+			t.Errorf("expected Destrezza, got %q", out.TiroSalvezza.TiroSalvezza)
+		}
+		if len(out.TiroSalvezza.Fallimento.Danni) != 1 || out.TiroSalvezza.Fallimento.Danni[0].NumeroDiDadi != 8 {
+			t.Errorf("unexpected fallimento: %+v", out.TiroSalvezza.Fallimento)
+		}
+	})
+
+	t.Run("cura decoded from other objects", func(t *testing.T) {
+		data := []byte(`{"effetto":{"numero-di-dadi":2,"tipo-di-dado":"d8","caratteristica-associata":"Saggezza"}}`)
+
+		var out EffettoIncantesimo
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if out.Cura == nil || out.Cura.NumeroDiDadi != 2 {
+			t.Fatalf("expected cura with 2 dice, got %+v", out.Cura)
+		}
+
+		roundTrip, err := json.Marshal(out)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var again EffettoIncantesimo
+		if err := json.Unmarshal(roundTrip, &again); err != nil {
+			t.Fatalf("unmarshal round trip: %v", err)
+		}
+		if again.Cura == nil || again.Cura.TipoDiDado != shared.D8 {
+			t.Errorf("cura lost in round trip: %s", roundTrip)
+		}
+	})
+
+	t.Run("missing effetto", func(t *testing.T) {
+		var out EffettoIncantesimo
+		if err := json.Unmarshal([]byte(`{"ripetizione-effetto":1}`), &out); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if out.Danni != nil || out.TiroSalvezza != nil || out.Cura != nil {
+			t.Errorf("expected empty effetto, got %+v", out)
+		}
+	})
+
+	t.Run("invalid effetto", func(t *testing.T) {
+		var out EffettoIncantesimo
+		if err := json.Unmarshal([]byte(`{"effetto":"fuoco"}`), &out); err == nil {
+			t.Fatal("expected error for scalar effetto")
+		}
+	})
+}
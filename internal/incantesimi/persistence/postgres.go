@@ -0,0 +1,170 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/incantesimi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type incantesimoRow struct {
+	ID                          string                                            `db:"id"`
+	Nome                        string                                            `db:"nome"`
+	Livello                     int32                                             `db:"livello"`
+	ScuolaDiMagia               string                                            `db:"scuola_di_magia"`
+	TempoDiLancio               string                                            `db:"tempo_di_lancio"`
+	Gittata                     sql.NullString                                    `db:"gittata"`
+	Area                        sql.NullString                                    `db:"area"`
+	Concentrazione              bool                                              `db:"concentrazione"`
+	SemprePreparato             bool                                              `db:"sempre_preparato"`
+	Rituale                     bool                                              `db:"rituale"`
+	Componenti                  pq.StringArray                                    `db:"componenti"`
+	ComponentiMateriali         sql.NullString                                    `db:"componenti_materiali"`
+	Durata                      string                                            `db:"durata"`
+	Descrizione                 sql.NullString                                    `db:"descrizione"`
+	EffettoIncantesimo          shared.JSONObject[incantesimi.EffettoIncantesimo] `db:"effetto_incantesimo"`
+	EffettoLivelloMaggiore      shared.JSONObject[incantesimi.EffettoIncantesimo] `db:"effetto_livello_maggiore"`
+	Classi                      pq.StringArray                                    `db:"classi"`
+	DocumentazioneDiRiferimento string                                            `db:"documentazione_di_riferimento"`
+}
+
+func (r *incantesimoRow) toIncantesimo() incantesimi.Incantesimo {
+	i := incantesimi.Incantesimo{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Livello:                     r.Livello,
+		ScuolaDiMagia:               incantesimi.ScuolaDiMagia(r.ScuolaDiMagia),
+		TempoDiLancio:               r.TempoDiLancio,
+		Gittata:                     r.Gittata.String,
+		Area:                        r.Area.String,
+		Concentrazione:              r.Concentrazione,
+		SemprePreparato:             r.SemprePreparato,
+		Rituale:                     r.Rituale,
+		EffettoIncantesimo:          r.EffettoIncantesimo.V,
+		Componenti:                  make([]incantesimi.Componente, len(r.Componenti)),
+		ComponentiMateriali:         r.ComponentiMateriali.String,
+		Durata:                      r.Durata,
+		Descrizione:                 r.Descrizione.String,
+		EffettoLivelloMaggiore:      r.EffettoLivelloMaggiore.V,
+		Classi:                      []string(r.Classi),
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+	for idx, c := range r.Componenti {
+		i.Componenti[idx] = incantesimi.Componente(c)
+	}
+	if i.Classi == nil {
+		i.Classi = []string{}
+	}
+	return i
+}
+
+const selectIncantesimo = `
+	SELECT id, nome, livello, scuola_di_magia, tempo_di_lancio, gittata, area,
+	       concentrazione, sempre_preparato, rituale, componenti, componenti_materiali,
+	       durata, descrizione, effetto_incantesimo, effetto_livello_maggiore, classi,
+	       documentazione_di_riferimento
+	FROM incantesimi`
+
+// filterConditions translates the spell-specific filters into SQL conditions
+// and their named arguments. The shared filters are applied by
+// shared.NewPaginatedQuery.
+func filterConditions(filter incantesimi.ListFilter) (string, map[string]any) {
+	var where string
+	args := make(map[string]any)
+
+	if filter.Livello != nil {
+		where += ` AND livello = :livello`
+		args["livello"] = *filter.Livello
+	}
+	if filter.ScuolaDiMagia != nil {
+		where += ` AND scuola_di_magia = :scuola_di_magia`
+		args["scuola_di_magia"] = string(*filter.ScuolaDiMagia)
+	}
+	if filter.TempoDiLancio != nil {
+		if *filter.TempoDiLancio == incantesimi.TempoDiLancioRituale {
+			where += ` AND rituale = TRUE`
+		} else {
+			where += ` AND tempo_di_lancio ILIKE :tempo_di_lancio`
+			args["tempo_di_lancio"] = "%" + shared.EscapeLike(*filter.TempoDiLancio) + "%"
+		}
+	}
+	if filter.Concentrazione != nil {
+		where += ` AND concentrazione = :concentrazione`
+		args["concentrazione"] = *filter.Concentrazione
+	}
+	if filter.Rituale != nil {
+		where += ` AND rituale = :rituale`
+		args["rituale"] = *filter.Rituale
+	}
+	if len(filter.Componenti) > 0 {
+		componenti := make([]string, len(filter.Componenti))
+		for i, c := range filter.Componenti {
+			componenti[i] = string(c)
+		}
+		where += ` AND componenti @> :componenti`
+		args["componenti"] = pq.Array(componenti)
+	}
+	if len(filter.Classi) > 0 {
+		where += ` AND classi @> :classi`
+		args["classi"] = pq.Array(filter.Classi)
+	}
+	if filter.Durata != nil {
+		where += ` AND durata ILIKE :durata`
+		args["durata"] = "%" + shared.EscapeLike(*filter.Durata) + "%"
+	}
+
+	return where, args
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filter incantesimi.ListFilter) ([]incantesimi.Incantesimo, int, error) {
+	where, args := filterConditions(filter)
+	q := shared.NewPaginatedQuery(
+		selectIncantesimo+` WHERE 1=1`+where,
+		`SELECT COUNT(*) FROM incantesimi WHERE 1=1`+where,
+		args,
+		filter.ListFilter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []incantesimoRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]incantesimi.Incantesimo, len(rows))
+	for i, row := range rows {
+		result[i] = row.toIncantesimo()
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*incantesimi.Incantesimo, error) {
+	var row incantesimoRow
+	if err := r.db.GetContext(ctx, &row, selectIncantesimo+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get incantesimo by id: %w", err)
+	}
+
+	incantesimo := row.toIncantesimo()
+	return &incantesimo, nil
+}
@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/incantesimi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func TestFilterConditions(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		where, args := filterConditions(incantesimi.ListFilter{})
+
+		if where != "" {
+			t.Errorf("expected empty conditions, got %q", where)
+		}
+		if len(args) != 0 {
+			t.Errorf("expected no args, got %v", args)
+		}
+	})
+
+	t.Run("all filters", func(t *testing.T) {
+		livello := int32(3)
+		scuola := incantesimi.Invocazione
+		tempo := "1 azione"
+		concentrazione := true
+		rituale := false
+		durata := "1 minuto"
+		filter := incantesimi.ListFilter{
+			Livello:        &livello,
+			ScuolaDiMagia:  &scuola,
+			TempoDiLancio:  &tempo,
+			Concentrazione: &concentrazione,
+			Rituale:        &rituale,
+			Componenti:     []incantesimi.Componente{incantesimi.Verbale, incantesimi.Materiale},
+			Classi:         []string{"mago", "stregone"},
+			Durata:         &durata,
+		}
+
+		where, args := filterConditions(filter)
+
+		for _, cond := range []string{
+			"livello = :livello",
+			"scuola_di_magia = :scuola_di_magia",
+			"tempo_di_lancio ILIKE :tempo_di_lancio",
+			"concentrazione = :concentrazione",
+			"rituale = :rituale",
+			"componenti @> :componenti",
+			"classi @> :classi",
+			"durata ILIKE :durata",
+		} {
+			if !strings.Contains(where, cond) {
+				t.Errorf("expected conditions to contain %q, got %q", cond, where)
+			}
+		}
+		if args["livello"] != int32(3) {
+			t.Errorf("expected livello arg 3, got %v", args["livello"])
+		}
+		if args["tempo_di_lancio"] != "%1 azione%" {
+			t.Errorf("unexpected tempo_di_lancio arg %v", args["tempo_di_lancio"])
+		}
+		componenti, ok := args["componenti"].(*pq.StringArray)
+		if !ok {
+			t.Fatalf("expected componenti arg to be a string array, got %T", args["componenti"])
+		}
+		if len(*componenti) != 2 || (*componenti)[0] != "V" || (*componenti)[1] != "M" {
+			t.Errorf("unexpected componenti arg %v", *componenti)
+		}
+	})
+
+	t.Run("tempo di lancio rituale selects ritual spells", func(t *testing.T) {
+		tempo := incantesimi.TempoDiLancioRituale
+
+		where, args := filterConditions(incantesimi.ListFilter{TempoDiLancio: &tempo})
+
+		if !strings.Contains(where, "rituale = TRUE") {
+			t.Errorf("expected ritual condition, got %q", where)
+		}
+		if _, ok := args["tempo_di_lancio"]; ok {
+			t.Error("expected no tempo_di_lancio arg for Rituale")
+		}
+	})
+
+	t.Run("durata escapes like patterns", func(t *testing.T) {
+		durata := "100%"
+
+		_, args := filterConditions(incantesimi.ListFilter{Durata: &durata})
+
+		if args["durata"] != `%100\%%` {
+			t.Errorf("expected escaped durata arg, got %v", args["durata"])
+		}
+	})
+}
+
+func TestEffettoIncantesimoColumn_Scan(t *testing.T) {
+	var e shared.JSONObject[incantesimi.EffettoIncantesimo]
+	src := []byte(`{"effetto":[{"tipo-di-danno":["Forza"],"numero-di-dadi":1,"tipo-di-dado":"d4"}]}`)
+	if err := e.Scan(src); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.V == nil || len(e.V.Danni) != 1 {
+		t.Fatalf("expected one danno, got %+v", e.V)
+	}
+}
+
+func TestIncantesimoRow_ToIncantesimo(t *testing.T) {
+	row := incantesimoRow{
+		ID:            "luce",
+		Nome:          "Luce",
+		ScuolaDiMagia: "Invocazione",
+		Componenti:    pq.StringArray{"V", "M"},
+	}
+
+	got := row.toIncantesimo()
+
+	if got.ScuolaDiMagia != incantesimi.Invocazione {
+		t.Errorf("expected Invocazione, got %q", got.ScuolaDiMagia)
+	}
+	if len(got.Componenti) != 2 || got.Componenti[1] != incantesimi.Materiale {
+		t.Errorf("unexpected componenti %v", got.Componenti)
+	}
+	if got.Classi == nil {
+		t.Error("expected empty classi slice, got nil")
+	}
+}
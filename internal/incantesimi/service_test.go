@@ -0,0 +1,139 @@
+package incantesimi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestService_ListIncantesimi(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		var capturedFilter ListFilter
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, filter ListFilter) ([]Incantesimo, int, error) {
+				capturedFilter = filter
+				return []Incantesimo{
+					{ID: "palla-di-fuoco", Nome: "Palla di Fuoco", Livello: 3, ScuolaDiMagia: Invocazione},
+				}, 1, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+		livello := int32(3)
+		filter := ListFilter{
+			ListFilter: shared.ListFilter{Limit: 20, Offset: 0},
+			Livello:    &livello,
+			Componenti: []Componente{Verbale, Somatica},
+		}
+
+		result, err := service.ListIncantesimi(ctx, filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 1 {
+			t.Errorf("expected 1 element, got %d", result.NumeroDiElementi)
+		}
+		if result.Pagina != 1 {
+			t.Errorf("expected page 1, got %d", result.Pagina)
+		}
+		if capturedFilter.Livello == nil || *capturedFilter.Livello != 3 {
+			t.Errorf("expected livello filter to be forwarded, got %v", capturedFilter.Livello)
+		}
+		if len(capturedFilter.Componenti) != 2 {
+			t.Errorf("expected componenti filter to be forwarded, got %v", capturedFilter.Componenti)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Incantesimo, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.ListIncantesimi(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestService_GetIncantesimo(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Incantesimo, error) {
+				if id == "dardo-incantato" {
+					return &Incantesimo{ID: "dardo-incantato", Nome: "Dardo Incantato", Livello: 1}, nil
+				}
+				return nil, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.GetIncantesimo(ctx, "dardo-incantato", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Nome != "Dardo Incantato" {
+			t.Errorf("expected nome 'Dardo Incantato', got '%s'", result.Nome)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := NewService(&MockRepository{}, nil, logger)
+
+		_, err := service.GetIncantesimo(ctx, "nonexistent", nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Incantesimo, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.GetIncantesimo(ctx, "dardo-incantato", nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/incantesimi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type IncantesimiService interface {
+	ListIncantesimi(ctx context.Context, filter incantesimi.ListFilter) (*incantesimi.ListIncantesimiResponse, error)
+	GetIncantesimo(ctx context.Context, id string, campi shared.Campi) (*incantesimi.Incantesimo, error)
+}
+
+type Handler struct {
+	service IncantesimiService
+}
+
+func NewHandler(service IncantesimiService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListIncantesimi)
+	r.Get("/{id-incantesimo}", h.GetIncantesimo)
+
+	return r
+}
+
+func newListFilterFromRequest(r *http.Request) (incantesimi.ListFilter, error) {
+	base, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		return incantesimi.ListFilter{}, err
+	}
+	filter := incantesimi.ListFilter{ListFilter: base}
+	query := r.URL.Query()
+
+	livello, err := shared.QueryInt(query, "livello", 0, 9)
+	if err != nil {
+		return filter, err
+	}
+	if livello != nil {
+		l := int32(*livello)
+		filter.Livello = &l
+	}
+
+	if filter.ScuolaDiMagia, err = shared.QueryEnum(query, "scuola-di-magia", incantesimi.ScuoleDiMagia...); err != nil {
+		return filter, err
+	}
+
+	if filter.TempoDiLancio, err = shared.QueryString(query, "tempo-di-lancio"); err != nil {
+		return filter, err
+	}
+	if filter.Concentrazione, err = shared.QueryBool(query, "concentrazione"); err != nil {
+		return filter, err
+	}
+	if filter.Rituale, err = shared.QueryBool(query, "rituale"); err != nil {
+		return filter, err
+	}
+
+	if filter.Componenti, err = shared.QueryEnumList(query, "componenti",
+		incantesimi.Verbale, incantesimi.Somatica, incantesimi.Materiale); err != nil {
+		return filter, err
+	}
+	if filter.Classi, err = shared.QueryList(query, "classi"); err != nil {
+		return filter, err
+	}
+	if filter.Durata, err = shared.QueryString(query, "durata"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) ListIncantesimi(w http.ResponseWriter, r *http.Request) {
+	filter, err := newListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListIncantesimi(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetIncantesimo(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-incantesimo")
+	if err := shared.ValidateID("id-incantesimo", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	incantesimo, err := h.service.GetIncantesimo(r.Context(), id, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, incantesimo, campi)
+}
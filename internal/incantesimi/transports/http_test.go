@@ -0,0 +1,202 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/incantesimi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	listIncantesimiFunc func(ctx context.Context, filter incantesimi.ListFilter) (*incantesimi.ListIncantesimiResponse, error)
+	getIncantesimoFunc  func(ctx context.Context, id string, campi shared.Campi) (*incantesimi.Incantesimo, error)
+}
+
+func (m *mockService) ListIncantesimi(ctx context.Context, filter incantesimi.ListFilter) (*incantesimi.ListIncantesimiResponse, error) {
+	if m.listIncantesimiFunc != nil {
+		return m.listIncantesimiFunc(ctx, filter)
+	}
+	return &incantesimi.ListIncantesimiResponse{}, nil
+}
+
+func (m *mockService) GetIncantesimo(ctx context.Context, id string, campi shared.Campi) (*incantesimi.Incantesimo, error) {
+	if m.getIncantesimoFunc != nil {
+		return m.getIncantesimoFunc(ctx, id, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc IncantesimiService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/incantesimi", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_ListIncantesimi(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := &mockService{
+			listIncantesimiFunc: func(_ context.Context, _ incantesimi.ListFilter) (*incantesimi.ListIncantesimiResponse, error) {
+				return &incantesimi.ListIncantesimiResponse{
+					PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+					Incantesimi: []incantesimi.Incantesimo{
+						{ID: "palla-di-fuoco", Nome: "Palla di Fuoco", Livello: 3},
+					},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/incantesimi", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+
+		var response incantesimi.ListIncantesimiResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Incantesimi) != 1 {
+			t.Errorf("expected 1 incantesimo, got %d", len(response.Incantesimi))
+		}
+	})
+
+	t.Run("with spell filters", func(t *testing.T) {
+		var captured incantesimi.ListFilter
+		svc := &mockService{
+			listIncantesimiFunc: func(_ context.Context, filter incantesimi.ListFilter) (*incantesimi.ListIncantesimiResponse, error) {
+				captured = filter
+				return &incantesimi.ListIncantesimiResponse{}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/incantesimi?nome=fuoco&livello=3&scuola-di-magia=Invocazione&concentrazione=false"+
+				"&rituale=true&componenti=V&componenti=S,M&classi=mago,stregone&durata=Istantanea"+
+				"&tempo-di-lancio=Rituale&$limit=5", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if captured.Nome == nil || *captured.Nome != "fuoco" {
+			t.Errorf("expected nome 'fuoco', got %v", captured.Nome)
+		}
+		if captured.Limit != 5 {
+			t.Errorf("expected limit 5, got %d", captured.Limit)
+		}
+		if captured.Livello == nil || *captured.Livello != 3 {
+			t.Errorf("expected livello 3, got %v", captured.Livello)
+		}
+		if captured.ScuolaDiMagia == nil || *captured.ScuolaDiMagia != incantesimi.Invocazione {
+			t.Errorf("expected scuola Invocazione, got %v", captured.ScuolaDiMagia)
+		}
+		if captured.Concentrazione == nil || *captured.Concentrazione {
+			t.Errorf("expected concentrazione false, got %v", captured.Concentrazione)
+		}
+		if captured.Rituale == nil || !*captured.Rituale {
+			t.Errorf("expected rituale true, got %v", captured.Rituale)
+		}
+		if len(captured.Componenti) != 3 {
+			t.Errorf("expected 3 componenti, got %v", captured.Componenti)
+		}
+		if len(captured.Classi) != 2 || captured.Classi[1] != "stregone" {
+			t.Errorf("unexpected classi %v", captured.Classi)
+		}
+		if captured.Durata == nil || *captured.Durata != "Istantanea" {
+			t.Errorf("expected durata Istantanea, got %v", captured.Durata)
+		}
+		if captured.TempoDiLancio == nil || *captured.TempoDiLancio != incantesimi.TempoDiLancioRituale {
+			t.Errorf("expected tempo-di-lancio Rituale, got %v", captured.TempoDiLancio)
+		}
+	})
+
+	invalid := []struct {
+		name  string
+		query string
+	}{
+		{"livello out of range", "livello=10"},
+		{"livello not integer", "livello=tre"},
+		{"unknown scuola", "scuola-di-magia=Cronomanzia"},
+		{"invalid concentrazione", "concentrazione=forse"},
+		{"unknown componente", "componenti=V,X"},
+		{"invalid limit", "$limit=abc"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/incantesimi?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_GetIncantesimo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := &mockService{
+			getIncantesimoFunc: func(_ context.Context, id string, _ shared.Campi) (*incantesimi.Incantesimo, error) {
+				return &incantesimi.Incantesimo{ID: id, Nome: "Dardo Incantato", Livello: 1}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/incantesimi/dardo-incantato", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+
+		var response incantesimi.Incantesimo
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.ID != "dardo-incantato" {
+			t.Errorf("expected id 'dardo-incantato', got '%s'", response.ID)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getIncantesimoFunc: func(_ context.Context, id string, _ shared.Campi) (*incantesimi.Incantesimo, error) {
+				return nil, incantesimi.ErrIncantesimoNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/incantesimi/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid id returns 400", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/incantesimi/inv@lid!", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
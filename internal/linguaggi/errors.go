@@ -0,0 +1,9 @@
+package linguaggi
+
+import (
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func ErrLinguaggioNotFound(id string) *shared.AppError {
+	return shared.NewNotFoundError("Linguaggio", id)
+}
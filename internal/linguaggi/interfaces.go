@@ -0,0 +1,8 @@
+package linguaggi
+
+import "context"
+
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Linguaggio, int, error)
+	GetByID(ctx context.Context, id string) (*Linguaggio, error)
+}
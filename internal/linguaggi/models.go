@@ -0,0 +1,29 @@
+package linguaggi
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type TipoLinguaggio string
+
+const (
+	Standard TipoLinguaggio = "Standard"
+	Raro     TipoLinguaggio = "Raro"
+)
+
+var TipiLinguaggio = []TipoLinguaggio{Standard, Raro}
+
+// Linguaggio is a language a character can know. Origine names the
+// creatures or place the language comes from.
+type Linguaggio struct {
+	ID                          string               `json:"id"`
+	Nome                        string               `json:"nome"`
+	Tipo                        TipoLinguaggio       `json:"tipo"`
+	Origine                     string               `json:"origine,omitempty"`
+	Descrizione                 string               `json:"descrizione,omitempty"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento"`
+}
+
+type ListFilter struct {
+	shared.ListFilter
+	Tipo *TipoLinguaggio
+}
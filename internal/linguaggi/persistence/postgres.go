@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/linguaggi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type linguaggioRow struct {
+	ID                          string         `db:"id"`
+	Nome                        string         `db:"nome"`
+	Tipo                        string         `db:"tipo"`
+	Origine                     sql.NullString `db:"origine"`
+	Descrizione                 sql.NullString `db:"descrizione"`
+	DocumentazioneDiRiferimento string         `db:"documentazione_di_riferimento"`
+}
+
+func (r *linguaggioRow) toLinguaggio() linguaggi.Linguaggio {
+	return linguaggi.Linguaggio{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Tipo:                        linguaggi.TipoLinguaggio(r.Tipo),
+		Origine:                     r.Origine.String,
+		Descrizione:                 r.Descrizione.String,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+}
+
+const selectLinguaggio = `
+	SELECT id, nome, tipo, origine, descrizione, documentazione_di_riferimento
+	FROM linguaggi`
+
+// filterConditions translates the language-specific filters into SQL
+// conditions and their named arguments.
+func filterConditions(filter linguaggi.ListFilter) (string, map[string]any) {
+	var where string
+	args := make(map[string]any)
+
+	if filter.Tipo != nil {
+		where += ` AND tipo = :tipo`
+		args["tipo"] = string(*filter.Tipo)
+	}
+
+	return where, args
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filter linguaggi.ListFilter) ([]linguaggi.Linguaggio, int, error) {
+	where, args := filterConditions(filter)
+	q := shared.NewPaginatedQuery(
+		selectLinguaggio+` WHERE 1=1`+where,
+		`SELECT COUNT(*) FROM linguaggi WHERE 1=1`+where,
+		args,
+		filter.ListFilter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []linguaggioRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]linguaggi.Linguaggio, len(rows))
+	for i, row := range rows {
+		result[i] = row.toLinguaggio()
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*linguaggi.Linguaggio, error) {
+	var row linguaggioRow
+	if err := r.db.GetContext(ctx, &row, selectLinguaggio+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get linguaggio by id: %w", err)
+	}
+
+	linguaggio := row.toLinguaggio()
+	return &linguaggio, nil
+}
@@ -0,0 +1,47 @@
+package persistence
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/linguaggi"
+)
+
+func TestFilterConditions(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		where, args := filterConditions(linguaggi.ListFilter{})
+
+		if where != "" || len(args) != 0 {
+			t.Errorf("expected no conditions, got %q %v", where, args)
+		}
+	})
+
+	t.Run("tipo", func(t *testing.T) {
+		tipo := linguaggi.Raro
+
+		where, args := filterConditions(linguaggi.ListFilter{Tipo: &tipo})
+
+		if !strings.Contains(where, "tipo = :tipo") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		if args["tipo"] != "Raro" {
+			t.Errorf("unexpected tipo arg %v", args["tipo"])
+		}
+	})
+}
+
+func TestLinguaggioRow_ToLinguaggio(t *testing.T) {
+	row := linguaggioRow{
+		ID:      "draconico",
+		Nome:    "Draconico",
+		Tipo:    "Raro",
+		Origine: sql.NullString{String: "Draghi", Valid: true},
+	}
+
+	got := row.toLinguaggio()
+
+	if got.Tipo != linguaggi.Raro || got.Origine != "Draghi" || got.Descrizione != "" {
+		t.Errorf("unexpected linguaggio %+v", got)
+	}
+}
@@ -0,0 +1,8 @@
+package linguaggi
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type ListLinguaggiResponse struct {
+	shared.PaginationMeta
+	Linguaggi []Linguaggio `json:"linguaggi"`
+}
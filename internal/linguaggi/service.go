@@ -0,0 +1,54 @@
+package linguaggi
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	repo      Repository
+	glossario shared.Glossario
+	logger    *slog.Logger
+}
+
+func NewService(repo Repository, glossario shared.Glossario, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:      repo,
+		glossario: glossario,
+		logger:    logger,
+	}
+}
+
+func (s *Service) ListLinguaggi(ctx context.Context, filter ListFilter) (*ListLinguaggiResponse, error) {
+	linguaggi, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list linguaggi", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListLinguaggiResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Linguaggi:      linguaggi,
+	}, nil
+}
+
+func (s *Service) GetLinguaggio(ctx context.Context, id string, campi shared.Campi) (*Linguaggio, error) {
+	linguaggio, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get linguaggio", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if linguaggio == nil {
+		return nil, ErrLinguaggioNotFound(id)
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		linguaggio.Riferimenti = s.glossario.Riferimenti(ctx, linguaggio)
+	}
+	return linguaggio, nil
+}
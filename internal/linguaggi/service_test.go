@@ -0,0 +1,100 @@
+package linguaggi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestService_ListLinguaggi(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Linguaggio, int, error) {
+				return []Linguaggio{
+					{ID: "comune", Nome: "Comune", Tipo: Standard},
+					{ID: "draconico", Nome: "Draconico", Tipo: Raro},
+				}, 2, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.ListLinguaggi(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 2 || len(result.Linguaggi) != 2 {
+			t.Errorf("expected 2 linguaggi, got %d/%d", result.NumeroDiElementi, len(result.Linguaggi))
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Linguaggio, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.ListLinguaggi(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestService_GetLinguaggio(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Linguaggio, error) {
+				return &Linguaggio{ID: id, Nome: "Elfico"}, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.GetLinguaggio(ctx, "elfico", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "elfico" {
+			t.Errorf("expected id 'elfico', got '%s'", result.ID)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := NewService(&MockRepository{}, nil, logger)
+
+		_, err := service.GetLinguaggio(ctx, "nonexistent", nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+}
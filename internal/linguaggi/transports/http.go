@@ -0,0 +1,85 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/linguaggi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type LinguaggiService interface {
+	ListLinguaggi(ctx context.Context, filter linguaggi.ListFilter) (*linguaggi.ListLinguaggiResponse, error)
+	GetLinguaggio(ctx context.Context, id string, campi shared.Campi) (*linguaggi.Linguaggio, error)
+}
+
+type Handler struct {
+	service LinguaggiService
+}
+
+func NewHandler(service LinguaggiService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListLinguaggi)
+	r.Get("/{id-linguaggio}", h.GetLinguaggio)
+
+	return r
+}
+
+func newListFilterFromRequest(r *http.Request) (linguaggi.ListFilter, error) {
+	base, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		return linguaggi.ListFilter{}, err
+	}
+	filter := linguaggi.ListFilter{ListFilter: base}
+
+	if filter.Tipo, err = shared.QueryEnum(r.URL.Query(), "tipo", linguaggi.TipiLinguaggio...); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) ListLinguaggi(w http.ResponseWriter, r *http.Request) {
+	filter, err := newListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListLinguaggi(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetLinguaggio(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-linguaggio")
+	if err := shared.ValidateID("id-linguaggio", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	linguaggio, err := h.service.GetLinguaggio(r.Context(), id, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, linguaggio, campi)
+}
@@ -0,0 +1,121 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/linguaggi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	listLinguaggiFunc func(ctx context.Context, filter linguaggi.ListFilter) (*linguaggi.ListLinguaggiResponse, error)
+	getLinguaggioFunc func(ctx context.Context, id string, campi shared.Campi) (*linguaggi.Linguaggio, error)
+}
+
+func (m *mockService) ListLinguaggi(ctx context.Context, filter linguaggi.ListFilter) (*linguaggi.ListLinguaggiResponse, error) {
+	if m.listLinguaggiFunc != nil {
+		return m.listLinguaggiFunc(ctx, filter)
+	}
+	return &linguaggi.ListLinguaggiResponse{}, nil
+}
+
+func (m *mockService) GetLinguaggio(ctx context.Context, id string, campi shared.Campi) (*linguaggi.Linguaggio, error) {
+	if m.getLinguaggioFunc != nil {
+		return m.getLinguaggioFunc(ctx, id, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc LinguaggiService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/linguaggi", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_ListLinguaggi(t *testing.T) {
+	t.Run("with tipo filter", func(t *testing.T) {
+		var captured linguaggi.ListFilter
+		svc := &mockService{
+			listLinguaggiFunc: func(_ context.Context, filter linguaggi.ListFilter) (*linguaggi.ListLinguaggiResponse, error) {
+				captured = filter
+				return &linguaggi.ListLinguaggiResponse{
+					PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+					Linguaggi:      []linguaggi.Linguaggio{{ID: "draconico", Nome: "Draconico", Tipo: linguaggi.Raro}},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/linguaggi?tipo=Raro", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if captured.Tipo == nil || *captured.Tipo != linguaggi.Raro {
+			t.Errorf("expected tipo Raro, got %v", captured.Tipo)
+		}
+
+		var response linguaggi.ListLinguaggiResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Linguaggi) != 1 {
+			t.Errorf("expected 1 linguaggio, got %d", len(response.Linguaggi))
+		}
+	})
+
+	t.Run("unknown tipo returns 400", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/linguaggi?tipo=Esotico", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestHandler_GetLinguaggio(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := &mockService{
+			getLinguaggioFunc: func(_ context.Context, id string, _ shared.Campi) (*linguaggi.Linguaggio, error) {
+				return &linguaggi.Linguaggio{ID: id, Nome: "Elfico"}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/linguaggi/elfico", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getLinguaggioFunc: func(_ context.Context, id string, _ shared.Campi) (*linguaggi.Linguaggio, error) {
+				return nil, linguaggi.ErrLinguaggioNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/linguaggi/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
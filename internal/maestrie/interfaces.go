@@ -0,0 +1,8 @@
+package maestrie
+
+import "context"
+
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Maestria, int, error)
+	GetByID(ctx context.Context, id string) (*Maestria, error)
+}
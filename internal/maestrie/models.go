@@ -0,0 +1,22 @@
+package maestrie
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+// Maestria is a weapon mastery property. Armi lists the ids of the weapons
+// that grant it.
+type Maestria struct {
+	ID                          string               `json:"id"`
+	Nome                        string               `json:"nome"`
+	Descrizione                 string               `json:"descrizione"`
+	Armi                        []string             `json:"armi"`
+	Effetto                     *shared.Effetto      `json:"effetto,omitempty"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento"`
+}
+
+// ListFilter extends the shared list filter with the arma reverse lookup:
+// when set, only the masteries available for that weapon are returned.
+type ListFilter struct {
+	shared.ListFilter
+	Arma *string
+}
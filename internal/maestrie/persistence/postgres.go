@@ -0,0 +1,106 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/maestrie"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type maestriaRow struct {
+	ID                          string                            `db:"id"`
+	Nome                        string                            `db:"nome"`
+	Descrizione                 string                            `db:"descrizione"`
+	Armi                        pq.StringArray                    `db:"armi"`
+	Effetto                     shared.JSONObject[shared.Effetto] `db:"effetto"`
+	DocumentazioneDiRiferimento string                            `db:"documentazione_di_riferimento"`
+}
+
+func (r *maestriaRow) toMaestria() maestrie.Maestria {
+	armi := []string(r.Armi)
+	if armi == nil {
+		armi = []string{}
+	}
+	return maestrie.Maestria{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Descrizione:                 r.Descrizione,
+		Armi:                        armi,
+		Effetto:                     r.Effetto.V,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+}
+
+const selectMaestria = `
+	SELECT id, nome, descrizione, armi, effetto, documentazione_di_riferimento
+	FROM maestrie`
+
+// filterConditions translates the mastery-specific filters into SQL
+// conditions and their named arguments. The shared filters are applied by
+// shared.NewPaginatedQuery.
+func filterConditions(filter maestrie.ListFilter) (string, map[string]any) {
+	var where string
+	args := make(map[string]any)
+
+	if filter.Arma != nil {
+		// Containment can use the GIN index on armi, unlike = ANY(armi).
+		// CAST rather than :: because sqlx reads :: as an escaped colon.
+		where += ` AND armi @> CAST(ARRAY[:arma] AS text[])`
+		args["arma"] = *filter.Arma
+	}
+
+	return where, args
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filter maestrie.ListFilter) ([]maestrie.Maestria, int, error) {
+	where, args := filterConditions(filter)
+	q := shared.NewPaginatedQuery(
+		selectMaestria+` WHERE 1=1`+where,
+		`SELECT COUNT(*) FROM maestrie WHERE 1=1`+where,
+		args,
+		filter.ListFilter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []maestriaRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]maestrie.Maestria, len(rows))
+	for i, row := range rows {
+		result[i] = row.toMaestria()
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*maestrie.Maestria, error) {
+	var row maestriaRow
+	if err := r.db.GetContext(ctx, &row, selectMaestria+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get maestria by id: %w", err)
+	}
+
+	maestria := row.toMaestria()
+	return &maestria, nil
+}
@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/maestrie"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func TestFilterConditions(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		where, args := filterConditions(maestrie.ListFilter{})
+
+		if where != "" || len(args) != 0 {
+			t.Errorf("expected no conditions, got %q %v", where, args)
+		}
+	})
+
+	t.Run("arma reverse lookup", func(t *testing.T) {
+		arma := "spada-lunga"
+
+		where, args := filterConditions(maestrie.ListFilter{Arma: &arma})
+
+		if !strings.Contains(where, "armi @> CAST(ARRAY[:arma] AS text[])") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		if args["arma"] != "spada-lunga" {
+			t.Errorf("unexpected arma arg %v", args["arma"])
+		}
+	})
+}
+
+func TestMaestriaRow_ToMaestria(t *testing.T) {
+	t.Run("full row", func(t *testing.T) {
+		row := maestriaRow{
+			ID:          "fiaccare",
+			Nome:        "Fiaccare",
+			Descrizione: "Il bersaglio ha svantaggio al prossimo tiro per colpire.",
+			Armi:        pq.StringArray{"mazza", "spada-lunga"},
+			Effetto: shared.JSONObject[shared.Effetto]{V: &shared.Effetto{
+				Modificatori: []shared.Modificatore{{Tipo: shared.ModificatoreVantaggioSvantaggio, Dettaglio: "Svantaggio"}},
+			}},
+			DocumentazioneDiRiferimento: "DND 2024",
+		}
+
+		m := row.toMaestria()
+
+		if len(m.Armi) != 2 || m.Armi[1] != "spada-lunga" {
+			t.Errorf("unexpected armi %v", m.Armi)
+		}
+		if m.Effetto == nil || len(m.Effetto.Modificatori) != 1 {
+			t.Errorf("unexpected effetto %+v", m.Effetto)
+		}
+	})
+
+	t.Run("null armi become empty list", func(t *testing.T) {
+		row := maestriaRow{ID: "spingere", Nome: "Spingere"}
+
+		m := row.toMaestria()
+
+		if m.Armi == nil || len(m.Armi) != 0 {
+			t.Errorf("expected empty armi, got %v", m.Armi)
+		}
+		if m.Effetto != nil {
+			t.Errorf("expected nil effetto, got %+v", m.Effetto)
+		}
+	})
+}
@@ -0,0 +1,8 @@
+package maestrie
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type ListMaestrieResponse struct {
+	shared.PaginationMeta
+	Maestrie []Maestria `json:"maestrie"`
+}
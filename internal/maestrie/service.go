@@ -0,0 +1,54 @@
+package maestrie
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	repo      Repository
+	glossario shared.Glossario
+	logger    *slog.Logger
+}
+
+func NewService(repo Repository, glossario shared.Glossario, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:      repo,
+		glossario: glossario,
+		logger:    logger,
+	}
+}
+
+func (s *Service) ListMaestrie(ctx context.Context, filter ListFilter) (*ListMaestrieResponse, error) {
+	maestrie, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list maestrie", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListMaestrieResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Maestrie:       maestrie,
+	}, nil
+}
+
+func (s *Service) GetMaestria(ctx context.Context, id string, campi shared.Campi) (*Maestria, error) {
+	maestria, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get maestria", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if maestria == nil {
+		return nil, ErrMaestriaNotFound(id)
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		maestria.Riferimenti = s.glossario.Riferimenti(ctx, maestria)
+	}
+	return maestria, nil
+}
@@ -0,0 +1,103 @@
+package maestrie
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestService_ListMaestrie(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Maestria, int, error) {
+				return []Maestria{
+					{ID: "fiaccare", Nome: "Fiaccare", Armi: []string{"spada-lunga", "mazza"}},
+					{ID: "rallentare", Nome: "Rallentare", Armi: []string{"arco-lungo"}},
+				}, 2, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.ListMaestrie(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 2 {
+			t.Errorf("expected 2 elements, got %d", result.NumeroDiElementi)
+		}
+		if len(result.Maestrie) != 2 {
+			t.Errorf("expected 2 maestrie, got %d", len(result.Maestrie))
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Maestria, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.ListMaestrie(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestService_GetMaestria(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Maestria, error) {
+				return &Maestria{ID: id, Nome: "Fiaccare"}, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.GetMaestria(ctx, "fiaccare", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "fiaccare" {
+			t.Errorf("expected id 'fiaccare', got '%s'", result.ID)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := NewService(&MockRepository{}, nil, logger)
+
+		_, err := service.GetMaestria(ctx, "nonexistent", nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/maestrie"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type MaestrieService interface {
+	ListMaestrie(ctx context.Context, filter maestrie.ListFilter) (*maestrie.ListMaestrieResponse, error)
+	GetMaestria(ctx context.Context, id string, campi shared.Campi) (*maestrie.Maestria, error)
+}
+
+type Handler struct {
+	service MaestrieService
+}
+
+func NewHandler(service MaestrieService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListMaestrie)
+	r.Get("/{id-maestria}", h.GetMaestria)
+
+	return r
+}
+
+func newListFilterFromRequest(r *http.Request) (maestrie.ListFilter, error) {
+	base, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		return maestrie.ListFilter{}, err
+	}
+	filter := maestrie.ListFilter{ListFilter: base}
+
+	if arma := r.URL.Query().Get("arma"); arma != "" {
+		if err := shared.ValidateID("arma", arma); err != nil {
+			return filter, err
+		}
+		filter.Arma = &arma
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) ListMaestrie(w http.ResponseWriter, r *http.Request) {
+	filter, err := newListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListMaestrie(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetMaestria(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-maestria")
+	if err := shared.ValidateID("id-maestria", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	maestria, err := h.service.GetMaestria(r.Context(), id, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, maestria, campi)
+}
@@ -0,0 +1,121 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/maestrie"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	listMaestrieFunc func(ctx context.Context, filter maestrie.ListFilter) (*maestrie.ListMaestrieResponse, error)
+	getMaestriaFunc  func(ctx context.Context, id string, campi shared.Campi) (*maestrie.Maestria, error)
+}
+
+func (m *mockService) ListMaestrie(ctx context.Context, filter maestrie.ListFilter) (*maestrie.ListMaestrieResponse, error) {
+	if m.listMaestrieFunc != nil {
+		return m.listMaestrieFunc(ctx, filter)
+	}
+	return &maestrie.ListMaestrieResponse{}, nil
+}
+
+func (m *mockService) GetMaestria(ctx context.Context, id string, campi shared.Campi) (*maestrie.Maestria, error) {
+	if m.getMaestriaFunc != nil {
+		return m.getMaestriaFunc(ctx, id, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc MaestrieService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/maestrie", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_ListMaestrie(t *testing.T) {
+	t.Run("with arma filter", func(t *testing.T) {
+		var captured maestrie.ListFilter
+		svc := &mockService{
+			listMaestrieFunc: func(_ context.Context, filter maestrie.ListFilter) (*maestrie.ListMaestrieResponse, error) {
+				captured = filter
+				return &maestrie.ListMaestrieResponse{
+					PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+					Maestrie:       []maestrie.Maestria{{ID: "fiaccare", Nome: "Fiaccare", Armi: []string{"spada-lunga"}}},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/maestrie?arma=spada-lunga", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if captured.Arma == nil || *captured.Arma != "spada-lunga" {
+			t.Errorf("expected arma spada-lunga, got %v", captured.Arma)
+		}
+
+		var response maestrie.ListMaestrieResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Maestrie) != 1 {
+			t.Errorf("expected 1 maestria, got %d", len(response.Maestrie))
+		}
+	})
+
+	t.Run("invalid arma returns 400", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/maestrie?arma=spada%20lunga!", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestHandler_GetMaestria(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := &mockService{
+			getMaestriaFunc: func(_ context.Context, id string, _ shared.Campi) (*maestrie.Maestria, error) {
+				return &maestrie.Maestria{ID: id, Nome: "Fiaccare"}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/maestrie/fiaccare", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getMaestriaFunc: func(_ context.Context, id string, _ shared.Campi) (*maestrie.Maestria, error) {
+				return nil, maestrie.ErrMaestriaNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/maestrie/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
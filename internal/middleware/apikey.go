@@ -7,24 +7,45 @@ import (
 	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
 )
 
-const APIKeyHeader = "X-API-Key"
+const (
+	APIKeyHeader   = "X-API-Key"
+	AdminKeyHeader = "X-Admin-Key"
+)
 
 func APIKey(apiKey string) func(next http.Handler) http.Handler {
+	return requireKey(APIKeyHeader, apiKey, "API key")
+}
+
+// AdminKey protects the write routes. It is checked on top of APIKey, so
+// an admin request carries both headers. Unlike APIKey it fails closed:
+// without a key every request is rejected.
+func AdminKey(adminKey string) func(next http.Handler) http.Handler {
+	if adminKey == "" {
+		return func(http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				shared.WriteJSON(w, http.StatusUnauthorized, shared.UnauthorizedError("admin key is not configured"))
+			})
+		}
+	}
+	return requireKey(AdminKeyHeader, adminKey, "admin key")
+}
+
+func requireKey(header, expected, name string) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if apiKey == "" {
+			if expected == "" {
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			key := r.Header.Get(APIKeyHeader)
+			key := r.Header.Get(header)
 			if key == "" {
-				shared.WriteJSON(w, http.StatusUnauthorized, shared.UnauthorizedError("missing API key"))
+				shared.WriteJSON(w, http.StatusUnauthorized, shared.UnauthorizedError("missing "+name))
 				return
 			}
 
-			if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) != 1 {
-				shared.WriteJSON(w, http.StatusUnauthorized, shared.UnauthorizedError("invalid API key"))
+			if subtle.ConstantTimeCompare([]byte(key), []byte(expected)) != 1 {
+				shared.WriteJSON(w, http.StatusUnauthorized, shared.UnauthorizedError("invalid "+name))
 				return
 			}
 
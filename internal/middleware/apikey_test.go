@@ -106,3 +106,66 @@ func TestAPIKey(t *testing.T) {
 		}
 	})
 }
+
+func TestAdminKey(t *testing.T) {
+	const adminKey = "admin-secret-key"
+
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		header     string
+		value      string
+		wantStatus int
+		wantDetail string
+	}{
+		{"valid key passes through", AdminKeyHeader, adminKey, http.StatusOK, ""},
+		{"missing key returns 401", "", "", http.StatusUnauthorized, "missing admin key"},
+		{"API key header is not enough", APIKeyHeader, adminKey, http.StatusUnauthorized, "missing admin key"},
+		{"invalid key returns 401", AdminKeyHeader, "wrong-key", http.StatusUnauthorized, "invalid admin key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := AdminKey(adminKey)(okHandler)
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantDetail == "" {
+				return
+			}
+			var errResp shared.ErrorObject
+			if err := json.NewDecoder(rec.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if len(errResp.Errors) == 0 || errResp.Errors[0].Detail != tt.wantDetail {
+				t.Errorf("expected detail %q, got %+v", tt.wantDetail, errResp.Errors)
+			}
+		})
+	}
+
+	t.Run("empty key rejects every request", func(t *testing.T) {
+		handler := AdminKey("")(okHandler)
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set(AdminKeyHeader, "")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected status 401, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,8 @@
+package mostri
+
+import "context"
+
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Mostro, int, error)
+	GetByID(ctx context.Context, id string) (*Mostro, error)
+}
@@ -0,0 +1,24 @@
+package mostri
+
+import (
+	"context"
+)
+
+type MockRepository struct {
+	ListFunc    func(ctx context.Context, filter ListFilter) ([]Mostro, int, error)
+	GetByIDFunc func(ctx context.Context, id string) (*Mostro, error)
+}
+
+func (m *MockRepository) List(ctx context.Context, filter ListFilter) ([]Mostro, int, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockRepository) GetByID(ctx context.Context, id string) (*Mostro, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
@@ -0,0 +1,116 @@
+package mostri
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type TipoDiCreatura string
+
+const (
+	Aberrazione TipoDiCreatura = "Aberrazione"
+	Bestia      TipoDiCreatura = "Bestia"
+	Celestiale  TipoDiCreatura = "Celestiale"
+	Costrutto   TipoDiCreatura = "Costrutto"
+	Drago       TipoDiCreatura = "Drago"
+	Elementale  TipoDiCreatura = "Elementale"
+	Folletto    TipoDiCreatura = "Folletto"
+	Gigante     TipoDiCreatura = "Gigante"
+	Immondo     TipoDiCreatura = "Immondo"
+	Melma       TipoDiCreatura = "Melma"
+	Mostruosita TipoDiCreatura = "Mostruosità"
+	NonMorto    TipoDiCreatura = "Non Morto"
+	Umanoide    TipoDiCreatura = "Umanoide"
+	Vegetale    TipoDiCreatura = "Vegetale"
+)
+
+var TipiDiCreatura = []TipoDiCreatura{
+	Aberrazione, Bestia, Celestiale, Costrutto, Drago, Elementale, Folletto,
+	Gigante, Immondo, Melma, Mostruosita, NonMorto, Umanoide, Vegetale,
+}
+
+// MaxGradoDiSfida is the highest challenge rating in the 2024 rules.
+const MaxGradoDiSfida = 30
+
+// ParseGradoDiSfida parses a challenge rating written either as a number
+// ("5", "0.25") or as one of the fractional ratings ("1/8", "1/4", "1/2").
+func ParseGradoDiSfida(s string) (float64, error) {
+	switch strings.TrimSpace(s) {
+	case "1/8":
+		return 0.125, nil
+	case "1/4":
+		return 0.25, nil
+	case "1/2":
+		return 0.5, nil
+	}
+	gs, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid grado di sfida %q", s)
+	}
+	if gs < 0 || gs > MaxGradoDiSfida {
+		return 0, fmt.Errorf("grado di sfida must be between 0 and %d", MaxGradoDiSfida)
+	}
+	return gs, nil
+}
+
+// Caratteristiche are the ability scores of the stat block.
+type Caratteristiche = shared.Punteggi
+
+// Azione is an entry of a stat block: a passive trait, an action, a bonus
+// action, a reaction or a legendary action.
+type Azione struct {
+	ID               string            `json:"id,omitempty"`
+	Nome             string            `json:"nome"`
+	Descrizione      string            `json:"descrizione,omitempty"`
+	TipoDiAzione     shared.TipoAzione `json:"tipo-di-azione,omitempty"`
+	NumeroDiUtilizzi int32             `json:"numero-di-utilizzi,omitempty"`
+	Ricarica         string            `json:"ricarica,omitempty"`
+	Attacchi         []shared.Attacco  `json:"attacchi,omitempty"`
+}
+
+type Mostro struct {
+	ID                          string                  `json:"id" db:"id"`
+	Nome                        string                  `json:"nome" db:"nome"`
+	Descrizione                 string                  `json:"descrizione,omitempty" db:"descrizione"`
+	Taglia                      shared.Taglia           `json:"taglia" db:"taglia"`
+	TipoDiCreatura              TipoDiCreatura          `json:"tipo-di-creatura" db:"tipo_di_creatura"`
+	Allineamento                string                  `json:"allineamento,omitempty" db:"allineamento"`
+	ClasseArmatura              int32                   `json:"classe-armatura" db:"classe_armatura"`
+	PuntiFerita                 int32                   `json:"punti-ferita" db:"punti_ferita"`
+	DadiPuntiFerita             string                  `json:"dadi-punti-ferita,omitempty" db:"dadi_punti_ferita"`
+	Velocita                    []shared.Velocita       `json:"velocità,omitempty"`
+	Caratteristiche             Caratteristiche         `json:"caratteristiche"`
+	TiriSalvezza                []shared.Caratteristica `json:"tiri-salvezza,omitempty"`
+	Abilita                     []shared.Abilita        `json:"abilità,omitempty"`
+	Sensi                       []shared.Senso          `json:"sensi,omitempty"`
+	Linguaggi                   []string                `json:"linguaggi,omitempty"`
+	Resistenze                  []shared.Difesa         `json:"resistenze,omitempty"`
+	Immunita                    []shared.Difesa         `json:"immunità,omitempty"`
+	Vulnerabilita               []shared.Difesa         `json:"vulnerabilità,omitempty"`
+	GradoDiSfida                float64                 `json:"grado-di-sfida" db:"grado_di_sfida"`
+	PuntiEsperienza             int32                   `json:"punti-esperienza" db:"punti_esperienza"`
+	BonusCompetenza             int32                   `json:"bonus-competenza" db:"bonus_competenza"`
+	Tratti                      []Azione                `json:"tratti,omitempty"`
+	Azioni                      []Azione                `json:"azioni,omitempty"`
+	AzioniBonus                 []Azione                `json:"azioni-bonus,omitempty"`
+	Reazioni                    []Azione                `json:"reazioni,omitempty"`
+	AzioniLeggendarie           []Azione                `json:"azioni-leggendarie,omitempty"`
+	Riferimenti                 []shared.Riferimento    `json:"riferimenti,omitempty" db:"-"`
+	DocumentazioneDiRiferimento string                  `json:"documentazione-di-riferimento" db:"documentazione_di_riferimento"`
+}
+
+// ListFilter extends the shared list filter with the monster-specific
+// filters. TipoDiCreatura and Taglia match any of the given values, while
+// ImmunitaDanno requires immunity to every listed damage type.
+type ListFilter struct {
+	shared.ListFilter
+	GradoDiSfida    *float64
+	GradoDiSfidaMin *float64
+	GradoDiSfidaMax *float64
+	TipoDiCreatura  []TipoDiCreatura
+	Taglia          []shared.Taglia
+	ImmunitaDanno   []shared.TipoDiDanno
+}
@@ -0,0 +1,35 @@
+package mostri
+
+import "testing"
+
+func TestParseGradoDiSfida(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"1/8", 0.125, false},
+		{"1/4", 0.25, false},
+		{"1/2", 0.5, false},
+		{"5", 5, false},
+		{"0.25", 0.25, false},
+		{"30", 30, false},
+		{"31", 0, true},
+		{"-1", 0, true},
+		{"1/3", 0, true},
+		{"drago", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseGradoDiSfida(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGradoDiSfida(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseGradoDiSfida(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
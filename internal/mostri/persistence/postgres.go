@@ -0,0 +1,197 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/mostri"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type caratteristicheJSON mostri.Caratteristiche
+
+func (c *caratteristicheJSON) Scan(src any) error          { return shared.ScanJSON(src, c) }
+func (c caratteristicheJSON) Value() (driver.Value, error) { return json.Marshal(c) }
+
+type mostroRow struct {
+	ID                          string                            `db:"id"`
+	Nome                        string                            `db:"nome"`
+	Descrizione                 sql.NullString                    `db:"descrizione"`
+	Taglia                      string                            `db:"taglia"`
+	TipoDiCreatura              string                            `db:"tipo_di_creatura"`
+	Allineamento                sql.NullString                    `db:"allineamento"`
+	ClasseArmatura              int32                             `db:"classe_armatura"`
+	PuntiFerita                 int32                             `db:"punti_ferita"`
+	DadiPuntiFerita             sql.NullString                    `db:"dadi_punti_ferita"`
+	Velocita                    shared.JSONSlice[shared.Velocita] `db:"velocita"`
+	Caratteristiche             caratteristicheJSON               `db:"caratteristiche"`
+	TiriSalvezza                pq.StringArray                    `db:"tiri_salvezza"`
+	Abilita                     shared.JSONSlice[shared.Abilita]  `db:"abilita"`
+	Sensi                       shared.JSONSlice[shared.Senso]    `db:"sensi"`
+	Linguaggi                   pq.StringArray                    `db:"linguaggi"`
+	Resistenze                  shared.JSONSlice[shared.Difesa]   `db:"resistenze"`
+	Immunita                    shared.JSONSlice[shared.Difesa]   `db:"immunita"`
+	Vulnerabilita               shared.JSONSlice[shared.Difesa]   `db:"vulnerabilita"`
+	GradoDiSfida                float64                           `db:"grado_di_sfida"`
+	PuntiEsperienza             sql.NullInt32                     `db:"punti_esperienza"`
+	BonusCompetenza             sql.NullInt32                     `db:"bonus_competenza"`
+	Tratti                      shared.JSONSlice[mostri.Azione]   `db:"tratti"`
+	Azioni                      shared.JSONSlice[mostri.Azione]   `db:"azioni"`
+	AzioniBonus                 shared.JSONSlice[mostri.Azione]   `db:"azioni_bonus"`
+	Reazioni                    shared.JSONSlice[mostri.Azione]   `db:"reazioni"`
+	AzioniLeggendarie           shared.JSONSlice[mostri.Azione]   `db:"azioni_leggendarie"`
+	DocumentazioneDiRiferimento string                            `db:"documentazione_di_riferimento"`
+}
+
+func (r *mostroRow) toMostro() mostri.Mostro {
+	m := mostri.Mostro{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Descrizione:                 r.Descrizione.String,
+		Taglia:                      shared.Taglia(r.Taglia),
+		TipoDiCreatura:              mostri.TipoDiCreatura(r.TipoDiCreatura),
+		Allineamento:                r.Allineamento.String,
+		ClasseArmatura:              r.ClasseArmatura,
+		PuntiFerita:                 r.PuntiFerita,
+		DadiPuntiFerita:             r.DadiPuntiFerita.String,
+		Velocita:                    r.Velocita,
+		Caratteristiche:             mostri.Caratteristiche(r.Caratteristiche),
+		Abilita:                     r.Abilita,
+		Sensi:                       r.Sensi,
+		Linguaggi:                   r.Linguaggi,
+		Resistenze:                  r.Resistenze,
+		Immunita:                    r.Immunita,
+		Vulnerabilita:               r.Vulnerabilita,
+		GradoDiSfida:                r.GradoDiSfida,
+		PuntiEsperienza:             r.PuntiEsperienza.Int32,
+		BonusCompetenza:             r.BonusCompetenza.Int32,
+		Tratti:                      r.Tratti,
+		Azioni:                      r.Azioni,
+		AzioniBonus:                 r.AzioniBonus,
+		Reazioni:                    r.Reazioni,
+		AzioniLeggendarie:           r.AzioniLeggendarie,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+	for _, ts := range r.TiriSalvezza {
+		m.TiriSalvezza = append(m.TiriSalvezza, shared.Caratteristica(ts))
+	}
+	return m
+}
+
+const selectMostro = `
+	SELECT id, nome, descrizione, taglia, tipo_di_creatura, allineamento, classe_armatura,
+	       punti_ferita, dadi_punti_ferita, velocita, caratteristiche, tiri_salvezza, abilita,
+	       sensi, linguaggi, resistenze, immunita, vulnerabilita, grado_di_sfida,
+	       punti_esperienza, bonus_competenza, tratti, azioni, azioni_bonus, reazioni,
+	       azioni_leggendarie, documentazione_di_riferimento
+	FROM mostri`
+
+// filterConditions translates the monster-specific filters into SQL
+// conditions and their named arguments. The shared filters are applied by
+// shared.NewPaginatedQuery.
+func filterConditions(filter mostri.ListFilter) (string, map[string]any, error) {
+	var where string
+	args := make(map[string]any)
+
+	if filter.GradoDiSfida != nil {
+		where += ` AND grado_di_sfida = :grado_di_sfida`
+		args["grado_di_sfida"] = *filter.GradoDiSfida
+	}
+	if filter.GradoDiSfidaMin != nil {
+		where += ` AND grado_di_sfida >= :grado_di_sfida_min`
+		args["grado_di_sfida_min"] = *filter.GradoDiSfidaMin
+	}
+	if filter.GradoDiSfidaMax != nil {
+		where += ` AND grado_di_sfida <= :grado_di_sfida_max`
+		args["grado_di_sfida_max"] = *filter.GradoDiSfidaMax
+	}
+	if len(filter.TipoDiCreatura) > 0 {
+		tipi := make([]string, len(filter.TipoDiCreatura))
+		for i, t := range filter.TipoDiCreatura {
+			tipi[i] = string(t)
+		}
+		where += ` AND tipo_di_creatura = ANY(:tipo_di_creatura)`
+		args["tipo_di_creatura"] = pq.Array(tipi)
+	}
+	if len(filter.Taglia) > 0 {
+		taglie := make([]string, len(filter.Taglia))
+		for i, t := range filter.Taglia {
+			taglie[i] = string(t)
+		}
+		where += ` AND taglia = ANY(:taglia)`
+		args["taglia"] = pq.Array(taglie)
+	}
+	if len(filter.ImmunitaDanno) > 0 {
+		// Each damage type must appear in some immunità entry: JSONB
+		// containment of one single-type entry per requested type.
+		entries := make([]shared.Difesa, len(filter.ImmunitaDanno))
+		for i, d := range filter.ImmunitaDanno {
+			entries[i] = shared.Difesa{TipoDiDanno: []shared.TipoDiDanno{d}}
+		}
+		immunita, err := json.Marshal(entries)
+		if err != nil {
+			return "", nil, fmt.Errorf("marshal immunita filter: %w", err)
+		}
+		where += ` AND immunita @> CAST(:immunita AS jsonb)`
+		args["immunita"] = string(immunita)
+	}
+
+	return where, args, nil
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filter mostri.ListFilter) ([]mostri.Mostro, int, error) {
+	where, args, err := filterConditions(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	q := shared.NewPaginatedQuery(
+		selectMostro+` WHERE 1=1`+where,
+		`SELECT COUNT(*) FROM mostri WHERE 1=1`+where,
+		args,
+		filter.ListFilter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []mostroRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]mostri.Mostro, len(rows))
+	for i, row := range rows {
+		result[i] = row.toMostro()
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*mostri.Mostro, error) {
+	var row mostroRow
+	if err := r.db.GetContext(ctx, &row, selectMostro+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get mostro by id: %w", err)
+	}
+
+	mostro := row.toMostro()
+	return &mostro, nil
+}
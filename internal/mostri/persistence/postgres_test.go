@@ -0,0 +1,116 @@
+package persistence
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/mostri"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func TestFilterConditions(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		where, args, err := filterConditions(mostri.ListFilter{})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if where != "" || len(args) != 0 {
+			t.Errorf("expected no conditions, got %q %v", where, args)
+		}
+	})
+
+	t.Run("grado di sfida range", func(t *testing.T) {
+		minGS, maxGS := 0.25, 5.0
+
+		where, args, err := filterConditions(mostri.ListFilter{GradoDiSfidaMin: &minGS, GradoDiSfidaMax: &maxGS})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(where, "grado_di_sfida >= :grado_di_sfida_min") ||
+			!strings.Contains(where, "grado_di_sfida <= :grado_di_sfida_max") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		if args["grado_di_sfida_min"] != 0.25 || args["grado_di_sfida_max"] != 5.0 {
+			t.Errorf("unexpected args %v", args)
+		}
+	})
+
+	t.Run("tipo and taglia match any value", func(t *testing.T) {
+		filter := mostri.ListFilter{
+			TipoDiCreatura: []mostri.TipoDiCreatura{mostri.Drago, mostri.NonMorto},
+			Taglia:         []shared.Taglia{shared.Enorme},
+		}
+
+		where, args, err := filterConditions(filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(where, "tipo_di_creatura = ANY(:tipo_di_creatura)") ||
+			!strings.Contains(where, "taglia = ANY(:taglia)") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		tipi := args["tipo_di_creatura"].(*pq.StringArray)
+		if len(*tipi) != 2 || (*tipi)[1] != "Non Morto" {
+			t.Errorf("unexpected tipo arg %v", *tipi)
+		}
+	})
+
+	t.Run("immunita requires every damage type", func(t *testing.T) {
+		filter := mostri.ListFilter{
+			ImmunitaDanno: []shared.TipoDiDanno{shared.Fuoco, shared.Veleno},
+		}
+
+		where, args, err := filterConditions(filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(where, "immunita @> CAST(:immunita AS jsonb)") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+
+		var entries []shared.Difesa
+		if err := json.Unmarshal([]byte(args["immunita"].(string)), &entries); err != nil {
+			t.Fatalf("immunita arg is not valid JSON: %v", err)
+		}
+		if len(entries) != 2 || entries[0].TipoDiDanno[0] != shared.Fuoco || entries[1].TipoDiDanno[0] != shared.Veleno {
+			t.Errorf("unexpected immunita entries %+v", entries)
+		}
+	})
+}
+
+func TestMostroRow_ToMostro(t *testing.T) {
+	var row mostroRow
+	row.ID = "drago-rosso-adulto"
+	row.Taglia = "Enorme"
+	row.TipoDiCreatura = "Drago"
+	row.GradoDiSfida = 17
+	row.TiriSalvezza = pq.StringArray{"Destrezza", "Saggezza"}
+	if err := row.Immunita.Scan([]byte(`[{"tipo-di-danno":["Fuoco"]}]`)); err != nil {
+		t.Fatalf("scan immunita: %v", err)
+	}
+	if err := row.Caratteristiche.Scan([]byte(`{"forza":27,"carisma":23}`)); err != nil {
+		t.Fatalf("scan caratteristiche: %v", err)
+	}
+
+	got := row.toMostro()
+
+	if got.Taglia != shared.Enorme || got.TipoDiCreatura != mostri.Drago {
+		t.Errorf("unexpected taglia/tipo %q/%q", got.Taglia, got.TipoDiCreatura)
+	}
+	if len(got.Immunita) != 1 || got.Immunita[0].TipoDiDanno[0] != shared.Fuoco {
+		t.Errorf("unexpected immunita %+v", got.Immunita)
+	}
+	if got.Caratteristiche.Forza != 27 || got.Caratteristiche.Carisma != 23 {
+		t.Errorf("unexpected caratteristiche %+v", got.Caratteristiche)
+	}
+	if len(got.TiriSalvezza) != 2 || got.TiriSalvezza[1] != shared.Saggezza {
+		t.Errorf("unexpected tiri salvezza %v", got.TiriSalvezza)
+	}
+}
@@ -0,0 +1,8 @@
+package mostri
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type ListMostriResponse struct {
+	shared.PaginationMeta
+	Mostri []Mostro `json:"mostri"`
+}
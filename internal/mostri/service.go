@@ -0,0 +1,54 @@
+package mostri
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	repo      Repository
+	glossario shared.Glossario
+	logger    *slog.Logger
+}
+
+func NewService(repo Repository, glossario shared.Glossario, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:      repo,
+		glossario: glossario,
+		logger:    logger,
+	}
+}
+
+func (s *Service) ListMostri(ctx context.Context, filter ListFilter) (*ListMostriResponse, error) {
+	mostri, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list mostri", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListMostriResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Mostri:         mostri,
+	}, nil
+}
+
+func (s *Service) GetMostro(ctx context.Context, id string, campi shared.Campi) (*Mostro, error) {
+	mostro, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get mostro", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if mostro == nil {
+		return nil, ErrMostroNotFound(id)
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		mostro.Riferimenti = s.glossario.Riferimenti(ctx, mostro)
+	}
+	return mostro, nil
+}
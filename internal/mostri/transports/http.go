@@ -0,0 +1,120 @@
+package transports
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/mostri"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type MostriService interface {
+	ListMostri(ctx context.Context, filter mostri.ListFilter) (*mostri.ListMostriResponse, error)
+	GetMostro(ctx context.Context, id string, campi shared.Campi) (*mostri.Mostro, error)
+}
+
+type Handler struct {
+	service MostriService
+}
+
+func NewHandler(service MostriService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListMostri)
+	r.Get("/{id-mostro}", h.GetMostro)
+
+	return r
+}
+
+func queryGradoDiSfida(query url.Values, name string) (*float64, error) {
+	value, err := shared.QueryString(query, name)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	gs, err := mostri.ParseGradoDiSfida(*value)
+	if err != nil {
+		return nil, err
+	}
+	return &gs, nil
+}
+
+func newListFilterFromRequest(r *http.Request) (mostri.ListFilter, error) {
+	base, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		return mostri.ListFilter{}, err
+	}
+	filter := mostri.ListFilter{ListFilter: base}
+	query := r.URL.Query()
+
+	if filter.GradoDiSfida, err = queryGradoDiSfida(query, "grado-di-sfida"); err != nil {
+		return filter, err
+	}
+	if filter.GradoDiSfidaMin, err = queryGradoDiSfida(query, "grado-di-sfida-min"); err != nil {
+		return filter, err
+	}
+	if filter.GradoDiSfidaMax, err = queryGradoDiSfida(query, "grado-di-sfida-max"); err != nil {
+		return filter, err
+	}
+
+	if filter.GradoDiSfidaMin != nil && filter.GradoDiSfidaMax != nil && *filter.GradoDiSfidaMin > *filter.GradoDiSfidaMax {
+		return filter, fmt.Errorf("grado-di-sfida-min cannot exceed grado-di-sfida-max")
+	}
+
+	if filter.TipoDiCreatura, err = shared.QueryEnumList(query, "tipo-di-creatura", mostri.TipiDiCreatura...); err != nil {
+		return filter, err
+	}
+	if filter.Taglia, err = shared.QueryEnumList(query, "taglia", shared.Taglie...); err != nil {
+		return filter, err
+	}
+	if filter.ImmunitaDanno, err = shared.QueryEnumList(query, "immunita-danno", shared.TipiDiDanno...); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) ListMostri(w http.ResponseWriter, r *http.Request) {
+	filter, err := newListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListMostri(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetMostro(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-mostro")
+	if err := shared.ValidateID("id-mostro", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	mostro, err := h.service.GetMostro(r.Context(), id, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, mostro, campi)
+}
@@ -0,0 +1,199 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/mostri"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	listMostriFunc func(ctx context.Context, filter mostri.ListFilter) (*mostri.ListMostriResponse, error)
+	getMostroFunc  func(ctx context.Context, id string, campi shared.Campi) (*mostri.Mostro, error)
+}
+
+func (m *mockService) ListMostri(ctx context.Context, filter mostri.ListFilter) (*mostri.ListMostriResponse, error) {
+	if m.listMostriFunc != nil {
+		return m.listMostriFunc(ctx, filter)
+	}
+	return &mostri.ListMostriResponse{}, nil
+}
+
+func (m *mockService) GetMostro(ctx context.Context, id string, campi shared.Campi) (*mostri.Mostro, error) {
+	if m.getMostroFunc != nil {
+		return m.getMostroFunc(ctx, id, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc MostriService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/mostri", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_ListMostri(t *testing.T) {
+	t.Run("with monster filters", func(t *testing.T) {
+		var captured mostri.ListFilter
+		svc := &mockService{
+			listMostriFunc: func(_ context.Context, filter mostri.ListFilter) (*mostri.ListMostriResponse, error) {
+				captured = filter
+				return &mostri.ListMostriResponse{
+					PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+					Mostri:         []mostri.Mostro{{ID: "goblin", Nome: "Goblin", GradoDiSfida: 0.25}},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/mostri?grado-di-sfida-min=1/4&grado-di-sfida-max=5&tipo-di-creatura=Umanoide,Drago"+
+				"&taglia=Piccola&immunita-danno=Fuoco&immunita-danno=Veleno", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if captured.GradoDiSfidaMin == nil || *captured.GradoDiSfidaMin != 0.25 {
+			t.Errorf("expected grado-di-sfida-min 0.25, got %v", captured.GradoDiSfidaMin)
+		}
+		if captured.GradoDiSfidaMax == nil || *captured.GradoDiSfidaMax != 5 {
+			t.Errorf("expected grado-di-sfida-max 5, got %v", captured.GradoDiSfidaMax)
+		}
+		if len(captured.TipoDiCreatura) != 2 || captured.TipoDiCreatura[1] != mostri.Drago {
+			t.Errorf("unexpected tipo-di-creatura %v", captured.TipoDiCreatura)
+		}
+		if len(captured.Taglia) != 1 || captured.Taglia[0] != shared.Piccola {
+			t.Errorf("unexpected taglia %v", captured.Taglia)
+		}
+		if len(captured.ImmunitaDanno) != 2 {
+			t.Errorf("unexpected immunita-danno %v", captured.ImmunitaDanno)
+		}
+
+		var response mostri.ListMostriResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Mostri) != 1 {
+			t.Errorf("expected 1 mostro, got %d", len(response.Mostri))
+		}
+	})
+
+	invalid := []struct {
+		name  string
+		query string
+	}{
+		{"invalid grado di sfida", "grado-di-sfida=tanto"},
+		{"min greater than max", "grado-di-sfida-min=5&grado-di-sfida-max=1"},
+		{"unknown tipo", "tipo-di-creatura=Robot"},
+		{"unknown taglia", "taglia=Minuta"},
+		{"unknown damage type", "immunita-danno=Sonoro"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/mostri?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_GetMostro(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := &mockService{
+			getMostroFunc: func(_ context.Context, id string, _ shared.Campi) (*mostri.Mostro, error) {
+				return &mostri.Mostro{ID: id, Nome: "Goblin"}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/mostri/goblin", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("with riferimenti", func(t *testing.T) {
+		svc := &mockService{
+			getMostroFunc: func(_ context.Context, id string, _ shared.Campi) (*mostri.Mostro, error) {
+				return &mostri.Mostro{ID: id, Nome: "Goblin", Riferimenti: []shared.Riferimento{
+					{Tipo: shared.RiferimentoCondizione, ID: "prono", Nome: "Prono", Link: "/v1/condizioni/prono"},
+				}}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/mostri/goblin", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		var got mostri.Mostro
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(got.Riferimenti) != 1 || got.Riferimenti[0].Link != "/v1/condizioni/prono" {
+			t.Errorf("unexpected riferimenti %+v", got.Riferimenti)
+		}
+	})
+
+	t.Run("campi with riferimenti", func(t *testing.T) {
+		var captured shared.Campi
+		svc := &mockService{
+			getMostroFunc: func(_ context.Context, id string, campi shared.Campi) (*mostri.Mostro, error) {
+				captured = campi
+				return &mostri.Mostro{ID: id, Nome: "Goblin", GradoDiSfida: 0.25, Riferimenti: []shared.Riferimento{
+					{Tipo: shared.RiferimentoCondizione, ID: "prono", Nome: "Prono", Link: "/v1/condizioni/prono"},
+				}}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/mostri/goblin?campi=nome,riferimenti", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if !captured.Contiene("riferimenti") || captured.Contiene("grado-di-sfida") {
+			t.Errorf("expected the campi to reach the service, got %v", captured)
+		}
+
+		var got map[string]any
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(got) != 2 || got["nome"] != "Goblin" || got["riferimenti"] == nil {
+			t.Errorf("unexpected response %v", got)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getMostroFunc: func(_ context.Context, id string, _ shared.Campi) (*mostri.Mostro, error) {
+				return nil, mostri.ErrMostroNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/mostri/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
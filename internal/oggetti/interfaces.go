@@ -0,0 +1,11 @@
+package oggetti
+
+import "context"
+
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Oggetto, int, error)
+	GetByID(ctx context.Context, id string) (*Oggetto, error)
+	// GetByIDs returns the items with the given ids in no particular
+	// order; ids without an item are skipped.
+	GetByIDs(ctx context.Context, ids []string) ([]Oggetto, error)
+}
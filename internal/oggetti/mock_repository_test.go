@@ -0,0 +1,32 @@
+package oggetti
+
+import (
+	"context"
+)
+
+type MockRepository struct {
+	ListFunc     func(ctx context.Context, filter ListFilter) ([]Oggetto, int, error)
+	GetByIDFunc  func(ctx context.Context, id string) (*Oggetto, error)
+	GetByIDsFunc func(ctx context.Context, ids []string) ([]Oggetto, error)
+}
+
+func (m *MockRepository) List(ctx context.Context, filter ListFilter) ([]Oggetto, int, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockRepository) GetByID(ctx context.Context, id string) (*Oggetto, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) GetByIDs(ctx context.Context, ids []string) ([]Oggetto, error) {
+	if m.GetByIDsFunc != nil {
+		return m.GetByIDsFunc(ctx, ids)
+	}
+	return nil, nil
+}
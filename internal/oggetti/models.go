@@ -0,0 +1,256 @@
+package oggetti
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type TipoOggetto string
+
+const (
+	TipoArma                     TipoOggetto = "Arma"
+	TipoArmatura                 TipoOggetto = "Armatura"
+	TipoUtensile                 TipoOggetto = "Utensile"
+	TipoOggettoMagico            TipoOggetto = "Oggetto Magico"
+	TipoAttrezzaturaAvventuriero TipoOggetto = "Attrezzatura da Avventuriero"
+	TipoCiboBevandeAlloggio      TipoOggetto = "Cibo, Bevanda o Alloggio"
+)
+
+var TipiOggetto = []TipoOggetto{
+	TipoArma, TipoArmatura, TipoUtensile, TipoOggettoMagico,
+	TipoAttrezzaturaAvventuriero, TipoCiboBevandeAlloggio,
+}
+
+type CategoriaArma string
+
+const (
+	Semplice CategoriaArma = "Semplice"
+	Marziale CategoriaArma = "Marziale"
+)
+
+var CategorieArma = []CategoriaArma{Semplice, Marziale}
+
+type CategoriaArmatura string
+
+const (
+	Leggera CategoriaArmatura = "Leggera"
+	Media   CategoriaArmatura = "Media"
+	Pesante CategoriaArmatura = "Pesante"
+	Scudo   CategoriaArmatura = "Scudo"
+)
+
+var CategorieArmatura = []CategoriaArmatura{Leggera, Media, Pesante, Scudo}
+
+type TipoArmaAttacco string
+
+const (
+	ArmaMelee    TipoArmaAttacco = "Melee"
+	ArmaDistanza TipoArmaAttacco = "Distanza"
+)
+
+// Peso is fractional because several items weigh less than a unit.
+type Peso struct {
+	Valore        float64 `json:"valore"`
+	UnitaDiMisura string  `json:"unità-di-misura,omitempty"`
+}
+
+type ProprietaArma struct {
+	Nome        string          `json:"nome"`
+	Descrizione string          `json:"descrizione,omitempty"`
+	Effetto     *shared.Effetto `json:"effetto,omitempty"`
+}
+
+type Arma struct {
+	Categoria    CategoriaArma   `json:"categoria"`
+	Tipo         TipoArmaAttacco `json:"tipo"`
+	ManiOccupate int32           `json:"mani-occupate,omitempty"`
+	Danno        []shared.Danno  `json:"danno,omitempty"`
+	Proprieta    []ProprietaArma `json:"proprietà,omitempty"`
+	Competenza   bool            `json:"competenza,omitempty"`
+	IDMaestria   string          `json:"id-maestria,omitempty"`
+}
+
+type ClasseArmatura struct {
+	Valore               int32                 `json:"valore"`
+	BonusCaratteristica  shared.Caratteristica `json:"bonus-caratteristica,omitempty"`
+	ValoreForzaRichiesto int32                 `json:"valore-forza-richiesto,omitempty"`
+	SvantaggioFurtivita  bool                  `json:"svantaggio-furtività,omitempty"`
+}
+
+type Armatura struct {
+	Categoria      CategoriaArmatura `json:"categoria"`
+	ManiOccupate   int32             `json:"mani-occupate,omitempty"`
+	ClasseArmatura ClasseArmatura    `json:"classe-armatura"`
+	Competenza     bool              `json:"competenza,omitempty"`
+}
+
+type UtilizzoUtensile struct {
+	Descrizione      string `json:"descrizione"`
+	ClasseDifficolta int32  `json:"classe-difficoltà,omitempty"`
+}
+
+type Utensile struct {
+	Competenza              bool                  `json:"competenza,omitempty"`
+	CaratteristicaAssociata shared.Caratteristica `json:"caratteristica-associata,omitempty"`
+	Utilizzo                []UtilizzoUtensile    `json:"utilizzo,omitempty"`
+	TipoDiCreazione         []string              `json:"tipo-di-creazione,omitempty"`
+	Varianti                []string              `json:"varianti,omitempty"`
+}
+
+type VarietaAttrezzatura struct {
+	Nome     string          `json:"nome"`
+	Peso     *Peso           `json:"peso,omitempty"`
+	Costo    *shared.Importo `json:"costo,omitempty"`
+	Quantita int32           `json:"quantità,omitempty"`
+}
+
+type AttrezzaturaAvventuriero struct {
+	Consumabile bool                  `json:"consumabile,omitempty"`
+	Varieta     []VarietaAttrezzatura `json:"varietà,omitempty"`
+	Effetto     *shared.Effetto       `json:"effetto,omitempty"`
+}
+
+type CategoriaCiboBevandeAlloggio string
+
+const (
+	CiboOBevanda CategoriaCiboBevandeAlloggio = "Cibo o Bevanda"
+	Alloggio     CategoriaCiboBevandeAlloggio = "Alloggio"
+)
+
+type TenoreAlloggio string
+
+const (
+	Squallido     TenoreAlloggio = "Squallido"
+	Povero        TenoreAlloggio = "Povero"
+	Modesto       TenoreAlloggio = "Modesto"
+	Confortevole  TenoreAlloggio = "Confortevole"
+	Benestante    TenoreAlloggio = "Benestante"
+	Aristocratico TenoreAlloggio = "Aristocratico"
+)
+
+// CiboBevandeAlloggio covers food, drink and lodging. For Alloggio the
+// item's costo is the cost per day and Tenore the lodging quality.
+type CiboBevandeAlloggio struct {
+	Tipo   CategoriaCiboBevandeAlloggio `json:"tipo"`
+	Tenore TenoreAlloggio               `json:"tenore,omitempty"`
+}
+
+// Oggetto is a catalogue item. The fields common to every item live on the
+// struct; the tipo-specific ones are carried by exactly one of the variant
+// pointers, selected by Tipo, and travel on the wire as "proprietà".
+// Quantita is how many pieces costo and peso refer to, such as 20 for a
+// bundle of arrows; zero means one. Oggetto Magico has no variant schema
+// yet.
+type Oggetto struct {
+	ID                          string               `json:"id"`
+	Nome                        string               `json:"nome"`
+	Tipo                        TipoOggetto          `json:"tipo"`
+	Descrizione                 string               `json:"descrizione,omitempty"`
+	Costo                       *shared.Importo      `json:"costo,omitempty"`
+	Peso                        *Peso                `json:"peso,omitempty"`
+	Quantita                    int32                `json:"quantità,omitempty"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento"`
+
+	Arma                     *Arma                     `json:"-"`
+	Armatura                 *Armatura                 `json:"-"`
+	Utensile                 *Utensile                 `json:"-"`
+	AttrezzaturaAvventuriero *AttrezzaturaAvventuriero `json:"-"`
+	CiboBevandeAlloggio      *CiboBevandeAlloggio      `json:"-"`
+}
+
+// oggettoFields has Oggetto's fields without its JSON methods.
+type oggettoFields Oggetto
+
+// Proprieta returns the variant selected by Tipo, or nil when it is unset.
+func (o Oggetto) Proprieta() any {
+	switch o.Tipo {
+	case TipoArma:
+		if o.Arma != nil {
+			return o.Arma
+		}
+	case TipoArmatura:
+		if o.Armatura != nil {
+			return o.Armatura
+		}
+	case TipoUtensile:
+		if o.Utensile != nil {
+			return o.Utensile
+		}
+	case TipoAttrezzaturaAvventuriero:
+		if o.AttrezzaturaAvventuriero != nil {
+			return o.AttrezzaturaAvventuriero
+		}
+	case TipoCiboBevandeAlloggio:
+		if o.CiboBevandeAlloggio != nil {
+			return o.CiboBevandeAlloggio
+		}
+	}
+	return nil
+}
+
+// DecodeProprieta decodes raw into the variant selected by Tipo. Empty or
+// null input leaves every variant unset.
+func (o *Oggetto) DecodeProprieta(raw []byte) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+
+	var dest any
+	switch o.Tipo {
+	case TipoArma:
+		o.Arma = &Arma{}
+		dest = o.Arma
+	case TipoArmatura:
+		o.Armatura = &Armatura{}
+		dest = o.Armatura
+	case TipoUtensile:
+		o.Utensile = &Utensile{}
+		dest = o.Utensile
+	case TipoAttrezzaturaAvventuriero:
+		o.AttrezzaturaAvventuriero = &AttrezzaturaAvventuriero{}
+		dest = o.AttrezzaturaAvventuriero
+	case TipoCiboBevandeAlloggio:
+		o.CiboBevandeAlloggio = &CiboBevandeAlloggio{}
+		dest = o.CiboBevandeAlloggio
+	default:
+		return fmt.Errorf("tipo %q does not accept proprietà", o.Tipo)
+	}
+	return json.Unmarshal(raw, dest)
+}
+
+func (o Oggetto) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		oggettoFields
+		Proprieta any `json:"proprietà,omitempty"`
+	}{oggettoFields(o), o.Proprieta()})
+}
+
+func (o *Oggetto) UnmarshalJSON(data []byte) error {
+	var in struct {
+		oggettoFields
+		Proprieta json.RawMessage `json:"proprietà"`
+	}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*o = Oggetto(in.oggettoFields)
+	return o.DecodeProprieta(in.Proprieta)
+}
+
+// ListFilter extends the shared list filter with the item-specific
+// filters. CategoriaArma and CategoriaArmatura only match items of the
+// corresponding tipo, and together match either; the costo bounds are
+// compared in monete di rame.
+type ListFilter struct {
+	shared.ListFilter
+	Tipo              []TipoOggetto
+	CategoriaArma     []CategoriaArma
+	CategoriaArmatura []CategoriaArmatura
+	CostoMin          *shared.Importo
+	CostoMax          *shared.Importo
+}
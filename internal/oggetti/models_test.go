@@ -0,0 +1,149 @@
+package oggetti
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func TestOggetto_JSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		oggetto Oggetto
+		check   func(t *testing.T, got Oggetto)
+	}{
+		{
+			name: "arma",
+			oggetto: Oggetto{
+				ID: "spada-lunga", Nome: "Spada Lunga", Tipo: TipoArma,
+				Costo: &shared.Importo{Quantita: 15, Valuta: shared.MO},
+				Arma: &Arma{
+					Categoria: Marziale,
+					Tipo:      ArmaMelee,
+					Danno:     []shared.Danno{{TipoDiDanno: []shared.TipoDiDanno{shared.Tagliente}, NumeroDiDadi: 1, TipoDiDado: shared.D8}},
+				},
+			},
+			check: func(t *testing.T, got Oggetto) {
+				if got.Arma == nil || got.Arma.Categoria != Marziale || len(got.Arma.Danno) != 1 {
+					t.Errorf("unexpected arma %+v", got.Arma)
+				}
+			},
+		},
+		{
+			name: "armatura",
+			oggetto: Oggetto{
+				ID: "cotta-di-maglia", Nome: "Cotta di Maglia", Tipo: TipoArmatura,
+				Armatura: &Armatura{Categoria: Pesante, ClasseArmatura: ClasseArmatura{Valore: 16, ValoreForzaRichiesto: 13, SvantaggioFurtivita: true}},
+			},
+			check: func(t *testing.T, got Oggetto) {
+				if got.Armatura == nil || got.Armatura.ClasseArmatura.Valore != 16 || !got.Armatura.ClasseArmatura.SvantaggioFurtivita {
+					t.Errorf("unexpected armatura %+v", got.Armatura)
+				}
+			},
+		},
+		{
+			name: "utensile",
+			oggetto: Oggetto{
+				ID: "arnesi-da-scasso", Nome: "Arnesi da Scasso", Tipo: TipoUtensile,
+				Utensile: &Utensile{CaratteristicaAssociata: shared.Destrezza, Utilizzo: []UtilizzoUtensile{{Descrizione: "Scassinare una serratura", ClasseDifficolta: 15}}},
+			},
+			check: func(t *testing.T, got Oggetto) {
+				if got.Utensile == nil || got.Utensile.Utilizzo[0].ClasseDifficolta != 15 {
+					t.Errorf("unexpected utensile %+v", got.Utensile)
+				}
+			},
+		},
+		{
+			name: "attrezzatura da avventuriero",
+			oggetto: Oggetto{
+				ID: "torcia", Nome: "Torcia", Tipo: TipoAttrezzaturaAvventuriero,
+				AttrezzaturaAvventuriero: &AttrezzaturaAvventuriero{Consumabile: true},
+			},
+			check: func(t *testing.T, got Oggetto) {
+				if got.AttrezzaturaAvventuriero == nil || !got.AttrezzaturaAvventuriero.Consumabile {
+					t.Errorf("unexpected attrezzatura %+v", got.AttrezzaturaAvventuriero)
+				}
+			},
+		},
+		{
+			name: "cibo, bevanda o alloggio",
+			oggetto: Oggetto{
+				ID: "locanda-modesta", Nome: "Locanda Modesta", Tipo: TipoCiboBevandeAlloggio,
+				CiboBevandeAlloggio: &CiboBevandeAlloggio{Tipo: Alloggio, Tenore: Modesto},
+			},
+			check: func(t *testing.T, got Oggetto) {
+				if got.CiboBevandeAlloggio == nil || got.CiboBevandeAlloggio.Tenore != Modesto {
+					t.Errorf("unexpected cibo bevande alloggio %+v", got.CiboBevandeAlloggio)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.oggetto)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if !strings.Contains(string(data), `"proprietà":{`) {
+				t.Errorf("expected proprietà object in %s", data)
+			}
+
+			var got Oggetto
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if got.ID != tt.oggetto.ID || got.Tipo != tt.oggetto.Tipo {
+				t.Errorf("unexpected oggetto %+v", got)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestOggetto_MarshalJSON(t *testing.T) {
+	t.Run("only the variant matching tipo is emitted", func(t *testing.T) {
+		o := Oggetto{ID: "x", Tipo: TipoArma, Armatura: &Armatura{Categoria: Scudo}}
+
+		data, err := json.Marshal(o)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		if strings.Contains(string(data), "proprietà") {
+			t.Errorf("expected no proprietà, got %s", data)
+		}
+	})
+}
+
+func TestOggetto_UnmarshalJSON(t *testing.T) {
+	t.Run("proprietà decoded by tipo", func(t *testing.T) {
+		data := `{"id":"scudo","nome":"Scudo","tipo":"Armatura","proprietà":{"categoria":"Scudo","mani-occupate":1,"classe-armatura":{"valore":2}}}`
+
+		var o Oggetto
+		if err := json.Unmarshal([]byte(data), &o); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if o.Armatura == nil || o.Armatura.Categoria != Scudo || o.Arma != nil {
+			t.Errorf("unexpected variants %+v", o)
+		}
+	})
+
+	t.Run("oggetto magico without proprietà", func(t *testing.T) {
+		var o Oggetto
+		if err := json.Unmarshal([]byte(`{"id":"borsa","nome":"Borsa Conservante","tipo":"Oggetto Magico"}`), &o); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if o.Proprieta() != nil {
+			t.Errorf("expected no proprietà, got %v", o.Proprieta())
+		}
+	})
+
+	t.Run("unknown tipo with proprietà", func(t *testing.T) {
+		var o Oggetto
+		if err := json.Unmarshal([]byte(`{"id":"x","tipo":"Pozione","proprietà":{}}`), &o); err == nil {
+			t.Fatal("expected error for unknown tipo")
+		}
+	})
+}
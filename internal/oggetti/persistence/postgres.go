@@ -0,0 +1,174 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type oggettoRow struct {
+	ID                          string          `db:"id"`
+	Nome                        string          `db:"nome"`
+	Tipo                        string          `db:"tipo"`
+	Descrizione                 sql.NullString  `db:"descrizione"`
+	CostoQuantita               sql.NullInt32   `db:"costo_quantita"`
+	CostoValuta                 sql.NullString  `db:"costo_valuta"`
+	PesoValore                  sql.NullFloat64 `db:"peso_valore"`
+	PesoUnitaDiMisura           sql.NullString  `db:"peso_unita_di_misura"`
+	Quantita                    sql.NullInt32   `db:"quantita"`
+	Proprieta                   []byte          `db:"proprieta"`
+	DocumentazioneDiRiferimento string          `db:"documentazione_di_riferimento"`
+}
+
+func (r *oggettoRow) toOggetto() (oggetti.Oggetto, error) {
+	o := oggetti.Oggetto{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Tipo:                        oggetti.TipoOggetto(r.Tipo),
+		Descrizione:                 r.Descrizione.String,
+		Quantita:                    r.Quantita.Int32,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+	if r.CostoQuantita.Valid && r.CostoValuta.Valid {
+		o.Costo = &shared.Importo{Quantita: r.CostoQuantita.Int32, Valuta: shared.Valuta(r.CostoValuta.String)}
+	}
+	if r.PesoValore.Valid {
+		o.Peso = &oggetti.Peso{Valore: r.PesoValore.Float64, UnitaDiMisura: r.PesoUnitaDiMisura.String}
+	}
+	if err := o.DecodeProprieta(r.Proprieta); err != nil {
+		return o, fmt.Errorf("decode proprieta of oggetto %s: %w", r.ID, err)
+	}
+	return o, nil
+}
+
+const selectOggetto = `
+	SELECT id, nome, tipo, descrizione, costo_quantita, costo_valuta, peso_valore,
+	       peso_unita_di_misura, quantita, proprieta, documentazione_di_riferimento
+	FROM oggetti`
+
+// filterConditions translates the item-specific filters into SQL conditions
+// and their named arguments. The shared filters are applied by
+// shared.NewPaginatedQuery.
+func filterConditions(filter oggetti.ListFilter) (string, map[string]any) {
+	var where string
+	args := make(map[string]any)
+
+	if len(filter.Tipo) > 0 {
+		tipi := make([]string, len(filter.Tipo))
+		for i, t := range filter.Tipo {
+			tipi[i] = string(t)
+		}
+		where += ` AND tipo = ANY(:tipo)`
+		args["tipo"] = pq.Array(tipi)
+	}
+	// The categoria filters select items of different tipi, so together
+	// they match weapons or armour of the requested categorie.
+	var categorie []string
+	if len(filter.CategoriaArma) > 0 {
+		valori := make([]string, len(filter.CategoriaArma))
+		for i, c := range filter.CategoriaArma {
+			valori[i] = string(c)
+		}
+		categorie = append(categorie, `(tipo = :tipo_arma AND proprieta->>'categoria' = ANY(:categoria_arma))`)
+		args["tipo_arma"] = string(oggetti.TipoArma)
+		args["categoria_arma"] = pq.Array(valori)
+	}
+	if len(filter.CategoriaArmatura) > 0 {
+		valori := make([]string, len(filter.CategoriaArmatura))
+		for i, c := range filter.CategoriaArmatura {
+			valori[i] = string(c)
+		}
+		categorie = append(categorie, `(tipo = :tipo_armatura AND proprieta->>'categoria' = ANY(:categoria_armatura))`)
+		args["tipo_armatura"] = string(oggetti.TipoArmatura)
+		args["categoria_armatura"] = pq.Array(valori)
+	}
+	if len(categorie) > 0 {
+		where += ` AND (` + strings.Join(categorie, ` OR `) + `)`
+	}
+	if filter.CostoMin != nil {
+		where += ` AND costo_in_mr >= :costo_min`
+		args["costo_min"] = filter.CostoMin.InMoneteDiRame()
+	}
+	if filter.CostoMax != nil {
+		where += ` AND costo_in_mr <= :costo_max`
+		args["costo_max"] = filter.CostoMax.InMoneteDiRame()
+	}
+
+	return where, args
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filter oggetti.ListFilter) ([]oggetti.Oggetto, int, error) {
+	where, args := filterConditions(filter)
+	q := shared.NewPaginatedQuery(
+		selectOggetto+` WHERE 1=1`+where,
+		`SELECT COUNT(*) FROM oggetti WHERE 1=1`+where,
+		args,
+		filter.ListFilter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []oggettoRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]oggetti.Oggetto, len(rows))
+	for i, row := range rows {
+		if result[i], err = row.toOggetto(); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*oggetti.Oggetto, error) {
+	var row oggettoRow
+	if err := r.db.GetContext(ctx, &row, selectOggetto+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get oggetto by id: %w", err)
+	}
+
+	oggetto, err := row.toOggetto()
+	if err != nil {
+		return nil, err
+	}
+	return &oggetto, nil
+}
+
+func (r *PostgresRepository) GetByIDs(ctx context.Context, ids []string) ([]oggetti.Oggetto, error) {
+	var rows []oggettoRow
+	if err := r.db.SelectContext(ctx, &rows, selectOggetto+` WHERE id = ANY($1)`, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("get oggetti by ids: %w", err)
+	}
+
+	result := make([]oggetti.Oggetto, len(rows))
+	for i, row := range rows {
+		var err error
+		if result[i], err = row.toOggetto(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,137 @@
+package persistence
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func TestFilterConditions(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		where, args := filterConditions(oggetti.ListFilter{})
+
+		if where != "" || len(args) != 0 {
+			t.Errorf("expected no conditions, got %q %v", where, args)
+		}
+	})
+
+	t.Run("tipo matches any value", func(t *testing.T) {
+		where, args := filterConditions(oggetti.ListFilter{Tipo: []oggetti.TipoOggetto{oggetti.TipoArma, oggetti.TipoUtensile}})
+
+		if !strings.Contains(where, "tipo = ANY(:tipo)") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		tipi := args["tipo"].(*pq.StringArray)
+		if len(*tipi) != 2 || (*tipi)[1] != "Utensile" {
+			t.Errorf("unexpected tipo arg %v", *tipi)
+		}
+	})
+
+	t.Run("categoria arma restricts to weapons", func(t *testing.T) {
+		where, args := filterConditions(oggetti.ListFilter{CategoriaArma: []oggetti.CategoriaArma{oggetti.Marziale}})
+
+		if !strings.Contains(where, "tipo = :tipo_arma AND proprieta->>'categoria' = ANY(:categoria_arma)") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		if args["tipo_arma"] != "Arma" {
+			t.Errorf("unexpected tipo_arma arg %v", args["tipo_arma"])
+		}
+	})
+
+	t.Run("categoria armatura restricts to armour", func(t *testing.T) {
+		where, args := filterConditions(oggetti.ListFilter{CategoriaArmatura: []oggetti.CategoriaArmatura{oggetti.Pesante, oggetti.Scudo}})
+
+		if !strings.Contains(where, "tipo = :tipo_armatura AND proprieta->>'categoria' = ANY(:categoria_armatura)") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		categorie := args["categoria_armatura"].(*pq.StringArray)
+		if len(*categorie) != 2 {
+			t.Errorf("unexpected categoria arg %v", *categorie)
+		}
+	})
+
+	t.Run("categoria arma or categoria armatura", func(t *testing.T) {
+		where, _ := filterConditions(oggetti.ListFilter{
+			CategoriaArma:     []oggetti.CategoriaArma{oggetti.Marziale},
+			CategoriaArmatura: []oggetti.CategoriaArmatura{oggetti.Scudo},
+		})
+
+		if !strings.Contains(where, "(tipo = :tipo_arma AND proprieta->>'categoria' = ANY(:categoria_arma)) OR (tipo = :tipo_armatura") {
+			t.Errorf("expected the categoria groups in OR, got %q", where)
+		}
+	})
+
+	t.Run("costo range in monete di rame", func(t *testing.T) {
+		filter := oggetti.ListFilter{
+			CostoMin: &shared.Importo{Quantita: 5, Valuta: shared.MA},
+			CostoMax: &shared.Importo{Quantita: 2, Valuta: shared.MO},
+		}
+
+		where, args := filterConditions(filter)
+
+		if !strings.Contains(where, "costo_in_mr >= :costo_min") || !strings.Contains(where, "costo_in_mr <= :costo_max") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		if args["costo_min"] != int64(50) || args["costo_max"] != int64(200) {
+			t.Errorf("unexpected args %v", args)
+		}
+	})
+}
+
+func TestOggettoRow_ToOggetto(t *testing.T) {
+	t.Run("full row", func(t *testing.T) {
+		row := oggettoRow{
+			ID:                          "spada-lunga",
+			Nome:                        "Spada Lunga",
+			Tipo:                        "Arma",
+			CostoQuantita:               sql.NullInt32{Int32: 15, Valid: true},
+			CostoValuta:                 sql.NullString{String: "MO", Valid: true},
+			PesoValore:                  sql.NullFloat64{Float64: 1.5, Valid: true},
+			PesoUnitaDiMisura:           sql.NullString{String: "kg", Valid: true},
+			Quantita:                    sql.NullInt32{Int32: 1, Valid: true},
+			Proprieta:                   []byte(`{"categoria":"Marziale","tipo":"Melee","id-maestria":"fiaccare"}`),
+			DocumentazioneDiRiferimento: "DND 2024",
+		}
+
+		o, err := row.toOggetto()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if o.Costo == nil || o.Costo.Quantita != 15 || o.Costo.Valuta != shared.MO {
+			t.Errorf("unexpected costo %+v", o.Costo)
+		}
+		if o.Peso == nil || o.Peso.Valore != 1.5 || o.Quantita != 1 {
+			t.Errorf("unexpected peso %+v or quantità %d", o.Peso, o.Quantita)
+		}
+		if o.Arma == nil || o.Arma.Categoria != oggetti.Marziale || o.Arma.IDMaestria != "fiaccare" {
+			t.Errorf("unexpected arma %+v", o.Arma)
+		}
+	})
+
+	t.Run("null columns", func(t *testing.T) {
+		row := oggettoRow{ID: "borsa", Nome: "Borsa Conservante", Tipo: "Oggetto Magico"}
+
+		o, err := row.toOggetto()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if o.Costo != nil || o.Peso != nil || o.Proprieta() != nil {
+			t.Errorf("expected empty optional fields, got %+v", o)
+		}
+	})
+
+	t.Run("invalid proprieta", func(t *testing.T) {
+		row := oggettoRow{ID: "x", Tipo: "Arma", Proprieta: []byte(`{"categoria":1}`)}
+
+		if _, err := row.toOggetto(); err == nil {
+			t.Fatal("expected error for invalid proprieta")
+		}
+	})
+}
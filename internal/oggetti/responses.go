@@ -0,0 +1,8 @@
+package oggetti
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type ListOggettiResponse struct {
+	shared.PaginationMeta
+	Oggetti []Oggetto `json:"oggetti"`
+}
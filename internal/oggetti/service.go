@@ -0,0 +1,54 @@
+package oggetti
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	repo      Repository
+	glossario shared.Glossario
+	logger    *slog.Logger
+}
+
+func NewService(repo Repository, glossario shared.Glossario, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:      repo,
+		glossario: glossario,
+		logger:    logger,
+	}
+}
+
+func (s *Service) ListOggetti(ctx context.Context, filter ListFilter) (*ListOggettiResponse, error) {
+	oggetti, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list oggetti", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListOggettiResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Oggetti:        oggetti,
+	}, nil
+}
+
+func (s *Service) GetOggetto(ctx context.Context, id string, campi shared.Campi) (*Oggetto, error) {
+	oggetto, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get oggetto", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if oggetto == nil {
+		return nil, ErrOggettoNotFound(id)
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		oggetto.Riferimenti = s.glossario.Riferimenti(ctx, oggetto)
+	}
+	return oggetto, nil
+}
@@ -0,0 +1,116 @@
+package transports
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type OggettiService interface {
+	ListOggetti(ctx context.Context, filter oggetti.ListFilter) (*oggetti.ListOggettiResponse, error)
+	GetOggetto(ctx context.Context, id string, campi shared.Campi) (*oggetti.Oggetto, error)
+}
+
+type Handler struct {
+	service OggettiService
+}
+
+func NewHandler(service OggettiService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListOggetti)
+	r.Get("/{id-oggetto}", h.GetOggetto)
+
+	return r
+}
+
+func queryImporto(query url.Values, name string) (*shared.Importo, error) {
+	value, err := shared.QueryString(query, name)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	importo, err := shared.ParseImporto(*value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &importo, nil
+}
+
+func newListFilterFromRequest(r *http.Request) (oggetti.ListFilter, error) {
+	base, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		return oggetti.ListFilter{}, err
+	}
+	filter := oggetti.ListFilter{ListFilter: base}
+	query := r.URL.Query()
+
+	if filter.Tipo, err = shared.QueryEnumList(query, "tipo", oggetti.TipiOggetto...); err != nil {
+		return filter, err
+	}
+	if filter.CategoriaArma, err = shared.QueryEnumList(query, "categoria-arma", oggetti.CategorieArma...); err != nil {
+		return filter, err
+	}
+	if filter.CategoriaArmatura, err = shared.QueryEnumList(query, "categoria-armatura", oggetti.CategorieArmatura...); err != nil {
+		return filter, err
+	}
+	if filter.CostoMin, err = queryImporto(query, "costo-min"); err != nil {
+		return filter, err
+	}
+	if filter.CostoMax, err = queryImporto(query, "costo-max"); err != nil {
+		return filter, err
+	}
+
+	if filter.CostoMin != nil && filter.CostoMax != nil && filter.CostoMin.InMoneteDiRame() > filter.CostoMax.InMoneteDiRame() {
+		return filter, fmt.Errorf("costo-min cannot exceed costo-max")
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) ListOggetti(w http.ResponseWriter, r *http.Request) {
+	filter, err := newListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListOggetti(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetOggetto(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-oggetto")
+	if err := shared.ValidateID("id-oggetto", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	oggetto, err := h.service.GetOggetto(r.Context(), id, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, oggetto, campi)
+}
@@ -0,0 +1,143 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/oggetti"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	listOggettiFunc func(ctx context.Context, filter oggetti.ListFilter) (*oggetti.ListOggettiResponse, error)
+	getOggettoFunc  func(ctx context.Context, id string, campi shared.Campi) (*oggetti.Oggetto, error)
+}
+
+func (m *mockService) ListOggetti(ctx context.Context, filter oggetti.ListFilter) (*oggetti.ListOggettiResponse, error) {
+	if m.listOggettiFunc != nil {
+		return m.listOggettiFunc(ctx, filter)
+	}
+	return &oggetti.ListOggettiResponse{}, nil
+}
+
+func (m *mockService) GetOggetto(ctx context.Context, id string, campi shared.Campi) (*oggetti.Oggetto, error) {
+	if m.getOggettoFunc != nil {
+		return m.getOggettoFunc(ctx, id, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc OggettiService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/oggetti", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_ListOggetti(t *testing.T) {
+	t.Run("with item filters", func(t *testing.T) {
+		var captured oggetti.ListFilter
+		svc := &mockService{
+			listOggettiFunc: func(_ context.Context, filter oggetti.ListFilter) (*oggetti.ListOggettiResponse, error) {
+				captured = filter
+				return &oggetti.ListOggettiResponse{
+					PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+					Oggetti:        []oggetti.Oggetto{{ID: "spada-lunga", Nome: "Spada Lunga", Tipo: oggetti.TipoArma}},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/oggetti?tipo=Arma&categoria-arma=Semplice,Marziale&costo-min=5%20MA&costo-max=20MO", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if len(captured.Tipo) != 1 || captured.Tipo[0] != oggetti.TipoArma {
+			t.Errorf("unexpected tipo %v", captured.Tipo)
+		}
+		if len(captured.CategoriaArma) != 2 || captured.CategoriaArma[1] != oggetti.Marziale {
+			t.Errorf("unexpected categoria-arma %v", captured.CategoriaArma)
+		}
+		if captured.CostoMin == nil || *captured.CostoMin != (shared.Importo{Quantita: 5, Valuta: shared.MA}) {
+			t.Errorf("unexpected costo-min %v", captured.CostoMin)
+		}
+		if captured.CostoMax == nil || *captured.CostoMax != (shared.Importo{Quantita: 20, Valuta: shared.MO}) {
+			t.Errorf("unexpected costo-max %v", captured.CostoMax)
+		}
+
+		var response oggetti.ListOggettiResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Oggetti) != 1 {
+			t.Errorf("expected 1 oggetto, got %d", len(response.Oggetti))
+		}
+	})
+
+	invalid := []struct {
+		name  string
+		query string
+	}{
+		{"unknown tipo", "tipo=Pozione"},
+		{"unknown categoria arma", "categoria-arma=Esotica"},
+		{"unknown categoria armatura", "categoria-armatura=Media,Imbottita"},
+		{"invalid costo", "costo-min=tanto"},
+		{"min greater than max", "costo-min=1MP&costo-max=5MO"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/oggetti?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_GetOggetto(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := &mockService{
+			getOggettoFunc: func(_ context.Context, id string, _ shared.Campi) (*oggetti.Oggetto, error) {
+				return &oggetti.Oggetto{ID: id, Nome: "Spada Lunga"}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/oggetti/spada-lunga", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getOggettoFunc: func(_ context.Context, id string, _ shared.Campi) (*oggetti.Oggetto, error) {
+				return nil, oggetti.ErrOggettoNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/oggetti/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package personaggi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+// Codes of the rule violations reported by Valida.
+const (
+	CodiceRisorsaSconosciuta       = "UNKNOWN_RESOURCE"
+	CodiceSottoclasseAltraClasse   = "SUBCLASS_CLASS_MISMATCH"
+	CodiceSottoclasseTroppoPresto  = "SUBCLASS_LEVEL_TOO_LOW"
+	CodicePrerequisitoMulticlasse  = "MULTICLASS_PREREQUISITE"
+	CodicePointBuyFuoriIntervallo  = "POINT_BUY_SCORE_OUT_OF_RANGE"
+	CodicePointBuySforato          = "POINT_BUY_OVERSPEND"
+	CodiceSerieStandard            = "STANDARD_ARRAY_MISMATCH"
+	CodiceCaratteristicaTroppoAlta = "ABILITY_SCORE_TOO_HIGH"
+	CodicePrerequisitoTalento      = "FEAT_PREREQUISITE"
+	CodiceTalentoNonRipetibile     = "FEAT_NOT_REPEATABLE"
+)
+
+var titoli = map[string]string{
+	CodiceRisorsaSconosciuta:       "Unknown resource",
+	CodiceSottoclasseAltraClasse:   "Subclass of another class",
+	CodiceSottoclasseTroppoPresto:  "Subclass chosen too early",
+	CodicePrerequisitoMulticlasse:  "Multiclass prerequisite not met",
+	CodicePointBuyFuoriIntervallo:  "Point buy score out of range",
+	CodicePointBuySforato:          "Point buy overspent",
+	CodiceSerieStandard:            "Scores differ from the standard array",
+	CodiceCaratteristicaTroppoAlta: "Ability score above 20",
+	CodicePrerequisitoTalento:      "Feat prerequisite not met",
+	CodiceTalentoNonRipetibile:     "Feat taken more than once",
+}
+
+func violazione(code, format string, args ...any) shared.ErrorCommon {
+	return shared.ErrorCommon{Code: code, Title: titoli[code], Detail: fmt.Sprintf(format, args...)}
+}
+
+// descriviPrerequisito formats p as "Forza o Destrezza 13".
+func descriviPrerequisito(p shared.PrerequisitoCaratteristica) string {
+	nomi := make([]string, len(p.Caratteristiche))
+	for i, c := range p.Caratteristiche {
+		nomi[i] = string(c)
+	}
+	return fmt.Sprintf("%s %d", strings.Join(nomi, " o "), p.ValoreMinimo)
+}
@@ -0,0 +1,37 @@
+package personaggi
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/background"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/specie"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/talenti"
+)
+
+type ClassiRepository interface {
+	GetByID(ctx context.Context, id string) (*classi.Classe, error)
+	// GetSottoclasse returns the subclass whatever its class, so that a
+	// subclass of the wrong class can be told apart from an unknown one.
+	GetSottoclasse(ctx context.Context, sottoclasseID string) (*classi.SottoClasse, error)
+}
+
+type SpecieRepository interface {
+	GetByID(ctx context.Context, id string) (*specie.Specie, error)
+}
+
+type BackgroundRepository interface {
+	GetByID(ctx context.Context, id string) (*background.Background, error)
+}
+
+type TalentiRepository interface {
+	GetByID(ctx context.Context, id string) (*talenti.Talento, error)
+}
+
+// Repositories are the modules a character draft refers to.
+type Repositories struct {
+	Classi     ClassiRepository
+	Specie     SpecieRepository
+	Background BackgroundRepository
+	Talenti    TalentiRepository
+}
@@ -0,0 +1,62 @@
+package personaggi
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/background"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/specie"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/talenti"
+)
+
+type MockClassiRepository struct {
+	GetByIDFunc        func(ctx context.Context, id string) (*classi.Classe, error)
+	GetSottoclasseFunc func(ctx context.Context, sottoclasseID string) (*classi.SottoClasse, error)
+}
+
+func (m *MockClassiRepository) GetByID(ctx context.Context, id string) (*classi.Classe, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockClassiRepository) GetSottoclasse(ctx context.Context, sottoclasseID string) (*classi.SottoClasse, error) {
+	if m.GetSottoclasseFunc != nil {
+		return m.GetSottoclasseFunc(ctx, sottoclasseID)
+	}
+	return nil, nil
+}
+
+type MockSpecieRepository struct {
+	GetByIDFunc func(ctx context.Context, id string) (*specie.Specie, error)
+}
+
+func (m *MockSpecieRepository) GetByID(ctx context.Context, id string) (*specie.Specie, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+type MockBackgroundRepository struct {
+	GetByIDFunc func(ctx context.Context, id string) (*background.Background, error)
+}
+
+func (m *MockBackgroundRepository) GetByID(ctx context.Context, id string) (*background.Background, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+type MockTalentiRepository struct {
+	GetByIDFunc func(ctx context.Context, id string) (*talenti.Talento, error)
+}
+
+func (m *MockTalentiRepository) GetByID(ctx context.Context, id string) (*talenti.Talento, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
@@ -0,0 +1,48 @@
+package personaggi
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+// MaxLivello is the highest character level.
+const MaxLivello = 20
+
+type ClassePersonaggio struct {
+	IDClasse      string `json:"id-classe"`
+	Livello       int32  `json:"livello"`
+	IDSottoclasse string `json:"id-sotto-classe,omitempty"`
+}
+
+// MetodoCaratteristiche is how the base ability scores were generated.
+// Manuale (rolled or assigned by the DM) is not checked.
+type MetodoCaratteristiche string
+
+const (
+	PointBuy      MetodoCaratteristiche = "point-buy"
+	SerieStandard MetodoCaratteristiche = "serie-standard"
+	Manuale       MetodoCaratteristiche = "manuale"
+)
+
+var MetodiCaratteristiche = []MetodoCaratteristiche{PointBuy, SerieStandard, Manuale}
+
+// Personaggio is a character draft. Classi are listed in the order they
+// were taken. Caratteristiche are the base scores produced by
+// MetodoCaratteristiche; IncrementiCaratteristiche are every increase on
+// top of them (background, feats), and the sum of the two is the final
+// score.
+type Personaggio struct {
+	IDSpecie                  string                `json:"id-specie,omitempty"`
+	IDBackground              string                `json:"id-background,omitempty"`
+	Classi                    []ClassePersonaggio   `json:"classi"`
+	Talenti                   []string              `json:"talenti,omitempty"`
+	MetodoCaratteristiche     MetodoCaratteristiche `json:"metodo-caratteristiche,omitempty"`
+	Caratteristiche           shared.Punteggi       `json:"caratteristiche"`
+	IncrementiCaratteristiche shared.Punteggi       `json:"incrementi-caratteristiche"`
+}
+
+// Livello returns the character level, the sum of the class levels.
+func (p Personaggio) Livello() int32 {
+	var livello int32
+	for _, c := range p.Classi {
+		livello += c.Livello
+	}
+	return livello
+}
@@ -0,0 +1,304 @@
+package personaggi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+const (
+	maxTalenti = 20
+	// Point buy: every score from 8 to 15, costing puntiPointBuy[score-8],
+	// within a budget of 27 points.
+	minPointBuy    = 8
+	maxPointBuy    = 15
+	budgetPointBuy = 27
+	// maxPunteggio is the highest ability score a character can reach.
+	maxPunteggio = 20
+)
+
+var (
+	puntiPointBuy = []int32{0, 1, 2, 3, 4, 5, 7, 9}
+	serieStandard = []int32{8, 10, 12, 13, 14, 15}
+)
+
+type Service struct {
+	repos  Repositories
+	logger *slog.Logger
+}
+
+func NewService(repos Repositories, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repos:  repos,
+		logger: logger,
+	}
+}
+
+// Valida checks a character draft against the rules and returns one error
+// per violation; a valid draft has no errors. A malformed draft is a bad
+// request rather than a violation.
+func (s *Service) Valida(ctx context.Context, p Personaggio) (*shared.ErrorObject, error) {
+	if err := valida(p); err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	v := &validazione{Service: s, personaggio: p, result: &shared.ErrorObject{Errors: []shared.ErrorCommon{}}}
+	for _, check := range []func(context.Context) error{
+		v.specie,
+		v.background,
+		v.classi,
+		v.caratteristiche,
+		v.talenti,
+	} {
+		if err := check(ctx); err != nil {
+			return nil, shared.NewInternalError(err)
+		}
+	}
+	return v.result, nil
+}
+
+func valida(p Personaggio) error {
+	if len(p.Classi) == 0 {
+		return errors.New("classi is required")
+	}
+	visti := make(map[string]bool, len(p.Classi))
+	for _, c := range p.Classi {
+		if err := shared.ValidateID("id-classe", c.IDClasse); err != nil {
+			return err
+		}
+		if c.IDSottoclasse != "" {
+			if err := shared.ValidateID("id-sotto-classe", c.IDSottoclasse); err != nil {
+				return err
+			}
+		}
+		if c.Livello < 1 || c.Livello > MaxLivello {
+			return fmt.Errorf("livello of %s must be between 1 and %d", c.IDClasse, MaxLivello)
+		}
+		if visti[c.IDClasse] {
+			return fmt.Errorf("classe %s is listed more than once", c.IDClasse)
+		}
+		visti[c.IDClasse] = true
+	}
+	if p.Livello() > MaxLivello {
+		return fmt.Errorf("total character level cannot exceed %d", MaxLivello)
+	}
+
+	for name, id := range map[string]string{"id-specie": p.IDSpecie, "id-background": p.IDBackground} {
+		if id == "" {
+			continue
+		}
+		if err := shared.ValidateID(name, id); err != nil {
+			return err
+		}
+	}
+	if len(p.Talenti) > maxTalenti {
+		return fmt.Errorf("talenti: too many values (max %d)", maxTalenti)
+	}
+	for _, id := range p.Talenti {
+		if err := shared.ValidateID("talenti", id); err != nil {
+			return err
+		}
+	}
+
+	if p.MetodoCaratteristiche != "" && !slices.Contains(MetodiCaratteristiche, p.MetodoCaratteristiche) {
+		return fmt.Errorf("metodo-caratteristiche must be one of: %s, %s, %s", PointBuy, SerieStandard, Manuale)
+	}
+	return nil
+}
+
+// validazione collects the violations of a single draft.
+type validazione struct {
+	*Service
+	personaggio Personaggio
+	result      *shared.ErrorObject
+}
+
+func (v *validazione) aggiungi(code, format string, args ...any) {
+	v.result.Errors = append(v.result.Errors, violazione(code, format, args...))
+}
+
+func (v *validazione) specie(ctx context.Context) error {
+	if v.personaggio.IDSpecie == "" {
+		return nil
+	}
+	specie, err := v.repos.Specie.GetByID(ctx, v.personaggio.IDSpecie)
+	if err != nil {
+		v.logger.Error("failed to get specie", "id", v.personaggio.IDSpecie, "error", err)
+		return err
+	}
+	if specie == nil {
+		v.aggiungi(CodiceRisorsaSconosciuta, "specie '%s' does not exist", v.personaggio.IDSpecie)
+	}
+	return nil
+}
+
+func (v *validazione) background(ctx context.Context) error {
+	if v.personaggio.IDBackground == "" {
+		return nil
+	}
+	background, err := v.repos.Background.GetByID(ctx, v.personaggio.IDBackground)
+	if err != nil {
+		v.logger.Error("failed to get background", "id", v.personaggio.IDBackground, "error", err)
+		return err
+	}
+	if background == nil {
+		v.aggiungi(CodiceRisorsaSconosciuta, "background '%s' does not exist", v.personaggio.IDBackground)
+	}
+	return nil
+}
+
+// classi checks the subclass choices and, for a multiclassed character,
+// the multiclass prerequisites of every class against the final scores.
+func (v *validazione) classi(ctx context.Context) error {
+	punteggi := v.personaggio.Caratteristiche.Somma(v.personaggio.IncrementiCaratteristiche)
+	multiclasse := len(v.personaggio.Classi) > 1
+
+	for _, c := range v.personaggio.Classi {
+		classe, err := v.repos.Classi.GetByID(ctx, c.IDClasse)
+		if err != nil {
+			v.logger.Error("failed to get classe", "id", c.IDClasse, "error", err)
+			return err
+		}
+		if classe == nil {
+			v.aggiungi(CodiceRisorsaSconosciuta, "classe '%s' does not exist", c.IDClasse)
+			continue
+		}
+
+		if multiclasse {
+			for _, p := range classe.PrerequisitiMulticlasse {
+				if !p.SoddisfattoDa(punteggi) {
+					v.aggiungi(CodicePrerequisitoMulticlasse, "classe '%s' requires %s to multiclass", c.IDClasse, descriviPrerequisito(p))
+				}
+			}
+		}
+
+		if c.IDSottoclasse != "" {
+			if err := v.sottoclasse(ctx, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *validazione) sottoclasse(ctx context.Context, c ClassePersonaggio) error {
+	sottoclasse, err := v.repos.Classi.GetSottoclasse(ctx, c.IDSottoclasse)
+	if err != nil {
+		v.logger.Error("failed to get sottoclasse", "id", c.IDSottoclasse, "error", err)
+		return err
+	}
+	if sottoclasse == nil {
+		v.aggiungi(CodiceRisorsaSconosciuta, "sotto-classe '%s' does not exist", c.IDSottoclasse)
+		return nil
+	}
+	if sottoclasse.IDClasseAssociata != c.IDClasse {
+		v.aggiungi(CodiceSottoclasseAltraClasse, "sotto-classe '%s' belongs to classe '%s', not '%s'",
+			c.IDSottoclasse, sottoclasse.IDClasseAssociata, c.IDClasse)
+		return nil
+	}
+	if livello := livelloSottoclasse(sottoclasse); c.Livello < livello {
+		v.aggiungi(CodiceSottoclasseTroppoPresto, "sotto-classe '%s' is available from level %d of classe '%s', not at level %d",
+			c.IDSottoclasse, livello, c.IDClasse, c.Livello)
+	}
+	return nil
+}
+
+// livelloSottoclasse is the class level a subclass is chosen at: the
+// first level its features start from.
+func livelloSottoclasse(s *classi.SottoClasse) int32 {
+	livello := int32(1)
+	for i, p := range s.ProprietaDiSottoclasse {
+		if i == 0 || p.LivelloClasse < livello {
+			livello = p.LivelloClasse
+		}
+	}
+	return livello
+}
+
+func (v *validazione) caratteristiche(_ context.Context) error {
+	base := v.personaggio.Caratteristiche
+	switch v.personaggio.MetodoCaratteristiche {
+	case PointBuy:
+		var spesa int32
+		for _, c := range shared.Caratteristiche {
+			punteggio := base.Valore(c)
+			if punteggio < minPointBuy || punteggio > maxPointBuy {
+				v.aggiungi(CodicePointBuyFuoriIntervallo, "%s is %d, point buy scores must be between %d and %d",
+					c, punteggio, minPointBuy, maxPointBuy)
+				continue
+			}
+			spesa += puntiPointBuy[punteggio-minPointBuy]
+		}
+		if spesa > budgetPointBuy {
+			v.aggiungi(CodicePointBuySforato, "point buy costs %d points, the budget is %d", spesa, budgetPointBuy)
+		}
+	case SerieStandard:
+		punteggi := make([]int32, len(shared.Caratteristiche))
+		for i, c := range shared.Caratteristiche {
+			punteggi[i] = base.Valore(c)
+		}
+		slices.Sort(punteggi)
+		if !slices.Equal(punteggi, serieStandard) {
+			v.aggiungi(CodiceSerieStandard, "scores %v are not a permutation of the standard array 15, 14, 13, 12, 10, 8", punteggi)
+		}
+	}
+
+	finali := base.Somma(v.personaggio.IncrementiCaratteristiche)
+	for _, c := range shared.Caratteristiche {
+		if punteggio := finali.Valore(c); punteggio > maxPunteggio {
+			v.aggiungi(CodiceCaratteristicaTroppoAlta, "%s is %d, the maximum is %d", c, punteggio, maxPunteggio)
+		}
+	}
+	return nil
+}
+
+// talenti checks the level and ability prerequisites of the chosen feats;
+// the class feature prerequisites are not checked.
+func (v *validazione) talenti(ctx context.Context) error {
+	punteggi := v.personaggio.Caratteristiche.Somma(v.personaggio.IncrementiCaratteristiche)
+	livello := v.personaggio.Livello()
+	presi := make(map[string]bool, len(v.personaggio.Talenti))
+
+	for _, id := range v.personaggio.Talenti {
+		talento, err := v.repos.Talenti.GetByID(ctx, id)
+		if err != nil {
+			v.logger.Error("failed to get talento", "id", id, "error", err)
+			return err
+		}
+		if talento == nil {
+			v.aggiungi(CodiceRisorsaSconosciuta, "talento '%s' does not exist", id)
+			continue
+		}
+
+		if presi[id] {
+			if !talento.Ripetibile {
+				v.aggiungi(CodiceTalentoNonRipetibile, "talento '%s' can only be taken once", id)
+			}
+			continue
+		}
+		presi[id] = true
+
+		if !talento.Prerequisiti.SoddisfattiAlLivello(livello) {
+			v.aggiungi(CodicePrerequisitoTalento, "talento '%s' requires level %d, the character is level %d",
+				id, talento.Prerequisiti.LivelloMinimo, livello)
+		}
+		if talento.Prerequisiti == nil {
+			continue
+		}
+		for _, p := range talento.Prerequisiti.Caratteristiche {
+			if !p.SoddisfattoDa(punteggi) {
+				v.aggiungi(CodicePrerequisitoTalento, "talento '%s' requires %s", id, descriviPrerequisito(p))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package personaggi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/background"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/classi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/specie"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/talenti"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func newTestRepositories() Repositories {
+	classiByID := map[string]*classi.Classe{
+		"guerriero": {ID: "guerriero", PrerequisitiMulticlasse: []shared.PrerequisitoCaratteristica{
+			{Caratteristiche: []shared.Caratteristica{shared.Forza, shared.Destrezza}, ValoreMinimo: 13},
+		}},
+		"paladino": {ID: "paladino", PrerequisitiMulticlasse: []shared.PrerequisitoCaratteristica{
+			{Caratteristiche: []shared.Caratteristica{shared.Forza}, ValoreMinimo: 13},
+			{Caratteristiche: []shared.Caratteristica{shared.Carisma}, ValoreMinimo: 13},
+		}},
+		"mago": {ID: "mago"},
+	}
+	sottoclassi := map[string]*classi.SottoClasse{
+		"campione": {ID: "campione", IDClasseAssociata: "guerriero", ProprietaDiSottoclasse: []classi.ProprietaLivello{
+			{LivelloClasse: 7}, {LivelloClasse: 3},
+		}},
+		"evocatore": {ID: "evocatore", IDClasseAssociata: "mago"},
+	}
+	talentiByID := map[string]*talenti.Talento{
+		"allerta": {ID: "allerta", Categoria: talenti.Origine},
+		"abile":   {ID: "abile", Categoria: talenti.Origine, Ripetibile: true},
+		"atleta": {ID: "atleta", Categoria: talenti.Generale, Prerequisiti: &talenti.Prerequisiti{
+			LivelloMinimo: 4,
+			Caratteristiche: []talenti.PrerequisitoCaratteristica{
+				{Caratteristiche: []shared.Caratteristica{shared.Forza, shared.Destrezza}, ValoreMinimo: 13},
+			},
+		}},
+	}
+
+	return Repositories{
+		Classi: &MockClassiRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*classi.Classe, error) {
+				return classiByID[id], nil
+			},
+			GetSottoclasseFunc: func(_ context.Context, id string) (*classi.SottoClasse, error) {
+				return sottoclassi[id], nil
+			},
+		},
+		Specie: &MockSpecieRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*specie.Specie, error) {
+				if id == "umano" {
+					return &specie.Specie{ID: id}, nil
+				}
+				return nil, nil
+			},
+		},
+		Background: &MockBackgroundRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*background.Background, error) {
+				if id == "soldato" {
+					return &background.Background{ID: id}, nil
+				}
+				return nil, nil
+			},
+		},
+		Talenti: &MockTalentiRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*talenti.Talento, error) {
+				return talentiByID[id], nil
+			},
+		},
+	}
+}
+
+// valido is a level 5 fighter built with point buy.
+func valido() Personaggio {
+	return Personaggio{
+		IDSpecie:                  "umano",
+		IDBackground:              "soldato",
+		Classi:                    []ClassePersonaggio{{IDClasse: "guerriero", Livello: 5, IDSottoclasse: "campione"}},
+		Talenti:                   []string{"allerta", "atleta"},
+		MetodoCaratteristiche:     PointBuy,
+		Caratteristiche:           shared.Punteggi{Forza: 15, Destrezza: 14, Costituzione: 13, Intelligenza: 8, Saggezza: 12, Carisma: 10},
+		IncrementiCaratteristiche: shared.Punteggi{Forza: 2, Costituzione: 1},
+	}
+}
+
+func codici(result *shared.ErrorObject) []string {
+	codes := make([]string, len(result.Errors))
+	for i, e := range result.Errors {
+		codes[i] = e.Code
+	}
+	return codes
+}
+
+func TestService_Valida(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	tests := []struct {
+		name   string
+		modify func(p *Personaggio)
+		codes  []string
+	}{
+		{"valid draft", func(*Personaggio) {}, nil},
+		{"unknown specie and background", func(p *Personaggio) {
+			p.IDSpecie, p.IDBackground = "drow", "eremita"
+		}, []string{CodiceRisorsaSconosciuta, CodiceRisorsaSconosciuta}},
+		{"unknown classe", func(p *Personaggio) {
+			p.Classi = []ClassePersonaggio{{IDClasse: "monaco", Livello: 5}}
+		}, []string{CodiceRisorsaSconosciuta}},
+		{"subclass of another class", func(p *Personaggio) {
+			p.Classi[0].IDSottoclasse = "evocatore"
+		}, []string{CodiceSottoclasseAltraClasse}},
+		{"subclass before its level", func(p *Personaggio) {
+			p.Classi[0].Livello = 2
+			p.Talenti = nil
+		}, []string{CodiceSottoclasseTroppoPresto}},
+		{"unknown subclass", func(p *Personaggio) {
+			p.Classi[0].IDSottoclasse = "samurai"
+		}, []string{CodiceRisorsaSconosciuta}},
+		{"multiclass prerequisites unmet", func(p *Personaggio) {
+			p.Classi = append(p.Classi, ClassePersonaggio{IDClasse: "paladino", Livello: 1})
+		}, []string{CodicePrerequisitoMulticlasse}},
+		{"multiclass prerequisites met with increases", func(p *Personaggio) {
+			p.Classi = append(p.Classi, ClassePersonaggio{IDClasse: "mago", Livello: 1})
+			p.IncrementiCaratteristiche.Carisma = 3
+			p.Classi = append(p.Classi, ClassePersonaggio{IDClasse: "paladino", Livello: 1})
+		}, nil},
+		{"point buy overspend", func(p *Personaggio) {
+			p.Caratteristiche.Intelligenza = 14
+		}, []string{CodicePointBuySforato}},
+		{"point buy out of range", func(p *Personaggio) {
+			p.Caratteristiche.Forza = 16
+			p.Caratteristiche.Carisma = 7
+		}, []string{CodicePointBuyFuoriIntervallo, CodicePointBuyFuoriIntervallo}},
+		{"standard array", func(p *Personaggio) {
+			p.MetodoCaratteristiche = SerieStandard
+			p.Caratteristiche.Intelligenza = 10
+			p.Caratteristiche.Saggezza = 12
+			p.Caratteristiche.Carisma = 8
+		}, nil},
+		{"not the standard array", func(p *Personaggio) {
+			p.MetodoCaratteristiche = SerieStandard
+			p.Caratteristiche.Carisma = 14
+		}, []string{CodiceSerieStandard}},
+		{"manual scores are not checked but capped", func(p *Personaggio) {
+			p.MetodoCaratteristiche = Manuale
+			p.Caratteristiche.Forza = 19
+		}, []string{CodiceCaratteristicaTroppoAlta}},
+		{"feat level prerequisite", func(p *Personaggio) {
+			p.Classi[0] = ClassePersonaggio{IDClasse: "guerriero", Livello: 3}
+		}, []string{CodicePrerequisitoTalento}},
+		{"feat ability prerequisite", func(p *Personaggio) {
+			p.Caratteristiche.Forza, p.Caratteristiche.Destrezza = 8, 8
+			p.IncrementiCaratteristiche.Forza = 0
+		}, []string{CodicePrerequisitoTalento}},
+		{"feats taken twice", func(p *Personaggio) {
+			p.Talenti = []string{"abile", "abile", "allerta", "allerta", "duro"}
+		}, []string{CodiceTalentoNonRipetibile, CodiceRisorsaSconosciuta}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valido()
+			tt.modify(&p)
+			service := NewService(newTestRepositories(), logger)
+
+			result, err := service.Valida(ctx, p)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := codici(result)
+			if len(got) != len(tt.codes) {
+				t.Fatalf("expected violations %v, got %+v", tt.codes, result.Errors)
+			}
+			for i, code := range tt.codes {
+				if got[i] != code {
+					t.Errorf("expected violations %v, got %v", tt.codes, got)
+				}
+				if result.Errors[i].Title == "" || result.Errors[i].Detail == "" {
+					t.Errorf("violation without title or detail: %+v", result.Errors[i])
+				}
+			}
+		})
+	}
+
+	t.Run("valid draft has an empty error list", func(t *testing.T) {
+		result, _ := NewService(newTestRepositories(), logger).Valida(ctx, valido())
+
+		if result.Errors == nil {
+			t.Error("expected an empty, non-nil error list")
+		}
+	})
+
+	t.Run("multiclass detail names the prerequisite", func(t *testing.T) {
+		p := valido()
+		p.Classi = append(p.Classi, ClassePersonaggio{IDClasse: "paladino", Livello: 1})
+
+		result, _ := NewService(newTestRepositories(), logger).Valida(ctx, p)
+
+		if want := "classe 'paladino' requires Carisma 13 to multiclass"; result.Errors[0].Detail != want {
+			t.Errorf("expected detail %q, got %q", want, result.Errors[0].Detail)
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		modify func(p *Personaggio)
+	}{
+		{"no classi", func(p *Personaggio) { p.Classi = nil }},
+		{"livello zero", func(p *Personaggio) { p.Classi[0].Livello = 0 }},
+		{"total above 20", func(p *Personaggio) {
+			p.Classi = append(p.Classi, ClassePersonaggio{IDClasse: "mago", Livello: 16})
+		}},
+		{"duplicate classe", func(p *Personaggio) { p.Classi = append(p.Classi, p.Classi[0]) }},
+		{"invalid specie id", func(p *Personaggio) { p.IDSpecie = "inv@lid" }},
+		{"invalid talento id", func(p *Personaggio) { p.Talenti = []string{"inv@lid"} }},
+		{"unknown metodo", func(p *Personaggio) { p.MetodoCaratteristiche = "tirati" }},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			p := valido()
+			tt.modify(&p)
+
+			_, err := NewService(newTestRepositories(), logger).Valida(ctx, p)
+
+			var appErr *shared.AppError
+			if !errors.As(err, &appErr) || appErr.HTTPStatus != 400 {
+				t.Errorf("expected 400 AppError, got %v", err)
+			}
+		})
+	}
+
+	t.Run("repository error", func(t *testing.T) {
+		repos := newTestRepositories()
+		repos.Talenti = &MockTalentiRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*talenti.Talento, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		_, err := NewService(repos, logger).Valida(ctx, valido())
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != 500 {
+			t.Errorf("expected 500 AppError, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/personaggi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PersonaggiService interface {
+	Valida(ctx context.Context, p personaggi.Personaggio) (*shared.ErrorObject, error)
+}
+
+type Handler struct {
+	service PersonaggiService
+}
+
+func NewHandler(service PersonaggiService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/valida", h.Valida)
+
+	return r
+}
+
+// Valida always answers 200 for a well formed draft; the rule violations,
+// if any, are the errors of the response.
+func (h *Handler) Valida(w http.ResponseWriter, r *http.Request) {
+	var p personaggi.Personaggio
+	if err := shared.DecodeJSON(w, r, &p); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	result, err := h.service.Valida(r.Context(), p)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,99 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/personaggi"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	validaFunc func(ctx context.Context, p personaggi.Personaggio) (*shared.ErrorObject, error)
+}
+
+func (m *mockService) Valida(ctx context.Context, p personaggi.Personaggio) (*shared.ErrorObject, error) {
+	if m.validaFunc != nil {
+		return m.validaFunc(ctx, p)
+	}
+	return &shared.ErrorObject{Errors: []shared.ErrorCommon{}}, nil
+}
+
+func newTestRouter(svc PersonaggiService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/personaggi", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_Valida(t *testing.T) {
+	t.Run("returns the violations", func(t *testing.T) {
+		var captured personaggi.Personaggio
+		svc := &mockService{
+			validaFunc: func(_ context.Context, p personaggi.Personaggio) (*shared.ErrorObject, error) {
+				captured = p
+				return &shared.ErrorObject{Errors: []shared.ErrorCommon{
+					{Code: personaggi.CodicePointBuySforato, Title: "Point buy overspent", Detail: "point buy costs 29 points, the budget is 27"},
+				}}, nil
+			},
+		}
+
+		body := `{"id-specie":"umano","id-background":"soldato",
+			"classi":[{"id-classe":"guerriero","livello":3,"id-sotto-classe":"campione"}],
+			"talenti":["allerta"],"metodo-caratteristiche":"point-buy",
+			"caratteristiche":{"forza":15,"destrezza":15,"costituzione":15,"intelligenza":8,"saggezza":8,"carisma":8},
+			"incrementi-caratteristiche":{"forza":2,"costituzione":1}}`
+		req := httptest.NewRequest(http.MethodPost, "/personaggi/valida", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if captured.Classi[0].IDSottoclasse != "campione" || captured.Caratteristiche.Destrezza != 15 ||
+			captured.IncrementiCaratteristiche.Forza != 2 || captured.MetodoCaratteristiche != personaggi.PointBuy {
+			t.Errorf("unexpected draft %+v", captured)
+		}
+
+		var response shared.ErrorObject
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Errors) != 1 || response.Errors[0].Code != personaggi.CodicePointBuySforato {
+			t.Errorf("unexpected response %+v", response)
+		}
+	})
+
+	t.Run("valid draft", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/personaggi/valida", strings.NewReader(`{"classi":[{"id-classe":"mago","livello":1}]}`))
+		rec := httptest.NewRecorder()
+
+		newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if body := strings.TrimSpace(rec.Body.String()); body != `{"errors":[]}` {
+			t.Errorf("unexpected body %s", body)
+		}
+	})
+
+	for _, body := range []string{``, `{"classi":`, `{"classe":[]}`} {
+		t.Run("invalid body "+body, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/personaggi/valida", strings.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
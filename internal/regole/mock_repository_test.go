@@ -0,0 +1,26 @@
+package regole
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type MockRepository struct {
+	ListFunc    func(ctx context.Context, filter shared.ListFilter) ([]Regola, int, error)
+	GetByIDFunc func(ctx context.Context, id string) (*Regola, error)
+}
+
+func (m *MockRepository) List(ctx context.Context, filter shared.ListFilter) ([]Regola, int, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockRepository) GetByID(ctx context.Context, id string) (*Regola, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/regole"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type regolaRow struct {
+	ID                          string         `db:"id"`
+	Nome                        string         `db:"nome"`
+	Descrizione                 string         `db:"descrizione"`
+	Sinonimi                    pq.StringArray `db:"sinonimi"`
+	DocumentazioneDiRiferimento string         `db:"documentazione_di_riferimento"`
+}
+
+func (r *regolaRow) toRegola() regole.Regola {
+	return regole.Regola{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Descrizione:                 r.Descrizione,
+		Sinonimi:                    r.Sinonimi,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+}
+
+const selectRegola = `
+	SELECT id, nome, descrizione, sinonimi, documentazione_di_riferimento
+	FROM regole`
+
+func (r *PostgresRepository) List(ctx context.Context, filter shared.ListFilter) ([]regole.Regola, int, error) {
+	q := shared.NewPaginatedQuery(
+		selectRegola+` WHERE 1=1`,
+		`SELECT COUNT(*) FROM regole WHERE 1=1`,
+		make(map[string]any),
+		filter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []regolaRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]regole.Regola, len(rows))
+	for i, row := range rows {
+		result[i] = row.toRegola()
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*regole.Regola, error) {
+	var row regolaRow
+	if err := r.db.GetContext(ctx, &row, selectRegola+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get regola by id: %w", err)
+	}
+
+	regola := row.toRegola()
+	return &regola, nil
+}
@@ -0,0 +1,24 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestRegolaRow_ToRegola(t *testing.T) {
+	row := regolaRow{
+		ID:       "vantaggio",
+		Nome:     "Vantaggio",
+		Sinonimi: pq.StringArray{"con vantaggio"},
+	}
+
+	got := row.toRegola()
+
+	if got.ID != "vantaggio" || got.Nome != "Vantaggio" {
+		t.Errorf("unexpected regola %+v", got)
+	}
+	if len(got.Sinonimi) != 1 || got.Sinonimi[0] != "con vantaggio" {
+		t.Errorf("unexpected sinonimi %v", got.Sinonimi)
+	}
+}
@@ -0,0 +1,8 @@
+package regole
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type ListRegoleResponse struct {
+	shared.PaginationMeta
+	Regole []Regola `json:"regole"`
+}
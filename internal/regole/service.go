@@ -0,0 +1,58 @@
+package regole
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	repo      Repository
+	glossario shared.Glossario
+	logger    *slog.Logger
+}
+
+func NewService(repo Repository, glossario shared.Glossario, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:      repo,
+		glossario: glossario,
+		logger:    logger,
+	}
+}
+
+func (s *Service) ListRegole(ctx context.Context, filter shared.ListFilter) (*ListRegoleResponse, error) {
+	regole, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list regole", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListRegoleResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Regole:         regole,
+	}, nil
+}
+
+func (s *Service) GetRegola(ctx context.Context, id string, campi shared.Campi) (*Regola, error) {
+	regola, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get regola", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if regola == nil {
+		return nil, ErrRegolaNotFound(id)
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		// A rule always mentions itself; only links to other entries are useful.
+		regola.Riferimenti = slices.DeleteFunc(s.glossario.Riferimenti(ctx, regola), func(rif shared.Riferimento) bool {
+			return rif.Tipo == shared.RiferimentoRegola && rif.ID == regola.ID
+		})
+	}
+	return regola, nil
+}
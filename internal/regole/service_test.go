@@ -0,0 +1,149 @@
+package regole
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+type mockGlossario []shared.Riferimento
+
+func (m mockGlossario) Riferimenti(_ context.Context, _ any) []shared.Riferimento {
+	return append([]shared.Riferimento(nil), m...)
+}
+
+func TestService_ListRegole(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ shared.ListFilter) ([]Regola, int, error) {
+				return []Regola{
+					{ID: "azione-di-attacco", Nome: "Azione di Attacco"},
+					{ID: "vantaggio", Nome: "Vantaggio"},
+				}, 2, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.ListRegole(ctx, shared.ListFilter{Limit: 20})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 2 || len(result.Regole) != 2 {
+			t.Errorf("expected 2 regole, got %d/%d", result.NumeroDiElementi, len(result.Regole))
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ shared.ListFilter) ([]Regola, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.ListRegole(ctx, shared.ListFilter{Limit: 20})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestService_GetRegola(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Regola, error) {
+				return &Regola{ID: id, Nome: "Vantaggio"}, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.GetRegola(ctx, "vantaggio", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "vantaggio" {
+			t.Errorf("expected id 'vantaggio', got '%s'", result.ID)
+		}
+	})
+
+	t.Run("riferimenti exclude the rule itself", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Regola, error) {
+				return &Regola{ID: id, Nome: "Lottare", Descrizione: "Lottare lascia il bersaglio Afferrato."}, nil
+			},
+		}
+		glossario := mockGlossario{
+			{Tipo: shared.RiferimentoRegola, ID: "lottare", Nome: "Lottare", Link: "/v1/regole/lottare"},
+			{Tipo: shared.RiferimentoCondizione, ID: "afferrato", Nome: "Afferrato", Link: "/v1/condizioni/afferrato"},
+		}
+
+		service := NewService(repo, glossario, logger)
+
+		result, err := service.GetRegola(ctx, "lottare", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Riferimenti) != 1 || result.Riferimenti[0].ID != "afferrato" {
+			t.Errorf("unexpected riferimenti %+v", result.Riferimenti)
+		}
+	})
+
+	t.Run("campi without riferimenti skip the glossario", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Regola, error) {
+				return &Regola{ID: id, Nome: "Lottare", Descrizione: "Lottare lascia il bersaglio Afferrato."}, nil
+			},
+		}
+		glossario := mockGlossario{{Tipo: shared.RiferimentoCondizione, ID: "afferrato", Nome: "Afferrato", Link: "/v1/condizioni/afferrato"}}
+
+		service := NewService(repo, glossario, logger)
+
+		result, err := service.GetRegola(ctx, "lottare", shared.Campi{"nome"})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Riferimenti != nil {
+			t.Errorf("expected no riferimenti, got %+v", result.Riferimenti)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := NewService(&MockRepository{}, nil, logger)
+
+		_, err := service.GetRegola(ctx, "nonexistent", nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+}
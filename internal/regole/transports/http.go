@@ -0,0 +1,71 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/regole"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type RegoleService interface {
+	ListRegole(ctx context.Context, filter shared.ListFilter) (*regole.ListRegoleResponse, error)
+	GetRegola(ctx context.Context, id string, campi shared.Campi) (*regole.Regola, error)
+}
+
+type Handler struct {
+	service RegoleService
+}
+
+func NewHandler(service RegoleService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListRegole)
+	r.Get("/{id-regola}", h.GetRegola)
+
+	return r
+}
+
+func (h *Handler) ListRegole(w http.ResponseWriter, r *http.Request) {
+	filter, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListRegole(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetRegola(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-regola")
+	if err := shared.ValidateID("id-regola", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	regola, err := h.service.GetRegola(r.Context(), id, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, regola, campi)
+}
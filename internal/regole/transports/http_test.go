@@ -0,0 +1,97 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/regole"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	listRegoleFunc func(ctx context.Context, filter shared.ListFilter) (*regole.ListRegoleResponse, error)
+	getRegolaFunc  func(ctx context.Context, id string, campi shared.Campi) (*regole.Regola, error)
+}
+
+func (m *mockService) ListRegole(ctx context.Context, filter shared.ListFilter) (*regole.ListRegoleResponse, error) {
+	if m.listRegoleFunc != nil {
+		return m.listRegoleFunc(ctx, filter)
+	}
+	return &regole.ListRegoleResponse{}, nil
+}
+
+func (m *mockService) GetRegola(ctx context.Context, id string, campi shared.Campi) (*regole.Regola, error) {
+	if m.getRegolaFunc != nil {
+		return m.getRegolaFunc(ctx, id, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc RegoleService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/regole", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_ListRegole(t *testing.T) {
+	svc := &mockService{
+		listRegoleFunc: func(_ context.Context, _ shared.ListFilter) (*regole.ListRegoleResponse, error) {
+			return &regole.ListRegoleResponse{
+				PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+				Regole:         []regole.Regola{{ID: "vantaggio", Nome: "Vantaggio"}},
+			}, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/regole", nil)
+	rec := httptest.NewRecorder()
+
+	newTestRouter(svc).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var response regole.ListRegoleResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Regole) != 1 {
+		t.Errorf("expected 1 regola, got %d", len(response.Regole))
+	}
+}
+
+func TestHandler_GetRegola(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getRegolaFunc: func(_ context.Context, id string, _ shared.Campi) (*regole.Regola, error) {
+				return nil, regole.ErrRegolaNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/regole/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid id returns 400", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/regole/inv@lid", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
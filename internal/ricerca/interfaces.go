@@ -0,0 +1,42 @@
+package ricerca
+
+import (
+	"context"
+	"slices"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+// Sorgente searches one resource type. Cerca only receives attributes
+// listed by HaAttributo.
+type Sorgente interface {
+	HaAttributo(nome string) bool
+	Cerca(ctx context.Context, filtro shared.FiltroRicerca) ([]any, error)
+}
+
+type sorgente[T any] struct {
+	attributi []string
+	cerca     func(ctx context.Context, filtro shared.FiltroRicerca) ([]T, error)
+}
+
+// NuovaSorgente adapts a module's search method, with the attributes its
+// model accepts, to a Sorgente.
+func NuovaSorgente[T any](attributi []string, cerca func(ctx context.Context, filtro shared.FiltroRicerca) ([]T, error)) Sorgente {
+	return &sorgente[T]{attributi: attributi, cerca: cerca}
+}
+
+func (s *sorgente[T]) HaAttributo(nome string) bool {
+	return slices.Contains(s.attributi, nome)
+}
+
+func (s *sorgente[T]) Cerca(ctx context.Context, filtro shared.FiltroRicerca) ([]any, error) {
+	items, err := s.cerca(ctx, filtro)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]any, len(items))
+	for i, item := range items {
+		result[i] = item
+	}
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package ricerca
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type TipoRicerca string
+
+const (
+	Classi      TipoRicerca = "CLASSI"
+	Sottoclassi TipoRicerca = "SOTTOCLASSI"
+	Mostri      TipoRicerca = "MOSTRI"
+	Incantesimi TipoRicerca = "INCANTESIMI"
+	Specie      TipoRicerca = "SPECIE"
+	Background  TipoRicerca = "BACKGROUND"
+	Pantheon    TipoRicerca = "PANTHEON"
+	Oggetti     TipoRicerca = "OGGETTI"
+	Talenti     TipoRicerca = "TALENTI"
+	Maestrie    TipoRicerca = "MAESTRIE"
+	Regole      TipoRicerca = "REGOLE"
+	Condizioni  TipoRicerca = "CONDIZIONI"
+	Bastioni    TipoRicerca = "BASTIONI"
+)
+
+var TipiRicerca = []TipoRicerca{
+	Classi, Sottoclassi, Mostri, Incantesimi, Specie, Background, Pantheon,
+	Oggetti, Talenti, Maestrie, Regole, Condizioni, Bastioni,
+}
+
+type Ordinamento string
+
+const (
+	OrdinamentoAsc  Ordinamento = "ASC"
+	OrdinamentoDesc Ordinamento = "DESC"
+)
+
+// CampoRicerca matches the attribute NomeAttributo against ParolaChiave, a
+// case insensitive regular expression.
+type CampoRicerca struct {
+	NomeAttributo string `json:"nome-attributo"`
+	ParolaChiave  string `json:"parola-chiave"`
+}
+
+// ContestoRicerca is the body of a search request. Limit and Offset apply
+// to each tipo separately; a missing or negative Limit returns up to 1000
+// records, the most a search returns. CampiInRisposta projects the
+// results, "*" or no fields meaning all of them. The JSON keys are
+// hyphenated, as everywhere else in the API; the spec's example uses
+// underscores (perimetro_ricerca, nome_attributo, ...) instead.
+type ContestoRicerca struct {
+	Tipi             []TipoRicerca            `json:"tipi"`
+	PerimetroRicerca []CampoRicerca           `json:"perimetro-ricerca,omitempty"`
+	Condizione       shared.CondizioneRicerca `json:"condizione,omitempty"`
+	CampiInRisposta  []string                 `json:"campi-in-risposta,omitempty"`
+	OrdinaPerCampo   string                   `json:"ordina-per-campo,omitempty"`
+	Ordinamento      Ordinamento              `json:"ordinamento,omitempty"`
+	Limit            *int                     `json:"$limit,omitempty"`
+	Offset           *int                     `json:"$offset,omitempty"`
+}
+
+// RisultatoRicerca maps each requested tipo to its projected results.
+type RisultatoRicerca map[TipoRicerca][]map[string]any
@@ -0,0 +1,195 @@
+package ricerca
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+const (
+	maxCriteri          = 10
+	maxLunghezzaPattern = 100
+	// maxRisultati caps the results of each tipo, and is the limit of a
+	// search without $limit.
+	maxRisultati = 1000
+)
+
+type Service struct {
+	sorgenti map[TipoRicerca]Sorgente
+	logger   *slog.Logger
+}
+
+func NewService(sorgenti map[TipoRicerca]Sorgente, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		sorgenti: sorgenti,
+		logger:   logger,
+	}
+}
+
+// Cerca runs the search on every requested tipo. A perimeter attribute the
+// tipo does not have is a criterion it cannot satisfy: with "and" the tipo
+// has no results, with "or" the criterion is ignored.
+func (s *Service) Cerca(ctx context.Context, contesto ContestoRicerca) (RisultatoRicerca, error) {
+	if err := s.valida(contesto); err != nil {
+		return nil, shared.NewBadRequestError(err.Error(), err)
+	}
+
+	result := make(RisultatoRicerca)
+	for _, tipo := range contesto.Tipi {
+		if _, done := result[tipo]; done {
+			continue
+		}
+		sorgente := s.sorgenti[tipo]
+
+		filtro, ok := filtroPer(sorgente, contesto)
+		if !ok {
+			result[tipo] = []map[string]any{}
+			continue
+		}
+
+		items, err := sorgente.Cerca(ctx, filtro)
+		if err != nil {
+			if shared.IsInvalidRegularExpression(err) {
+				return nil, shared.NewBadRequestError("parola-chiave is not a valid regular expression", err)
+			}
+			s.logger.Error("failed to search", "tipo", tipo, "error", err)
+			return nil, shared.NewInternalError(err)
+		}
+
+		projected := make([]map[string]any, len(items))
+		for i, item := range items {
+			if projected[i], err = shared.Campi(contesto.CampiInRisposta).Proietta(item); err != nil {
+				s.logger.Error("failed to project search result", "tipo", tipo, "error", err)
+				return nil, shared.NewInternalError(err)
+			}
+		}
+		result[tipo] = projected
+	}
+	return result, nil
+}
+
+func (s *Service) valida(c ContestoRicerca) error {
+	if len(c.Tipi) == 0 {
+		return fmt.Errorf("tipi is required")
+	}
+	for _, tipo := range c.Tipi {
+		if !slices.Contains(TipiRicerca, tipo) {
+			return fmt.Errorf("tipi must contain only: %s", elenco(TipiRicerca))
+		}
+		if _, ok := s.sorgenti[tipo]; !ok {
+			return fmt.Errorf("tipo %s is not searchable yet; supported tipi: %s", tipo, elenco(s.tipiSupportati()))
+		}
+	}
+
+	if c.Limit != nil && *c.Limit > maxRisultati {
+		return fmt.Errorf("$limit cannot exceed %d", maxRisultati)
+	}
+
+	switch c.Condizione {
+	case "", shared.CondizioneAnd, shared.CondizioneOr:
+	default:
+		return fmt.Errorf("condizione must be one of: and, or")
+	}
+	switch c.Ordinamento {
+	case "", OrdinamentoAsc, OrdinamentoDesc:
+	default:
+		return fmt.Errorf("ordinamento must be one of: ASC, DESC")
+	}
+
+	if len(c.PerimetroRicerca) > maxCriteri {
+		return fmt.Errorf("perimetro-ricerca: too many values (max %d)", maxCriteri)
+	}
+	for _, campo := range c.PerimetroRicerca {
+		if campo.NomeAttributo == "" || campo.ParolaChiave == "" {
+			return fmt.Errorf("perimetro-ricerca: nome-attributo and parola-chiave are required")
+		}
+		if len(campo.ParolaChiave) > maxLunghezzaPattern {
+			return fmt.Errorf("parola-chiave cannot exceed %d", maxLunghezzaPattern)
+		}
+		// Go's RE2 syntax is a subset of Postgres' that cannot backtrack
+		// exponentially; rejecting what it cannot parse keeps costly
+		// patterns such as backreferences out of the database.
+		if _, err := regexp.Compile(campo.ParolaChiave); err != nil {
+			return fmt.Errorf("parola-chiave %q is not a valid regular expression", campo.ParolaChiave)
+		}
+		if !s.attributoNoto(c.Tipi, campo.NomeAttributo) {
+			return fmt.Errorf("nome-attributo %q does not exist for the requested tipi", campo.NomeAttributo)
+		}
+	}
+	if c.OrdinaPerCampo != "" && !s.attributoNoto(c.Tipi, c.OrdinaPerCampo) {
+		return fmt.Errorf("ordina-per-campo %q does not exist for the requested tipi", c.OrdinaPerCampo)
+	}
+	return nil
+}
+
+func (s *Service) attributoNoto(tipi []TipoRicerca, nome string) bool {
+	return slices.ContainsFunc(tipi, func(tipo TipoRicerca) bool {
+		return s.sorgenti[tipo].HaAttributo(nome)
+	})
+}
+
+func (s *Service) tipiSupportati() []TipoRicerca {
+	return slices.DeleteFunc(slices.Clone(TipiRicerca), func(tipo TipoRicerca) bool {
+		_, ok := s.sorgenti[tipo]
+		return !ok
+	})
+}
+
+// filtroPer builds the filter for one tipo. It reports false when the tipo
+// cannot have results.
+func filtroPer(sorgente Sorgente, c ContestoRicerca) (shared.FiltroRicerca, bool) {
+	filtro := shared.FiltroRicerca{
+		Condizione: shared.CondizioneAnd,
+		Sort:       shared.SortAsc,
+		Limit:      maxRisultati,
+	}
+	if c.Condizione == shared.CondizioneOr {
+		filtro.Condizione = shared.CondizioneOr
+	}
+	if c.Ordinamento == OrdinamentoDesc {
+		filtro.Sort = shared.SortDesc
+	}
+	if c.Limit != nil && *c.Limit >= 0 {
+		filtro.Limit = *c.Limit
+	}
+	if c.Offset != nil && *c.Offset > 0 {
+		filtro.Offset = *c.Offset
+	}
+	if sorgente.HaAttributo(c.OrdinaPerCampo) {
+		filtro.OrdinaPer = c.OrdinaPerCampo
+	}
+
+	for _, campo := range c.PerimetroRicerca {
+		if !sorgente.HaAttributo(campo.NomeAttributo) {
+			if filtro.Condizione == shared.CondizioneAnd {
+				return filtro, false
+			}
+			continue
+		}
+		filtro.Criteri = append(filtro.Criteri, shared.CriterioRicerca{
+			Attributo: campo.NomeAttributo,
+			Pattern:   campo.ParolaChiave,
+		})
+	}
+	if len(c.PerimetroRicerca) > 0 && len(filtro.Criteri) == 0 {
+		return filtro, false
+	}
+	return filtro, true
+}
+
+func elenco(tipi []TipoRicerca) string {
+	names := make([]string, len(tipi))
+	for i, t := range tipi {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,210 @@
+package ricerca
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+type voce struct {
+	ID          string `json:"id"`
+	Nome        string `json:"nome"`
+	Descrizione string `json:"descrizione"`
+}
+
+type mockSorgente struct {
+	calls    int
+	captured shared.FiltroRicerca
+	err      error
+}
+
+func (m *mockSorgente) cerca(_ context.Context, filtro shared.FiltroRicerca) ([]voce, error) {
+	m.calls++
+	m.captured = filtro
+	return []voce{{ID: "mago", Nome: "Mago", Descrizione: "Studioso dell'arcano"}}, m.err
+}
+
+func newTestService(classi, sottoclassi *mockSorgente) *Service {
+	return NewService(map[TipoRicerca]Sorgente{
+		Classi:      NuovaSorgente([]string{"id", "nome", "dado-vita"}, classi.cerca),
+		Sottoclassi: NuovaSorgente([]string{"id", "nome", "id-classe-associata"}, sottoclassi.cerca),
+	}, newTestLogger())
+}
+
+func intPtr(i int) *int { return &i }
+
+func TestService_Cerca(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("builds the filter for each tipo", func(t *testing.T) {
+		classi, sottoclassi := &mockSorgente{}, &mockSorgente{}
+		service := newTestService(classi, sottoclassi)
+
+		result, err := service.Cerca(ctx, ContestoRicerca{
+			Tipi:             []TipoRicerca{Classi, Sottoclassi},
+			PerimetroRicerca: []CampoRicerca{{NomeAttributo: "nome", ParolaChiave: ".*ma.*"}},
+			OrdinaPerCampo:   "dado-vita",
+			Ordinamento:      OrdinamentoDesc,
+			Limit:            intPtr(5),
+			Offset:           intPtr(-3),
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result[Classi]) != 1 || len(result[Sottoclassi]) != 1 {
+			t.Errorf("unexpected result %v", result)
+		}
+		f := classi.captured
+		if f.Condizione != shared.CondizioneAnd || f.Sort != shared.SortDesc || f.Limit != 5 || f.Offset != 0 {
+			t.Errorf("unexpected classi filter %+v", f)
+		}
+		if f.OrdinaPer != "dado-vita" || len(f.Criteri) != 1 || f.Criteri[0].Pattern != ".*ma.*" {
+			t.Errorf("unexpected classi filter %+v", f)
+		}
+		if sottoclassi.captured.OrdinaPer != "" {
+			t.Errorf("sort attribute unknown to sottoclassi should fall back to default, got %q", sottoclassi.captured.OrdinaPer)
+		}
+	})
+
+	t.Run("missing limit returns up to the maximum", func(t *testing.T) {
+		classi := &mockSorgente{}
+		service := newTestService(classi, &mockSorgente{})
+
+		if _, err := service.Cerca(ctx, ContestoRicerca{Tipi: []TipoRicerca{Classi}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if classi.captured.Limit != maxRisultati {
+			t.Errorf("expected limit %d, got %d", maxRisultati, classi.captured.Limit)
+		}
+	})
+
+	t.Run("attribute missing from a tipo with and yields no results", func(t *testing.T) {
+		classi, sottoclassi := &mockSorgente{}, &mockSorgente{}
+		service := newTestService(classi, sottoclassi)
+
+		result, err := service.Cerca(ctx, ContestoRicerca{
+			Tipi:             []TipoRicerca{Classi, Sottoclassi},
+			PerimetroRicerca: []CampoRicerca{{NomeAttributo: "id-classe-associata", ParolaChiave: "mago"}},
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if classi.calls != 0 || result[Classi] == nil || len(result[Classi]) != 0 {
+			t.Errorf("expected empty CLASSI without querying, got %v after %d calls", result[Classi], classi.calls)
+		}
+		if sottoclassi.calls != 1 {
+			t.Errorf("expected SOTTOCLASSI to be searched")
+		}
+	})
+
+	t.Run("attribute missing from a tipo with or is ignored", func(t *testing.T) {
+		classi := &mockSorgente{}
+		service := newTestService(classi, &mockSorgente{})
+
+		_, err := service.Cerca(ctx, ContestoRicerca{
+			Tipi:       []TipoRicerca{Classi, Sottoclassi},
+			Condizione: shared.CondizioneOr,
+			PerimetroRicerca: []CampoRicerca{
+				{NomeAttributo: "id-classe-associata", ParolaChiave: "mago"},
+				{NomeAttributo: "nome", ParolaChiave: "mago"},
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(classi.captured.Criteri) != 1 || classi.captured.Criteri[0].Attributo != "nome" {
+			t.Errorf("unexpected criteri %+v", classi.captured.Criteri)
+		}
+	})
+
+	t.Run("projects the requested fields", func(t *testing.T) {
+		service := newTestService(&mockSorgente{}, &mockSorgente{})
+
+		result, err := service.Cerca(ctx, ContestoRicerca{
+			Tipi:            []TipoRicerca{Classi},
+			CampiInRisposta: []string{"id", "scuola"},
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got := result[Classi][0]
+		if len(got) != 1 || got["id"] != "mago" {
+			t.Errorf("unexpected projection %v", got)
+		}
+	})
+
+	t.Run("star returns every field", func(t *testing.T) {
+		service := newTestService(&mockSorgente{}, &mockSorgente{})
+
+		result, _ := service.Cerca(ctx, ContestoRicerca{Tipi: []TipoRicerca{Classi}, CampiInRisposta: []string{"*"}})
+
+		if len(result[Classi][0]) != 3 {
+			t.Errorf("expected all fields, got %v", result[Classi][0])
+		}
+	})
+
+	invalid := []struct {
+		name     string
+		contesto ContestoRicerca
+	}{
+		{"no tipi", ContestoRicerca{}},
+		{"unknown tipo", ContestoRicerca{Tipi: []TipoRicerca{"NPC"}}},
+		{"tipo not searchable yet", ContestoRicerca{Tipi: []TipoRicerca{Mostri}}},
+		{"unknown condizione", ContestoRicerca{Tipi: []TipoRicerca{Classi}, Condizione: "xor"}},
+		{"unknown ordinamento", ContestoRicerca{Tipi: []TipoRicerca{Classi}, Ordinamento: "UP"}},
+		{"unknown attribute", ContestoRicerca{Tipi: []TipoRicerca{Classi}, PerimetroRicerca: []CampoRicerca{{NomeAttributo: "scuola", ParolaChiave: "x"}}}},
+		{"empty keyword", ContestoRicerca{Tipi: []TipoRicerca{Classi}, PerimetroRicerca: []CampoRicerca{{NomeAttributo: "nome"}}}},
+		{"invalid regex", ContestoRicerca{Tipi: []TipoRicerca{Classi}, PerimetroRicerca: []CampoRicerca{{NomeAttributo: "nome", ParolaChiave: "(mago"}}}},
+		{"backreference", ContestoRicerca{Tipi: []TipoRicerca{Classi}, PerimetroRicerca: []CampoRicerca{{NomeAttributo: "nome", ParolaChiave: `(a)\1`}}}},
+		{"unknown sort attribute", ContestoRicerca{Tipi: []TipoRicerca{Classi}, OrdinaPerCampo: "scuola"}},
+		{"limit above the maximum", ContestoRicerca{Tipi: []TipoRicerca{Classi}, Limit: intPtr(maxRisultati + 1)}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			service := newTestService(&mockSorgente{}, &mockSorgente{})
+
+			_, err := service.Cerca(ctx, tt.contesto)
+
+			var appErr *shared.AppError
+			if !errors.As(err, &appErr) || appErr.HTTPStatus != 400 {
+				t.Errorf("expected 400 AppError, got %v", err)
+			}
+		})
+	}
+
+	t.Run("regex rejected by postgres returns 400", func(t *testing.T) {
+		service := newTestService(&mockSorgente{err: &pq.Error{Code: "2201B"}}, &mockSorgente{})
+
+		_, err := service.Cerca(ctx, ContestoRicerca{Tipi: []TipoRicerca{Classi}})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != 400 {
+			t.Errorf("expected 400 AppError, got %v", err)
+		}
+	})
+
+	t.Run("repository error returns 500", func(t *testing.T) {
+		service := newTestService(&mockSorgente{err: errors.New("database error")}, &mockSorgente{})
+
+		_, err := service.Cerca(ctx, ContestoRicerca{Tipi: []TipoRicerca{Classi}})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != 500 {
+			t.Errorf("expected 500 AppError, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/ricerca"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type RicercaService interface {
+	Cerca(ctx context.Context, contesto ricerca.ContestoRicerca) (ricerca.RisultatoRicerca, error)
+}
+
+type Handler struct {
+	service RicercaService
+}
+
+func NewHandler(service RicercaService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.Cerca)
+
+	return r
+}
+
+func (h *Handler) Cerca(w http.ResponseWriter, r *http.Request) {
+	var contesto ricerca.ContestoRicerca
+	if err := shared.DecodeJSON(w, r, &contesto); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	result, err := h.service.Cerca(r.Context(), contesto)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, result)
+}
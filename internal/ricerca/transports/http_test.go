@@ -0,0 +1,95 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/ricerca"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type mockService struct {
+	cercaFunc func(ctx context.Context, contesto ricerca.ContestoRicerca) (ricerca.RisultatoRicerca, error)
+}
+
+func (m *mockService) Cerca(ctx context.Context, contesto ricerca.ContestoRicerca) (ricerca.RisultatoRicerca, error) {
+	if m.cercaFunc != nil {
+		return m.cercaFunc(ctx, contesto)
+	}
+	return ricerca.RisultatoRicerca{}, nil
+}
+
+func newTestRouter(svc RicercaService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/ricerca", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_Cerca(t *testing.T) {
+	t.Run("decodes the search context", func(t *testing.T) {
+		var captured ricerca.ContestoRicerca
+		svc := &mockService{
+			cercaFunc: func(_ context.Context, contesto ricerca.ContestoRicerca) (ricerca.RisultatoRicerca, error) {
+				captured = contesto
+				return ricerca.RisultatoRicerca{
+					ricerca.Classi: {{"id": "mago", "nome": "Mago"}},
+				}, nil
+			},
+		}
+
+		body := `{"tipi":["CLASSI"],"perimetro-ricerca":[{"nome-attributo":"nome","parola-chiave":"^ma"}],
+			"condizione":"or","campi-in-risposta":["id","nome"],"ordinamento":"DESC","$limit":5,"$offset":10}`
+		req := httptest.NewRequest(http.MethodPost, "/ricerca", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if len(captured.PerimetroRicerca) != 1 || captured.PerimetroRicerca[0].ParolaChiave != "^ma" {
+			t.Errorf("unexpected perimetro %+v", captured.PerimetroRicerca)
+		}
+		if captured.Condizione != shared.CondizioneOr || captured.Ordinamento != ricerca.OrdinamentoDesc {
+			t.Errorf("unexpected condizione/ordinamento %q/%q", captured.Condizione, captured.Ordinamento)
+		}
+		if captured.Limit == nil || *captured.Limit != 5 || captured.Offset == nil || *captured.Offset != 10 {
+			t.Errorf("unexpected paging %v %v", captured.Limit, captured.Offset)
+		}
+
+		var response map[string][]map[string]any
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response["CLASSI"]) != 1 {
+			t.Errorf("expected 1 CLASSI result, got %v", response)
+		}
+	})
+
+	invalid := []struct {
+		name string
+		body string
+	}{
+		{"empty body", ``},
+		{"malformed JSON", `{"tipi":`},
+		{"unknown field", `{"tipi":["CLASSI"],"perimetro_ricerca":[]}`},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/ricerca", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AutoreHeader names the editor of a write. It is recorded in the revision
+// history of the resources that keep one. It is advisory: the admin key is
+// shared, so any holder can send any name and the header does not
+// authenticate the editor.
+const AutoreHeader = "X-Autore"
+
+const maxLunghezzaAutore = 255
+
+type autoreKey struct{}
+
+// ConAutore returns a copy of ctx carrying the editor of the request.
+func ConAutore(ctx context.Context, autore string) context.Context {
+	return context.WithValue(ctx, autoreKey{}, autore)
+}
+
+// Autore returns the editor carried by ctx, or "" when unknown.
+func Autore(ctx context.Context) string {
+	autore, _ := ctx.Value(autoreKey{}).(string)
+	return autore
+}
+
+// AutoreDaRichiesta is a middleware that moves the AutoreHeader of the
+// request into its context.
+func AutoreDaRichiesta(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		autore := r.Header.Get(AutoreHeader)
+		if len(autore) > maxLunghezzaAutore {
+			err := fmt.Errorf("%s cannot exceed %d characters", AutoreHeader, maxLunghezzaAutore)
+			WriteError(w, NewBadRequestError(err.Error(), err))
+			return
+		}
+		if autore != "" {
+			r = r.WithContext(ConAutore(r.Context(), autore))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
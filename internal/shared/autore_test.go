@@ -0,0 +1,48 @@
+package shared
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAutoreDaRichiesta(t *testing.T) {
+	var got string
+	handler := AutoreDaRichiesta(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = Autore(r.Context())
+	}))
+
+	t.Run("header in context", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/", nil)
+		req.Header.Set(AutoreHeader, "Elminster")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if got != "Elminster" {
+			t.Errorf("expected autore Elminster, got %q", got)
+		}
+	})
+
+	t.Run("no header", func(t *testing.T) {
+		got = "x"
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/", nil))
+
+		if got != "" {
+			t.Errorf("expected no autore, got %q", got)
+		}
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/", nil)
+		req.Header.Set(AutoreHeader, strings.Repeat("a", 256))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
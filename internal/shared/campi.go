@@ -0,0 +1,110 @@
+package shared
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+)
+
+// TuttiICampi requests every field of a resource.
+const TuttiICampi = "*"
+
+// Campi is a sparse fieldset: the JSON fields of a resource a client wants
+// back. No fields or "*" mean all of them; fields the resource does not
+// have are left out rather than rejected, as for the campi-in-risposta of
+// a search.
+type Campi []string
+
+// QueryCampi reads the campi parameter, given as repeated keys, comma
+// separated or both.
+func QueryCampi(query url.Values) (Campi, error) {
+	campi, err := QueryList(query, "campi")
+	if err != nil {
+		return nil, err
+	}
+	return Campi(campi), nil
+}
+
+// Tutti reports whether every field is requested.
+func (c Campi) Tutti() bool {
+	return len(c) == 0 || slices.Contains(c, TuttiICampi)
+}
+
+// Contiene reports whether campo is requested.
+func (c Campi) Contiene(campo string) bool {
+	return c.Tutti() || slices.Contains(c, campo)
+}
+
+// Proietta returns the requested JSON fields of item.
+func (c Campi) Proietta(item any) (map[string]any, error) {
+	raw, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return c.proiettaCampi(fields), nil
+}
+
+func (c Campi) proiettaCampi(fields map[string]any) map[string]any {
+	if c.Tutti() {
+		return fields
+	}
+	projected := make(map[string]any, len(c))
+	for _, campo := range c {
+		if v, ok := fields[campo]; ok {
+			projected[campo] = v
+		}
+	}
+	return projected
+}
+
+// proiettaRisposta projects a response body. The items of a list response,
+// recognised by its embedded PaginationMeta, are projected one by one and
+// the pagination fields are kept; any other value is projected as a whole.
+func (c Campi) proiettaRisposta(data any) (any, error) {
+	if _, ok := data.(elenco); !ok {
+		return c.Proietta(data)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range fields {
+		items, ok := value.([]any)
+		if !ok {
+			continue
+		}
+		for i, item := range items {
+			if object, ok := item.(map[string]any); ok {
+				items[i] = c.proiettaCampi(object)
+			}
+		}
+		fields[key] = items
+	}
+	return fields, nil
+}
+
+// Colonne returns the SQL columns needed to read campi: the required ones
+// followed by those that colonne maps the requested fields to. It returns
+// nil when every field is requested, in which case the caller selects
+// every column.
+func (c Campi) Colonne(colonne map[string]string, obbligatorie ...string) []string {
+	if c.Tutti() {
+		return nil
+	}
+	result := slices.Clone(obbligatorie)
+	for _, campo := range c {
+		if col, ok := colonne[campo]; ok && !slices.Contains(result, col) {
+			result = append(result, col)
+		}
+	}
+	return result
+}
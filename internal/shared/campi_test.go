@@ -0,0 +1,104 @@
+package shared
+
+import (
+	"net/url"
+	"slices"
+	"testing"
+)
+
+func TestQueryCampi(t *testing.T) {
+	t.Run("absent requests every field", func(t *testing.T) {
+		campi, err := QueryCampi(url.Values{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !campi.Tutti() {
+			t.Errorf("expected every field, got %v", campi)
+		}
+	})
+
+	t.Run("comma separated", func(t *testing.T) {
+		campi, err := QueryCampi(url.Values{"campi": {"id,nome", "dado-vita"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(campi, Campi{"id", "nome", "dado-vita"}) {
+			t.Errorf("unexpected campi %v", campi)
+		}
+		if campi.Tutti() || campi.Contiene("descrizione") || !campi.Contiene("nome") {
+			t.Errorf("unexpected membership for %v", campi)
+		}
+	})
+
+	t.Run("star requests every field", func(t *testing.T) {
+		campi, err := QueryCampi(url.Values{"campi": {"nome,*"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !campi.Tutti() || !campi.Contiene("descrizione") {
+			t.Errorf("expected every field, got %v", campi)
+		}
+	})
+
+	t.Run("too many fields", func(t *testing.T) {
+		if _, err := QueryCampi(url.Values{"campi": {"a,b,c,d,e,f,g,h,i,j,k"}}); err == nil {
+			t.Fatal("expected error for too many fields")
+		}
+	})
+}
+
+func TestCampi_Proietta(t *testing.T) {
+	item := struct {
+		ID   string `json:"id"`
+		Nome string `json:"nome"`
+		Peso int    `json:"peso"`
+	}{ID: "corda", Nome: "Corda", Peso: 5}
+
+	t.Run("keeps requested fields and drops unknown ones", func(t *testing.T) {
+		got, err := Campi{"nome", "sconosciuto"}.Proietta(item)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 1 || got["nome"] != "Corda" {
+			t.Errorf("unexpected projection %v", got)
+		}
+	})
+
+	t.Run("no fields keeps everything", func(t *testing.T) {
+		got, err := Campi(nil).Proietta(item)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 3 {
+			t.Errorf("expected 3 fields, got %v", got)
+		}
+	})
+}
+
+func TestCampi_Colonne(t *testing.T) {
+	colonne := map[string]string{
+		"id":        "id",
+		"nome":      "nome",
+		"dado-vita": "dado_vita",
+	}
+
+	tests := []struct {
+		name  string
+		campi Campi
+		want  []string
+	}{
+		{"every field", nil, nil},
+		{"star", Campi{"*"}, nil},
+		{"required first and deduplicated", Campi{"dado-vita", "id", "nome"}, []string{"id", "dado_vita", "nome"}},
+		{"unknown fields are dropped", Campi{"sconosciuto"}, []string{"id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.campi.Colonne(colonne, "id")
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Colonne() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,16 @@
 package shared
 
-import "strings"
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
 
 // EscapeLike escapes special characters (%, _, \) in a string
 // intended for use in SQL LIKE/ILIKE patterns.
@@ -10,3 +20,123 @@ func EscapeLike(s string) string {
 	s = strings.ReplaceAll(s, `_`, `\_`)
 	return s
 }
+
+// ScanJSON decodes a JSONB column value into dest. A NULL column leaves
+// dest untouched.
+func ScanJSON(src any, dest any) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return errors.New("ScanJSON: source is not []byte")
+	}
+	return json.Unmarshal(source, dest)
+}
+
+// JSONSlice is a JSONB column holding a list of T. NULL scans to a nil slice
+// and a nil slice is stored as NULL.
+type JSONSlice[T any] []T
+
+func (s *JSONSlice[T]) Scan(src any) error {
+	if src == nil {
+		*s = nil
+		return nil
+	}
+	return ScanJSON(src, (*[]T)(s))
+}
+
+func (s JSONSlice[T]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal([]T(s))
+}
+
+// JSONObject is a nullable JSONB column holding a single T. NULL scans to a
+// nil V and a nil V is stored as NULL.
+type JSONObject[T any] struct {
+	V *T
+}
+
+func (o *JSONObject[T]) Scan(src any) error {
+	if src == nil {
+		o.V = nil
+		return nil
+	}
+	o.V = new(T)
+	return ScanJSON(src, o.V)
+}
+
+func (o JSONObject[T]) Value() (driver.Value, error) {
+	if o.V == nil {
+		return nil, nil
+	}
+	return json.Marshal(o.V)
+}
+
+// PaginatedQuery applies standard filters (nome, documentazione-di-riferimento),
+// sort order, and pagination to a base query and its count counterpart.
+type PaginatedQuery struct {
+	Query      string
+	CountQuery string
+	Args       map[string]any
+}
+
+// NewPaginatedQuery builds a PaginatedQuery. Module-specific conditions must
+// already be part of baseQuery and baseCountQuery, with their named
+// parameters in args.
+func NewPaginatedQuery(baseQuery, baseCountQuery string, args map[string]any, filter ListFilter) *PaginatedQuery {
+	if filter.Nome != nil {
+		baseQuery += ` AND nome ILIKE :nome`
+		baseCountQuery += ` AND nome ILIKE :nome`
+		args["nome"] = "%" + EscapeLike(*filter.Nome) + "%"
+	}
+	if len(filter.DocumentazioneDiRiferimento) > 0 {
+		baseQuery += ` AND documentazione_di_riferimento = ANY(:docs)`
+		baseCountQuery += ` AND documentazione_di_riferimento = ANY(:docs)`
+		args["docs"] = pq.Array(filter.DocumentazioneDiRiferimento)
+	}
+
+	orderDir := "ASC"
+	if filter.Sort == SortDesc {
+		orderDir = "DESC"
+	}
+	baseQuery += fmt.Sprintf(` ORDER BY nome %s`, orderDir)
+	baseQuery += ` LIMIT :limit OFFSET :offset`
+	args["limit"] = filter.Limit
+	args["offset"] = filter.Offset
+
+	return &PaginatedQuery{Query: baseQuery, CountQuery: baseCountQuery, Args: args}
+}
+
+func (q *PaginatedQuery) Count(ctx context.Context, db *sqlx.DB) (int, error) {
+	var total int
+	stmt, err := db.PrepareNamedContext(ctx, q.CountQuery)
+	if err != nil {
+		return 0, fmt.Errorf("prepare count query: %w", err)
+	}
+	defer stmt.Close()
+	if err := stmt.GetContext(ctx, &total, q.Args); err != nil {
+		return 0, fmt.Errorf("execute count query: %w", err)
+	}
+	return total, nil
+}
+
+func (q *PaginatedQuery) SelectRows(ctx context.Context, db *sqlx.DB, dest any) error {
+	return SelectNamed(ctx, db, dest, q.Query, q.Args)
+}
+
+// SelectNamed runs a query with named parameters and scans every row into
+// dest.
+func SelectNamed(ctx context.Context, db *sqlx.DB, dest any, query string, args map[string]any) error {
+	stmt, err := db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare query: %w", err)
+	}
+	defer stmt.Close()
+	if err := stmt.SelectContext(ctx, dest, args); err != nil {
+		return fmt.Errorf("execute query: %w", err)
+	}
+	return nil
+}
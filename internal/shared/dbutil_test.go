@@ -1,6 +1,9 @@
 package shared
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestEscapeLike(t *testing.T) {
 	tests := []struct {
@@ -26,3 +29,170 @@ func TestEscapeLike(t *testing.T) {
 		})
 	}
 }
+
+func TestScanJSON(t *testing.T) {
+	t.Run("nil source returns nil", func(t *testing.T) {
+		var dest []string
+		if err := ScanJSON(nil, &dest); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dest != nil {
+			t.Errorf("expected nil dest, got %v", dest)
+		}
+	})
+
+	t.Run("valid JSON bytes", func(t *testing.T) {
+		var dest []string
+		src := []byte(`["a","b"]`)
+		if err := ScanJSON(src, &dest); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(dest) != 2 || dest[0] != "a" || dest[1] != "b" {
+			t.Errorf("unexpected result: %v", dest)
+		}
+	})
+
+	t.Run("non-byte source returns error", func(t *testing.T) {
+		var dest []string
+		if err := ScanJSON("not bytes", &dest); err == nil {
+			t.Fatal("expected error for non-byte source")
+		}
+	})
+
+	t.Run("invalid JSON returns error", func(t *testing.T) {
+		var dest []string
+		src := []byte(`{invalid}`)
+		if err := ScanJSON(src, &dest); err == nil {
+			t.Fatal("expected error for invalid JSON")
+		}
+	})
+}
+
+func TestNewPaginatedQuery(t *testing.T) {
+	t.Run("basic query without filters", func(t *testing.T) {
+		args := make(map[string]any)
+		filter := ListFilter{Limit: 20, Offset: 0, Sort: SortAsc}
+
+		q := NewPaginatedQuery("SELECT * FROM t WHERE 1=1", "SELECT COUNT(*) FROM t WHERE 1=1", args, filter)
+
+		if q.Args["limit"] != 20 {
+			t.Errorf("expected limit 20, got %v", q.Args["limit"])
+		}
+		if q.Args["offset"] != 0 {
+			t.Errorf("expected offset 0, got %v", q.Args["offset"])
+		}
+	})
+
+	t.Run("with nome filter", func(t *testing.T) {
+		args := make(map[string]any)
+		nome := "test%val"
+		filter := ListFilter{Limit: 20, Offset: 0, Sort: SortAsc, Nome: &nome}
+
+		q := NewPaginatedQuery("SELECT * FROM t WHERE 1=1", "SELECT COUNT(*) FROM t WHERE 1=1", args, filter)
+
+		nomeArg, ok := q.Args["nome"].(string)
+		if !ok {
+			t.Fatal("expected nome arg to be string")
+		}
+		// % in the user input should be escaped
+		if nomeArg != `%test\%val%` {
+			t.Errorf("expected escaped nome arg, got %q", nomeArg)
+		}
+	})
+
+	t.Run("desc sort", func(t *testing.T) {
+		args := make(map[string]any)
+		filter := ListFilter{Limit: 20, Offset: 0, Sort: SortDesc}
+
+		q := NewPaginatedQuery("SELECT * FROM t WHERE 1=1", "SELECT COUNT(*) FROM t WHERE 1=1", args, filter)
+
+		if q.Query == "" {
+			t.Fatal("expected non-empty query")
+		}
+		// The query should contain DESC
+		if !strings.Contains(q.Query, "DESC") {
+			t.Errorf("expected query to contain DESC, got %q", q.Query)
+		}
+	})
+}
+
+func TestJSONSlice_ScanValue(t *testing.T) {
+	t.Run("scan nil sets nil", func(t *testing.T) {
+		s := JSONSlice[Senso]{{Nome: "Scurovisione"}}
+		if err := s.Scan(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s != nil {
+			t.Errorf("expected nil, got %v", s)
+		}
+	})
+
+	t.Run("scan valid JSON", func(t *testing.T) {
+		var s JSONSlice[Senso]
+		if err := s.Scan([]byte(`[{"nome":"Scurovisione","gittata":"18 m"}]`)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(s) != 1 || s[0].Gittata != "18 m" {
+			t.Errorf("unexpected result: %v", s)
+		}
+	})
+
+	t.Run("value nil returns nil", func(t *testing.T) {
+		var s JSONSlice[Senso]
+		val, err := s.Value()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if val != nil {
+			t.Errorf("expected nil value, got %v", val)
+		}
+	})
+
+	t.Run("value non-nil returns JSON", func(t *testing.T) {
+		s := JSONSlice[Senso]{{Nome: "Vista cieca"}}
+		val, err := s.Value()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(val.([]byte)) != `[{"nome":"Vista cieca"}]` {
+			t.Errorf("unexpected value: %s", val)
+		}
+	})
+}
+
+func TestJSONObject_ScanValue(t *testing.T) {
+	t.Run("scan nil sets nil", func(t *testing.T) {
+		o := JSONObject[Senso]{V: &Senso{Nome: "Scurovisione"}}
+		if err := o.Scan(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if o.V != nil {
+			t.Errorf("expected nil, got %+v", o.V)
+		}
+	})
+
+	t.Run("value nil returns nil", func(t *testing.T) {
+		val, err := JSONObject[Senso]{}.Value()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if val != nil {
+			t.Errorf("expected nil value, got %v", val)
+		}
+	})
+
+	t.Run("value round trips through scan", func(t *testing.T) {
+		val, err := JSONObject[Senso]{V: &Senso{Nome: "Vista cieca", Gittata: "3 m"}}.Value()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var out JSONObject[Senso]
+		if err := out.Scan(val); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.V == nil || out.V.Gittata != "3 m" {
+			t.Errorf("unexpected round trip result: %+v", out.V)
+		}
+	})
+}
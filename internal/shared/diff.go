@@ -0,0 +1,88 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Differenza is a change between two JSON documents. Prima is absent when
+// the member was added and Dopo when it was removed.
+type Differenza struct {
+	Percorso string          `json:"percorso"`
+	Prima    json.RawMessage `json:"prima,omitempty"`
+	Dopo     json.RawMessage `json:"dopo,omitempty"`
+}
+
+// DiffJSON lists the changes from the document prima to dopo. Objects are
+// compared member by member, with the path of nested members joined by
+// dots; any other value, arrays included, is compared as a whole. The
+// changes are sorted by path.
+func DiffJSON(prima, dopo []byte) ([]Differenza, error) {
+	var a, b any
+	if err := json.Unmarshal(prima, &a); err != nil {
+		return nil, fmt.Errorf("invalid JSON document: %w", err)
+	}
+	if err := json.Unmarshal(dopo, &b); err != nil {
+		return nil, fmt.Errorf("invalid JSON document: %w", err)
+	}
+
+	differenze := []Differenza{}
+	if err := diff("", a, b, true, true, &differenze); err != nil {
+		return nil, err
+	}
+	return differenze, nil
+}
+
+func diff(percorso string, a, b any, inA, inB bool, differenze *[]Differenza) error {
+	objA, okA := a.(map[string]any)
+	objB, okB := b.(map[string]any)
+	if okA && okB {
+		keys := make([]string, 0, len(objA)+len(objB))
+		for k := range objA {
+			keys = append(keys, k)
+		}
+		for k := range objB {
+			if _, ok := objA[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			path := k
+			if percorso != "" {
+				path = percorso + "." + k
+			}
+			va, hasA := objA[k]
+			vb, hasB := objB[k]
+			if err := diff(path, va, vb, hasA, hasB, differenze); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var d Differenza
+	d.Percorso = percorso
+	if inA {
+		raw, err := json.Marshal(a)
+		if err != nil {
+			return err
+		}
+		d.Prima = raw
+	}
+	if inB {
+		raw, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+		d.Dopo = raw
+	}
+	if inA && inB && bytes.Equal(d.Prima, d.Dopo) {
+		return nil
+	}
+	*differenze = append(*differenze, d)
+	return nil
+}
@@ -0,0 +1,46 @@
+package shared
+
+import (
+	"testing"
+)
+
+func TestDiffJSON(t *testing.T) {
+	prima := `{"nome":"Mago","descrizione":"Studioso","equipaggiamento":{"opzione-a":[1,2],"opzione-b":{"valuta":"MO"}},"tipo":"Completo"}`
+	dopo := `{"nome":"Mago","descrizione":"Studioso di magia","equipaggiamento":{"opzione-a":[1,2,3],"opzione-b":{"valuta":"MO"}},"livello":3}`
+
+	got, err := DiffJSON([]byte(prima), []byte(dopo))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct{ percorso, prima, dopo string }{
+		{"descrizione", `"Studioso"`, `"Studioso di magia"`},
+		{"equipaggiamento.opzione-a", `[1,2]`, `[1,2,3]`},
+		{"livello", ``, `3`},
+		{"tipo", `"Completo"`, ``},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Percorso != w.percorso || string(got[i].Prima) != w.prima || string(got[i].Dopo) != w.dopo {
+			t.Errorf("change %d: expected %+v, got %s %s %s", i, w, got[i].Percorso, got[i].Prima, got[i].Dopo)
+		}
+	}
+}
+
+func TestDiffJSON_Equal(t *testing.T) {
+	got, err := DiffJSON([]byte(`{"a":{"b":[1]}}`), []byte(`{ "a": { "b": [1] } }`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("expected an empty list, got %#v", got)
+	}
+}
+
+func TestDiffJSON_Invalid(t *testing.T) {
+	if _, err := DiffJSON([]byte(`{`), []byte(`{}`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
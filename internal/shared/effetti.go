@@ -0,0 +1,163 @@
+package shared
+
+// Collegamento links an attack or a healing effect to the spell or item
+// that produces it. Exactly one of the two ids is expected to be set.
+type Collegamento struct {
+	IDIncantesimo string `json:"id-incantesimo,omitempty"`
+	IDOggetto     string `json:"id-oggetto,omitempty"`
+}
+
+type Danno struct {
+	TipoDiDanno             []TipoDiDanno  `json:"tipo-di-danno"`
+	NumeroDiDadi            int32          `json:"numero-di-dadi"`
+	TipoDiDado              TipoDiDado     `json:"tipo-di-dado"`
+	CaratteristicaAssociata Caratteristica `json:"caratteristica-associata,omitempty"`
+}
+
+type EsitoTiroSalvezza struct {
+	Descrizione string    `json:"descrizione,omitempty"`
+	Danni       []Danno   `json:"danni,omitempty"`
+	Effetti     []Effetto `json:"effetti,omitempty"`
+}
+
+type TiroSalvezzaEffetto struct {
+	TiroSalvezza Caratteristica    `json:"tiro-salvezza"`
+	Successo     EsitoTiroSalvezza `json:"successo"`
+	Fallimento   EsitoTiroSalvezza `json:"fallimento"`
+}
+
+type Cura struct {
+	ID                      string         `json:"id,omitempty"`
+	Nome                    string         `json:"nome,omitempty"`
+	Descrizione             string         `json:"descrizione,omitempty"`
+	Link                    *Collegamento  `json:"link,omitempty"`
+	TipoDiAzione            TipoAzione     `json:"tipo-di-azione,omitempty"`
+	Bonus                   int32          `json:"bonus,omitempty"`
+	NumeroDiDadi            int32          `json:"numero-di-dadi,omitempty"`
+	TipoDiDado              TipoDiDado     `json:"tipo-di-dado,omitempty"`
+	CaratteristicaAssociata Caratteristica `json:"caratteristica-associata,omitempty"`
+}
+
+type Effetto struct {
+	Nome         string         `json:"nome,omitempty"`
+	SiApplicaA   []string       `json:"si-applica-a,omitempty"`
+	Descrizione  string         `json:"descrizione,omitempty"`
+	Bonus        string         `json:"bonus,omitempty"`
+	Modificatori []Modificatore `json:"modificatori,omitempty"`
+}
+
+type TipoModificatore string
+
+const (
+	ModificatoreValoreTotaleCaratteristica TipoModificatore = "Valore Totale Caratteristica"
+	ModificatoreBonusCaratteristica        TipoModificatore = "Bonus Caratteristica"
+	ModificatoreVantaggioSvantaggio        TipoModificatore = "Vantaggio Svantaggio"
+	ModificatoreClasseArmatura             TipoModificatore = "Classe Armatura"
+	ModificatoreDifesa                     TipoModificatore = "Difesa"
+	ModificatoreSlotArmonizzazione         TipoModificatore = "Slot Armonizzazione"
+	ModificatoreSenso                      TipoModificatore = "Senso"
+	ModificatorePuntiVitaMassimi           TipoModificatore = "Punti Vita Massimi"
+	ModificatoreIniziativa                 TipoModificatore = "Iniziativa"
+	ModificatoreVelocita                   TipoModificatore = "Velocità"
+	ModificatoreLingua                     TipoModificatore = "Lingua"
+	ModificatoreCompetenzaTiroSalvezza     TipoModificatore = "Competenza Tiro Salvezza"
+	ModificatoreCompetenzaAbilita          TipoModificatore = "Competenza Abilità"
+	ModificatoreCompetenzaArma             TipoModificatore = "Competenza Arma"
+	ModificatoreCompetenzaArmatura         TipoModificatore = "Competenza Armatura"
+	ModificatoreCompetenzaUtensile         TipoModificatore = "Competenza Utensile"
+	ModificatoreValoreTiroSalvezza         TipoModificatore = "Valore Tiro Salvezza"
+	ModificatoreAbilita                    TipoModificatore = "Abilità"
+	ModificatoreIncantesimi                TipoModificatore = "Incantesimi"
+	ModificatoreTiroPerColpireArma         TipoModificatore = "Tiro per Colpire Arma"
+	ModificatoreClasseDifficolta           TipoModificatore = "Classe Difficoltà"
+)
+
+type TipoModifica string
+
+const (
+	ModificaMinimo         TipoModifica = "minimo"
+	ModificaMassimo        TipoModifica = "massimo"
+	ModificaMoltiplicatore TipoModifica = "moltiplicatore"
+	ModificaSovrascrittura TipoModifica = "sovrascrittura"
+	ModificaSomma          TipoModifica = "somma"
+)
+
+type Competenza string
+
+const (
+	NonCompetenza   Competenza = "Non Competenza"
+	MezzaCompetenza Competenza = "Mezza Competenza"
+	ConCompetenza   Competenza = "Competenza"
+	Expertise       Competenza = "Expertise"
+)
+
+// Modificatore flattens the spec's Modificatore* variants into a single
+// struct discriminated by Tipo; each variant only fills the fields it uses.
+// IDRiferimento holds the referenced arma, armatura, utensile, lingua or
+// senso, while Dettaglio carries free-form qualifiers such as "Vantaggio",
+// "Resistenza" or the target of a speed modifier.
+type Modificatore struct {
+	Tipo           TipoModificatore `json:"tipo"`
+	Caratteristica Caratteristica   `json:"caratteristica,omitempty"`
+	Abilita        string           `json:"abilità,omitempty"`
+	IDRiferimento  string           `json:"id-riferimento,omitempty"`
+	TipoModifica   TipoModifica     `json:"tipo-modifica,omitempty"`
+	Valore         int32            `json:"valore,omitempty"`
+	Competenza     Competenza       `json:"competenza,omitempty"`
+	Dettaglio      string           `json:"dettaglio,omitempty"`
+	Situazione     string           `json:"situazione,omitempty"`
+}
+
+type TipoAttacco string
+
+const (
+	AttaccoMelee                   TipoAttacco = "Melee"
+	AttaccoDistanza                TipoAttacco = "Distanza"
+	AttaccoIncantesimo             TipoAttacco = "Incantesimo"
+	AttaccoTiroSalvezzaIncantesimo TipoAttacco = "Tiro Salvezza Incantesimo"
+	AttaccoTiroSalvezza            TipoAttacco = "Tiro Salvezza"
+)
+
+// Attacco describes an attack. The spec's effetto_attacco is either a Danno
+// list or a TiroSalvezzaEffetto; here they are two distinct fields.
+type Attacco struct {
+	ID                      string               `json:"id,omitempty"`
+	Nome                    string               `json:"nome"`
+	Tipo                    TipoAttacco          `json:"tipo"`
+	Descrizione             string               `json:"descrizione,omitempty"`
+	TipoDiAzione            TipoAzione           `json:"tipo-di-azione,omitempty"`
+	Link                    *Collegamento        `json:"link,omitempty"`
+	ColpisceAutomaticamente bool                 `json:"colpisce-automaticamente,omitempty"`
+	HaPortata               bool                 `json:"ha-portata,omitempty"`
+	TipoCompetenza          Competenza           `json:"tipo-competenza,omitempty"`
+	Bonus                   int32                `json:"bonus,omitempty"`
+	Gittata                 string               `json:"gittata,omitempty"`
+	FormaAreaEffetto        string               `json:"forma-area-effetto,omitempty"`
+	DimensioneAreaEffetto   string               `json:"dimensione-area-effetto,omitempty"`
+	CaratteristicaAssociata Caratteristica       `json:"caratteristica-associata,omitempty"`
+	Danni                   []Danno              `json:"danni,omitempty"`
+	TiroSalvezza            *TiroSalvezzaEffetto `json:"tiro-salvezza,omitempty"`
+}
+
+// Tratto is a trait granted by a class, species, lineage or feat. Besides
+// its description it may carry the mechanical effects it grants.
+type Tratto struct {
+	ID                         string     `json:"id,omitempty"`
+	Nome                       string     `json:"nome"`
+	Descrizione                string     `json:"descrizione,omitempty"`
+	TipoAzione                 TipoAzione `json:"tipo-azione,omitempty"`
+	TipoDiSorgente             string     `json:"tipo-di-sorgente,omitempty"`
+	Livello                    int32      `json:"livello,omitempty"`
+	IDIncantesimo              string     `json:"id-incantesimo,omitempty"`
+	CompetenzaExpertiseAbilita []Abilita  `json:"competenza-expertise-abilità,omitempty"`
+	Sensi                      []Senso    `json:"sensi,omitempty"`
+	NumeroDiUtilizzi           int32      `json:"numero-di-utilizzi,omitempty"`
+	ResetConRiposoBreve        bool       `json:"reset-con-riposo-breve,omitempty"`
+	ResetConRiposoLungo        bool       `json:"reset-con-riposo-lungo,omitempty"`
+	Resistenze                 []Difesa   `json:"resistenze,omitempty"`
+	Vulnerabilita              []Difesa   `json:"vulnerabilità,omitempty"`
+	Immunita                   []Difesa   `json:"immunità,omitempty"`
+	Attacco                    []Attacco  `json:"attacco,omitempty"`
+	Effetto                    []Effetto  `json:"effetto,omitempty"`
+	Cura                       []Cura     `json:"cura,omitempty"`
+}
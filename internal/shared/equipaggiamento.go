@@ -0,0 +1,14 @@
+package shared
+
+type OggettoPartenza struct {
+	ID       string `json:"id,omitempty"`
+	Nome     string `json:"nome,omitempty"`
+	Quantita int32  `json:"quantità,omitempty"`
+}
+
+// EquipaggiamentoPartenza is the starting equipment choice offered by a
+// class or background: a list of items or an amount of money.
+type EquipaggiamentoPartenza struct {
+	OpzioneA []OggettoPartenza `json:"opzione-a,omitempty"`
+	OpzioneB *Importo          `json:"opzione-b,omitempty"`
+}
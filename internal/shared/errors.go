@@ -39,6 +39,18 @@ func InternalServerError(detail string) ErrorObject {
 	return NewErrorObject("INTERNAL_ERROR", "Internal Server Error", detail)
 }
 
+func ConflictError(detail string) ErrorObject {
+	return NewErrorObject("CONFLICT", "Conflict", detail)
+}
+
+func PreconditionFailedError(detail string) ErrorObject {
+	return NewErrorObject("PRECONDITION_FAILED", "Precondition Failed", detail)
+}
+
+func PreconditionRequiredError(detail string) ErrorObject {
+	return NewErrorObject("PRECONDITION_REQUIRED", "Precondition Required", detail)
+}
+
 func UnauthorizedError(detail string) ErrorObject {
 	return NewErrorObject("UNAUTHORIZED", "Unauthorized", detail)
 }
@@ -80,6 +92,23 @@ func NewNotFoundError(resource, id string) *AppError {
 	return NewAppError(http.StatusNotFound, NotFoundError(detail), nil)
 }
 
+func NewConflictError(resource, id string) *AppError {
+	detail := fmt.Sprintf("%s with id '%s' already exists", resource, id)
+	return NewAppError(http.StatusConflict, ConflictError(detail), nil)
+}
+
+// NewPreconditionFailedError reports that the resource changed since the
+// version the client sent in If-Match.
+func NewPreconditionFailedError(resource, id string) *AppError {
+	detail := fmt.Sprintf("%s with id '%s' was modified by another request; fetch it again and retry", resource, id)
+	return NewAppError(http.StatusPreconditionFailed, PreconditionFailedError(detail), nil)
+}
+
+// NewPreconditionRequiredError reports a write without If-Match.
+func NewPreconditionRequiredError(detail string) *AppError {
+	return NewAppError(http.StatusPreconditionRequired, PreconditionRequiredError(detail), nil)
+}
+
 func NewInternalError(err error) *AppError {
 	return NewAppError(http.StatusInternalServerError, InternalServerError("an unexpected error occurred"), err)
 }
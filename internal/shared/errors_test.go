@@ -96,6 +96,36 @@ func TestAppError(t *testing.T) {
 		}
 	})
 
+	t.Run("NewConflictError", func(t *testing.T) {
+		err := NewConflictError("classe", "barbaro")
+
+		if err.HTTPStatus != http.StatusConflict {
+			t.Errorf("expected status 409, got %d", err.HTTPStatus)
+		}
+		if err.Response.Errors[0].Code != "CONFLICT" || err.Response.Errors[0].Detail != "classe with id 'barbaro' already exists" {
+			t.Errorf("unexpected error: %+v", err.Response.Errors[0])
+		}
+	})
+
+	t.Run("NewPreconditionFailedError", func(t *testing.T) {
+		err := NewPreconditionFailedError("classe", "barbaro")
+
+		if err.HTTPStatus != http.StatusPreconditionFailed {
+			t.Errorf("expected status 412, got %d", err.HTTPStatus)
+		}
+		if err.Response.Errors[0].Code != "PRECONDITION_FAILED" {
+			t.Errorf("unexpected code %q", err.Response.Errors[0].Code)
+		}
+	})
+
+	t.Run("NewPreconditionRequiredError", func(t *testing.T) {
+		err := NewPreconditionRequiredError("If-Match is required")
+
+		if err.HTTPStatus != http.StatusPreconditionRequired {
+			t.Errorf("expected status 428, got %d", err.HTTPStatus)
+		}
+	})
+
 	t.Run("NewInternalError", func(t *testing.T) {
 		err := NewInternalError(errors.New("boom"))
 
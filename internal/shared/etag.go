@@ -0,0 +1,159 @@
+package shared
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ETag returns the strong entity tag of a resource version.
+func ETag(versione int64) string {
+	return `"` + strconv.FormatInt(versione, 36) + `"`
+}
+
+// ETagRappresentazione returns the strong entity tag of a representation
+// that also embeds data read from other sources, such as expanded items or
+// glossary references: the resource version followed by a hash of that
+// data, so that the tag changes when any of them does. Without dipendenze
+// it is the ETag of the version.
+func ETagRappresentazione(versione int64, dipendenze ...any) (string, error) {
+	if len(dipendenze) == 0 {
+		return ETag(versione), nil
+	}
+	h := sha256.New()
+	enc := json.NewEncoder(h)
+	for _, d := range dipendenze {
+		if err := enc.Encode(d); err != nil {
+			return "", err
+		}
+	}
+	return `"` + strconv.FormatInt(versione, 36) + "-" + hex.EncodeToString(h.Sum(nil)[:8]) + `"`, nil
+}
+
+// versioneETag returns the ETag of the version a representation tag was
+// built from.
+func versioneETag(tag string) string {
+	if versione, _, ok := strings.Cut(tag, "-"); ok && strings.HasPrefix(tag, `"`) {
+		return versione + `"`
+	}
+	return tag
+}
+
+// CheckNotModified sets the ETag header of a read and reports whether the
+// If-None-Match of the request matches it. In that case it has written a
+// 304 and the handler must stop.
+func CheckNotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, tag := range splitETags(header) {
+		// If-None-Match uses the weak comparison.
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// Precondition is the If-Match header of a write: the versions the client
+// expects to replace. A write that may also create the resource accepts
+// If-None-Match: * instead, met only when the resource does not exist.
+type Precondition struct {
+	etags   []string
+	any     bool
+	assente bool
+}
+
+// IfMatch reads the If-Match header, which writes to existing resources
+// require.
+func IfMatch(r *http.Request) (Precondition, error) {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return Precondition{}, NewPreconditionRequiredError("If-Match header is required; send the ETag of the resource")
+	}
+	var p Precondition
+	for _, tag := range splitETags(header) {
+		if tag == "*" {
+			p.any = true
+			continue
+		}
+		p.etags = append(p.etags, tag)
+	}
+	return p, nil
+}
+
+// IfMatchOrNoneMatch reads the precondition of a write that replaces an
+// existing resource or creates a missing one: If-Match for the former,
+// If-None-Match: * for the latter.
+func IfMatchOrNoneMatch(r *http.Request) (Precondition, error) {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return IfMatch(r)
+	}
+	if strings.TrimSpace(header) != "*" {
+		return Precondition{}, NewBadRequestError("If-None-Match must be *", nil)
+	}
+	p := Precondition{assente: true}
+	if r.Header.Get("If-Match") != "" {
+		ifMatch, err := IfMatch(r)
+		if err != nil {
+			return Precondition{}, err
+		}
+		p.etags, p.any = ifMatch.etags, ifMatch.any
+	}
+	return p, nil
+}
+
+// IfMatchAny is the precondition met by any version, as If-Match: *.
+func IfMatchAny() Precondition {
+	return Precondition{any: true}
+}
+
+// IfNoneMatchAny is the precondition met only by a missing resource, as
+// If-None-Match: *.
+func IfNoneMatchAny() Precondition {
+	return Precondition{assente: true}
+}
+
+// Matches reports whether the current etag of an existing resource meets
+// the precondition. If-Match uses the strong comparison, so weak tags never
+// match. A write replaces the stored version, so the tag of any
+// representation of it matches.
+func (p Precondition) Matches(etag string) bool {
+	if p.assente {
+		return false
+	}
+	if p.any {
+		return true
+	}
+	for _, tag := range p.etags {
+		if tag == etag || versioneETag(tag) == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesAssente reports whether a missing resource meets the precondition.
+// Only If-None-Match: * does; If-Match, even *, requires a current
+// representation.
+func (p Precondition) MatchesAssente() bool {
+	return p.assente && !p.any && len(p.etags) == 0
+}
+
+func splitETags(header string) []string {
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,175 @@
+package shared
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckNotModified(t *testing.T) {
+	etag := ETag(1700000000123456)
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{"no header", "", false},
+		{"same etag", etag, true},
+		{"weak form of the same etag", "W/" + etag, true},
+		{"one of many", `"abc", ` + etag, true},
+		{"star", "*", true},
+		{"other etag", `"abc"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			got := CheckNotModified(rec, req, etag)
+
+			if got != tt.want {
+				t.Fatalf("CheckNotModified() = %v, want %v", got, tt.want)
+			}
+			if rec.Header().Get("ETag") != etag {
+				t.Errorf("expected ETag header %q, got %q", etag, rec.Header().Get("ETag"))
+			}
+			if got && rec.Code != http.StatusNotModified {
+				t.Errorf("expected status 304, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestETagRappresentazione(t *testing.T) {
+	if got, _ := ETagRappresentazione(42); got != ETag(42) {
+		t.Errorf("expected the version ETag without dipendenze, got %s", got)
+	}
+
+	a, err := ETagRappresentazione(42, []string{"ascia"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a == ETag(42) {
+		t.Errorf("expected a tag other than the version ETag, got %s", a)
+	}
+	if b, _ := ETagRappresentazione(42, []string{"ascia"}); b != a {
+		t.Errorf("expected the same tag for the same data, got %s and %s", a, b)
+	}
+	if b, _ := ETagRappresentazione(42, []string{"spada"}); b == a {
+		t.Errorf("expected the tag to change with the data, got %s", b)
+	}
+	if _, err := ETagRappresentazione(42, func() {}); err == nil {
+		t.Error("expected an error for data that cannot be encoded")
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	etag := ETag(42)
+	rappresentazione, _ := ETagRappresentazione(42, []string{"ascia"})
+	altraRappresentazione, _ := ETagRappresentazione(43, []string{"ascia"})
+
+	t.Run("missing header", func(t *testing.T) {
+		_, err := IfMatch(httptest.NewRequest(http.MethodPut, "/", nil))
+
+		var appErr *AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != http.StatusPreconditionRequired {
+			t.Fatalf("expected 428 AppError, got %v", err)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    bool
+	}{
+		{"same etag", etag, true},
+		{"one of many", `"zz", ` + etag, true},
+		{"star", "*", true},
+		{"weak etag never matches", "W/" + etag, false},
+		{"other etag", `"zz"`, false},
+		{"representation of the same version", rappresentazione, true},
+		{"representation of another version", altraRappresentazione, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/", nil)
+			req.Header.Set("If-Match", tt.ifMatch)
+
+			p, err := IfMatch(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := p.Matches(etag); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfMatchOrNoneMatch(t *testing.T) {
+	etag := ETag(42)
+
+	tests := []struct {
+		name        string
+		ifMatch     string
+		ifNoneMatch string
+		esistente   bool
+		assente     bool
+	}{
+		{"if-match etag", etag, "", true, false},
+		{"if-match star", "*", "", true, false},
+		{"if-none-match star", "", "*", false, true},
+		{"both", "*", "*", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+
+			p, err := IfMatchOrNoneMatch(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := p.Matches(etag); got != tt.esistente {
+				t.Errorf("Matches() = %v, want %v", got, tt.esistente)
+			}
+			if got := p.MatchesAssente(); got != tt.assente {
+				t.Errorf("MatchesAssente() = %v, want %v", got, tt.assente)
+			}
+		})
+	}
+
+	t.Run("no header", func(t *testing.T) {
+		_, err := IfMatchOrNoneMatch(httptest.NewRequest(http.MethodPost, "/", nil))
+
+		var appErr *AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != http.StatusPreconditionRequired {
+			t.Fatalf("expected 428 AppError, got %v", err)
+		}
+	})
+
+	t.Run("if-none-match etag", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("If-None-Match", etag)
+
+		_, err := IfMatchOrNoneMatch(req)
+
+		var appErr *AppError
+		if !errors.As(err, &appErr) || appErr.HTTPStatus != http.StatusBadRequest {
+			t.Fatalf("expected 400 AppError, got %v", err)
+		}
+	})
+}
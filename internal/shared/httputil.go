@@ -3,10 +3,31 @@ package shared
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 )
 
+const maxRequestBodyBytes = 1 << 20
+
+// DecodeJSON decodes a JSON request body of at most 1 MiB into dst.
+// Unknown fields and trailing data are rejected.
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("request body is required")
+		}
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	if dec.More() {
+		return fmt.Errorf("invalid request body: unexpected data after JSON object")
+	}
+	return nil
+}
+
 func WriteJSON(w http.ResponseWriter, status int, data any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -17,6 +38,21 @@ func WriteJSON(w http.ResponseWriter, status int, data any) {
 	}
 }
 
+// WriteJSONCampi writes data restricted to campi. The items of a list
+// response are projected one by one, any other value as a whole.
+func WriteJSONCampi(w http.ResponseWriter, status int, data any, campi Campi) {
+	if campi.Tutti() {
+		WriteJSON(w, status, data)
+		return
+	}
+	projected, err := campi.proiettaRisposta(data)
+	if err != nil {
+		WriteError(w, NewInternalError(err))
+		return
+	}
+	WriteJSON(w, status, projected)
+}
+
 func WriteError(w http.ResponseWriter, err error) {
 	var appErr *AppError
 	if errors.As(err, &appErr) {
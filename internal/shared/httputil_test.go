@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -44,6 +45,55 @@ func TestWriteJSON(t *testing.T) {
 	})
 }
 
+func TestWriteJSONCampi(t *testing.T) {
+	type voce struct {
+		ID   string `json:"id"`
+		Nome string `json:"nome"`
+	}
+	type elencoVoci struct {
+		PaginationMeta
+		Voci []voce `json:"voci"`
+	}
+
+	t.Run("projects the items of a list", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		data := elencoVoci{
+			PaginationMeta: PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+			Voci:           []voce{{ID: "mago", Nome: "Mago"}},
+		}
+
+		WriteJSONCampi(rec, http.StatusOK, data, Campi{"nome"})
+
+		var body struct {
+			Pagina int              `json:"pagina"`
+			Voci   []map[string]any `json:"voci"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if body.Pagina != 1 {
+			t.Errorf("expected pagination to be kept, got pagina %d", body.Pagina)
+		}
+		if len(body.Voci) != 1 || len(body.Voci[0]) != 1 || body.Voci[0]["nome"] != "Mago" {
+			t.Errorf("unexpected voci %v", body.Voci)
+		}
+	})
+
+	t.Run("projects a single object", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		WriteJSONCampi(rec, http.StatusOK, voce{ID: "mago", Nome: "Mago"}, Campi{"id"})
+
+		var body map[string]any
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if len(body) != 1 || body["id"] != "mago" {
+			t.Errorf("unexpected body %v", body)
+		}
+	})
+}
+
 func TestWriteError(t *testing.T) {
 	t.Run("AppError with 4xx", func(t *testing.T) {
 		rec := httptest.NewRecorder()
@@ -93,3 +143,38 @@ func TestWriteError(t *testing.T) {
 		}
 	})
 }
+
+func TestDecodeJSON(t *testing.T) {
+	type payload struct {
+		Nome string `json:"nome"`
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"valid", `{"nome":"Mago"}`, false},
+		{"empty body", ``, true},
+		{"malformed", `{"nome":`, true},
+		{"unknown field", `{"nome":"Mago","livello":3}`, true},
+		{"trailing data", `{"nome":"Mago"} {}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			var got payload
+			err := DecodeJSON(rec, req, &got)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Nome != "Mago" {
+				t.Errorf("expected nome Mago, got %q", got.Nome)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package shared
+
+import (
+	"strconv"
+	"strings"
+)
+
+type TipoDiDado string
+
+const (
+	D3  TipoDiDado = "d3"
+	D4  TipoDiDado = "d4"
+	D6  TipoDiDado = "d6"
+	D8  TipoDiDado = "d8"
+	D10 TipoDiDado = "d10"
+	D12 TipoDiDado = "d12"
+	D20 TipoDiDado = "d20"
+)
+
+var TipiDiDado = []TipoDiDado{D3, D4, D6, D8, D10, D12, D20}
+
+// Facce returns the number of faces of the die, or 0 when t is not of the
+// form dN.
+func (t TipoDiDado) Facce() int {
+	s, ok := strings.CutPrefix(string(t), "d")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0
+	}
+	return n
+}
+
+type TipoAzione string
+
+const (
+	Nessuna        TipoAzione = "Nessuna"
+	AzioneBonus    TipoAzione = "Azione Bonus"
+	Azione         TipoAzione = "Azione"
+	Reazione       TipoAzione = "Reazione"
+	AzioneGratuita TipoAzione = "Azione Gratuita"
+)
+
+var TipiAzione = []TipoAzione{Nessuna, AzioneBonus, Azione, Reazione, AzioneGratuita}
+
+type Caratteristica string
+
+const (
+	Forza        Caratteristica = "Forza"
+	Destrezza    Caratteristica = "Destrezza"
+	Costituzione Caratteristica = "Costituzione"
+	Intelligenza Caratteristica = "Intelligenza"
+	Saggezza     Caratteristica = "Saggezza"
+	Carisma      Caratteristica = "Carisma"
+)
+
+var Caratteristiche = []Caratteristica{Forza, Destrezza, Costituzione, Intelligenza, Saggezza, Carisma}
+
+// Punteggi holds a score for each of the six abilities.
+type Punteggi struct {
+	Forza        int32 `json:"forza"`
+	Destrezza    int32 `json:"destrezza"`
+	Costituzione int32 `json:"costituzione"`
+	Intelligenza int32 `json:"intelligenza"`
+	Saggezza     int32 `json:"saggezza"`
+	Carisma      int32 `json:"carisma"`
+}
+
+// Valore returns the score of c, or 0 when c is not one of the six
+// abilities.
+func (p Punteggi) Valore(c Caratteristica) int32 {
+	switch c {
+	case Forza:
+		return p.Forza
+	case Destrezza:
+		return p.Destrezza
+	case Costituzione:
+		return p.Costituzione
+	case Intelligenza:
+		return p.Intelligenza
+	case Saggezza:
+		return p.Saggezza
+	case Carisma:
+		return p.Carisma
+	default:
+		return 0
+	}
+}
+
+// Somma returns the scores of p increased by those of o.
+func (p Punteggi) Somma(o Punteggi) Punteggi {
+	return Punteggi{
+		Forza:        p.Forza + o.Forza,
+		Destrezza:    p.Destrezza + o.Destrezza,
+		Costituzione: p.Costituzione + o.Costituzione,
+		Intelligenza: p.Intelligenza + o.Intelligenza,
+		Saggezza:     p.Saggezza + o.Saggezza,
+		Carisma:      p.Carisma + o.Carisma,
+	}
+}
+
+// PrerequisitoCaratteristica requires a score of at least ValoreMinimo in
+// any one of Caratteristiche ("Forza o Destrezza 13").
+type PrerequisitoCaratteristica struct {
+	Caratteristiche []Caratteristica `json:"caratteristiche"`
+	ValoreMinimo    int32            `json:"valore-minimo"`
+}
+
+// SoddisfattoDa reports whether punteggi meet the prerequisite.
+func (p PrerequisitoCaratteristica) SoddisfattoDa(punteggi Punteggi) bool {
+	for _, c := range p.Caratteristiche {
+		if punteggi.Valore(c) >= p.ValoreMinimo {
+			return true
+		}
+	}
+	return false
+}
+
+// CaratteristicaNessuna and CaratteristicaAutomatica are the extra values
+// accepted wherever the spec declares a "caratteristica associata".
+const (
+	CaratteristicaNessuna    Caratteristica = "Nessuna"
+	CaratteristicaAutomatica Caratteristica = "Automatica"
+)
+
+type TipoDiDanno string
+
+const (
+	Perforante  TipoDiDanno = "Perforante"
+	Contundente TipoDiDanno = "Contundente"
+	Tagliente   TipoDiDanno = "Tagliente"
+	Necrotico   TipoDiDanno = "Necrotico"
+	Radioso     TipoDiDanno = "Radioso"
+	Fuoco       TipoDiDanno = "Fuoco"
+	Ghiaccio    TipoDiDanno = "Ghiaccio"
+	Acido       TipoDiDanno = "Acido"
+	Veleno      TipoDiDanno = "Veleno"
+	Tuono       TipoDiDanno = "Tuono"
+	Fulmine     TipoDiDanno = "Fulmine"
+	DannoForza  TipoDiDanno = "Forza"
+	Psichico    TipoDiDanno = "Psichico"
+)
+
+var TipiDiDanno = []TipoDiDanno{
+	Perforante, Contundente, Tagliente, Necrotico, Radioso, Fuoco, Ghiaccio,
+	Acido, Veleno, Tuono, Fulmine, DannoForza, Psichico,
+}
+
+type Taglia string
+
+const (
+	Minuscola    Taglia = "Minuscola"
+	Piccola      Taglia = "Piccola"
+	Media        Taglia = "Media"
+	Grande       Taglia = "Grande"
+	Enorme       Taglia = "Enorme"
+	Gargantuesca Taglia = "Gargantuesca"
+)
+
+var Taglie = []Taglia{Minuscola, Piccola, Media, Grande, Enorme, Gargantuesca}
+
+type Condizione string
+
+const (
+	Infatuato    Condizione = "Infatuato"
+	Paralizzato  Condizione = "Paralizzato"
+	Stordito     Condizione = "Stordito"
+	Spaventato   Condizione = "Spaventato"
+	Assordato    Condizione = "Assordato"
+	Accecato     Condizione = "Accecato"
+	Esausto      Condizione = "Esausto"
+	Incapacitato Condizione = "Incapacitato"
+	Pietrificato Condizione = "Pietrificato"
+	Invisibile   Condizione = "Invisibile"
+	Avvelenato   Condizione = "Avvelenato"
+	Prono        Condizione = "Prono"
+	Afferrato    Condizione = "Afferrato"
+	Trattenuto   Condizione = "Trattenuto"
+	PrivoDiSensi Condizione = "Privo di sensi"
+)
+
+type Allineamento string
+
+const (
+	LegaleBuono      Allineamento = "Legale Buono"
+	NeutraleBuono    Allineamento = "Neutrale Buono"
+	CaoticoBuono     Allineamento = "Caotico Buono"
+	LegaleNeutrale   Allineamento = "Legale Neutrale"
+	Neutrale         Allineamento = "Neutrale"
+	CaoticoNeutrale  Allineamento = "Caotico Neutrale"
+	LegaleMalvagio   Allineamento = "Legale Malvagio"
+	NeutraleMalvagio Allineamento = "Neutrale Malvagio"
+	CaoticoMalvagio  Allineamento = "Caotico Malvagio"
+)
+
+var Allineamenti = []Allineamento{
+	LegaleBuono, NeutraleBuono, CaoticoBuono,
+	LegaleNeutrale, Neutrale, CaoticoNeutrale,
+	LegaleMalvagio, NeutraleMalvagio, CaoticoMalvagio,
+}
+
+type TipoVelocita string
+
+const (
+	Corsa       TipoVelocita = "Corsa"
+	Volo        TipoVelocita = "Volo"
+	Nuoto       TipoVelocita = "Nuoto"
+	Scavo       TipoVelocita = "Scavo"
+	Arrampicata TipoVelocita = "Arrampicata"
+)
+
+var TipiVelocita = []TipoVelocita{Corsa, Volo, Nuoto, Scavo, Arrampicata}
+
+type Velocita struct {
+	Tipo          TipoVelocita `json:"tipo"`
+	Valore        int32        `json:"valore"`
+	UnitaDiMisura string       `json:"unità-di-misura,omitempty"`
+}
+
+type Abilita struct {
+	Abilita                 string         `json:"abilità"`
+	Competenza              bool           `json:"competenza,omitempty"`
+	Expertise               bool           `json:"expertise,omitempty"`
+	Bonus                   int32          `json:"bonus,omitempty"`
+	CaratteristicaCollegata Caratteristica `json:"caratteristica-collegata,omitempty"`
+}
+
+type Senso struct {
+	ID          string `json:"id,omitempty"`
+	Nome        string `json:"nome"`
+	Descrizione string `json:"descrizione,omitempty"`
+	Gittata     string `json:"gittata,omitempty"`
+}
+
+// Difesa is the spec's TipiDiDanniECondizioni: the damage types and
+// conditions covered by a resistenza, immunità or vulnerabilità.
+type Difesa struct {
+	TipoDiDanno         []TipoDiDanno `json:"tipo-di-danno,omitempty"`
+	Condizione          []Condizione  `json:"condizione,omitempty"`
+	Tipo                string        `json:"tipo,omitempty"`
+	BonusTiroPerColpire int32         `json:"bonus-tiro-per-colpire,omitempty"`
+}
@@ -0,0 +1,35 @@
+package shared
+
+import "testing"
+
+func TestTipoDiDado_Facce(t *testing.T) {
+	tests := map[TipoDiDado]int{D4: 4, D12: 12, D20: 20, "d100": 100, "": 0, "6": 0, "dx": 0, "d0": 0}
+	for dado, want := range tests {
+		if got := dado.Facce(); got != want {
+			t.Errorf("%q.Facce() = %d, want %d", dado, got, want)
+		}
+	}
+}
+
+func TestPrerequisitoCaratteristica_SoddisfattoDa(t *testing.T) {
+	punteggi := Punteggi{Forza: 8, Destrezza: 14}.Somma(Punteggi{Forza: 1, Saggezza: 13})
+	if punteggi.Valore(Forza) != 9 || punteggi.Valore(Saggezza) != 13 || punteggi.Valore("Nessuna") != 0 {
+		t.Fatalf("unexpected punteggi %+v", punteggi)
+	}
+
+	tests := []struct {
+		name string
+		p    PrerequisitoCaratteristica
+		want bool
+	}{
+		{"any of the abilities", PrerequisitoCaratteristica{Caratteristiche: []Caratteristica{Forza, Destrezza}, ValoreMinimo: 13}, true},
+		{"score exactly at the minimum", PrerequisitoCaratteristica{Caratteristiche: []Caratteristica{Saggezza}, ValoreMinimo: 13}, true},
+		{"score below the minimum", PrerequisitoCaratteristica{Caratteristiche: []Caratteristica{Forza}, ValoreMinimo: 13}, false},
+		{"no abilities", PrerequisitoCaratteristica{ValoreMinimo: 13}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.p.SoddisfattoDa(punteggi); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ReadMergePatch reads a JSON Merge Patch (RFC 7396) request body of at
+// most 1 MiB. The patch must be a JSON object.
+func ReadMergePatch(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, fmt.Errorf("request body is required")
+	}
+	var patch map[string]any
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return nil, fmt.Errorf("invalid request body: merge patch must be a JSON object: %w", err)
+	}
+	return body, nil
+}
+
+// ApplyMergePatch applies a JSON Merge Patch to the JSON encoding of doc
+// and decodes the result into dst. Members set to null are removed, objects
+// are merged recursively and any other value replaces the current one.
+// Fields unknown to dst are rejected, as by DecodeJSON.
+func ApplyMergePatch(doc any, patch []byte, dst any) error {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	var target, changes any
+	if err := json.Unmarshal(raw, &target); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return fmt.Errorf("invalid merge patch: %w", err)
+	}
+
+	merged, err := json.Marshal(mergePatch(target, changes))
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("invalid merge patch: %w", err)
+	}
+	return nil
+}
+
+func mergePatch(target, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	result, ok := target.(map[string]any)
+	if !ok {
+		result = make(map[string]any, len(changes))
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = mergePatch(result[key], value)
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package shared
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	type dettagli struct {
+		Peso   int    `json:"peso,omitempty"`
+		Valuta string `json:"valuta,omitempty"`
+	}
+	type voce struct {
+		ID       string    `json:"id"`
+		Nome     string    `json:"nome"`
+		Tag      []string  `json:"tag,omitempty"`
+		Dettagli *dettagli `json:"dettagli,omitempty"`
+	}
+	doc := voce{ID: "corda", Nome: "Corda", Tag: []string{"a", "b"}, Dettagli: &dettagli{Peso: 5, Valuta: "mo"}}
+
+	t.Run("merges objects and replaces arrays", func(t *testing.T) {
+		var got voce
+		err := ApplyMergePatch(doc, []byte(`{"nome":"Corda di seta","tag":["c"],"dettagli":{"peso":2}}`), &got)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.ID != "corda" || got.Nome != "Corda di seta" {
+			t.Errorf("unexpected id/nome %q/%q", got.ID, got.Nome)
+		}
+		if len(got.Tag) != 1 || got.Tag[0] != "c" {
+			t.Errorf("expected tag to be replaced, got %v", got.Tag)
+		}
+		if got.Dettagli == nil || got.Dettagli.Peso != 2 || got.Dettagli.Valuta != "mo" {
+			t.Errorf("expected dettagli to be merged, got %+v", got.Dettagli)
+		}
+	})
+
+	t.Run("null removes a member", func(t *testing.T) {
+		var got voce
+		if err := ApplyMergePatch(doc, []byte(`{"dettagli":null}`), &got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Dettagli != nil {
+			t.Errorf("expected dettagli to be removed, got %+v", got.Dettagli)
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		var got voce
+		if err := ApplyMergePatch(doc, []byte(`{"colore":"rosso"}`), &got); err == nil {
+			t.Fatal("expected error for unknown field")
+		}
+	})
+}
+
+func TestReadMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"object", `{"nome":"Mago"}`, false},
+		{"empty body", ``, true},
+		{"not an object", `["nome"]`, true},
+		{"invalid JSON", `{"nome":`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			_, err := ReadMergePatch(rec, req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadMergePatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
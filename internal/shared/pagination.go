@@ -25,6 +25,9 @@ type ListFilter struct {
 	Sort                        SortOrder
 	Limit                       int
 	Offset                      int
+	// Campi are the fields of each item to return. Repositories may use
+	// them to read fewer columns; the handler projects the response.
+	Campi Campi
 }
 
 type listFilterRequest struct {
@@ -90,6 +93,12 @@ func NewListFilterFromRequest(r *http.Request) (ListFilter, error) {
 		filter.DocumentazioneDiRiferimento = docs
 	}
 
+	campi, err := QueryCampi(query)
+	if err != nil {
+		return filter, err
+	}
+	filter.Campi = campi
+
 	if req.Sort == "desc" {
 		filter.Sort = SortDesc
 	}
@@ -105,6 +114,13 @@ type PaginationMeta struct {
 	NumeroDiElementi int `json:"numero-di-elementi"`
 }
 
+// elenco is implemented by the list responses, which embed PaginationMeta.
+type elenco interface {
+	elenco()
+}
+
+func (PaginationMeta) elenco() {}
+
 func (f ListFilter) Page() int {
 	if f.Limit == 0 {
 		return 0
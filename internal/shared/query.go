@@ -0,0 +1,133 @@
+package shared
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxQueryValueLength = 100
+	maxQueryListItems   = 10
+)
+
+// QueryString returns the value of an optional string parameter, or nil
+// when the parameter is absent or empty.
+func QueryString(query url.Values, name string) (*string, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	if len(value) > maxQueryValueLength {
+		return nil, fmt.Errorf("%s cannot exceed %d", name, maxQueryValueLength)
+	}
+	return &value, nil
+}
+
+// QueryInt returns the value of an optional integer parameter bounded by
+// minValue and maxValue, or nil when the parameter is absent.
+func QueryInt(query url.Values, name string, minValue, maxValue int) (*int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a valid integer", name)
+	}
+	if i < minValue || i > maxValue {
+		return nil, fmt.Errorf("%s must be between %d and %d", name, minValue, maxValue)
+	}
+	return &i, nil
+}
+
+// QueryBool returns the value of an optional boolean parameter, or nil when
+// the parameter is absent.
+func QueryBool(query url.Values, name string) (*bool, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a valid boolean", name)
+	}
+	return &b, nil
+}
+
+// QueryTime returns the value of an optional RFC 3339 timestamp parameter,
+// or nil when the parameter is absent.
+func QueryTime(query url.Values, name string) (*time.Time, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 timestamp, such as 2026-01-01T00:00:00Z", name)
+	}
+	return &t, nil
+}
+
+// QueryList collects a multi-valued parameter. Values may be given as
+// repeated keys (?x=a&x=b), comma separated (?x=a,b) or both.
+func QueryList(query url.Values, name string) ([]string, error) {
+	var values []string
+	for _, raw := range query[name] {
+		for _, v := range strings.Split(raw, ",") {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				continue
+			}
+			if len(v) > maxQueryValueLength {
+				return nil, fmt.Errorf("%s: value exceeds max length of %d", name, maxQueryValueLength)
+			}
+			values = append(values, v)
+		}
+	}
+	if len(values) > maxQueryListItems {
+		return nil, fmt.Errorf("%s: too many values (max %d)", name, maxQueryListItems)
+	}
+	return values, nil
+}
+
+// QueryEnum returns the value of an optional parameter restricted to the
+// allowed values, or nil when the parameter is absent.
+func QueryEnum[T ~string](query url.Values, name string, allowed ...T) (*T, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	if !slices.Contains(allowed, T(value)) {
+		return nil, enumError(name, allowed)
+	}
+	v := T(value)
+	return &v, nil
+}
+
+// QueryEnumList is QueryList restricted to the allowed values.
+func QueryEnumList[T ~string](query url.Values, name string, allowed ...T) ([]T, error) {
+	values, err := QueryList(query, name)
+	if err != nil {
+		return nil, err
+	}
+	var result []T
+	for _, v := range values {
+		if !slices.Contains(allowed, T(v)) {
+			return nil, enumError(name, allowed)
+		}
+		result = append(result, T(v))
+	}
+	return result, nil
+}
+
+func enumError[T ~string](name string, allowed []T) error {
+	names := make([]string, len(allowed))
+	for i, a := range allowed {
+		names[i] = string(a)
+	}
+	return fmt.Errorf("%s must be one of: %s", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,172 @@
+package shared
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryString(t *testing.T) {
+	t.Run("absent returns nil", func(t *testing.T) {
+		got, err := QueryString(url.Values{}, "durata")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != nil {
+			t.Errorf("expected nil, got %q", *got)
+		}
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		_, err := QueryString(url.Values{"durata": {strings.Repeat("a", 101)}}, "durata")
+		if err == nil {
+			t.Fatal("expected error for long value")
+		}
+	})
+}
+
+func TestQueryInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *int
+		wantErr bool
+	}{
+		{"absent", "", nil, false},
+		{"valid", "3", intPtr(3), false},
+		{"lower bound", "0", intPtr(0), false},
+		{"upper bound", "9", intPtr(9), false},
+		{"below min", "-1", nil, true},
+		{"above max", "10", nil, true},
+		{"not integer", "abc", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{}
+			if tt.value != "" {
+				query.Set("livello", tt.value)
+			}
+
+			got, err := QueryInt(query, "livello", 0, 9)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QueryInt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == nil && got != nil {
+				t.Errorf("expected nil, got %d", *got)
+			}
+			if tt.want != nil && (got == nil || *got != *tt.want) {
+				t.Errorf("expected %d, got %v", *tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQueryBool(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *bool
+		wantErr bool
+	}{
+		{"absent", "", nil, false},
+		{"true", "true", boolPtr(true), false},
+		{"false", "false", boolPtr(false), false},
+		{"invalid", "forse", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{}
+			if tt.value != "" {
+				query.Set("rituale", tt.value)
+			}
+
+			got, err := QueryBool(query, "rituale")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QueryBool() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == nil && got != nil {
+				t.Errorf("expected nil, got %v", *got)
+			}
+			if tt.want != nil && (got == nil || *got != *tt.want) {
+				t.Errorf("expected %v, got %v", *tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQueryTime(t *testing.T) {
+	t.Run("absent returns nil", func(t *testing.T) {
+		got, err := QueryTime(url.Values{}, "al")
+		if err != nil || got != nil {
+			t.Errorf("expected nil, got %v, %v", got, err)
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		got, err := QueryTime(url.Values{"al": {"2026-01-01T10:00:00+01:00"}}, "al")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("date only", func(t *testing.T) {
+		if _, err := QueryTime(url.Values{"al": {"2026-01-01"}}, "al"); err == nil {
+			t.Error("expected error for a timestamp without time")
+		}
+	})
+}
+
+func TestQueryList(t *testing.T) {
+	t.Run("repeated and comma separated", func(t *testing.T) {
+		query := url.Values{"classi": {"mago,chierico", "bardo", " "}}
+
+		got, err := QueryList(query, "classi")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 3 || got[0] != "mago" || got[1] != "chierico" || got[2] != "bardo" {
+			t.Errorf("unexpected result: %v", got)
+		}
+	})
+
+	t.Run("too many values", func(t *testing.T) {
+		query := url.Values{"classi": {"a,b,c,d,e,f,g,h,i,j,k"}}
+
+		if _, err := QueryList(query, "classi"); err == nil {
+			t.Fatal("expected error for too many values")
+		}
+	})
+}
+
+func TestQueryEnum(t *testing.T) {
+	t.Run("allowed value", func(t *testing.T) {
+		got, err := QueryEnum(url.Values{"sort": {"asc"}}, "sort", "asc", "desc")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got == nil || *got != "asc" {
+			t.Errorf("expected asc, got %v", got)
+		}
+	})
+
+	t.Run("disallowed value", func(t *testing.T) {
+		if _, err := QueryEnum(url.Values{"sort": {"up"}}, "sort", "asc", "desc"); err == nil {
+			t.Fatal("expected error for disallowed value")
+		}
+	})
+
+	t.Run("list with disallowed value", func(t *testing.T) {
+		if _, err := QueryEnumList(url.Values{"componenti": {"V,X"}}, "componenti", "V", "S", "M"); err == nil {
+			t.Fatal("expected error for disallowed value")
+		}
+	})
+}
+
+func intPtr(i int) *int    { return &i }
+func boolPtr(b bool) *bool { return &b }
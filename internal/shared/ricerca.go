@@ -0,0 +1,92 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type CondizioneRicerca string
+
+const (
+	CondizioneAnd CondizioneRicerca = "and"
+	CondizioneOr  CondizioneRicerca = "or"
+)
+
+// CriterioRicerca requires the attribute to match Pattern, a case
+// insensitive POSIX regular expression.
+type CriterioRicerca struct {
+	Attributo string
+	Pattern   string
+}
+
+// FiltroRicerca is a search over a single resource type. Attribute names
+// are the resource's search attributes, already validated by the caller. A
+// negative Limit means no limit.
+type FiltroRicerca struct {
+	Criteri    []CriterioRicerca
+	Condizione CondizioneRicerca
+	OrdinaPer  string
+	Sort       SortOrder
+	Limit      int
+	Offset     int
+}
+
+// Query appends the criteria, ordering and paging of f to baseQuery, which
+// must end with a WHERE clause. colonne maps each search attribute to the
+// SQL expression it reads; the expressions are trusted, the patterns are
+// passed as named arguments. Results are ordered by nome when OrdinaPer is
+// empty, with id as tie breaker so paging is stable.
+func (f FiltroRicerca) Query(baseQuery string, colonne map[string]string) (string, map[string]any, error) {
+	args := make(map[string]any)
+
+	if len(f.Criteri) > 0 {
+		conditions := make([]string, len(f.Criteri))
+		for i, c := range f.Criteri {
+			col, ok := colonne[c.Attributo]
+			if !ok {
+				return "", nil, fmt.Errorf("unknown search attribute %q", c.Attributo)
+			}
+			name := fmt.Sprintf("perimetro_%d", i)
+			conditions[i] = fmt.Sprintf("CAST(%s AS TEXT) ~* :%s", col, name)
+			args[name] = c.Pattern
+		}
+		op := " AND "
+		if f.Condizione == CondizioneOr {
+			op = " OR "
+		}
+		baseQuery += ` AND (` + strings.Join(conditions, op) + `)`
+	}
+
+	orderBy := colonne["nome"]
+	if f.OrdinaPer != "" {
+		col, ok := colonne[f.OrdinaPer]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown sort attribute %q", f.OrdinaPer)
+		}
+		orderBy = col
+	}
+	orderDir := "ASC"
+	if f.Sort == SortDesc {
+		orderDir = "DESC"
+	}
+	baseQuery += fmt.Sprintf(` ORDER BY %s %s, id ASC`, orderBy, orderDir)
+
+	if f.Limit >= 0 {
+		baseQuery += ` LIMIT :limit`
+		args["limit"] = f.Limit
+	}
+	baseQuery += ` OFFSET :offset`
+	args["offset"] = max(f.Offset, 0)
+
+	return baseQuery, args, nil
+}
+
+// IsInvalidRegularExpression reports whether err is Postgres rejecting a
+// regular expression, which for search patterns is a client error.
+func IsInvalidRegularExpression(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "2201B"
+}
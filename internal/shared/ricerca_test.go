@@ -0,0 +1,73 @@
+package shared
+
+import (
+	"strings"
+	"testing"
+)
+
+var colonneTest = map[string]string{
+	"id":        "id",
+	"nome":      "nome",
+	"dado-vita": "dado_vita",
+}
+
+func TestFiltroRicerca_Query(t *testing.T) {
+	const base = `SELECT id FROM classi WHERE 1=1`
+
+	t.Run("defaults order by nome without limit", func(t *testing.T) {
+		query, args, err := FiltroRicerca{Limit: -1}.Query(base, colonneTest)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasSuffix(query, ` ORDER BY nome ASC, id ASC OFFSET :offset`) {
+			t.Errorf("unexpected query %q", query)
+		}
+		if _, ok := args["limit"]; ok {
+			t.Errorf("expected no limit arg, got %v", args)
+		}
+	})
+
+	t.Run("or criteria, sort and paging", func(t *testing.T) {
+		filtro := FiltroRicerca{
+			Criteri: []CriterioRicerca{
+				{Attributo: "nome", Pattern: "^ma"},
+				{Attributo: "dado-vita", Pattern: "12"},
+			},
+			Condizione: CondizioneOr,
+			OrdinaPer:  "dado-vita",
+			Sort:       SortDesc,
+			Limit:      5,
+			Offset:     10,
+		}
+
+		query, args, err := filtro.Query(base, colonneTest)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(query, ` AND (CAST(nome AS TEXT) ~* :perimetro_0 OR CAST(dado_vita AS TEXT) ~* :perimetro_1)`) {
+			t.Errorf("unexpected conditions %q", query)
+		}
+		if !strings.HasSuffix(query, ` ORDER BY dado_vita DESC, id ASC LIMIT :limit OFFSET :offset`) {
+			t.Errorf("unexpected ordering %q", query)
+		}
+		if args["perimetro_0"] != "^ma" || args["perimetro_1"] != "12" || args["limit"] != 5 || args["offset"] != 10 {
+			t.Errorf("unexpected args %v", args)
+		}
+	})
+
+	t.Run("unknown attribute", func(t *testing.T) {
+		filtro := FiltroRicerca{Criteri: []CriterioRicerca{{Attributo: "scuola", Pattern: "x"}}}
+
+		if _, _, err := filtro.Query(base, colonneTest); err == nil {
+			t.Fatal("expected error for unknown attribute")
+		}
+	})
+
+	t.Run("unknown sort attribute", func(t *testing.T) {
+		if _, _, err := (FiltroRicerca{OrdinaPer: "scuola"}).Query(base, colonneTest); err == nil {
+			t.Fatal("expected error for unknown sort attribute")
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package shared
+
+import "context"
+
+type TipoRiferimento string
+
+const (
+	RiferimentoRegola     TipoRiferimento = "regola"
+	RiferimentoCondizione TipoRiferimento = "condizione"
+)
+
+// Riferimento links a rule or condition mentioned in the text of a
+// resource. Link is the API path of the referenced resource.
+type Riferimento struct {
+	Tipo TipoRiferimento `json:"tipo"`
+	ID   string          `json:"id"`
+	Nome string          `json:"nome"`
+	Link string          `json:"link"`
+}
+
+// Glossario finds the rules and conditions mentioned in the descrizione
+// fields of a resource. Lookups are best effort: a failure yields no
+// references rather than an error. Services built with a nil Glossario
+// return no references.
+type Glossario interface {
+	Riferimenti(ctx context.Context, risorsa any) []Riferimento
+}
@@ -0,0 +1,146 @@
+package shared
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Valuta string
+
+const (
+	MR Valuta = "MR"
+	MA Valuta = "MA"
+	ME Valuta = "ME"
+	MO Valuta = "MO"
+	MP Valuta = "MP"
+)
+
+var Valute = []Valuta{MR, MA, ME, MO, MP}
+
+// valoreInMR is the worth of one coin of each currency in monete di rame.
+var valoreInMR = map[Valuta]int64{
+	MR: 1,
+	MA: 10,
+	ME: 50,
+	MO: 100,
+	MP: 1000,
+}
+
+// Valore returns the worth of one coin of v, or 0 for an unknown currency.
+func (v Valuta) Valore() Denaro {
+	return Denaro(valoreInMR[v])
+}
+
+type Importo struct {
+	Quantita int32  `json:"quantità"`
+	Valuta   Valuta `json:"valuta"`
+}
+
+// InMoneteDiRame returns the amount expressed in monete di rame, the
+// smallest unit, so that amounts in different currencies can be compared.
+func (i Importo) InMoneteDiRame() int64 {
+	return int64(i.Quantita) * valoreInMR[i.Valuta]
+}
+
+// Confronta compares i and j by value, whatever their currency: it returns
+// -1 if i is worth less than j, 0 if they are worth the same and +1
+// otherwise.
+func (i Importo) Confronta(j Importo) int {
+	return cmp.Compare(i.InMoneteDiRame(), j.InMoneteDiRame())
+}
+
+// ParseImporto parses an amount written as a quantity followed by a
+// currency, with or without a space ("15 MO", "15mo").
+func ParseImporto(s string) (Importo, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if i <= 0 {
+		return Importo{}, fmt.Errorf("invalid importo %q", s)
+	}
+	quantita, err := strconv.ParseInt(s[:i], 10, 32)
+	if err != nil {
+		return Importo{}, fmt.Errorf("invalid importo %q", s)
+	}
+	valuta := Valuta(strings.ToUpper(strings.TrimSpace(s[i:])))
+	if _, ok := valoreInMR[valuta]; !ok {
+		return Importo{}, fmt.Errorf("invalid valuta %q in importo %q", valuta, s)
+	}
+	return Importo{Quantita: int32(quantita), Valuta: valuta}, nil
+}
+
+// Denaro is a sum of money in monete di rame. Amounts in different
+// currencies add up and compare exactly once converted to Denaro, with the
+// usual integer operators.
+type Denaro int64
+
+// DenaroDi returns the total value of importi.
+func DenaroDi(importi ...Importo) Denaro {
+	var d Denaro
+	for _, i := range importi {
+		d += Denaro(i.InMoneteDiRame())
+	}
+	return d
+}
+
+// Converti expresses d in valuta. The part of d worth less than a coin of
+// valuta is returned as the remainder.
+func (d Denaro) Converti(valuta Valuta) (Importo, Denaro, error) {
+	valore, ok := valoreInMR[valuta]
+	if !ok {
+		return Importo{}, 0, fmt.Errorf("invalid valuta %q", valuta)
+	}
+	if d < 0 {
+		return Importo{}, 0, errors.New("denaro cannot be negative")
+	}
+	quantita := int64(d) / valore
+	if quantita > math.MaxInt32 {
+		return Importo{}, 0, fmt.Errorf("denaro is too large to be expressed in %s", valuta)
+	}
+	return Importo{Quantita: int32(quantita), Valuta: valuta}, d - Denaro(quantita*valore), nil
+}
+
+// Normalizza returns d as the fewest coins of the given currencies, from
+// the most to the least valuable, skipping the currencies it does not
+// need; with no currencies every one is used. Any value that the given
+// currencies cannot express is returned as the remainder.
+func (d Denaro) Normalizza(valute ...Valuta) ([]Importo, Denaro, error) {
+	if len(valute) == 0 {
+		valute = Valute
+	}
+	ordinate := slices.Clone(valute)
+	slices.SortFunc(ordinate, func(a, b Valuta) int { return cmp.Compare(valoreInMR[b], valoreInMR[a]) })
+
+	monete := []Importo{}
+	for _, valuta := range slices.Compact(ordinate) {
+		importo, resto, err := d.Converti(valuta)
+		if err != nil {
+			return nil, 0, err
+		}
+		if importo.Quantita > 0 {
+			monete = append(monete, importo)
+		}
+		d = resto
+	}
+	return monete, d, nil
+}
+
+// Resto returns the change due when pagato is given for prezzo, as the
+// fewest coins of the given currencies (every one when none is given).
+func Resto(prezzo, pagato Denaro, valute ...Valuta) ([]Importo, error) {
+	if pagato < prezzo {
+		return nil, fmt.Errorf("pagato (%d MR) is less than prezzo (%d MR)", pagato, prezzo)
+	}
+	monete, resto, err := (pagato - prezzo).Normalizza(valute...)
+	if err != nil {
+		return nil, err
+	}
+	if resto != 0 {
+		return nil, fmt.Errorf("%d MR of change cannot be given with %v", resto, valute)
+	}
+	return monete, nil
+}
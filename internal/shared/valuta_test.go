@@ -0,0 +1,172 @@
+package shared
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestImporto_InMoneteDiRame(t *testing.T) {
+	tests := []struct {
+		importo Importo
+		want    int64
+	}{
+		{Importo{Quantita: 3, Valuta: MR}, 3},
+		{Importo{Quantita: 2, Valuta: MA}, 20},
+		{Importo{Quantita: 1, Valuta: ME}, 50},
+		{Importo{Quantita: 15, Valuta: MO}, 1500},
+		{Importo{Quantita: 2, Valuta: MP}, 2000},
+	}
+
+	for _, tt := range tests {
+		if got := tt.importo.InMoneteDiRame(); got != tt.want {
+			t.Errorf("%d %s: expected %d, got %d", tt.importo.Quantita, tt.importo.Valuta, tt.want, got)
+		}
+	}
+}
+
+func TestParseImporto(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Importo
+		wantErr bool
+	}{
+		{"15 MO", Importo{Quantita: 15, Valuta: MO}, false},
+		{"2mp", Importo{Quantita: 2, Valuta: MP}, false},
+		{" 0 MR ", Importo{Quantita: 0, Valuta: MR}, false},
+		{"MO", Importo{}, true},
+		{"15", Importo{}, true},
+		{"15 MX", Importo{}, true},
+		{"-5 MO", Importo{}, true},
+		{"99999999999 MO", Importo{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseImporto(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseImporto() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestImporto_Confronta(t *testing.T) {
+	tests := []struct {
+		i, j Importo
+		want int
+	}{
+		{Importo{Quantita: 1, Valuta: MO}, Importo{Quantita: 10, Valuta: MA}, 0},
+		{Importo{Quantita: 1, Valuta: ME}, Importo{Quantita: 1, Valuta: MO}, -1},
+		{Importo{Quantita: 1, Valuta: MP}, Importo{Quantita: 999, Valuta: MR}, 1},
+	}
+
+	for _, tt := range tests {
+		if got := tt.i.Confronta(tt.j); got != tt.want {
+			t.Errorf("%v vs %v: expected %d, got %d", tt.i, tt.j, tt.want, got)
+		}
+	}
+}
+
+func TestDenaroDi(t *testing.T) {
+	got := DenaroDi(Importo{Quantita: 2, Valuta: MO}, Importo{Quantita: 1, Valuta: ME}, Importo{Quantita: 3, Valuta: MR})
+
+	if got != 253 {
+		t.Errorf("expected 253 MR, got %d", got)
+	}
+}
+
+func TestDenaro_Converti(t *testing.T) {
+	tests := []struct {
+		name      string
+		denaro    Denaro
+		valuta    Valuta
+		want      Importo
+		wantResto Denaro
+		wantErr   bool
+	}{
+		{"exact", 1500, MA, Importo{Quantita: 150, Valuta: MA}, 0, false},
+		{"with remainder", 1537, MO, Importo{Quantita: 15, Valuta: MO}, 37, false},
+		{"less than a coin", 40, ME, Importo{Quantita: 0, Valuta: ME}, 40, false},
+		{"invalid valuta", 10, "MX", Importo{}, 0, true},
+		{"negative", -1, MR, Importo{}, 0, true},
+		{"too large", Denaro(math.MaxInt32) * 10, MR, Importo{}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, resto, err := tt.denaro.Converti(tt.valuta)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Converti() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || resto != tt.wantResto {
+				t.Errorf("expected %v and %d MR, got %v and %d MR", tt.want, tt.wantResto, got, resto)
+			}
+		})
+	}
+}
+
+func TestDenaro_Normalizza(t *testing.T) {
+	t.Run("every currency", func(t *testing.T) {
+		got, resto, err := Denaro(1687).Normalizza()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []Importo{{Quantita: 1, Valuta: MP}, {Quantita: 6, Valuta: MO}, {Quantita: 1, Valuta: ME}, {Quantita: 3, Valuta: MA}, {Quantita: 7, Valuta: MR}}
+		if !slices.Equal(got, want) || resto != 0 {
+			t.Errorf("expected %v, got %v with remainder %d", want, got, resto)
+		}
+	})
+
+	t.Run("selected currencies in any order", func(t *testing.T) {
+		got, resto, err := Denaro(1687).Normalizza(MA, MO, MA)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []Importo{{Quantita: 16, Valuta: MO}, {Quantita: 8, Valuta: MA}}
+		if !slices.Equal(got, want) || resto != 7 {
+			t.Errorf("expected %v and 7 MR, got %v and %d MR", want, got, resto)
+		}
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		got, _, _ := Denaro(0).Normalizza()
+
+		if got == nil || len(got) != 0 {
+			t.Errorf("expected an empty list, got %v", got)
+		}
+	})
+}
+
+func TestResto(t *testing.T) {
+	prezzo := DenaroDi(Importo{Quantita: 2, Valuta: MA}, Importo{Quantita: 5, Valuta: MR})
+
+	t.Run("change", func(t *testing.T) {
+		got, err := Resto(prezzo, DenaroDi(Importo{Quantita: 1, Valuta: MO}))
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []Importo{{Quantita: 1, Valuta: ME}, {Quantita: 2, Valuta: MA}, {Quantita: 5, Valuta: MR}}
+		if !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("not enough paid", func(t *testing.T) {
+		if _, err := Resto(prezzo, 20); err == nil {
+			t.Fatal("expected error when pagato is less than prezzo")
+		}
+	})
+
+	t.Run("change that cannot be given", func(t *testing.T) {
+		if _, err := Resto(prezzo, 100, MO, MA); err == nil {
+			t.Fatal("expected error when the change needs missing coins")
+		}
+	})
+}
@@ -0,0 +1,14 @@
+package specie
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Specie, int, error)
+	GetByID(ctx context.Context, id string) (*Specie, error)
+	ListLignaggi(ctx context.Context, specieID string, filter shared.ListFilter) ([]Lignaggio, int, error)
+	GetLignaggioByID(ctx context.Context, specieID, lignaggioID string) (*Lignaggio, error)
+}
@@ -0,0 +1,42 @@
+package specie
+
+import (
+	"context"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type MockRepository struct {
+	ListFunc             func(ctx context.Context, filter ListFilter) ([]Specie, int, error)
+	GetByIDFunc          func(ctx context.Context, id string) (*Specie, error)
+	ListLignaggiFunc     func(ctx context.Context, specieID string, filter shared.ListFilter) ([]Lignaggio, int, error)
+	GetLignaggioByIDFunc func(ctx context.Context, specieID, lignaggioID string) (*Lignaggio, error)
+}
+
+func (m *MockRepository) List(ctx context.Context, filter ListFilter) ([]Specie, int, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockRepository) GetByID(ctx context.Context, id string) (*Specie, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) ListLignaggi(ctx context.Context, specieID string, filter shared.ListFilter) ([]Lignaggio, int, error) {
+	if m.ListLignaggiFunc != nil {
+		return m.ListLignaggiFunc(ctx, specieID, filter)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockRepository) GetLignaggioByID(ctx context.Context, specieID, lignaggioID string) (*Lignaggio, error) {
+	if m.GetLignaggioByIDFunc != nil {
+		return m.GetLignaggioByIDFunc(ctx, specieID, lignaggioID)
+	}
+	return nil, nil
+}
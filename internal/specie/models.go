@@ -0,0 +1,36 @@
+package specie
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type Lignaggio struct {
+	ID                          string               `json:"id" db:"id"`
+	Nome                        string               `json:"nome" db:"nome"`
+	Descrizione                 string               `json:"descrizione" db:"descrizione"`
+	IDSpecie                    string               `json:"id-specie" db:"id_specie"`
+	Tratti                      []shared.Tratto      `json:"tratti,omitempty"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty" db:"-"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento" db:"documentazione_di_riferimento"`
+}
+
+type Specie struct {
+	ID                          string               `json:"id" db:"id"`
+	Nome                        string               `json:"nome" db:"nome"`
+	TipoDiCreatura              string               `json:"tipo" db:"tipo_di_creatura"`
+	Taglia                      shared.Taglia        `json:"taglia" db:"taglia"`
+	DettaglioTaglia             string               `json:"dettaglio-taglia,omitempty" db:"dettaglio_taglia"`
+	Velocita                    []shared.Velocita    `json:"velocità"`
+	Descrizione                 string               `json:"descrizione" db:"descrizione"`
+	Tratti                      []shared.Tratto      `json:"tratti,omitempty"`
+	Lignaggio                   []Lignaggio          `json:"lignaggio,omitempty"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty" db:"-"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento" db:"documentazione_di_riferimento"`
+}
+
+// ListFilter extends the shared list filter with the species-specific
+// filters. Taglia matches any of the given sizes, TipoVelocita requires a
+// speed of every given kind.
+type ListFilter struct {
+	shared.ListFilter
+	Taglia       []shared.Taglia
+	TipoVelocita []shared.TipoVelocita
+}
@@ -0,0 +1,226 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/specie"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type specieRow struct {
+	ID                          string                            `db:"id"`
+	Nome                        string                            `db:"nome"`
+	TipoDiCreatura              string                            `db:"tipo_di_creatura"`
+	Taglia                      string                            `db:"taglia"`
+	DettaglioTaglia             sql.NullString                    `db:"dettaglio_taglia"`
+	Velocita                    shared.JSONSlice[shared.Velocita] `db:"velocita"`
+	Descrizione                 sql.NullString                    `db:"descrizione"`
+	Tratti                      shared.JSONSlice[shared.Tratto]   `db:"tratti"`
+	DocumentazioneDiRiferimento string                            `db:"documentazione_di_riferimento"`
+}
+
+func (r *specieRow) toSpecie(lignaggi []specie.Lignaggio) specie.Specie {
+	return specie.Specie{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		TipoDiCreatura:              r.TipoDiCreatura,
+		Taglia:                      shared.Taglia(r.Taglia),
+		DettaglioTaglia:             r.DettaglioTaglia.String,
+		Velocita:                    r.Velocita,
+		Descrizione:                 r.Descrizione.String,
+		Tratti:                      r.Tratti,
+		Lignaggio:                   lignaggi,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+}
+
+type lignaggioRow struct {
+	ID                          string                          `db:"id"`
+	Nome                        string                          `db:"nome"`
+	Descrizione                 sql.NullString                  `db:"descrizione"`
+	IDSpecie                    string                          `db:"id_specie"`
+	Tratti                      shared.JSONSlice[shared.Tratto] `db:"tratti"`
+	DocumentazioneDiRiferimento string                          `db:"documentazione_di_riferimento"`
+}
+
+func (r *lignaggioRow) toLignaggio() specie.Lignaggio {
+	return specie.Lignaggio{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Descrizione:                 r.Descrizione.String,
+		IDSpecie:                    r.IDSpecie,
+		Tratti:                      r.Tratti,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+}
+
+const selectSpecie = `
+	SELECT id, nome, tipo_di_creatura, taglia, dettaglio_taglia, velocita, descrizione,
+	       tratti, documentazione_di_riferimento
+	FROM specie`
+
+const selectLignaggio = `
+	SELECT id, nome, descrizione, id_specie, tratti, documentazione_di_riferimento
+	FROM lignaggi`
+
+// filterConditions translates the species-specific filters into SQL
+// conditions and their named arguments. The shared filters are applied by
+// shared.NewPaginatedQuery.
+func filterConditions(filter specie.ListFilter) (string, map[string]any, error) {
+	var where string
+	args := make(map[string]any)
+
+	if len(filter.Taglia) > 0 {
+		taglie := make([]string, len(filter.Taglia))
+		for i, t := range filter.Taglia {
+			taglie[i] = string(t)
+		}
+		where += ` AND taglia = ANY(:taglia)`
+		args["taglia"] = pq.Array(taglie)
+	}
+	if len(filter.TipoVelocita) > 0 {
+		// One {"tipo": ...} entry per requested kind: containment requires
+		// a speed of every kind.
+		entries := make([]map[string]shared.TipoVelocita, len(filter.TipoVelocita))
+		for i, v := range filter.TipoVelocita {
+			entries[i] = map[string]shared.TipoVelocita{"tipo": v}
+		}
+		velocita, err := json.Marshal(entries)
+		if err != nil {
+			return "", nil, fmt.Errorf("marshal velocita filter: %w", err)
+		}
+		where += ` AND velocita @> CAST(:velocita AS jsonb)`
+		args["velocita"] = string(velocita)
+	}
+
+	return where, args, nil
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filter specie.ListFilter) ([]specie.Specie, int, error) {
+	where, args, err := filterConditions(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	q := shared.NewPaginatedQuery(
+		selectSpecie+` WHERE 1=1`+where,
+		`SELECT COUNT(*) FROM specie WHERE 1=1`+where,
+		args,
+		filter.ListFilter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []specieRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	lignaggiMap, err := r.getLignaggiBySpecieIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]specie.Specie, len(rows))
+	for i, row := range rows {
+		result[i] = row.toSpecie(lignaggiMap[row.ID])
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*specie.Specie, error) {
+	var row specieRow
+	if err := r.db.GetContext(ctx, &row, selectSpecie+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get specie by id: %w", err)
+	}
+
+	lignaggiMap, err := r.getLignaggiBySpecieIDs(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	s := row.toSpecie(lignaggiMap[id])
+	return &s, nil
+}
+
+func (r *PostgresRepository) getLignaggiBySpecieIDs(ctx context.Context, specieIDs []string) (map[string][]specie.Lignaggio, error) {
+	result := make(map[string][]specie.Lignaggio)
+	if len(specieIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []lignaggioRow
+	if err := r.db.SelectContext(ctx, &rows, selectLignaggio+` WHERE id_specie = ANY($1) ORDER BY nome`, pq.Array(specieIDs)); err != nil {
+		return nil, fmt.Errorf("batch get lignaggi: %w", err)
+	}
+
+	for _, row := range rows {
+		result[row.IDSpecie] = append(result[row.IDSpecie], row.toLignaggio())
+	}
+
+	return result, nil
+}
+
+func (r *PostgresRepository) ListLignaggi(ctx context.Context, specieID string, filter shared.ListFilter) ([]specie.Lignaggio, int, error) {
+	q := shared.NewPaginatedQuery(
+		selectLignaggio+` WHERE id_specie = :specie_id`,
+		`SELECT COUNT(*) FROM lignaggi WHERE id_specie = :specie_id`,
+		map[string]any{"specie_id": specieID},
+		filter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []lignaggioRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]specie.Lignaggio, len(rows))
+	for i, row := range rows {
+		result[i] = row.toLignaggio()
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetLignaggioByID(ctx context.Context, specieID, lignaggioID string) (*specie.Lignaggio, error) {
+	var row lignaggioRow
+	if err := r.db.GetContext(ctx, &row, selectLignaggio+` WHERE id = $1 AND id_specie = $2`, lignaggioID, specieID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get lignaggio by id: %w", err)
+	}
+
+	lignaggio := row.toLignaggio()
+	return &lignaggio, nil
+}
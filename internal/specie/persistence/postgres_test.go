@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/specie"
+)
+
+func TestFilterConditions(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		where, args, err := filterConditions(specie.ListFilter{})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if where != "" || len(args) != 0 {
+			t.Errorf("expected no conditions, got %q %v", where, args)
+		}
+	})
+
+	t.Run("taglia matches any value", func(t *testing.T) {
+		where, args, err := filterConditions(specie.ListFilter{Taglia: []shared.Taglia{shared.Piccola, shared.Media}})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(where, "taglia = ANY(:taglia)") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		taglie := args["taglia"].(*pq.StringArray)
+		if len(*taglie) != 2 {
+			t.Errorf("unexpected taglia arg %v", *taglie)
+		}
+	})
+
+	t.Run("tipo velocita requires every kind", func(t *testing.T) {
+		filter := specie.ListFilter{TipoVelocita: []shared.TipoVelocita{shared.Volo, shared.Nuoto}}
+
+		where, args, err := filterConditions(filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(where, "velocita @> CAST(:velocita AS jsonb)") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+
+		var entries []shared.Velocita
+		if err := json.Unmarshal([]byte(args["velocita"].(string)), &entries); err != nil {
+			t.Fatalf("velocita arg is not valid JSON: %v", err)
+		}
+		if len(entries) != 2 || entries[0].Tipo != shared.Volo || entries[1].Tipo != shared.Nuoto {
+			t.Errorf("unexpected velocita entries %v", entries)
+		}
+		if strings.Contains(args["velocita"].(string), "valore") {
+			t.Errorf("velocita entries must only constrain tipo, got %s", args["velocita"])
+		}
+	})
+}
+
+func TestSpecieRow_ToSpecie(t *testing.T) {
+	row := specieRow{
+		ID:              "elfo",
+		Nome:            "Elfo",
+		TipoDiCreatura:  "Umanoide",
+		Taglia:          "Media",
+		DettaglioTaglia: sql.NullString{String: "circa 1,5-1,8 m", Valid: true},
+		Velocita:        shared.JSONSlice[shared.Velocita]{{Tipo: shared.Corsa, Valore: 9, UnitaDiMisura: "m"}},
+		Tratti:          shared.JSONSlice[shared.Tratto]{{Nome: "Trance"}},
+	}
+	lignaggi := []specie.Lignaggio{{ID: "drow", Nome: "Drow", IDSpecie: "elfo"}}
+
+	s := row.toSpecie(lignaggi)
+
+	if s.Taglia != shared.Media || s.DettaglioTaglia != "circa 1,5-1,8 m" {
+		t.Errorf("unexpected taglia %q %q", s.Taglia, s.DettaglioTaglia)
+	}
+	if len(s.Velocita) != 1 || s.Velocita[0].Valore != 9 {
+		t.Errorf("unexpected velocita %v", s.Velocita)
+	}
+	if len(s.Lignaggio) != 1 || s.Lignaggio[0].ID != "drow" {
+		t.Errorf("unexpected lignaggio %v", s.Lignaggio)
+	}
+	if s.Descrizione != "" {
+		t.Errorf("expected empty descrizione, got %q", s.Descrizione)
+	}
+}
+
+func TestLignaggioRow_ToLignaggio(t *testing.T) {
+	row := lignaggioRow{
+		ID:          "drow",
+		Nome:        "Drow",
+		Descrizione: sql.NullString{String: "Elfi del Sottosuolo", Valid: true},
+		IDSpecie:    "elfo",
+		Tratti:      shared.JSONSlice[shared.Tratto]{{Nome: "Magia Drow", IDIncantesimo: "luci-danzanti"}},
+	}
+
+	l := row.toLignaggio()
+
+	if l.IDSpecie != "elfo" || l.Descrizione != "Elfi del Sottosuolo" {
+		t.Errorf("unexpected lignaggio %+v", l)
+	}
+	if len(l.Tratti) != 1 || l.Tratti[0].IDIncantesimo != "luci-danzanti" {
+		t.Errorf("unexpected tratti %v", l.Tratti)
+	}
+}
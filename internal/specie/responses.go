@@ -0,0 +1,13 @@
+package specie
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type ListSpecieResponse struct {
+	shared.PaginationMeta
+	Specie []Specie `json:"specie"`
+}
+
+type ListLignaggiResponse struct {
+	shared.PaginationMeta
+	Lignaggi []Lignaggio `json:"lignaggi"`
+}
@@ -0,0 +1,102 @@
+package specie
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	repo      Repository
+	glossario shared.Glossario
+	logger    *slog.Logger
+}
+
+func NewService(repo Repository, glossario shared.Glossario, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:      repo,
+		glossario: glossario,
+		logger:    logger,
+	}
+}
+
+func (s *Service) ListSpecie(ctx context.Context, filter ListFilter) (*ListSpecieResponse, error) {
+	specie, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list specie", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListSpecieResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Specie:         specie,
+	}, nil
+}
+
+func (s *Service) GetSpecie(ctx context.Context, id string, campi shared.Campi) (*Specie, error) {
+	specie, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get specie", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if specie == nil {
+		return nil, ErrSpecieNotFound(id)
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		specie.Riferimenti = s.glossario.Riferimenti(ctx, specie)
+	}
+	return specie, nil
+}
+
+func (s *Service) verifySpecieExists(ctx context.Context, specieID string) error {
+	specie, err := s.repo.GetByID(ctx, specieID)
+	if err != nil {
+		s.logger.Error("failed to verify specie existence", "id", specieID, "error", err)
+		return shared.NewInternalError(err)
+	}
+	if specie == nil {
+		return ErrSpecieNotFound(specieID)
+	}
+	return nil
+}
+
+func (s *Service) ListLignaggi(ctx context.Context, specieID string, filter shared.ListFilter) (*ListLignaggiResponse, error) {
+	if err := s.verifySpecieExists(ctx, specieID); err != nil {
+		return nil, err
+	}
+
+	lignaggi, total, err := s.repo.ListLignaggi(ctx, specieID, filter)
+	if err != nil {
+		s.logger.Error("failed to list lignaggi", "specieID", specieID, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListLignaggiResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Lignaggi:       lignaggi,
+	}, nil
+}
+
+func (s *Service) GetLignaggio(ctx context.Context, specieID, lignaggioID string, campi shared.Campi) (*Lignaggio, error) {
+	if err := s.verifySpecieExists(ctx, specieID); err != nil {
+		return nil, err
+	}
+
+	lignaggio, err := s.repo.GetLignaggioByID(ctx, specieID, lignaggioID)
+	if err != nil {
+		s.logger.Error("failed to get lignaggio", "specieID", specieID, "lignaggioID", lignaggioID, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if lignaggio == nil {
+		return nil, ErrLignaggioNotFound(lignaggioID)
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		lignaggio.Riferimenti = s.glossario.Riferimenti(ctx, lignaggio)
+	}
+	return lignaggio, nil
+}
@@ -0,0 +1,445 @@
+package specie
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestService_ListSpecie(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		expectedSpecie := []Specie{
+			{ID: "elfo", Nome: "Elfo", Taglia: shared.Media},
+			{ID: "nano", Nome: "Nano", Taglia: shared.Media},
+		}
+
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, filter ListFilter) ([]Specie, int, error) {
+				return expectedSpecie, 2, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+		filter := ListFilter{ListFilter: shared.ListFilter{Limit: 20, Offset: 0}}
+
+		result, err := service.ListSpecie(ctx, filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 2 {
+			t.Errorf("expected 2 elements, got %d", result.NumeroDiElementi)
+		}
+		if len(result.Specie) != 2 {
+			t.Errorf("expected 2 specie, got %d", len(result.Specie))
+		}
+		if result.Pagina != 1 {
+			t.Errorf("expected page 1, got %d", result.Pagina)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Specie, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+		filter := ListFilter{ListFilter: shared.ListFilter{Limit: 20, Offset: 0}}
+
+		_, err := service.ListSpecie(ctx, filter)
+
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+
+	t.Run("empty result", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Specie, int, error) {
+				return []Specie{}, 0, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+		filter := ListFilter{ListFilter: shared.ListFilter{Limit: 20, Offset: 0}}
+
+		result, err := service.ListSpecie(ctx, filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 0 {
+			t.Errorf("expected 0 elements, got %d", result.NumeroDiElementi)
+		}
+		if len(result.Specie) != 0 {
+			t.Errorf("expected 0 specie, got %d", len(result.Specie))
+		}
+	})
+}
+
+func TestService_GetSpecie(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		expectedSpecie := &Specie{
+			ID:     "elfo",
+			Nome:   "Elfo",
+			Taglia: shared.Media,
+		}
+
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Specie, error) {
+				if id == "elfo" {
+					return expectedSpecie, nil
+				}
+				return nil, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.GetSpecie(ctx, "elfo", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "elfo" {
+			t.Errorf("expected id 'barbaro', got '%s'", result.ID)
+		}
+		if result.Nome != "Elfo" {
+			t.Errorf("expected nome 'Barbaro', got '%s'", result.Nome)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Specie, error) {
+				return nil, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.GetSpecie(ctx, "nonexistent", nil)
+
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Specie, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.GetSpecie(ctx, "elfo", nil)
+
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestService_ListLignaggi(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		parentSpecie := &Specie{ID: "elfo", Nome: "Elfo"}
+		expectedLignaggi := []Lignaggio{
+			{ID: "alto-elfo", Nome: "Alto Elfo", IDSpecie: "elfo"},
+			{ID: "drow", Nome: "Drow", IDSpecie: "elfo"},
+		}
+
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Specie, error) {
+				if id == "elfo" {
+					return parentSpecie, nil
+				}
+				return nil, nil
+			},
+			ListLignaggiFunc: func(_ context.Context, specieID string, _ shared.ListFilter) ([]Lignaggio, int, error) {
+				if specieID == "elfo" {
+					return expectedLignaggi, 2, nil
+				}
+				return nil, 0, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+		filter := shared.ListFilter{Limit: 20, Offset: 0}
+
+		result, err := service.ListLignaggi(ctx, "elfo", filter)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 2 {
+			t.Errorf("expected 2 elements, got %d", result.NumeroDiElementi)
+		}
+		if len(result.Lignaggi) != 2 {
+			t.Errorf("expected 2 lignaggi, got %d", len(result.Lignaggi))
+		}
+	})
+
+	t.Run("parent specie not found", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Specie, error) {
+				return nil, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+		filter := shared.ListFilter{Limit: 20, Offset: 0}
+
+		_, err := service.ListLignaggi(ctx, "nonexistent", filter)
+
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+
+	t.Run("repository error on get parent", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Specie, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+		filter := shared.ListFilter{Limit: 20, Offset: 0}
+
+		_, err := service.ListLignaggi(ctx, "elfo", filter)
+
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+
+	t.Run("repository error on list lignaggi", func(t *testing.T) {
+		parentSpecie := &Specie{ID: "elfo", Nome: "Elfo"}
+
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Specie, error) {
+				return parentSpecie, nil
+			},
+			ListLignaggiFunc: func(_ context.Context, _ string, _ shared.ListFilter) ([]Lignaggio, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+		filter := shared.ListFilter{Limit: 20, Offset: 0}
+
+		_, err := service.ListLignaggi(ctx, "elfo", filter)
+
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestService_GetLignaggio(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		parentSpecie := &Specie{ID: "elfo", Nome: "Elfo"}
+		expectedLignaggio := &Lignaggio{
+			ID:       "alto-elfo",
+			Nome:     "Alto Elfo",
+			IDSpecie: "elfo",
+		}
+
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Specie, error) {
+				if id == "elfo" {
+					return parentSpecie, nil
+				}
+				return nil, nil
+			},
+			GetLignaggioByIDFunc: func(_ context.Context, specieID, lignaggioID string) (*Lignaggio, error) {
+				if specieID == "elfo" && lignaggioID == "alto-elfo" {
+					return expectedLignaggio, nil
+				}
+				return nil, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.GetLignaggio(ctx, "elfo", "alto-elfo", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "alto-elfo" {
+			t.Errorf("expected id 'berserker', got '%s'", result.ID)
+		}
+		if result.Nome != "Alto Elfo" {
+			t.Errorf("expected nome 'Berserker', got '%s'", result.Nome)
+		}
+	})
+
+	t.Run("parent specie not found", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Specie, error) {
+				return nil, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.GetLignaggio(ctx, "nonexistent", "alto-elfo", nil)
+
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+
+	t.Run("lignaggio not found", func(t *testing.T) {
+		parentSpecie := &Specie{ID: "elfo", Nome: "Elfo"}
+
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Specie, error) {
+				return parentSpecie, nil
+			},
+			GetLignaggioByIDFunc: func(_ context.Context, _, _ string) (*Lignaggio, error) {
+				return nil, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.GetLignaggio(ctx, "elfo", "nonexistent", nil)
+
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+
+	t.Run("repository error on get parent", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Specie, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.GetLignaggio(ctx, "elfo", "alto-elfo", nil)
+
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+
+	t.Run("repository error on get lignaggio", func(t *testing.T) {
+		parentSpecie := &Specie{ID: "elfo", Nome: "Elfo"}
+
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, _ string) (*Specie, error) {
+				return parentSpecie, nil
+			},
+			GetLignaggioByIDFunc: func(_ context.Context, _, _ string) (*Lignaggio, error) {
+				return nil, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.GetLignaggio(ctx, "elfo", "alto-elfo", nil)
+
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/specie"
+)
+
+type SpecieService interface {
+	ListSpecie(ctx context.Context, filter specie.ListFilter) (*specie.ListSpecieResponse, error)
+	GetSpecie(ctx context.Context, id string, campi shared.Campi) (*specie.Specie, error)
+	ListLignaggi(ctx context.Context, specieID string, filter shared.ListFilter) (*specie.ListLignaggiResponse, error)
+	GetLignaggio(ctx context.Context, specieID, lignaggioID string, campi shared.Campi) (*specie.Lignaggio, error)
+}
+
+type Handler struct {
+	service SpecieService
+}
+
+func NewHandler(service SpecieService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListSpecie)
+	r.Get("/{id-specie}", h.GetSpecie)
+	r.Get("/{id-specie}/lignaggi", h.ListLignaggi)
+	r.Get("/{id-specie}/lignaggi/{id-lignaggio}", h.GetLignaggio)
+
+	return r
+}
+
+func newListFilterFromRequest(r *http.Request) (specie.ListFilter, error) {
+	base, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		return specie.ListFilter{}, err
+	}
+	filter := specie.ListFilter{ListFilter: base}
+	query := r.URL.Query()
+
+	if filter.Taglia, err = shared.QueryEnumList(query, "taglia", shared.Taglie...); err != nil {
+		return filter, err
+	}
+	if filter.TipoVelocita, err = shared.QueryEnumList(query, "tipo-velocita", shared.TipiVelocita...); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) ListSpecie(w http.ResponseWriter, r *http.Request) {
+	filter, err := newListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListSpecie(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetSpecie(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-specie")
+	if err := shared.ValidateID("id-specie", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	s, err := h.service.GetSpecie(r.Context(), id, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, s, campi)
+}
+
+func (h *Handler) ListLignaggi(w http.ResponseWriter, r *http.Request) {
+	specieID := chi.URLParam(r, "id-specie")
+	if err := shared.ValidateID("id-specie", specieID); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	filter, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListLignaggi(r.Context(), specieID, filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetLignaggio(w http.ResponseWriter, r *http.Request) {
+	specieID := chi.URLParam(r, "id-specie")
+	if err := shared.ValidateID("id-specie", specieID); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	lignaggioID := chi.URLParam(r, "id-lignaggio")
+	if err := shared.ValidateID("id-lignaggio", lignaggioID); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	lignaggio, err := h.service.GetLignaggio(r.Context(), specieID, lignaggioID, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, lignaggio, campi)
+}
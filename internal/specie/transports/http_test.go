@@ -0,0 +1,237 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/specie"
+)
+
+type mockService struct {
+	listSpecieFunc   func(ctx context.Context, filter specie.ListFilter) (*specie.ListSpecieResponse, error)
+	getSpecieFunc    func(ctx context.Context, id string, campi shared.Campi) (*specie.Specie, error)
+	listLignaggiFunc func(ctx context.Context, specieID string, filter shared.ListFilter) (*specie.ListLignaggiResponse, error)
+	getLignaggioFunc func(ctx context.Context, specieID, lignaggioID string, campi shared.Campi) (*specie.Lignaggio, error)
+}
+
+func (m *mockService) ListSpecie(ctx context.Context, filter specie.ListFilter) (*specie.ListSpecieResponse, error) {
+	if m.listSpecieFunc != nil {
+		return m.listSpecieFunc(ctx, filter)
+	}
+	return &specie.ListSpecieResponse{}, nil
+}
+
+func (m *mockService) GetSpecie(ctx context.Context, id string, campi shared.Campi) (*specie.Specie, error) {
+	if m.getSpecieFunc != nil {
+		return m.getSpecieFunc(ctx, id, campi)
+	}
+	return nil, nil
+}
+
+func (m *mockService) ListLignaggi(ctx context.Context, specieID string, filter shared.ListFilter) (*specie.ListLignaggiResponse, error) {
+	if m.listLignaggiFunc != nil {
+		return m.listLignaggiFunc(ctx, specieID, filter)
+	}
+	return &specie.ListLignaggiResponse{}, nil
+}
+
+func (m *mockService) GetLignaggio(ctx context.Context, specieID, lignaggioID string, campi shared.Campi) (*specie.Lignaggio, error) {
+	if m.getLignaggioFunc != nil {
+		return m.getLignaggioFunc(ctx, specieID, lignaggioID, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc SpecieService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/specie", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_ListSpecie(t *testing.T) {
+	t.Run("with species filters", func(t *testing.T) {
+		var captured specie.ListFilter
+		svc := &mockService{
+			listSpecieFunc: func(_ context.Context, filter specie.ListFilter) (*specie.ListSpecieResponse, error) {
+				captured = filter
+				return &specie.ListSpecieResponse{
+					PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+					Specie:         []specie.Specie{{ID: "aarakocra", Nome: "Aarakocra", Taglia: shared.Media}},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/specie?taglia=Piccola,Media&tipo-velocita=Volo", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if len(captured.Taglia) != 2 || captured.Taglia[1] != shared.Media {
+			t.Errorf("unexpected taglia %v", captured.Taglia)
+		}
+		if len(captured.TipoVelocita) != 1 || captured.TipoVelocita[0] != shared.Volo {
+			t.Errorf("unexpected tipo-velocita %v", captured.TipoVelocita)
+		}
+
+		var response specie.ListSpecieResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Specie) != 1 {
+			t.Errorf("expected 1 specie, got %d", len(response.Specie))
+		}
+	})
+
+	t.Run("unknown taglia returns 400", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/specie?taglia=Minuta", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestHandler_GetSpecie(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := &mockService{
+			getSpecieFunc: func(_ context.Context, id string, _ shared.Campi) (*specie.Specie, error) {
+				return &specie.Specie{
+					ID:        id,
+					Nome:      "Elfo",
+					Velocita:  []shared.Velocita{{Tipo: shared.Corsa, Valore: 9, UnitaDiMisura: "m"}},
+					Lignaggio: []specie.Lignaggio{{ID: "drow", Nome: "Drow", IDSpecie: id}},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/specie/elfo", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+
+		var response specie.Specie
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Lignaggio) != 1 || response.Lignaggio[0].ID != "drow" {
+			t.Errorf("unexpected lignaggio %v", response.Lignaggio)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getSpecieFunc: func(_ context.Context, id string, _ shared.Campi) (*specie.Specie, error) {
+				return nil, specie.ErrSpecieNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/specie/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
+
+func TestHandler_ListLignaggi(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var capturedID string
+		svc := &mockService{
+			listLignaggiFunc: func(_ context.Context, specieID string, _ shared.ListFilter) (*specie.ListLignaggiResponse, error) {
+				capturedID = specieID
+				return &specie.ListLignaggiResponse{
+					PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 2},
+					Lignaggi: []specie.Lignaggio{
+						{ID: "alto-elfo", Nome: "Alto Elfo", IDSpecie: specieID},
+						{ID: "drow", Nome: "Drow", IDSpecie: specieID},
+					},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/specie/elfo/lignaggi", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if capturedID != "elfo" {
+			t.Errorf("expected specie id elfo, got %q", capturedID)
+		}
+	})
+}
+
+func TestHandler_GetLignaggio(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var capturedSpecie, capturedLignaggio string
+		svc := &mockService{
+			getLignaggioFunc: func(_ context.Context, specieID, lignaggioID string, _ shared.Campi) (*specie.Lignaggio, error) {
+				capturedSpecie, capturedLignaggio = specieID, lignaggioID
+				return &specie.Lignaggio{
+					ID:       lignaggioID,
+					Nome:     "Drow",
+					IDSpecie: specieID,
+					Tratti:   []shared.Tratto{{Nome: "Scurovisione superiore", Sensi: []shared.Senso{{Nome: "Scurovisione", Gittata: "36 m"}}}},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/specie/elfo/lignaggi/drow", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		if capturedSpecie != "elfo" || capturedLignaggio != "drow" {
+			t.Errorf("unexpected ids %q %q", capturedSpecie, capturedLignaggio)
+		}
+
+		var response specie.Lignaggio
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Tratti) != 1 || len(response.Tratti[0].Sensi) != 1 {
+			t.Errorf("unexpected tratti %v", response.Tratti)
+		}
+	})
+
+	t.Run("lignaggio not found", func(t *testing.T) {
+		svc := &mockService{
+			getLignaggioFunc: func(_ context.Context, _, lignaggioID string, _ shared.Campi) (*specie.Lignaggio, error) {
+				return nil, specie.ErrLignaggioNotFound(lignaggioID)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/specie/elfo/lignaggi/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
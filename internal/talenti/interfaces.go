@@ -0,0 +1,8 @@
+package talenti
+
+import "context"
+
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Talento, int, error)
+	GetByID(ctx context.Context, id string) (*Talento, error)
+}
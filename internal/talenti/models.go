@@ -0,0 +1,60 @@
+package talenti
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type CategoriaTalento string
+
+const (
+	Origine              CategoriaTalento = "Origine"
+	Generale             CategoriaTalento = "Generale"
+	StileDiCombattimento CategoriaTalento = "Stile di Combattimento"
+	DonoEpico            CategoriaTalento = "Dono Epico"
+)
+
+var CategorieTalento = []CategoriaTalento{Origine, Generale, StileDiCombattimento, DonoEpico}
+
+// MaxLivello is the highest character level.
+const MaxLivello = 20
+
+// PrerequisitoCaratteristica is shared with the class multiclass
+// prerequisites.
+type PrerequisitoCaratteristica = shared.PrerequisitoCaratteristica
+
+// Prerequisiti lists every condition a character must meet to take a feat.
+// PrivilegiDiClasse names the class features required, such as
+// "Incantesimi" or "Stile di Combattimento"; Altro holds any prerequisite
+// that cannot be expressed by the other fields.
+type Prerequisiti struct {
+	LivelloMinimo     int32                        `json:"livello-minimo,omitempty"`
+	Caratteristiche   []PrerequisitoCaratteristica `json:"caratteristiche,omitempty"`
+	PrivilegiDiClasse []string                     `json:"privilegi-di-classe,omitempty"`
+	Altro             string                       `json:"altro,omitempty"`
+}
+
+// SoddisfattiAlLivello reports whether the level prerequisite is met by a
+// character of the given level. The other prerequisites depend on the
+// character sheet and are not checked.
+func (p *Prerequisiti) SoddisfattiAlLivello(livello int32) bool {
+	return p == nil || p.LivelloMinimo <= livello
+}
+
+type Talento struct {
+	ID                          string               `json:"id"`
+	Nome                        string               `json:"nome"`
+	Descrizione                 string               `json:"descrizione"`
+	Categoria                   CategoriaTalento     `json:"categoria"`
+	Prerequisiti                *Prerequisiti        `json:"prerequisiti,omitempty"`
+	Ripetibile                  bool                 `json:"ripetibile"`
+	Effetti                     []shared.Effetto     `json:"effetti,omitempty"`
+	Riferimenti                 []shared.Riferimento `json:"riferimenti,omitempty"`
+	DocumentazioneDiRiferimento string               `json:"documentazione-di-riferimento"`
+}
+
+// ListFilter extends the shared list filter with the feat-specific
+// filters. Livello keeps only the feats whose level prerequisite is met at
+// that character level.
+type ListFilter struct {
+	shared.ListFilter
+	Categoria []CategoriaTalento
+	Livello   *int32
+}
@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/talenti"
+)
+
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+type talentoRow struct {
+	ID                          string                                  `db:"id"`
+	Nome                        string                                  `db:"nome"`
+	Descrizione                 sql.NullString                          `db:"descrizione"`
+	Categoria                   string                                  `db:"categoria"`
+	Prerequisiti                shared.JSONObject[talenti.Prerequisiti] `db:"prerequisiti"`
+	Ripetibile                  bool                                    `db:"ripetibile"`
+	Effetti                     shared.JSONSlice[shared.Effetto]        `db:"effetti"`
+	DocumentazioneDiRiferimento string                                  `db:"documentazione_di_riferimento"`
+}
+
+func (r *talentoRow) toTalento() talenti.Talento {
+	return talenti.Talento{
+		ID:                          r.ID,
+		Nome:                        r.Nome,
+		Descrizione:                 r.Descrizione.String,
+		Categoria:                   talenti.CategoriaTalento(r.Categoria),
+		Prerequisiti:                r.Prerequisiti.V,
+		Ripetibile:                  r.Ripetibile,
+		Effetti:                     r.Effetti,
+		DocumentazioneDiRiferimento: r.DocumentazioneDiRiferimento,
+	}
+}
+
+const selectTalento = `
+	SELECT id, nome, descrizione, categoria, prerequisiti, ripetibile, effetti,
+	       documentazione_di_riferimento
+	FROM talenti`
+
+// filterConditions translates the feat-specific filters into SQL
+// conditions and their named arguments. The level filter relies on the
+// livello_minimo column generated from prerequisiti.
+func filterConditions(filter talenti.ListFilter) (string, map[string]any) {
+	var where string
+	args := make(map[string]any)
+
+	if len(filter.Categoria) > 0 {
+		categorie := make([]string, len(filter.Categoria))
+		for i, c := range filter.Categoria {
+			categorie[i] = string(c)
+		}
+		where += ` AND categoria = ANY(:categoria)`
+		args["categoria"] = pq.Array(categorie)
+	}
+	if filter.Livello != nil {
+		where += ` AND livello_minimo <= :livello`
+		args["livello"] = *filter.Livello
+	}
+
+	return where, args
+}
+
+func (r *PostgresRepository) List(ctx context.Context, filter talenti.ListFilter) ([]talenti.Talento, int, error) {
+	where, args := filterConditions(filter)
+	q := shared.NewPaginatedQuery(
+		selectTalento+` WHERE 1=1`+where,
+		`SELECT COUNT(*) FROM talenti WHERE 1=1`+where,
+		args,
+		filter.ListFilter,
+	)
+
+	total, err := q.Count(ctx, r.db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []talentoRow
+	if err := q.SelectRows(ctx, r.db, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]talenti.Talento, len(rows))
+	for i, row := range rows {
+		result[i] = row.toTalento()
+	}
+
+	return result, total, nil
+}
+
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*talenti.Talento, error) {
+	var row talentoRow
+	if err := r.db.GetContext(ctx, &row, selectTalento+` WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get talento by id: %w", err)
+	}
+
+	talento := row.toTalento()
+	return &talento, nil
+}
@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/talenti"
+)
+
+func TestFilterConditions(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		where, args := filterConditions(talenti.ListFilter{})
+
+		if where != "" || len(args) != 0 {
+			t.Errorf("expected no conditions, got %q %v", where, args)
+		}
+	})
+
+	t.Run("categoria matches any value", func(t *testing.T) {
+		where, args := filterConditions(talenti.ListFilter{
+			Categoria: []talenti.CategoriaTalento{talenti.Origine, talenti.StileDiCombattimento},
+		})
+
+		if !strings.Contains(where, "categoria = ANY(:categoria)") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		categorie := args["categoria"].(*pq.StringArray)
+		if len(*categorie) != 2 || (*categorie)[1] != "Stile di Combattimento" {
+			t.Errorf("unexpected categoria arg %v", *categorie)
+		}
+	})
+
+	t.Run("livello keeps reachable feats", func(t *testing.T) {
+		livello := int32(4)
+
+		where, args := filterConditions(talenti.ListFilter{Livello: &livello})
+
+		if !strings.Contains(where, "livello_minimo <= :livello") {
+			t.Errorf("unexpected conditions %q", where)
+		}
+		if args["livello"] != int32(4) {
+			t.Errorf("unexpected livello arg %v", args["livello"])
+		}
+	})
+}
+
+func TestTalentoRow_ToTalento(t *testing.T) {
+	var row talentoRow
+	row.ID = "mago-della-guerra"
+	row.Categoria = "Generale"
+	if err := row.Prerequisiti.Scan([]byte(`{"livello-minimo":4,"privilegi-di-classe":["Incantesimi"]}`)); err != nil {
+		t.Fatalf("scan prerequisiti: %v", err)
+	}
+	if err := row.Effetti.Scan([]byte(`[{"nome":"Aumento dei Punteggi di Caratteristica","modificatori":[{"tipo":"Bonus Caratteristica","caratteristica":"Intelligenza","valore":1}]}]`)); err != nil {
+		t.Fatalf("scan effetti: %v", err)
+	}
+
+	got := row.toTalento()
+
+	if got.Categoria != talenti.Generale {
+		t.Errorf("unexpected categoria %q", got.Categoria)
+	}
+	if got.Prerequisiti == nil || got.Prerequisiti.LivelloMinimo != 4 || got.Prerequisiti.PrivilegiDiClasse[0] != "Incantesimi" {
+		t.Errorf("unexpected prerequisiti %+v", got.Prerequisiti)
+	}
+	if len(got.Effetti) != 1 || got.Effetti[0].Modificatori[0].Caratteristica != shared.Intelligenza {
+		t.Errorf("unexpected effetti %+v", got.Effetti)
+	}
+
+	t.Run("null prerequisiti", func(t *testing.T) {
+		var row talentoRow
+		if err := row.Prerequisiti.Scan(nil); err != nil {
+			t.Fatalf("scan prerequisiti: %v", err)
+		}
+		if got := row.toTalento(); got.Prerequisiti != nil {
+			t.Errorf("expected nil prerequisiti, got %+v", got.Prerequisiti)
+		}
+	})
+}
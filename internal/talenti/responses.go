@@ -0,0 +1,8 @@
+package talenti
+
+import "github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+
+type ListTalentiResponse struct {
+	shared.PaginationMeta
+	Talenti []Talento `json:"talenti"`
+}
@@ -0,0 +1,54 @@
+package talenti
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+type Service struct {
+	repo      Repository
+	glossario shared.Glossario
+	logger    *slog.Logger
+}
+
+func NewService(repo Repository, glossario shared.Glossario, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Service{
+		repo:      repo,
+		glossario: glossario,
+		logger:    logger,
+	}
+}
+
+func (s *Service) ListTalenti(ctx context.Context, filter ListFilter) (*ListTalentiResponse, error) {
+	talenti, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list talenti", "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+
+	return &ListTalentiResponse{
+		PaginationMeta: shared.PaginationMeta{Pagina: filter.Page(), NumeroDiElementi: total},
+		Talenti:        talenti,
+	}, nil
+}
+
+func (s *Service) GetTalento(ctx context.Context, id string, campi shared.Campi) (*Talento, error) {
+	talento, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("failed to get talento", "id", id, "error", err)
+		return nil, shared.NewInternalError(err)
+	}
+	if talento == nil {
+		return nil, ErrTalentoNotFound(id)
+	}
+	if s.glossario != nil && campi.Contiene("riferimenti") {
+		talento.Riferimenti = s.glossario.Riferimenti(ctx, talento)
+	}
+	return talento, nil
+}
@@ -0,0 +1,130 @@
+package talenti
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestService_ListTalenti(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		var captured ListFilter
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, filter ListFilter) ([]Talento, int, error) {
+				captured = filter
+				return []Talento{
+					{ID: "allerta", Nome: "Allerta", Categoria: Origine},
+					{ID: "tiratore-scelto", Nome: "Tiratore Scelto", Categoria: Generale},
+				}, 2, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		livello := int32(4)
+		result, err := service.ListTalenti(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}, Livello: &livello})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.NumeroDiElementi != 2 || len(result.Talenti) != 2 {
+			t.Errorf("expected 2 talenti, got %d/%d", result.NumeroDiElementi, len(result.Talenti))
+		}
+		if captured.Livello == nil || *captured.Livello != 4 {
+			t.Errorf("expected livello 4 to reach the repository, got %v", captured.Livello)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(_ context.Context, _ ListFilter) ([]Talento, int, error) {
+				return nil, 0, errors.New("database error")
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		_, err := service.ListTalenti(ctx, ListFilter{ListFilter: shared.ListFilter{Limit: 20}})
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 500 {
+			t.Errorf("expected status 500, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestService_GetTalento(t *testing.T) {
+	ctx := context.Background()
+	logger := newTestLogger()
+
+	t.Run("success", func(t *testing.T) {
+		repo := &MockRepository{
+			GetByIDFunc: func(_ context.Context, id string) (*Talento, error) {
+				return &Talento{ID: id, Nome: "Allerta"}, nil
+			},
+		}
+
+		service := NewService(repo, nil, logger)
+
+		result, err := service.GetTalento(ctx, "allerta", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ID != "allerta" {
+			t.Errorf("expected id 'allerta', got '%s'", result.ID)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service := NewService(&MockRepository{}, nil, logger)
+
+		_, err := service.GetTalento(ctx, "nonexistent", nil)
+
+		var appErr *shared.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected AppError, got %T", err)
+		}
+		if appErr.HTTPStatus != 404 {
+			t.Errorf("expected status 404, got %d", appErr.HTTPStatus)
+		}
+	})
+}
+
+func TestPrerequisiti_SoddisfattiAlLivello(t *testing.T) {
+	tests := []struct {
+		name         string
+		prerequisiti *Prerequisiti
+		livello      int32
+		want         bool
+	}{
+		{"no prerequisites", nil, 1, true},
+		{"below minimum", &Prerequisiti{LivelloMinimo: 4}, 3, false},
+		{"at minimum", &Prerequisiti{LivelloMinimo: 4}, 4, true},
+		{"ability thresholds ignored", &Prerequisiti{
+			Caratteristiche: []PrerequisitoCaratteristica{{Caratteristiche: []shared.Caratteristica{shared.Forza}, ValoreMinimo: 13}},
+		}, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.prerequisiti.SoddisfattiAlLivello(tt.livello); got != tt.want {
+				t.Errorf("SoddisfattiAlLivello(%d) = %v, want %v", tt.livello, got, tt.want)
+			}
+		})
+	}
+}
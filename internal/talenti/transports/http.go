@@ -0,0 +1,95 @@
+package transports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/talenti"
+)
+
+type TalentiService interface {
+	ListTalenti(ctx context.Context, filter talenti.ListFilter) (*talenti.ListTalentiResponse, error)
+	GetTalento(ctx context.Context, id string, campi shared.Campi) (*talenti.Talento, error)
+}
+
+type Handler struct {
+	service TalentiService
+}
+
+func NewHandler(service TalentiService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.ListTalenti)
+	r.Get("/{id-talento}", h.GetTalento)
+
+	return r
+}
+
+func newListFilterFromRequest(r *http.Request) (talenti.ListFilter, error) {
+	base, err := shared.NewListFilterFromRequest(r)
+	if err != nil {
+		return talenti.ListFilter{}, err
+	}
+	filter := talenti.ListFilter{ListFilter: base}
+	query := r.URL.Query()
+
+	if filter.Categoria, err = shared.QueryEnumList(query, "categoria", talenti.CategorieTalento...); err != nil {
+		return filter, err
+	}
+
+	livello, err := shared.QueryInt(query, "livello", 1, talenti.MaxLivello)
+	if err != nil {
+		return filter, err
+	}
+	if livello != nil {
+		l := int32(*livello)
+		filter.Livello = &l
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) ListTalenti(w http.ResponseWriter, r *http.Request) {
+	filter, err := newListFilterFromRequest(r)
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	response, err := h.service.ListTalenti(r.Context(), filter)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, response, filter.Campi)
+}
+
+func (h *Handler) GetTalento(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id-talento")
+	if err := shared.ValidateID("id-talento", id); err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	campi, err := shared.QueryCampi(r.URL.Query())
+	if err != nil {
+		shared.WriteError(w, shared.NewBadRequestError(err.Error(), err))
+		return
+	}
+
+	talento, err := h.service.GetTalento(r.Context(), id, campi)
+	if err != nil {
+		shared.WriteError(w, err)
+		return
+	}
+
+	shared.WriteJSONCampi(w, http.StatusOK, talento, campi)
+}
@@ -0,0 +1,135 @@
+package transports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/emiliopalmerini/quintaedizione.api/internal/shared"
+	"github.com/emiliopalmerini/quintaedizione.api/internal/talenti"
+)
+
+type mockService struct {
+	listTalentiFunc func(ctx context.Context, filter talenti.ListFilter) (*talenti.ListTalentiResponse, error)
+	getTalentoFunc  func(ctx context.Context, id string, campi shared.Campi) (*talenti.Talento, error)
+}
+
+func (m *mockService) ListTalenti(ctx context.Context, filter talenti.ListFilter) (*talenti.ListTalentiResponse, error) {
+	if m.listTalentiFunc != nil {
+		return m.listTalentiFunc(ctx, filter)
+	}
+	return &talenti.ListTalentiResponse{}, nil
+}
+
+func (m *mockService) GetTalento(ctx context.Context, id string, campi shared.Campi) (*talenti.Talento, error) {
+	if m.getTalentoFunc != nil {
+		return m.getTalentoFunc(ctx, id, campi)
+	}
+	return nil, nil
+}
+
+func newTestRouter(svc TalentiService) chi.Router {
+	r := chi.NewRouter()
+	r.Mount("/talenti", NewHandler(svc).Routes())
+	return r
+}
+
+func TestHandler_ListTalenti(t *testing.T) {
+	t.Run("with feat filters", func(t *testing.T) {
+		var captured talenti.ListFilter
+		svc := &mockService{
+			listTalentiFunc: func(_ context.Context, filter talenti.ListFilter) (*talenti.ListTalentiResponse, error) {
+				captured = filter
+				return &talenti.ListTalentiResponse{
+					PaginationMeta: shared.PaginationMeta{Pagina: 1, NumeroDiElementi: 1},
+					Talenti:        []talenti.Talento{{ID: "allerta", Nome: "Allerta", Categoria: talenti.Origine}},
+				}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/talenti?categoria=Origine&categoria=Stile%20di%20Combattimento&livello=4", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if len(captured.Categoria) != 2 || captured.Categoria[1] != talenti.StileDiCombattimento {
+			t.Errorf("unexpected categoria %v", captured.Categoria)
+		}
+		if captured.Livello == nil || *captured.Livello != 4 {
+			t.Errorf("expected livello 4, got %v", captured.Livello)
+		}
+
+		var response talenti.ListTalentiResponse
+		if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(response.Talenti) != 1 {
+			t.Errorf("expected 1 talento, got %d", len(response.Talenti))
+		}
+	})
+
+	invalid := []struct {
+		name  string
+		query string
+	}{
+		{"unknown categoria", "categoria=Razziale"},
+		{"livello too low", "livello=0"},
+		{"livello too high", "livello=21"},
+		{"livello not integer", "livello=quarto"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name+" returns 400", func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/talenti?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			newTestRouter(&mockService{}).ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_GetTalento(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		svc := &mockService{
+			getTalentoFunc: func(_ context.Context, id string, _ shared.Campi) (*talenti.Talento, error) {
+				return &talenti.Talento{ID: id, Nome: "Allerta"}, nil
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/talenti/allerta", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &mockService{
+			getTalentoFunc: func(_ context.Context, id string, _ shared.Campi) (*talenti.Talento, error) {
+				return nil, talenti.ErrTalentoNotFound(id)
+			},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/talenti/nonexistent", nil)
+		rec := httptest.NewRecorder()
+
+		newTestRouter(svc).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rec.Code)
+		}
+	})
+}
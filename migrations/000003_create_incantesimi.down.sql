@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_incantesimi_classi;
+DROP INDEX IF EXISTS idx_incantesimi_componenti;
+DROP INDEX IF EXISTS idx_incantesimi_scuola;
+DROP INDEX IF EXISTS idx_incantesimi_livello;
+DROP INDEX IF EXISTS idx_incantesimi_nome;
+DROP TABLE IF EXISTS incantesimi;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS incantesimi (
+    id                            VARCHAR(255) PRIMARY KEY,
+    nome                          VARCHAR(255) NOT NULL,
+    livello                       SMALLINT NOT NULL CHECK (livello BETWEEN 0 AND 9),
+    scuola_di_magia               VARCHAR(50) NOT NULL,
+    tempo_di_lancio               VARCHAR(100) NOT NULL,
+    gittata                       VARCHAR(100),
+    area                          VARCHAR(100),
+    concentrazione                BOOLEAN NOT NULL DEFAULT FALSE,
+    sempre_preparato              BOOLEAN NOT NULL DEFAULT FALSE,
+    rituale                       BOOLEAN NOT NULL DEFAULT FALSE,
+    componenti                    TEXT[] NOT NULL DEFAULT '{}',
+    componenti_materiali          TEXT,
+    durata                        VARCHAR(100) NOT NULL,
+    descrizione                   TEXT,
+    effetto_incantesimo           JSONB,
+    effetto_livello_maggiore      JSONB,
+    classi                        TEXT[] NOT NULL DEFAULT '{}',
+    documentazione_di_riferimento VARCHAR(50) DEFAULT 'DND 2024',
+    created_at                    TIMESTAMP DEFAULT NOW(),
+    updated_at                    TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_incantesimi_nome ON incantesimi(nome);
+CREATE INDEX IF NOT EXISTS idx_incantesimi_livello ON incantesimi(livello);
+CREATE INDEX IF NOT EXISTS idx_incantesimi_scuola ON incantesimi(scuola_di_magia);
+CREATE INDEX IF NOT EXISTS idx_incantesimi_componenti ON incantesimi USING GIN (componenti);
+CREATE INDEX IF NOT EXISTS idx_incantesimi_classi ON incantesimi USING GIN (classi);
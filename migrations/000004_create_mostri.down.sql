@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_mostri_immunita;
+DROP INDEX IF EXISTS idx_mostri_taglia;
+DROP INDEX IF EXISTS idx_mostri_tipo;
+DROP INDEX IF EXISTS idx_mostri_grado_di_sfida;
+DROP INDEX IF EXISTS idx_mostri_nome;
+DROP TABLE IF EXISTS mostri;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS mostri (
+    id                            VARCHAR(255) PRIMARY KEY,
+    nome                          VARCHAR(255) NOT NULL,
+    descrizione                   TEXT,
+    taglia                        VARCHAR(20) NOT NULL,
+    tipo_di_creatura              VARCHAR(50) NOT NULL,
+    allineamento                  VARCHAR(50),
+    classe_armatura               SMALLINT NOT NULL,
+    punti_ferita                  INTEGER NOT NULL,
+    dadi_punti_ferita             VARCHAR(50),
+    velocita                      JSONB,
+    caratteristiche               JSONB,
+    tiri_salvezza                 TEXT[] NOT NULL DEFAULT '{}',
+    abilita                       JSONB,
+    sensi                         JSONB,
+    linguaggi                     TEXT[] NOT NULL DEFAULT '{}',
+    resistenze                    JSONB,
+    immunita                      JSONB,
+    vulnerabilita                 JSONB,
+    grado_di_sfida                NUMERIC(6,3) NOT NULL CHECK (grado_di_sfida BETWEEN 0 AND 30),
+    punti_esperienza              INTEGER,
+    bonus_competenza              SMALLINT,
+    tratti                        JSONB,
+    azioni                        JSONB,
+    azioni_bonus                  JSONB,
+    reazioni                      JSONB,
+    azioni_leggendarie            JSONB,
+    documentazione_di_riferimento VARCHAR(50) DEFAULT 'DND 2024',
+    created_at                    TIMESTAMP DEFAULT NOW(),
+    updated_at                    TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_mostri_nome ON mostri(nome);
+CREATE INDEX IF NOT EXISTS idx_mostri_grado_di_sfida ON mostri(grado_di_sfida);
+CREATE INDEX IF NOT EXISTS idx_mostri_tipo ON mostri(tipo_di_creatura);
+CREATE INDEX IF NOT EXISTS idx_mostri_taglia ON mostri(taglia);
+CREATE INDEX IF NOT EXISTS idx_mostri_immunita ON mostri USING GIN (immunita jsonb_path_ops);
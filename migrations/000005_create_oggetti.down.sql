@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_oggetti_costo;
+DROP INDEX IF EXISTS idx_oggetti_categoria;
+DROP INDEX IF EXISTS idx_oggetti_tipo;
+DROP INDEX IF EXISTS idx_oggetti_nome;
+DROP TABLE IF EXISTS oggetti;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS oggetti (
+    id                            VARCHAR(255) PRIMARY KEY,
+    nome                          VARCHAR(255) NOT NULL,
+    tipo                          VARCHAR(50) NOT NULL,
+    descrizione                   TEXT,
+    costo_quantita                INTEGER CHECK (costo_quantita >= 0),
+    costo_valuta                  VARCHAR(2) CHECK (costo_valuta IN ('MR', 'MA', 'ME', 'MO', 'MP')),
+    costo_in_mr                   BIGINT GENERATED ALWAYS AS (
+        costo_quantita::BIGINT * CASE costo_valuta
+            WHEN 'MR' THEN 1
+            WHEN 'MA' THEN 10
+            WHEN 'ME' THEN 50
+            WHEN 'MO' THEN 100
+            WHEN 'MP' THEN 1000
+        END
+    ) STORED,
+    peso_valore                   NUMERIC(8,2),
+    peso_unita_di_misura          VARCHAR(20),
+    proprieta                     JSONB,
+    documentazione_di_riferimento VARCHAR(50) DEFAULT 'DND 2024',
+    created_at                    TIMESTAMP DEFAULT NOW(),
+    updated_at                    TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_oggetti_nome ON oggetti(nome);
+CREATE INDEX IF NOT EXISTS idx_oggetti_tipo ON oggetti(tipo);
+CREATE INDEX IF NOT EXISTS idx_oggetti_categoria ON oggetti(tipo, (proprieta->>'categoria'));
+CREATE INDEX IF NOT EXISTS idx_oggetti_costo ON oggetti(costo_in_mr);
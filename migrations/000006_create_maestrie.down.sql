@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_maestrie_armi;
+DROP INDEX IF EXISTS idx_maestrie_nome;
+DROP TABLE IF EXISTS maestrie;
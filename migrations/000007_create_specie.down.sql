@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_lignaggi_nome;
+DROP INDEX IF EXISTS idx_lignaggi_specie;
+DROP TABLE IF EXISTS lignaggi;
+DROP INDEX IF EXISTS idx_specie_velocita;
+DROP INDEX IF EXISTS idx_specie_taglia;
+DROP INDEX IF EXISTS idx_specie_nome;
+DROP TABLE IF EXISTS specie;
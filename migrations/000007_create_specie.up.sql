@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS specie (
+    id                            VARCHAR(255) PRIMARY KEY,
+    nome                          VARCHAR(255) NOT NULL,
+    tipo_di_creatura              VARCHAR(50) NOT NULL,
+    taglia                        VARCHAR(20) NOT NULL,
+    dettaglio_taglia              VARCHAR(255),
+    velocita                      JSONB,
+    descrizione                   TEXT,
+    tratti                        JSONB,
+    documentazione_di_riferimento VARCHAR(50) DEFAULT 'DND 2024',
+    created_at                    TIMESTAMP DEFAULT NOW(),
+    updated_at                    TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_specie_nome ON specie(nome);
+CREATE INDEX IF NOT EXISTS idx_specie_taglia ON specie(taglia);
+CREATE INDEX IF NOT EXISTS idx_specie_velocita ON specie USING GIN (velocita jsonb_path_ops);
+
+CREATE TABLE IF NOT EXISTS lignaggi (
+    id                            VARCHAR(255) PRIMARY KEY,
+    nome                          VARCHAR(255) NOT NULL,
+    descrizione                   TEXT,
+    id_specie                     VARCHAR(255) NOT NULL REFERENCES specie(id) ON DELETE CASCADE,
+    tratti                        JSONB,
+    documentazione_di_riferimento VARCHAR(50) DEFAULT 'DND 2024',
+    created_at                    TIMESTAMP DEFAULT NOW(),
+    updated_at                    TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lignaggi_specie ON lignaggi(id_specie);
+CREATE INDEX IF NOT EXISTS idx_lignaggi_nome ON lignaggi(nome);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_talenti_categoria;
+DROP INDEX IF EXISTS idx_talenti_nome;
+DROP TABLE IF EXISTS talenti;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_background_caratteristiche;
+DROP INDEX IF EXISTS idx_background_nome;
+DROP TABLE IF EXISTS background;
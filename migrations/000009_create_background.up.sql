@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS background (
+    id                            VARCHAR(255) PRIMARY KEY,
+    nome                          VARCHAR(255) NOT NULL,
+    descrizione                   TEXT,
+    caratteristiche_associate     TEXT[] NOT NULL DEFAULT '{}',
+    id_talento                    VARCHAR(255),
+    competenza_abilita            JSONB,
+    competenza_utensili           TEXT[] NOT NULL DEFAULT '{}',
+    equipaggiamento               JSONB,
+    documentazione_di_riferimento VARCHAR(50) DEFAULT 'DND 2024',
+    created_at                    TIMESTAMP DEFAULT NOW(),
+    updated_at                    TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_background_nome ON background(nome);
+CREATE INDEX IF NOT EXISTS idx_background_caratteristiche ON background USING GIN (caratteristiche_associate);
@@ -0,0 +1,11 @@
+ALTER TABLE background DROP CONSTRAINT IF EXISTS fk_background_talento;
+
+DROP INDEX IF EXISTS idx_talenti_livello_minimo;
+
+ALTER TABLE talenti DROP CONSTRAINT IF EXISTS chk_talenti_categoria;
+
+ALTER TABLE talenti
+    DROP COLUMN IF EXISTS effetti,
+    DROP COLUMN IF EXISTS ripetibile,
+    DROP COLUMN IF EXISTS livello_minimo,
+    DROP COLUMN IF EXISTS prerequisiti;
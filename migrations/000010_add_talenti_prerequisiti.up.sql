@@ -0,0 +1,17 @@
+ALTER TABLE talenti
+    ADD COLUMN IF NOT EXISTS prerequisiti JSONB,
+    ADD COLUMN IF NOT EXISTS livello_minimo SMALLINT GENERATED ALWAYS AS (
+        COALESCE((prerequisiti->>'livello-minimo')::SMALLINT, 1)
+    ) STORED,
+    ADD COLUMN IF NOT EXISTS ripetibile BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS effetti JSONB;
+
+ALTER TABLE talenti
+    ADD CONSTRAINT chk_talenti_categoria
+    CHECK (categoria IN ('Origine', 'Generale', 'Stile di Combattimento', 'Dono Epico'));
+
+CREATE INDEX IF NOT EXISTS idx_talenti_livello_minimo ON talenti(livello_minimo);
+
+ALTER TABLE background
+    ADD CONSTRAINT fk_background_talento
+    FOREIGN KEY (id_talento) REFERENCES talenti(id);
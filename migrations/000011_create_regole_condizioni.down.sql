@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_condizioni_nome;
+DROP TABLE IF EXISTS condizioni;
+DROP INDEX IF EXISTS idx_regole_nome;
+DROP TABLE IF EXISTS regole;
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_bastioni_ordini;
+DROP INDEX IF EXISTS idx_bastioni_livello_richiesto;
+DROP INDEX IF EXISTS idx_bastioni_nome;
+DROP TABLE IF EXISTS bastioni;
+
+DROP INDEX IF EXISTS idx_divinita_domini;
+DROP INDEX IF EXISTS idx_divinita_pantheon;
+DROP INDEX IF EXISTS idx_divinita_nome;
+DROP TABLE IF EXISTS divinita;
+
+DROP INDEX IF EXISTS idx_linguaggi_tipo;
+DROP INDEX IF EXISTS idx_linguaggi_nome;
+DROP TABLE IF EXISTS linguaggi;
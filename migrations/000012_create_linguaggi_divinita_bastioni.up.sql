@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS linguaggi (
+    id                            VARCHAR(255) PRIMARY KEY,
+    nome                          VARCHAR(255) NOT NULL,
+    tipo                          VARCHAR(20) NOT NULL CHECK (tipo IN ('Standard', 'Raro')),
+    origine                       VARCHAR(255),
+    descrizione                   TEXT,
+    documentazione_di_riferimento VARCHAR(50) DEFAULT 'DND 2024',
+    created_at                    TIMESTAMP DEFAULT NOW(),
+    updated_at                    TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_linguaggi_nome ON linguaggi(nome);
+CREATE INDEX IF NOT EXISTS idx_linguaggi_tipo ON linguaggi(tipo);
+
+CREATE TABLE IF NOT EXISTS divinita (
+    id                            VARCHAR(255) PRIMARY KEY,
+    nome                          VARCHAR(255) NOT NULL,
+    pantheon                      VARCHAR(255) NOT NULL,
+    allineamento                  VARCHAR(50) NOT NULL,
+    domini                        TEXT[] NOT NULL DEFAULT '{}',
+    simbolo                       VARCHAR(255),
+    descrizione                   TEXT,
+    documentazione_di_riferimento VARCHAR(50) DEFAULT 'DND 2024',
+    created_at                    TIMESTAMP DEFAULT NOW(),
+    updated_at                    TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_divinita_nome ON divinita(nome);
+CREATE INDEX IF NOT EXISTS idx_divinita_pantheon ON divinita(pantheon);
+CREATE INDEX IF NOT EXISTS idx_divinita_domini ON divinita USING GIN (domini);
+
+CREATE TABLE IF NOT EXISTS bastioni (
+    id                            VARCHAR(255) PRIMARY KEY,
+    nome                          VARCHAR(255) NOT NULL,
+    tipo                          VARCHAR(20) NOT NULL CHECK (tipo IN ('Base', 'Speciale')),
+    livello_richiesto             SMALLINT NOT NULL DEFAULT 5 CHECK (livello_richiesto BETWEEN 1 AND 20),
+    prerequisito                  TEXT,
+    spazio                        VARCHAR(20) NOT NULL CHECK (spazio IN ('Angusto', 'Spazioso', 'Vasto')),
+    ordini                        TEXT[] NOT NULL DEFAULT '{}',
+    gregari                       SMALLINT NOT NULL DEFAULT 0,
+    descrizione                   TEXT,
+    documentazione_di_riferimento VARCHAR(50) DEFAULT 'DND 2024',
+    created_at                    TIMESTAMP DEFAULT NOW(),
+    updated_at                    TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bastioni_nome ON bastioni(nome);
+CREATE INDEX IF NOT EXISTS idx_bastioni_livello_richiesto ON bastioni(livello_richiesto);
+CREATE INDEX IF NOT EXISTS idx_bastioni_ordini ON bastioni USING GIN (ordini);
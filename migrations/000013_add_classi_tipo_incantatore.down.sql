@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_classi_tipo_incantatore;
+
+ALTER TABLE sottoclassi DROP COLUMN IF EXISTS tipo_incantatore;
+
+ALTER TABLE classi DROP COLUMN IF EXISTS tipo_incantatore;
@@ -0,0 +1,11 @@
+ALTER TABLE classi
+    ADD COLUMN IF NOT EXISTS tipo_incantatore VARCHAR(20)
+    CONSTRAINT chk_classi_tipo_incantatore
+    CHECK (tipo_incantatore IN ('Completo', 'Mezzo', 'Terzo', 'Patto'));
+
+ALTER TABLE sottoclassi
+    ADD COLUMN IF NOT EXISTS tipo_incantatore VARCHAR(20)
+    CONSTRAINT chk_sottoclassi_tipo_incantatore
+    CHECK (tipo_incantatore IN ('Completo', 'Mezzo', 'Terzo', 'Patto'));
+
+CREATE INDEX IF NOT EXISTS idx_classi_tipo_incantatore ON classi(tipo_incantatore);
@@ -0,0 +1 @@
+ALTER TABLE classi DROP COLUMN IF EXISTS prerequisiti_multiclasse;
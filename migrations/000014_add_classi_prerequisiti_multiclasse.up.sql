@@ -0,0 +1 @@
+ALTER TABLE classi ADD COLUMN IF NOT EXISTS prerequisiti_multiclasse JSONB;
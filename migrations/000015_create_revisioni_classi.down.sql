@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_revisioni_classi_classe;
+DROP TABLE IF EXISTS revisioni_classi;
@@ -0,0 +1,55 @@
+-- Every write to classi and sottoclassi adds a revision with the resource as
+-- it was written, in the JSON format of the API. Deletions have no dati.
+CREATE TABLE IF NOT EXISTS revisioni_classi (
+    id           BIGSERIAL PRIMARY KEY,
+    tipo_risorsa VARCHAR(20) NOT NULL
+                 CONSTRAINT chk_revisioni_classi_tipo_risorsa
+                 CHECK (tipo_risorsa IN ('classe', 'sottoclasse')),
+    id_risorsa   VARCHAR(255) NOT NULL,
+    id_classe    VARCHAR(255) NOT NULL,
+    numero       INTEGER NOT NULL,
+    operazione   VARCHAR(20) NOT NULL
+                 CONSTRAINT chk_revisioni_classi_operazione
+                 CHECK (operazione IN ('creazione', 'modifica', 'eliminazione', 'ripristino')),
+    autore       VARCHAR(255),
+    dati         JSONB,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp(),
+    -- A subclass id can be reused under another class once the first
+    -- subclass is deleted, and each of them has its own history.
+    CONSTRAINT uq_revisioni_classi_numero UNIQUE (tipo_risorsa, id_classe, id_risorsa, numero)
+);
+
+CREATE INDEX IF NOT EXISTS idx_revisioni_classi_classe ON revisioni_classi(id_classe, tipo_risorsa, created_at);
+
+-- The current rows become the first revision of their history.
+INSERT INTO revisioni_classi (tipo_risorsa, id_risorsa, id_classe, numero, operazione, dati, created_at)
+SELECT 'classe', id, id, 1, 'creazione',
+       jsonb_strip_nulls(jsonb_build_object(
+           'id', id,
+           'nome', nome,
+           'descrizione', COALESCE(descrizione, ''),
+           'documentazione-di-riferimento', COALESCE(documentazione_di_riferimento, ''),
+           'dado-vita', dado_vita,
+           'tipo-incantatore', tipo_incantatore,
+           'prerequisiti-multiclasse', prerequisiti_multiclasse,
+           'equipaggiamento-id-partenza', equipaggiamento_partenza,
+           'proprietà-di-classe', proprieta_di_classe
+       )),
+       COALESCE(updated_at, created_at, NOW())
+FROM classi
+ON CONFLICT DO NOTHING;
+
+INSERT INTO revisioni_classi (tipo_risorsa, id_risorsa, id_classe, numero, operazione, dati, created_at)
+SELECT 'sottoclasse', id, id_classe_associata, 1, 'creazione',
+       jsonb_strip_nulls(jsonb_build_object(
+           'id', id,
+           'nome', nome,
+           'descrizione', COALESCE(descrizione, ''),
+           'documentazione-di-riferimento', COALESCE(documentazione_di_riferimento, ''),
+           'id-classe-associata', id_classe_associata,
+           'tipo-incantatore', tipo_incantatore,
+           'proprietà-di-sottoclasse', proprieta_di_sottoclasse
+       )),
+       COALESCE(updated_at, created_at, NOW())
+FROM sottoclassi
+ON CONFLICT DO NOTHING;
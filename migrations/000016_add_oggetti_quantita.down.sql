@@ -0,0 +1 @@
+ALTER TABLE oggetti DROP COLUMN IF EXISTS quantita;
@@ -0,0 +1 @@
+ALTER TABLE oggetti ADD COLUMN IF NOT EXISTS quantita INTEGER CHECK (quantita > 0);